@@ -0,0 +1,199 @@
+package gexapi
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/web"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/base/util"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+)
+
+//UpsertAssetH is http handler
+/**
+ *
+ * @api {POST} /usr/upsertAsset Upsert Asset
+ * @apiName UpsertAsset
+ * @apiGroup Asset
+ *
+ * @apiUse AssetUpdate
+ *
+ * @apiDescription the asset is added when it is not registered, else only the fields sent are updated
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Asset) {Object} asset the asset info
+ * @apiUse AssetObject
+ *
+ * @apiParamExample  {JSON} AddAsset:
+ * {
+ *     "asset": "YWE",
+ *     "name": "Gold",
+ *     "display_precision": 4,
+ *     "withdraw_min": "0.01",
+ *     "withdraw_fee": "0.001"
+ * }
+ * @apiParamExample  {JSON} UpdateAsset:
+ * {
+ *     "asset": "YWE",
+ *     "name": "Gold",
+ *     "withdraw_fee": "0.002"
+ * }
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "asset": {
+ *         "asset": "YWE",
+ *         "create_time": 1667475452051,
+ *         "deposit": 100,
+ *         "display_precision": 4,
+ *         "name": "Gold",
+ *         "status": 100,
+ *         "tid": 1000,
+ *         "update_time": 1667475452051,
+ *         "withdraw": 100,
+ *         "withdraw_min": "0.01"
+ *     },
+ *     "code": 0
+ * }
+ *
+ */
+func UpsertAssetH(s *web.Session) web.Result {
+	asset := &gexdb.Asset{}
+	data, err := s.RecvValidJSON(asset, gexdb.AssetFilterInsert, gexdb.AssetFilterOptional)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !AdminAccess(s) {
+		return util.ReturnCodeLocalErr(s, define.NotAccess, "srv-err", define.ErrNotAccess)
+	}
+	sent := xmap.M{}
+	_, err = converter.UnmarshalJSON(bytes.NewBuffer(data), &sent)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	fields := []string{}
+	for _, field := range strings.Split(gexdb.AssetFilterInsert, ",") {
+		if _, ok := sent[field]; ok {
+			fields = append(fields, field)
+		}
+	}
+	err = gexdb.UpsertAssetFilter(s.R.Context(), asset, strings.Join(fields, ","))
+	if err != nil {
+		xlog.Errorf("UpsertAssetH upsert asset by %v fail with %v", converter.JSON(asset), err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	xlog.Infof("UpsertAssetH upsert asset by %v success", converter.JSON(asset))
+	return s.SendJSON(xmap.M{
+		"code":  define.Success,
+		"asset": asset,
+	})
+}
+
+//UpdateAssetStatusH is http handler
+/**
+ *
+ * @api {GET} /usr/updateAssetStatus Update Asset Status
+ * @apiName UpdateAssetStatus
+ * @apiGroup Asset
+ *
+ * @apiParam  {String} asset the asset key to update
+ * @apiParam  {Number} status the asset status, all status supported is <a href="#metadata-Asset">AssetStatusAll</a>, 200 is locked
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ *
+ * @apiParamExample  {Query} LockAsset:
+ * asset=YWE&status=200
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0
+ * }
+ *
+ */
+func UpdateAssetStatusH(s *web.Session) web.Result {
+	var asset string
+	var status gexdb.AssetStatus
+	err := s.ValidFormat(`
+		asset,R|S,L:0;
+		status,R|I,e:;
+	`, &asset, &status)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !AdminAccess(s) {
+		return util.ReturnCodeLocalErr(s, define.NotAccess, "srv-err", define.ErrNotAccess)
+	}
+	err = gexdb.UpdateAssetStatus(s.R.Context(), asset, status)
+	if err != nil {
+		xlog.Errorf("UpdateAssetStatusH update asset %v status to %v fail with %v", asset, status, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = define.NotFound
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	xlog.Infof("UpdateAssetStatusH update asset %v status to %v success", asset, status)
+	return s.SendJSON(xmap.M{
+		"code": define.Success,
+	})
+}
+
+//ListAssetH is http handler
+/**
+ *
+ * @api {GET} /pub/listAsset List Asset
+ * @apiName ListAsset
+ * @apiGroup Asset
+ *
+ * @apiParam  {String} [asset] the asset key to filter, multi with comma
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Asset) {Array} assets the asset info, it is order by asset asc
+ * @apiUse AssetObject
+ *
+ * @apiParamExample  {Query} Request-Example:
+ * asset=YWE,USDT
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "assets": [
+ *         {
+ *             "asset": "YWE",
+ *             "create_time": 1667475452051,
+ *             "deposit": 100,
+ *             "display_precision": 4,
+ *             "name": "Gold",
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "update_time": 1667475452051,
+ *             "withdraw": 100,
+ *             "withdraw_min": "0.01"
+ *         }
+ *     ],
+ *     "code": 0
+ * }
+ *
+ */
+func ListAssetH(s *web.Session) web.Result {
+	var assets []string
+	err := s.ValidFormat(`
+		asset,O|S,L:0;
+	`, &assets)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	assetList, _, err := gexdb.ListAsset(s.R.Context(), assets...)
+	if err != nil {
+		xlog.Errorf("ListAssetH list asset by %v fail with %v", assets, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":   define.Success,
+		"assets": assetList,
+	})
+}
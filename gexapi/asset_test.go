@@ -0,0 +1,61 @@
+package gexapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+func TestAsset(t *testing.T) {
+	asset := &gexdb.Asset{
+		Asset:            spotBalanceBase,
+		Name:             "Gold",
+		DisplayPrecision: 4,
+		WithdrawMin:      decimal.NewFromFloat(0.01),
+	}
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	ts.Should(t, "code", define.NotAccess).PostJSONMap(asset, "/usr/upsertAsset")
+	ts.Should(t, "code", define.NotAccess).GetMap("/usr/updateAssetStatus?asset=%v&status=%v", spotBalanceBase, gexdb.AssetStatusLocked)
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+	upsertAsset, _ := ts.Should(t, "code", define.Success, "/asset/tid", xmap.ShouldIsNoZero).PostJSONMap(asset, "/usr/upsertAsset")
+	fmt.Printf("upsertAsset--->%v\n", converter.JSON(upsertAsset))
+	ts.Should(t, "code", define.Success, "/asset/display_precision", 4, "/asset/name", "Gold2").PostJSONMap(xmap.M{"asset": spotBalanceBase, "name": "Gold2"}, "/usr/upsertAsset")
+	ts.Should(t, "code", define.Success).GetMap("/usr/updateAssetStatus?asset=%v&status=%v", spotBalanceBase, gexdb.AssetStatusLocked)
+	ts.Should(t, "code", define.Success, "/asset/status", gexdb.AssetStatusLocked, "/asset/display_precision", 4).PostJSONMap(xmap.M{"asset": spotBalanceBase, "name": "Gold"}, "/usr/upsertAsset")
+	ts.Should(t, "code", define.Success).GetMap("/usr/updateAssetStatus?asset=%v&status=%v", spotBalanceBase, gexdb.AssetStatusNormal)
+	listAsset, _ := ts.Should(t, "code", define.Success, "assets", xmap.ShouldIsNoEmpty).GetMap("/pub/listAsset")
+	fmt.Printf("listAsset--->%v\n", converter.JSON(listAsset))
+	ts.Should(t, "code", define.Success, "assets", xmap.ShouldIsNoEmpty).GetMap("/pub/listAsset?asset=%v", spotBalanceBase)
+	//balance with asset
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	listBalance, _ := ts.Should(t, "code", define.Success, "/assets/"+spotBalanceBase, xmap.ShouldIsNoNil).GetMap("/usr/listBalance?area=%d", gexdb.BalanceAreaSpot)
+	fmt.Printf("listBalance--->%v\n", converter.JSON(listBalance))
+	//
+	//test error
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+	ts.Should(t, "code", define.ArgsInvalid).PostJSONMap("xxx", "/usr/upsertAsset")
+	ts.Should(t, "code", define.ArgsInvalid).PostJSONMap(&gexdb.Asset{Name: "Gold"}, "/usr/upsertAsset")
+	ts.Should(t, "code", define.ArgsInvalid).PostJSONMap(&gexdb.Asset{Asset: spotBalanceBase, Name: "Gold", Status: -100}, "/usr/upsertAsset")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/updateAssetStatus?asset=%v&status=%v", spotBalanceBase, -100)
+	ts.Should(t, "code", define.NotFound).GetMap("/usr/updateAssetStatus?asset=%v&status=%v", "NONE", gexdb.AssetStatusLocked)
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Rows.Scan", 2).Should(t, "code", define.ServerError).PostJSONMap(asset, "/usr/upsertAsset")
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/pub/listAsset")
+	pgx.MockerSetCall("Pool.Exec", 1).Should(t, "code", define.ServerError).GetMap("/usr/updateAssetStatus?asset=%v&status=%v", spotBalanceBase, gexdb.AssetStatusNormal)
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	pgx.MockerSetCall("Pool.Query", 2).Should(t, "code", define.ServerError).GetMap("/usr/listBalance?area=%d", gexdb.BalanceAreaSpot)
+}
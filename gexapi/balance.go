@@ -1,13 +1,21 @@
 package gexapi
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/codingeasygo/util/converter"
 	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/codingeasygo/util/xtime"
 	"github.com/codingeasygo/web"
 	"github.com/gexservice/gexservice/base/define"
 	"github.com/gexservice/gexservice/base/util"
 	"github.com/gexservice/gexservice/base/xlog"
 	"github.com/gexservice/gexservice/gexdb"
 	"github.com/gexservice/gexservice/market"
+	"github.com/gexservice/gexservice/matcher"
+	"github.com/shopspring/decimal"
 )
 
 //LoadBalanceOverviewH is http handler
@@ -100,9 +108,25 @@ func LoadBalanceOverviewH(s *web.Session) web.Result {
  * @apiSuccess (Balance) {Array} balances the user balance info
  * @apiUse BalanceObject
  * @apiSuccess (Success) {Object} values balance estimated value, mapping by key is balances.asset to value is estimated value
+ * @apiSuccess (Asset) {Object} assets the registered asset info, mapping by key is balances.asset to value is asset info, the asset is not registered is not returned
+ * @apiUse AssetObject
  *
  * @apiSuccessExample {type} Success-Response:
  * {
+ *     "assets": {
+ *         "YWE": {
+ *             "asset": "YWE",
+ *             "create_time": 1667475452051,
+ *             "deposit": 100,
+ *             "display_precision": 4,
+ *             "name": "Gold",
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "update_time": 1667475452051,
+ *             "withdraw": 100,
+ *             "withdraw_min": "0.01"
+ *         }
+ *     },
  *     "balances": {
  *         "MMK": {
  *             "asset": "MMK",
@@ -162,10 +186,346 @@ func ListBalanceH(s *web.Session) web.Result {
 		xlog.Errorf("ListBalanceH calc user %v %v balance overview fail with %v", userID, area, err)
 		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
 	}
+	assets := []string{}
+	for _, balance := range balances {
+		assets = append(assets, balance.Asset)
+	}
+	assetMap := map[string]*gexdb.Asset{}
+	if len(assets) > 0 {
+		_, assetMap, err = gexdb.ListAsset(s.R.Context(), assets...)
+		if err != nil {
+			xlog.Errorf("ListBalanceH list asset by %v fail with %v", assets, err)
+			return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+		}
+	}
 	return s.SendJSON(xmap.M{
 		"code":        0,
 		"total_value": totalValue,
 		"balances":    balances,
 		"values":      values,
+		"assets":      assetMap,
+	})
+}
+
+//ListBalanceRecordH is http handler
+/**
+ *
+ * @api {GET} /usr/listBalanceRecord List Balance Record
+ * @apiName ListBalanceRecord
+ * @apiGroup Balance
+ *
+ * @apiUse BalanceRecordUnifySearcher
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (BalanceRecord) {Array} records the balance record array, it is order by tid desc default
+ * @apiUse BalanceRecordObject
+ * @apiSuccess (Success) {Number} total the total count of balance record
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "records": [
+ *         {
+ *             "area": 200,
+ *             "asset": "USDT",
+ *             "create_time": 1667475452051,
+ *             "free": "9905",
+ *             "free_delta": "-95",
+ *             "locked": "95",
+ *             "locked_delta": "95",
+ *             "margin": "0",
+ *             "margin_delta": "0",
+ *             "ref_id": "202211031937320100009",
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "type": 200,
+ *             "update_time": 1667475452051,
+ *             "user_id": 100002
+ *         }
+ *     ],
+ *     "total": 1
+ * }
+ */
+func ListBalanceRecordH(s *web.Session) web.Result {
+	searcher := &gexdb.BalanceRecordUnifySearcher{}
+	err := s.Valid(searcher, "#all")
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	searcher.Where.UserID = xsql.Int64Array{userID}
+	err = searcher.Apply(s.R.Context())
+	if err != nil {
+		xlog.Errorf("ListBalanceRecordH search balance record fail with %v by %v", err, converter.JSON(searcher))
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":    define.Success,
+		"records": searcher.Query.Records,
+		"total":   searcher.Count.Total,
+	})
+}
+
+//ListBalanceHistoryH is http handler
+/**
+ *
+ * @api {GET} /usr/listBalanceHistory List Balance History
+ * @apiName ListBalanceHistory
+ * @apiGroup Balance
+ *
+ * @apiParam  {String} [asset] the balance asset, all asset is returned when it is empty
+ * @apiParam  {Number} [start_time] the start time of history create_time, default is 30 days ago
+ * @apiParam  {Number} [end_time] the end time of history create_time, default is now
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (BalanceHistory) {Array} histories the daily balance valuation array, it is order by create_time asc
+ * @apiUse BalanceHistoryObject
+ *
+ * @apiParamExample  {Query} ListBalanceHistory:
+ * asset=USDT&start_time=1667404800000&end_time=1667491200000
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "histories": [
+ *         {
+ *             "asset": "USDT",
+ *             "create_time": 1667404800000,
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "update_time": 1667404860000,
+ *             "user_id": 100002,
+ *             "valuation": "10000"
+ *         }
+ *     ]
+ * }
+ */
+func ListBalanceHistoryH(s *web.Session) web.Result {
+	var asset string
+	var startTime, endTime int64
+	var err = s.ValidFormat(`
+		asset,O|S,L:0;
+		start_time,O|I,R:0;
+		end_time,O|I,R:0;
+	`, &asset, &startTime, &endTime)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	end := time.Now()
+	if endTime > 0 {
+		end = xtime.TimeUnix(endTime)
+	}
+	start := end.Add(-30 * 24 * time.Hour)
+	if startTime > 0 {
+		start = xtime.TimeUnix(startTime)
+	}
+	userID := s.Value("user_id").(int64)
+	histories, err := gexdb.ListUserBalanceHistory(s.R.Context(), userID, asset, start, end)
+	if err != nil {
+		xlog.Errorf("ListBalanceHistoryH list balance history by %v,%v,%v,%v fail with %v", userID, asset, start, end, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":      define.Success,
+		"histories": histories,
+	})
+}
+
+//TransferBalanceH is http handler
+/**
+ *
+ * @api {GET} /usr/transferBalance Transfer Balance
+ * @apiName TransferBalance
+ * @apiGroup Balance
+ *
+ *
+ * @apiParam  {String} asset the balance asset to transfer
+ * @apiParam  {Number} from the source balance area, all type supported is <a href="#metadata-Balance">BalanceAreaAll</a>
+ * @apiParam  {Number} to the target balance area, all type supported is <a href="#metadata-Balance">BalanceAreaAll</a>
+ * @apiParam  {Number} quantity the transfer quantity, only free balance can be transferred, the margin locked by futures is kept
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a> or <a href="#metadata-ExReturnCode">ExReturnCode</a>
+ * @apiSuccess (BalanceTransfer) {Object} transfer the balance transfer record
+ * @apiUse BalanceTransferObject
+ *
+ * @apiSuccessExample {type} Success-Response:
+ * {
+ *     "code": 0,
+ *     "transfer": {
+ *         "asset": "USDT",
+ *         "create_time": 1667475452051,
+ *         "from_area": 200,
+ *         "quantity": "100",
+ *         "status": 100,
+ *         "tid": 1000,
+ *         "to_area": 300,
+ *         "update_time": 1667475452051,
+ *         "user_id": 100002
+ *     }
+ * }
+ *
+ */
+func TransferBalanceH(s *web.Session) web.Result {
+	var asset string
+	var from, to gexdb.BalanceArea
+	var quantity decimal.Decimal
+	err := s.ValidFormat(`
+		asset,R|S,L:0;
+		from,R|I,e:;
+		to,R|I,e:;
+		quantity,R|F,R:0;
+	`, &asset, &from, &to, &quantity)
+	if err == nil && from == to {
+		err = fmt.Errorf("from/to area must be different")
+	}
+	if err == nil && quantity.Sign() <= 0 {
+		err = fmt.Errorf("quantity must be greater than zero")
+	}
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	transfer, err := gexdb.TransferBalance(s.R.Context(), userID, asset, from, to, quantity)
+	if err != nil {
+		xlog.Errorf("TransferBalanceH transfer user %v %v balance from %v to %v by %v fail with %v", userID, asset, from, to, quantity, err)
+		code := define.ServerError
+		if gexdb.IsErrBalanceNotEnought(err) {
+			code = gexdb.CodeBalanceNotEnought
+		} else if gexdb.IsErrBalanceNotFound(err) {
+			code = gexdb.CodeBalanceNotFound
+		} else if gexdb.IsErrAssetInvalid(err) {
+			code = gexdb.CodeAssetInvalid
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	xlog.Infof("TransferBalanceH transfer user %v %v balance from %v to %v by %v success", userID, asset, from, to, quantity)
+	return s.SendJSON(xmap.M{
+		"code":     define.Success,
+		"transfer": transfer,
+	})
+}
+
+//SearchBalanceTransferH is http handler
+/**
+ *
+ * @api {GET} /usr/searchBalanceTransfer Search Balance Transfer
+ * @apiName SearchBalanceTransfer
+ * @apiGroup Balance
+ *
+ * @apiUse BalanceTransferUnifySearcher
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (BalanceTransfer) {Array} transfers the balance transfer record array
+ * @apiUse BalanceTransferObject
+ * @apiSuccess (Success) {Number} total the total count of balance transfer record
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "transfers": [
+ *         {
+ *             "asset": "USDT",
+ *             "create_time": 1667475452051,
+ *             "from_area": 200,
+ *             "quantity": "100",
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "to_area": 300,
+ *             "update_time": 1667475452051,
+ *             "user_id": 100002
+ *         }
+ *     ],
+ *     "total": 1
+ * }
+ */
+func SearchBalanceTransferH(s *web.Session) web.Result {
+	searcher := &gexdb.BalanceTransferUnifySearcher{}
+	err := s.Valid(searcher, "#all")
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	searcher.Where.UserID = xsql.Int64Array{userID}
+	err = searcher.Apply(s.R.Context())
+	if err != nil {
+		xlog.Errorf("SearchBalanceTransferH search balance transfer fail with %v by %v", err, converter.JSON(searcher))
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":      define.Success,
+		"transfers": searcher.Query.Transfers,
+		"total":     searcher.Count.Total,
+	})
+}
+
+//LoadBalanceReconcileH is http handler
+/**
+ *
+ * @api {GET} /usr/loadBalanceReconcile Load Balance Reconcile
+ * @apiName LoadBalanceReconcile
+ * @apiGroup Balance
+ *
+ * @apiParam  {Number} [refresh] run reconcile now when refresh=1, else return the last reconcile result
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Success) {Object} reconcile the balance reconcile result
+ * @apiSuccess (Success) {Number} reconcile.checked the checked balance count
+ * @apiSuccess (Success) {Number} reconcile.time the reconcile time
+ * @apiSuccess (Success) {Array} reconcile.drifts the balance which locked/margin is not matched to open order and holding
+ * @apiSuccess (Drift) {Number} user_id the balance user id
+ * @apiSuccess (Drift) {Number} area the balance area, all type supported is <a href="#metadata-Balance">BalanceAreaAll</a>
+ * @apiSuccess (Drift) {String} asset the balance asset
+ * @apiSuccess (Drift) {String} locked the stored balance locked
+ * @apiSuccess (Drift) {String} expect_locked the expected balance locked by open order and holding
+ * @apiSuccess (Drift) {String} margin the stored balance margin
+ * @apiSuccess (Drift) {String} expect_margin the expected balance margin by holding
+ * @apiSuccess (Drift) {Boolean} frozen whether the user is frozen by reconcile
+ *
+ * @apiParamExample  {Query} Refresh:
+ * refresh=1
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "reconcile": {
+ *         "checked": 10,
+ *         "drifts": [
+ *             {
+ *                 "user_id": 100002,
+ *                 "area": 300,
+ *                 "asset": "USDT",
+ *                 "locked": "100",
+ *                 "expect_locked": "95",
+ *                 "margin": "50",
+ *                 "expect_margin": "50",
+ *                 "frozen": false
+ *             }
+ *         ],
+ *         "time": 1667475452051
+ *     }
+ * }
+ */
+func LoadBalanceReconcileH(s *web.Session) web.Result {
+	var refresh int
+	var err = s.ValidFormat(`
+		refresh,O|I,R:0~1;
+	`, &refresh)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !AdminAccess(s) {
+		return util.ReturnCodeLocalErr(s, define.NotAccess, "srv-err", define.ErrNotAccess)
+	}
+	reconcile := matcher.LastReconcile()
+	if refresh == 1 || reconcile == nil {
+		reconcile, err = matcher.ReconcileBalance(s.R.Context())
+		if err != nil {
+			xlog.Errorf("LoadBalanceReconcileH reconcile balance fail with %v", err)
+			return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+		}
+	}
+	return s.SendJSON(xmap.M{
+		"code":      define.Success,
+		"reconcile": reconcile,
 	})
 }
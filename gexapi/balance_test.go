@@ -7,8 +7,10 @@ import (
 	"github.com/codingeasygo/crud/pgx"
 	"github.com/codingeasygo/util/converter"
 	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xsql"
 	"github.com/gexservice/gexservice/base/define"
 	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
 )
 
 // func TestChangeBalance(t *testing.T) {
@@ -93,3 +95,96 @@ func TestBalance(t *testing.T) {
 	pgx.Should(t, "code", define.ArgsInvalid).GetMap("/usr/listBalance?area=%d", 1)
 	pgx.MockerSetCall("Rows.Scan", 1).Should(t, "code", define.ServerError).GetMap("/usr/listBalance?area=%d", gexdb.BalanceAreaSpot)
 }
+
+func TestTransferBalance(t *testing.T) {
+	clearCookie()
+	login, err := ts.GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	if err != nil || login.Int64("code") != 0 {
+		t.Errorf("err:%v,code:%v", err, login)
+		return
+	}
+	//
+	transferBalance, _ := ts.Should(t, "code", define.Success, "transfer", xmap.ShouldIsNoNil).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=1", spotBalanceQuote, gexdb.BalanceAreaSpot, gexdb.BalanceAreaFutures)
+	fmt.Printf("transferBalance--->%v\n", converter.JSON(transferBalance))
+	ts.Should(t, "code", define.Success).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=1", spotBalanceQuote, gexdb.BalanceAreaFutures, gexdb.BalanceAreaSpot)
+	searchBalanceTransfer, _ := ts.Should(t, "code", define.Success, "transfers", xmap.ShouldIsNoEmpty).GetMap("/usr/searchBalanceTransfer?area=%d", gexdb.BalanceAreaFutures)
+	fmt.Printf("searchBalanceTransfer--->%v\n", converter.JSON(searchBalanceTransfer))
+	//
+	//test error
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=1", spotBalanceQuote, gexdb.BalanceAreaSpot, gexdb.BalanceAreaSpot)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=0", spotBalanceQuote, gexdb.BalanceAreaSpot, gexdb.BalanceAreaFutures)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=1", spotBalanceQuote, 1, gexdb.BalanceAreaFutures)
+	ts.Should(t, "code", gexdb.CodeBalanceNotEnought).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=100000000", spotBalanceQuote, gexdb.BalanceAreaSpot, gexdb.BalanceAreaFutures)
+	ts.Should(t, "code", gexdb.CodeAssetInvalid).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=1", "NONE", gexdb.BalanceAreaSpot, gexdb.BalanceAreaFutures)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/searchBalanceTransfer?area=%d", 1)
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Pool.Begin", 1).Should(t, "code", define.ServerError).GetMap("/usr/transferBalance?asset=%v&from=%d&to=%d&quantity=1", spotBalanceQuote, gexdb.BalanceAreaSpot, gexdb.BalanceAreaFutures)
+	pgx.MockerSetCall("Rows.Scan", 1).Should(t, "code", define.ServerError).GetMap("/usr/searchBalanceTransfer")
+}
+
+func TestListBalanceRecord(t *testing.T) {
+	clearCookie()
+	login, err := ts.GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	if err != nil || login.Int64("code") != 0 {
+		t.Errorf("err:%v,code:%v", err, login)
+		return
+	}
+	//
+	listBalanceRecord, _ := ts.Should(t, "code", define.Success, "records", xmap.ShouldIsNoEmpty).GetMap("/usr/listBalanceRecord?area=%d", gexdb.BalanceAreaSpot)
+	fmt.Printf("listBalanceRecord--->%v\n", converter.JSON(listBalanceRecord))
+	ts.Should(t, "code", define.Success, "records", xmap.ShouldIsNoEmpty).GetMap("/usr/listBalanceRecord?asset=%v&type=%d", spotBalanceQuote, gexdb.BalanceRecordTypeChange)
+	//
+	//test error
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/listBalanceRecord?type=%d", 1)
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Rows.Scan", 1).Should(t, "code", define.ServerError).GetMap("/usr/listBalanceRecord")
+}
+
+func TestListBalanceHistory(t *testing.T) {
+	syncer := gexdb.NewBalanceRecordSyncer(0, func(assets ...string) (prices map[string]decimal.Decimal) {
+		prices = map[string]decimal.Decimal{}
+		for _, asset := range assets {
+			prices[asset] = decimal.NewFromFloat(1)
+		}
+		return
+	})
+	syncer.Proc()
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	listBalanceHistory, _ := ts.Should(t, "code", define.Success, "histories", xmap.ShouldIsNoEmpty).GetMap("/usr/listBalanceHistory")
+	fmt.Printf("listBalanceHistory--->%v\n", converter.JSON(listBalanceHistory))
+	ts.Should(t, "code", define.Success, "histories", xmap.ShouldIsNoEmpty).GetMap("/usr/listBalanceHistory?asset=%v&start_time=%v&end_time=%v", spotBalanceQuote, xsql.TimeStartOfToday().Timestamp(), xsql.TimeNow().Timestamp())
+	//
+	//test error
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/listBalanceHistory?start_time=x")
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/usr/listBalanceHistory")
+}
+
+func TestLoadBalanceReconcile(t *testing.T) {
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	ts.Should(t, "code", define.NotAccess).GetMap("/usr/loadBalanceReconcile")
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+	loadBalanceReconcile, _ := ts.Should(t, "code", define.Success, "reconcile", xmap.ShouldIsNoNil).GetMap("/usr/loadBalanceReconcile")
+	fmt.Printf("loadBalanceReconcile--->%v\n", converter.JSON(loadBalanceReconcile))
+	ts.Should(t, "code", define.Success, "reconcile", xmap.ShouldIsNoNil).GetMap("/usr/loadBalanceReconcile?refresh=1")
+	//
+	//test error
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/loadBalanceReconcile?refresh=x")
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/usr/loadBalanceReconcile?refresh=1")
+}
@@ -39,13 +39,31 @@ func Handle(pre string, mux *web.SessionMux) {
 	// mux.HandleFunc("^"+pre+"/usr/changeUserBalance(\\?.*)?$", ChangeUserBalanceH)
 	mux.HandleFunc("^"+pre+"/usr/loadBalanceOverview(\\?.*)?$", LoadBalanceOverviewH)
 	mux.HandleFunc("^"+pre+"/usr/listBalance(\\?.*)?$", ListBalanceH)
+	mux.HandleFunc("^"+pre+"/usr/listBalanceRecord(\\?.*)?$", ListBalanceRecordH)
+	mux.HandleFunc("^"+pre+"/usr/listBalanceHistory(\\?.*)?$", ListBalanceHistoryH)
+	mux.HandleFunc("^"+pre+"/usr/transferBalance(\\?.*)?$", TransferBalanceH)
+	mux.HandleFunc("^"+pre+"/usr/searchBalanceTransfer(\\?.*)?$", SearchBalanceTransferH)
+	mux.HandleFunc("^"+pre+"/usr/loadBalanceReconcile(\\?.*)?$", LoadBalanceReconcileH)
+	//asset
+	mux.HandleFunc("^"+pre+"/pub/listAsset(\\?.*)?$", ListAssetH)
+	mux.HandleFunc("^"+pre+"/usr/upsertAsset(\\?.*)?$", UpsertAssetH)
+	mux.HandleFunc("^"+pre+"/usr/updateAssetStatus(\\?.*)?$", UpdateAssetStatusH)
 	//order
 	mux.HandleFunc("^"+pre+"/usr/createWithdrawOrder(\\?.*)?$", CreateWithdrawOrderH)
+	mux.HandleFunc("^"+pre+"/usr/loadWithdrawForm(\\?.*)?$", LoadWithdrawFormH)
 	mux.HandleFunc("^"+pre+"/usr/cancelWithdrawOrder(\\?.*)?$", CancelWithdrawOrderH)
-	// mux.HandleFunc("^"+pre+"/usr/createGoldbarOrder(\\?.*)?$", CreateGoldbarOrderH)
-	// mux.HandleFunc("^"+pre+"/usr/cancelGoldbarOrder(\\?.*)?$", CancelGoldbarOrderH)
-	// mux.HandleFunc("^"+pre+"/usr/verifyGoldbarOrder(\\?.*)?$", VerifyGoldbarOrderH)
+	mux.HandleFunc("^"+pre+"/usr/searchWithdrawOrder(\\?.*)?$", SearchWithdrawOrderH)
+	mux.HandleFunc("^"+pre+"/usr/reviewWithdrawOrder(\\?.*)?$", ReviewWithdrawOrderH)
+	mux.HandleFunc("^"+pre+"/usr/addWithdrawAddress(\\?.*)?$", AddWithdrawAddressH)
+	mux.HandleFunc("^"+pre+"/usr/removeWithdrawAddress(\\?.*)?$", RemoveWithdrawAddressH)
+	mux.HandleFunc("^"+pre+"/usr/listWithdrawAddress(\\?.*)?$", ListWithdrawAddressH)
+	mux.HandleFunc("^"+pre+"/usr/updateWithdrawWhitelist(\\?.*)?$", UpdateWithdrawWhitelistH)
+	mux.HandleFunc("^"+pre+"/usr/createGoldbarOrder(\\?.*)?$", CreateGoldbarOrderH)
+	mux.HandleFunc("^"+pre+"/usr/cancelGoldbarOrder(\\?.*)?$", CancelGoldbarOrderH)
+	mux.HandleFunc("^"+pre+"/usr/verifyGoldbarOrder(\\?.*)?$", VerifyGoldbarOrderH)
+	mux.HandleFunc("^"+pre+"/usr/loadGoldbarOrder(\\?.*)?$", LoadGoldbarOrderH)
 	mux.HandleFunc("^"+pre+"/usr/createTopupOrder(\\?.*)?$", CreateTopupOrderH)
+	mux.HandleFunc("^"+pre+"/pub/topupCallback(\\?.*)?$", TopupCallbackH)
 	// mux.HandleFunc("^"+pre+"/usr/searchMyUserOrder(\\?.*)?$", SearchMyUserOrderH)
 	mux.HandleFunc("^"+pre+"/usr/placeOrder", PlaceOrderH)
 	mux.HandleFunc("^"+pre+"/usr/cancelOrder", CancelOrderH)
@@ -53,10 +71,27 @@ func Handle(pre string, mux *web.SessionMux) {
 	mux.HandleFunc("^"+pre+"/usr/queryOrder(\\?.*)?$", QueryOrderH)
 	// mux.HandleFunc("^"+pre+"/usr/countOrderComm(\\?.*)?$", CountOrderCommH)
 	//market
+	mux.HandleFunc("^"+pre+"/usr/rebuildKLine(\\?.*)?$", RebuildKLineH)
+	handleMarket(pre, mux)
+}
+
+//HandleEdge will register market handler only, it is used on edge node which is serving market data by bridge without matcher
+func HandleEdge(pre string, mux *web.SessionMux) {
+	handleMarket(pre, mux)
+}
+
+func handleMarket(pre string, mux *web.SessionMux) {
 	MarketOnline = NewOnlineHander(mux, market.Shared)
 	mux.Handle("^"+pre+"/ws/market(\\?.*)?$", MarketOnline)
 	mux.HandleFunc("^"+pre+"/pub/listKLine(\\?.*)?$", ListKLineH)
 	mux.HandleFunc("^"+pre+"/pub/loadDepth(\\?.*)?$", LoadDepthH)
+	mux.HandleFunc("^"+pre+"/pub/listTrades(\\?.*)?$", ListTradesH)
+	mux.HandleFunc("^"+pre+"/pub/listTicker(\\?.*)?$", ListTickerH)
+	mux.HandleFunc("^"+pre+"/pub/udf/config(\\?.*)?$", UDFConfigH)
+	mux.HandleFunc("^"+pre+"/pub/udf/symbols(\\?.*)?$", UDFSymbolsH)
+	mux.HandleFunc("^"+pre+"/pub/udf/search(\\?.*)?$", UDFSearchH)
+	mux.HandleFunc("^"+pre+"/pub/udf/history(\\?.*)?$", UDFHistoryH)
+	mux.HandleFunc("^"+pre+"/pub/udf/time(\\?.*)?$", UDFTimeH)
 	// mux.HandleFunc("^"+pre+"/pub/listMarketOrder(\\?.*)?$", ListMarketOrderH)
 }
 
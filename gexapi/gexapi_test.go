@@ -94,6 +94,7 @@ func init() {
 		proxyAddr = proxyServer
 	}
 	basedb.StoreConf(ctx, gexdb.ConfigBrokerCommRate, "0.8")
+	basedb.StoreConf(ctx, gexdb.ConfigWithdrawAddressDelay, "0")
 }
 
 // func clear() {
@@ -1,12 +1,15 @@
 package gexapi
 
 import (
+	"time"
+
 	"github.com/codingeasygo/util/xmap"
 	"github.com/codingeasygo/util/xtime"
 	"github.com/codingeasygo/web"
 	"github.com/gexservice/gexservice/base/define"
 	"github.com/gexservice/gexservice/base/util"
 	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
 	"github.com/gexservice/gexservice/market"
 )
 
@@ -17,17 +20,46 @@ import (
  * @apiName WsMarket
  * @apiGroup Market
  *
- * @apiParam  {String} action subscribe action, supported is "sub.kline"/"sub.depth"/"sub.ticker"
- * @apiParam  {Arrasy} [intervals] the kline interval, only for "sub.kline", supported is "5min"/"30min"/"1hour"/"4hour"/"day"/"week"/"mon"
+ * @apiParam  {String} [codec] the query parameter of connect url to set message codec, supported is "json"/"msgpack", default is "json"
+ * @apiParam  {String} [compress] the query parameter of connect url to set message compress, supported is "deflate", default is not compress.
+ *   the "json" without compress is sent by text frame, other is sent by binary frame which is [flag byte][payload], the payload is deflated when flag&1==1,
+ *   the "msgpack" payload is same field name as json, the decimal is extension type 1 which data is decimal string like "100.5", the time is millisecond timestamp.
+ *   client can send command by text json frame or binary frame by negotiated codec
+ * @apiParam  {String} action subscribe action, supported is "codec"/"sub"/"unsub"/"list"/"ping"/"pong"/"sub.kline"/"sub.depth"/"sub.depth_diff"/"sub.ticker"/"sub.trades"/"sub.user", the "sub.user" is only for login user,
+ *   the "codec" is used to change codec/compress after connected, the response is sent by new codec.
+ *   the "sub.*" is replacing all subscription of the type, the "sub"/"unsub" is adding/removing the channels to/from current subscription, the "list" is returning current subscription.
+ *   the "ping" is responsed by "pong", server will send "ping" when connection is idle more than 20s, the connection is closed when it is idle more than 60s after client opt in keepalive by sending "ping"/"pong",
+ *   the client which never send "ping"/"pong" is not closed by idle, so the listen-only client is compatible
+ *   the user data is not supported on edge node which is serving market data by bridge, the "sub.user" and "user" channel is rejected with code 401, the "sub.user" should be connected to matching node
+ * @apiParam  {Array} [channels] the subscribe channels, only for "sub"/"unsub"
+ * @apiParam  {String} channels.type the channel type, supported is "kline"/"depth"/"depth_diff"/"ticker"/"trades"/"user", the "user" is only for login user
+ * @apiParam  {String} [channels.symbol] the channel symbol, required for "sub" except "user", it will remove all symbol of type when it is empty on "unsub"
+ * @apiParam  {String} [channels.interval] the kline interval, only for "kline"
+ * @apiParam  {Number} [channels.max] the depth max size, only for "depth"
+ * @apiParam  {Arrasy} [intervals] the kline interval, only for "sub.kline", supported is "1min"/"3min"/"5min"/"15min"/"30min"/"1hour"/"2hour"/"4hour"/"6hour"/"12hour"/"1day"/"3day"/"1week"/"1mon"
  * @apiParam  {Number} [max] the depth max size
  *
  * @apiSuccess (Success) {Number} code the response code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
  * @apiSuccess (Success) {Number} tid the int order id
- * @apiSuccess (Success) {String} action the received action, supported is "sub.kline"/"sub.depth"/"notify.kline"/"notify.depth"
+ * @apiSuccess (Success) {String} action the received action, supported is "codec"/"sub"/"unsub"/"list"/"ping"/"pong"/"error"/"sub.kline"/"sub.depth"/"sub.depth_diff"/"sub.ticker"/"sub.trades"/"sub.user"/"notify.kline"/"notify.depth"/"notify.depth_diff"/"notify.ticker"/"notify.trades"/"notify.user"
  * @apiSuccess (Success) {Object} depth the received depth data, only for "notify.depth"
  * @apiSuccess (Success) {String} depth.symbol the received depth symbol
+ * @apiSuccess (Success) {Number} depth.sequence the matcher event sequence of depth by symbol, the depth is full snapshot and the push is merged to latest depth when client is slow,
+ *   so the sequence may be not continuous, client should only drop the depth which sequence is less than received, use "sub.depth_diff" to maintain local book by update id
  * @apiSuccess (Success) {Array} depth.bids the received depth bids data, the inner data is ["price","quantity"]
  * @apiSuccess (Success) {Array} depth.asks the received depth asks data, the inner data is ["price","quantity"]
+ * @apiSuccess (Success) {Number} depth.update_id the depth update id by symbol, only for "notify.depth"
+ * @apiSuccess (Success) {Number} depth.first_update_id the first update id of changed price level, only for "notify.depth_diff"
+ * @apiSuccess (Success) {Number} depth.last_update_id the last update id of changed price level, only for "notify.depth_diff", the quantity is "0" when price level is removed from book,
+ *   the price level which is only moved out of the 30 levels window is not notified, client should keep the best 30 levels by each side after apply diff,
+ *   the update id is seeded from matcher sequence, so it is not reset when server restart, client should resync by snapshot when update id is not continuous
+ *   the local book should be built by: subscribe "sub.depth_diff" and buffer diff, load snapshot by /pub/loadDepth?max=30, drop diff which last_update_id<=snapshot update_id,
+ *   the first applied diff should be first_update_id<=update_id+1<=last_update_id, and each next diff should be first_update_id==previous last_update_id+1, otherwise resync by snapshot
+ * @apiSuccess (Success) {Object} update_ids the current depth update id by symbol, only for "sub"/"sub.depth_diff", the diff is started from update_id+1
+ * @apiSuccess (Success) {Number} count the active subscription count, only for "sub"/"unsub"/"list", the code is 1100 when count is exceeded limit
+ * @apiSuccess (Success) {Number} max the max subscription count of connection, only for "list"
+ * @apiSuccess (Success) {Array} channels the active subscription channels, only for "list"
+ * @apiSuccess (Success) {Number} time the server timestamp, only for "ping"/"pong"
  * @apiSuccess (Success) {Object} kline the received kline data, only for "notify.kline"
  * @apiSuccess (Success) {String} kline.symbol the received kline symbol
  * @apiSuccess (Success) {String} kline.start_time the received kline id, the timeline
@@ -38,6 +70,14 @@ import (
  * @apiSuccess (Success) {String} kline.close the received kline close price
  * @apiSuccess (Success) {String} kline.high the received kline high price
  * @apiSuccess (Success) {String} kline.low the received kline low price
+ * @apiSuccess (Success) {Object} ticker the received ticker data, only for "notify.ticker", the ask/bid/close/sequence is from depth and the other is 24h rolling statistics, see <a href="#api-Market-ListTicker">ListTicker</a>
+ * @apiSuccess (Success) {Array} trades the received trade of one match, only for "notify.trades", see <a href="#api-Market-ListTrades">ListTrades</a>
+ * @apiSuccess (Success) {Object} user the received user data which is changed by matched, only for "notify.user"
+ * @apiSuccess (Success) {String} user.symbol the matched symbol
+ * @apiSuccess (Success) {Number} user.sequence the matcher event sequence by symbol
+ * @apiSuccess (Success) {Array} user.orders the changed order of login user, see <a href="#api-Order-QueryOrder">Order</a>
+ * @apiSuccess (Success) {Array} user.balances the changed balance of login user, see <a href="#api-Balance-ListBalance">Balance</a>
+ * @apiSuccess (Success) {Array} user.holdings the changed holding of login user
  *
  * @apiParamExample  {JSON} Subscribe-KLine:
  * {
@@ -59,11 +99,65 @@ import (
  *         }
  *     ]
  * }
+ * @apiParamExample  {JSON} Subscribe-DepthDiff:
+ * {
+ *     "action": "sub.depth_diff",
+ *     "symbols": ["spot.YWEUSDT"]
+ * }
  * @apiParamExample  {JSON} Subscribe-Ticker:
  * {
  *     "action": "sub.ticker",
  *     "symbols": ["spot.YWEUSDT"]
  * }
+ * @apiParamExample  {JSON} Subscribe-Trades:
+ * {
+ *     "action": "sub.trades",
+ *     "symbols": ["spot.YWEUSDT"]
+ * }
+ * @apiParamExample  {JSON} Subscribe-User:
+ * {
+ *     "action": "sub.user"
+ * }
+ * @apiParamExample  {JSON} Subscribe-Channel:
+ * {
+ *     "action": "sub",
+ *     "channels": [
+ *         {
+ *             "type": "kline",
+ *             "symbol": "spot.YWEUSDT",
+ *             "interval": "5min"
+ *         },
+ *         {
+ *             "type": "depth_diff",
+ *             "symbol": "spot.YWEUSDT"
+ *         }
+ *     ]
+ * }
+ * @apiParamExample  {JSON} Unsubscribe-Channel:
+ * {
+ *     "action": "unsub",
+ *     "channels": [
+ *         {
+ *             "type": "kline",
+ *             "symbol": "spot.YWEUSDT",
+ *             "interval": "5min"
+ *         }
+ *     ]
+ * }
+ * @apiParamExample  {JSON} List-Channel:
+ * {
+ *     "action": "list"
+ * }
+ * @apiParamExample  {JSON} Ping:
+ * {
+ *     "action": "ping"
+ * }
+ * @apiParamExample  {JSON} Change-Codec:
+ * {
+ *     "action": "codec",
+ *     "codec": "msgpack",
+ *     "compress": "deflate"
+ * }
  *
  * @apiSuccessExample {JSON} Reponse-Depth:
  * {
@@ -76,11 +170,62 @@ import (
  *     "action": "sub.depth",
  *     "code": 0
  * }
+ * @apiSuccessExample {JSON} Reponse-DepthDiff:
+ * {
+ *     "action": "sub.depth_diff",
+ *     "code": 0,
+ *     "update_ids": {
+ *         "spot.YWEUSDT": 100
+ *     }
+ * }
  * @apiSuccessExample {JSON} Reponse-Ticker:
  * {
  *     "action": "sub.ticker",
  *     "code": 0
  * }
+ * @apiSuccessExample {JSON} Reponse-Trades:
+ * {
+ *     "action": "sub.trades",
+ *     "code": 0
+ * }
+ * @apiSuccessExample {JSON} Reponse-User:
+ * {
+ *     "action": "sub.user",
+ *     "code": 0
+ * }
+ * @apiSuccessExample {JSON} Reponse-Sub:
+ * {
+ *     "action": "sub",
+ *     "code": 0,
+ *     "count": 2,
+ *     "update_ids": {
+ *         "spot.YWEUSDT": 100
+ *     }
+ * }
+ * @apiSuccessExample {JSON} Reponse-List:
+ * {
+ *     "action": "list",
+ *     "code": 0,
+ *     "count": 1,
+ *     "max": 100,
+ *     "channels": [
+ *         {
+ *             "type": "depth_diff",
+ *             "symbol": "spot.YWEUSDT"
+ *         }
+ *     ]
+ * }
+ * @apiSuccessExample {JSON} Reponse-Pong:
+ * {
+ *     "action": "pong",
+ *     "code": 0,
+ *     "time": 1660000000000
+ * }
+ * @apiSuccessExample {JSON} Reponse-Codec:
+ * {
+ *     "action": "codec",
+ *     "code": 0
+ * }
  *
  * @apiSuccessExample {JSON} Notify-Depth:
  * {
@@ -88,6 +233,7 @@ import (
  *     "action": "notify.depth",
  *     "depth": {
  *         "symbol": "spot.YWEUSDT",
+ *         "sequence": 100,
  *         "bids": [
  *             [
  *                 "90",
@@ -102,6 +248,29 @@ import (
  *         ]
  *     }
  * }
+ * @apiSuccessExample {JSON} Notify-DepthDiff:
+ * {
+ *     "code": 0,
+ *     "action": "notify.depth_diff",
+ *     "depth": {
+ *         "symbol": "spot.YWEUSDT",
+ *         "sequence": 102,
+ *         "first_update_id": 101,
+ *         "last_update_id": 102,
+ *         "bids": [
+ *             [
+ *                 "90",
+ *                 "0"
+ *             ]
+ *         ],
+ *         "asks": [
+ *             [
+ *                 "100",
+ *                 "1"
+ *             ]
+ *         ]
+ *     }
+ * }
  * @apiSuccessExample {JSON} Notify-Ticker:
  * {
  *     "code": 0,
@@ -116,7 +285,65 @@ import (
  *             "100",
  *             "2"
  *         ],
- *         "close": "100"
+ *         "close": "100",
+ *         "sequence": 100,
+ *         "open": "100",
+ *         "high": "120",
+ *         "low": "90",
+ *         "last": "110",
+ *         "volume": "5",
+ *         "quote_volume": "510",
+ *         "change": "10",
+ *         "change_percent": "10",
+ *         "count": 4
+ *     }
+ * }
+ * @apiSuccessExample {JSON} Notify-Trades:
+ * {
+ *     "code": 0,
+ *     "action": "notify.trades",
+ *     "symbol": "spot.YWEUSDT",
+ *     "trades": [
+ *         {
+ *             "symbol": "spot.YWEUSDT",
+ *             "sequence": 100,
+ *             "side": "buy",
+ *             "price": "100",
+ *             "quantity": "1",
+ *             "time": 1632578330897
+ *         }
+ *     ]
+ * }
+ * @apiSuccessExample {JSON} Notify-User:
+ * {
+ *     "code": 0,
+ *     "action": "notify.user",
+ *     "user": {
+ *         "symbol": "spot.YWEUSDT",
+ *         "sequence": 100,
+ *         "orders": [
+ *             {
+ *                 "order_id": "202109260000000001",
+ *                 "user_id": 100,
+ *                 "symbol": "spot.YWEUSDT",
+ *                 "side": "buy",
+ *                 "quantity": "1",
+ *                 "filled": "1",
+ *                 "price": "100",
+ *                 "avg_price": "100",
+ *                 "status": 400
+ *             }
+ *         ],
+ *         "balances": [
+ *             {
+ *                 "user_id": 100,
+ *                 "area": 200,
+ *                 "asset": "USDT",
+ *                 "free": "9900",
+ *                 "locked": "0"
+ *             }
+ *         ],
+ *         "holdings": []
  *     }
  * }
  *
@@ -150,7 +377,8 @@ var MarketOnline *OnlineHander
  * @apiName ListKLine
  * @apiGroup Market
  *
- * @apiParam  {String} interval the kline interval, supported is "5min"/"30min"/"1hour"/"4hour"/"1day"/"1week"/"1mon"
+ * @apiParam  {String} interval the kline interval, supported is "1min"/"3min"/"5min"/"15min"/"30min"/"1hour"/"2hour"/"4hour"/"6hour"/"12hour"/"1day"/"3day"/"1week"/"1mon",
+ *   the day bar is started from 00:00 of server kline timezone, the week bar is started from sunday and the month bar is started from first day of calendar month
  * @apiParam  {Number} start_time filter kline kline.start_time>=start_time
  * @apiParam  {Number} end_time filter kline kline.start_time<end_time
  *
@@ -223,6 +451,8 @@ func ListKLineH(s *web.Session) web.Result {
  * @apiSuccess (Success) {Object} depth the depth info
  * @apiSuccess (Success) {Array} depth.bids the depth bid array
  * @apiSuccess (Success) {Array} depth.asks the depth ask array
+ * @apiSuccess (Success) {Number} depth.sequence the matcher event sequence of depth by symbol
+ * @apiSuccess (Success) {Number} depth.update_id the depth update id by symbol, it is used to apply "notify.depth_diff" on ws
  *
  * @apiParamExample  {Query} QueryOrder:
  * max=10
@@ -232,6 +462,7 @@ func ListKLineH(s *web.Session) web.Result {
  * {
  *     "code": 0,
  *     "depth": {
+ *         "update_id": 100,
  *         "bids": [
  *             [
  *                 "86.5",
@@ -264,3 +495,202 @@ func LoadDepthH(s *web.Session) web.Result {
 		"depth": depth,
 	})
 }
+
+//ListTradesH is http handler
+/**
+ *
+ * @api {GET} /pub/listTrades List Trades
+ * @apiName ListTrades
+ * @apiGroup Market
+ *
+ * @apiParam  {String} symbol the trade symbol
+ * @apiParam  {Number} [limit] the max trade count, default is 50, max is 100
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Success) {Array} trades the latest trade array, the newest trade is first
+ * @apiSuccess (Success) {String} trades.symbol the trade symbol
+ * @apiSuccess (Success) {Number} trades.sequence the matcher event sequence of trade
+ * @apiSuccess (Success) {String} trades.side the taker order side, supported is "buy"/"sell"
+ * @apiSuccess (Success) {String} trades.price the trade price
+ * @apiSuccess (Success) {String} trades.quantity the trade quantity
+ * @apiSuccess (Success) {Number} trades.time the trade time
+ *
+ * @apiParamExample  {Query} QueryTrades:
+ * symbol=spot.YWEUSDT&limit=10
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "trades": [
+ *         {
+ *             "symbol": "spot.YWEUSDT",
+ *             "sequence": 100,
+ *             "side": "buy",
+ *             "price": "100",
+ *             "quantity": "1",
+ *             "time": 1632578330897
+ *         }
+ *     ]
+ * }
+ *
+ */
+func ListTradesH(s *web.Session) web.Result {
+	var symbol string
+	var limit int = 50
+	var err = s.ValidFormat(`
+		symbol,R|S,L:0;
+		limit,O|I,R:1~100;
+	`, &symbol, &limit)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	trades, err := market.ListTrades(s.R.Context(), symbol, limit)
+	if err != nil {
+		xlog.Warnf("ListTradesH list trades fail with %v", err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":   0,
+		"trades": trades,
+	})
+}
+
+//ListTickerH is http handler
+/**
+ *
+ * @api {GET} /pub/listTicker List Ticker
+ * @apiName ListTicker
+ * @apiGroup Market
+ *
+ * @apiParam  {String} [symbols] the symbol to list, multi symbol is seperated by comma, all symbol is returned when it is empty
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Success) {Array} tickers the 24h rolling ticker array
+ * @apiSuccess (Success) {String} tickers.symbol the ticker symbol
+ * @apiSuccess (Success) {String} tickers.open the first trade price in 24h
+ * @apiSuccess (Success) {String} tickers.high the highest trade price in 24h
+ * @apiSuccess (Success) {String} tickers.low the lowest trade price in 24h
+ * @apiSuccess (Success) {String} tickers.last the last trade price
+ * @apiSuccess (Success) {String} tickers.volume the total traded quantity in 24h
+ * @apiSuccess (Success) {String} tickers.quote_volume the total traded price in 24h
+ * @apiSuccess (Success) {String} tickers.change the price change in 24h, it is last-open
+ * @apiSuccess (Success) {String} tickers.change_percent the price change percentage in 24h, it is (last-open)/open*100
+ * @apiSuccess (Success) {Number} tickers.count the trade count in 24h
+ * @apiSuccess (Success) {Number} tickers.open_time the window start time
+ * @apiSuccess (Success) {Number} tickers.close_time the window end time
+ *
+ * @apiParamExample  {Query} QueryTicker:
+ * symbols=spot.YWEUSDT,futures.YWEUSDT
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "tickers": [
+ *         {
+ *             "symbol": "spot.YWEUSDT",
+ *             "open": "100",
+ *             "high": "120",
+ *             "low": "90",
+ *             "last": "110",
+ *             "volume": "5",
+ *             "quote_volume": "510",
+ *             "change": "10",
+ *             "change_percent": "10",
+ *             "count": 4,
+ *             "open_time": 1632491930897,
+ *             "close_time": 1632578330897
+ *         }
+ *     ]
+ * }
+ *
+ */
+func ListTickerH(s *web.Session) web.Result {
+	var symbols []string
+	var err = s.ValidFormat(`
+		symbols,O|S,L:0;
+	`, &symbols)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	tickers := market.ListTicker(symbols...)
+	return s.SendJSON(xmap.M{
+		"code":    0,
+		"tickers": tickers,
+	})
+}
+
+//RebuildKLineH is http handler
+/**
+ *
+ * @api {GET} /usr/rebuildKLine Rebuild KLine
+ * @apiName RebuildKLine
+ * @apiGroup Market
+ *
+ * @apiParam  {String} symbol the kline symbol
+ * @apiParam  {String} interval the kline interval, supported is same as /pub/listKLine
+ * @apiParam  {Number} start_time rebuild kline kline.start_time>=start_time, it is aligned to kline bar start
+ * @apiParam  {Number} [end_time] rebuild kline kline.start_time<end_time, the current generating kline bar is never rebuild
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Success) {Array} lines the rebuild kline array which is generated by order transaction, see /pub/listKLine
+ *
+ * @apiParamExample  {Query} QueryOrder:
+ * symbol=spot.YWEUSDT&interval=5min&start_time=1632578100000&end_time=1632578400000
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "lines": [
+ *         {
+ *             "tid": 0,
+ *             "symbol": "spot.YWEUSDT",
+ *             "interval": "5min",
+ *             "amount": "1",
+ *             "count": 1,
+ *             "open": "100",
+ *             "close": "100",
+ *             "low": "100",
+ *             "high": "100",
+ *             "volume": "100",
+ *             "start_time": 1632578100000,
+ *             "update_time": 1632578330897
+ *         }
+ *     ]
+ * }
+ *
+ */
+func RebuildKLineH(s *web.Session) web.Result {
+	var symbol, interval string
+	var startTime, endTime int64
+	var err = s.ValidFormat(`
+		symbol,R|S,L:0;
+		interval,R|S,L:0;
+		start_time,R|I,R:0;
+		end_time,O|I,R:0;
+	`, &symbol, &interval, &startTime, &endTime)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if _, err = gexdb.StringInterv(interval); err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !AdminAccess(s) {
+		return util.ReturnCodeLocalErr(s, define.NotAccess, "srv-err", define.ErrNotAccess)
+	}
+	var end time.Time
+	if endTime > 0 {
+		end = xtime.TimeUnix(endTime)
+	}
+	lines, err := market.RebuildKLine(s.R.Context(), symbol, interval, xtime.TimeUnix(startTime), end)
+	if err != nil {
+		xlog.Warnf("RebuildKLineH rebuild kline by %v/%v fail with %v", symbol, interval, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":  0,
+		"lines": lines,
+	})
+}
@@ -23,6 +23,27 @@ func TestMarket(t *testing.T) {
 	loadDepthRes, _ := ts.Should(t, "code", define.Success, "/depth/bids", xmap.ShouldIsNoEmpty).GetMap("/pub/loadDepth?symbol=%v&max=%v", symbol, 10)
 	fmt.Printf("loadDepthRes--->%v\n", converter.JSON(loadDepthRes))
 
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/pub/listTrades?symbol=%v&limit=%v", symbol, 1000)
+	listTradesRes, _ := ts.Should(t, "code", define.Success, "trades", xmap.ShouldIsNoEmpty).GetMap("/pub/listTrades?symbol=%v&limit=%v", symbol, 10)
+	fmt.Printf("listTradesRes--->%v\n", converter.JSON(listTradesRes))
+
+	listTickerRes, _ := ts.Should(t, "code", define.Success, "tickers", xmap.ShouldIsNoEmpty).GetMap("/pub/listTicker")
+	fmt.Printf("listTickerRes--->%v\n", converter.JSON(listTickerRes))
+	ts.Should(t, "code", define.Success, "tickers", xmap.ShouldIsNoEmpty).GetMap("/pub/listTicker?symbols=%v", symbol)
+
+	//rebuild kline
+	startTime := xsql.TimeNow().Timestamp() - 3600*1000
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	ts.Should(t, "code", define.NotAccess).GetMap("/usr/rebuildKLine?symbol=%v&interval=5min&start_time=%v", symbol, startTime)
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/rebuildKLine?symbol=%v&interval=5min&start_time=%v", symbol, "xx")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/rebuildKLine?symbol=%v&interval=xx&start_time=%v", symbol, startTime)
+	rebuildKLine, _ := ts.Should(t, "code", define.Success).GetMap("/usr/rebuildKLine?symbol=%v&interval=5min&start_time=%v", symbol, startTime)
+	fmt.Printf("rebuildKLine--->%v\n", converter.JSON(rebuildKLine))
+	ts.Should(t, "code", define.ServerError).GetMap("/usr/rebuildKLine?symbol=%v&interval=5min&start_time=%v&end_time=%v", symbol, startTime, startTime)
+
 	//
 	//test error
 	pgx.MockerStart()
@@ -30,5 +51,6 @@ func TestMarket(t *testing.T) {
 	pgx.MockerClear()
 
 	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/pub/listKLine?symbol=%v&interval=5min&start_time=100&end_time=%v", symbol, xsql.TimeNow().Timestamp())
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/pub/listTrades?symbol=%v&limit=%v", "none", 10)
 
 }
@@ -108,6 +108,8 @@ func PlaceOrderH(s *web.Session) web.Result {
 		code := define.ServerError
 		if matcher.IsErrBalanceNotEnought(err) {
 			code = gexdb.CodeBalanceNotEnought
+		} else if limitCode := matcher.RiskLimitCode(err); limitCode > 0 {
+			code = limitCode
 		}
 		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
 	}
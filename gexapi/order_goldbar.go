@@ -1 +1,396 @@
 package gexapi
+
+import (
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xhash"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/web"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/base/util"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+)
+
+//CreateGoldbarOrderH is http handler
+/**
+ *
+ * @api {GET} /usr/createGoldbarOrder Create Goldbar Order
+ * @apiName CreateGoldbarOrder
+ * @apiGroup Order
+ *
+ * @apiParam  {Number} quantity the goldbar quantity to redeem, the quantity*goldbar_rate plus goldbar_fee of YWE is locked on funds balance, see <a href="#api-Conf-ConfGoldbar">ConfGoldbar</a>
+ * @apiParam  {String} city the pickup city, it must be in goldbar_address when configured
+ * @apiParam  {String} address the pickup address, it must be in goldbar_address when configured
+ * @apiParam  {String} password the trade password
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a> or <a href="#metadata-ExReturnCode">ExReturnCode</a>, 7600 is pickup address invalid, 7520 is out of daily quota, 7530 is out of monthly quota
+ * @apiSuccess (Withdraw) {Object} goldbar the created goldbar order info, the type is 300
+ * @apiUse WithdrawObject
+ * @apiSuccess (Success) {String} goldbar.transaction.code the pickup code, it will be verified when pickup, it is only returned to the order owner
+ * @apiSuccess (Success) {Array} goldbar.transaction.history the status history, the item is {status,user_id,note,create_time}
+ *
+ * @apiParamExample  {Query} CreateGoldbarOrder:
+ * quantity=1&city=city&address=address&password=123
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "goldbar": {
+ *         "address": "address",
+ *         "asset": "YWE",
+ *         "create_time": 1632668243553,
+ *         "creator": 100005,
+ *         "fee": "8",
+ *         "order_id": "202109262257230100001",
+ *         "quantity": "1608",
+ *         "status": 100,
+ *         "tid": 1000,
+ *         "transaction": {
+ *             "address": "address",
+ *             "city": "city",
+ *             "code": "023812",
+ *             "fee_rate": "0.005",
+ *             "history": [
+ *                 {
+ *                     "create_time": 1632668243553,
+ *                     "note": "",
+ *                     "status": 100,
+ *                     "user_id": 100005
+ *                 }
+ *             ],
+ *             "quantity": 1,
+ *             "rate": "1600"
+ *         },
+ *         "type": 300,
+ *         "update_time": 1632668243553,
+ *         "user_id": 100005
+ *     }
+ * }
+ *
+ */
+func CreateGoldbarOrderH(s *web.Session) web.Result {
+	var quantity int64
+	var city, address, password string
+	err := s.ValidFormat(`
+		quantity,R|I,R:0;
+		city,R|S,L:0;
+		address,R|S,L:0;
+		password,R|S,L:0;
+	`, &quantity, &city, &address, &password)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	err = gexdb.UserVerifyTradePassword(s.R.Context(), userID, xhash.SHA1([]byte(password)))
+	if err != nil {
+		xlog.Errorf("CreateGoldbarOrderH verify user %v trade password fail with %v", userID, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = gexdb.CodeTradePassInvalid
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	goldbar, err := gexdb.CreateGoldbar(s.R.Context(), userID, quantity, city, address)
+	if err != nil {
+		xlog.Errorf("CreateGoldbarOrderH create user %v goldbar %v to %v %v fail with %v", userID, quantity, city, address, err)
+		code := define.ServerError
+		if gexdb.IsErrBalanceNotEnought(err) || gexdb.IsErrBalanceNotFound(err) {
+			code = gexdb.CodeBalanceNotEnought
+		} else if gexdb.IsErrGoldbarAddressInvalid(err) {
+			code = gexdb.CodeGoldbarAddressInvalid
+		} else if gexdb.IsErrAssetInvalid(err) {
+			code = gexdb.CodeAssetInvalid
+		} else if gexdb.IsErrWithdrawDailyQuota(err) {
+			code = gexdb.CodeWithdrawDailyQuota
+		} else if gexdb.IsErrWithdrawMonthlyQuota(err) {
+			code = gexdb.CodeWithdrawMonthlyQuota
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	xlog.Infof("CreateGoldbarOrderH create goldbar %v by user %v from %v success", goldbar.OrderID, userID, s.R.RemoteAddr)
+	return s.SendJSON(xmap.M{
+		"code":    define.Success,
+		"goldbar": goldbar,
+	})
+}
+
+//CancelGoldbarOrderH is http handler
+/**
+ *
+ * @api {GET} /usr/cancelGoldbarOrder Cancel Goldbar Order
+ * @apiName CancelGoldbarOrder
+ * @apiGroup Order
+ *
+ * @apiParam  {String} order_id the goldbar order id, normal user only can cancel self pending order, admin can cancel all pending order
+ * @apiParam  {String} [note] the cancel note
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Withdraw) {Object} goldbar the canceled goldbar order info, the locked YWE is refunded to free
+ * @apiUse WithdrawObject
+ *
+ * @apiParamExample  {Query} CancelGoldbarOrder:
+ * order_id=202109262257230100001
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "goldbar": {
+ *         "address": "address",
+ *         "asset": "YWE",
+ *         "create_time": 1632668243553,
+ *         "creator": 100005,
+ *         "fee": "8",
+ *         "order_id": "202109262257230100001",
+ *         "quantity": "1608",
+ *         "status": 320,
+ *         "tid": 1000,
+ *         "transaction": {
+ *             "address": "address",
+ *             "city": "city",
+ *             "code": "023812",
+ *             "fee_rate": "0.005",
+ *             "history": [
+ *                 {
+ *                     "create_time": 1632668243553,
+ *                     "note": "",
+ *                     "status": 100,
+ *                     "user_id": 100005
+ *                 },
+ *                 {
+ *                     "create_time": 1632668253553,
+ *                     "note": "",
+ *                     "status": 320,
+ *                     "user_id": 100005
+ *                 }
+ *             ],
+ *             "quantity": 1,
+ *             "rate": "1600"
+ *         },
+ *         "type": 300,
+ *         "update_time": 1632668243553,
+ *         "user_id": 100005
+ *     }
+ * }
+ *
+ */
+func CancelGoldbarOrderH(s *web.Session) web.Result {
+	var orderID, note string
+	err := s.ValidFormat(`
+		order_id,R|S,L:0;
+		note,O|S,L:0;
+	`, &orderID, &note)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	targetUserID := userID
+	if AdminAccess(s) {
+		targetUserID = 0
+	}
+	goldbar, err := gexdb.CancelGoldbar(s.R.Context(), targetUserID, userID, orderID, note)
+	if err != nil {
+		xlog.Errorf("CancelGoldbarOrderH cancel goldbar %v by user %v fail with %v", orderID, userID, err)
+		code := define.ServerError
+		if err == define.ErrNotAccess {
+			code = define.NotAccess
+		} else if err == pgx.ErrNoRows {
+			code = define.NotFound
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	hideGoldbarCode(userID, goldbar)
+	xlog.Infof("CancelGoldbarOrderH cancel goldbar by user %v from %v success with %v", userID, s.R.RemoteAddr, converter.JSON(goldbar))
+	return s.SendJSON(xmap.M{
+		"code":    define.Success,
+		"goldbar": goldbar,
+	})
+}
+
+//VerifyGoldbarOrderH is http handler
+/**
+ *
+ * @api {GET} /usr/verifyGoldbarOrder Verify Goldbar Order
+ * @apiName VerifyGoldbarOrder
+ * @apiGroup Order
+ *
+ * @apiParam  {String} order_id the goldbar order id
+ * @apiParam  {String} code the pickup code which is showed by user
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a> or <a href="#metadata-ExReturnCode">ExReturnCode</a>, 7610 is pickup code invalid or locked by too many wrong code
+ * @apiSuccess (Withdraw) {Object} goldbar the done goldbar order info, the locked YWE is released
+ * @apiUse WithdrawObject
+ *
+ * @apiParamExample  {Query} VerifyGoldbarOrder:
+ * order_id=202109262257230100001&code=023812
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "goldbar": {
+ *         "address": "address",
+ *         "asset": "YWE",
+ *         "create_time": 1632668243553,
+ *         "creator": 100005,
+ *         "fee": "8",
+ *         "order_id": "202109262257230100001",
+ *         "quantity": "1608",
+ *         "status": 300,
+ *         "tid": 1000,
+ *         "transaction": {
+ *             "address": "address",
+ *             "city": "city",
+ *             "fee_rate": "0.005",
+ *             "history": [
+ *                 {
+ *                     "create_time": 1632668243553,
+ *                     "note": "",
+ *                     "status": 100,
+ *                     "user_id": 100005
+ *                 },
+ *                 {
+ *                     "create_time": 1632668253553,
+ *                     "note": "",
+ *                     "status": 300,
+ *                     "user_id": 100001
+ *                 }
+ *             ],
+ *             "quantity": 1,
+ *             "rate": "1600"
+ *         },
+ *         "type": 300,
+ *         "update_time": 1632668243553,
+ *         "user_id": 100005
+ *     }
+ * }
+ *
+ */
+func VerifyGoldbarOrderH(s *web.Session) web.Result {
+	var orderID, pickupCode string
+	err := s.ValidFormat(`
+		order_id,R|S,L:0;
+		code,R|S,L:0;
+	`, &orderID, &pickupCode)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !AdminAccess(s) {
+		return util.ReturnCodeLocalErr(s, define.NotAccess, "srv-err", define.ErrNotAccess)
+	}
+	userID := s.Value("user_id").(int64)
+	goldbar, err := gexdb.VerifyGoldbar(s.R.Context(), userID, orderID, pickupCode)
+	if err != nil {
+		xlog.Errorf("VerifyGoldbarOrderH verify goldbar %v by user %v fail with %v", orderID, userID, err)
+		code := define.ServerError
+		if gexdb.IsErrGoldbarCodeInvalid(err) {
+			code = gexdb.CodeGoldbarCodeInvalid
+		} else if err == pgx.ErrNoRows {
+			code = define.NotFound
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	hideGoldbarCode(userID, goldbar)
+	xlog.Infof("VerifyGoldbarOrderH verify goldbar by user %v from %v success with %v", userID, s.R.RemoteAddr, converter.JSON(goldbar))
+	return s.SendJSON(xmap.M{
+		"code":    define.Success,
+		"goldbar": goldbar,
+	})
+}
+
+//LoadGoldbarOrderH is http handler
+/**
+ *
+ * @api {GET} /usr/loadGoldbarOrder Load Goldbar Order
+ * @apiName LoadGoldbarOrder
+ * @apiGroup Order
+ *
+ * @apiParam  {String} order_id the goldbar order id, normal user only can load self order
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Withdraw) {Object} goldbar the goldbar order info, the status history is on transaction.history, the pickup code is only returned to the order owner
+ * @apiUse WithdrawObject
+ *
+ * @apiParamExample  {Query} LoadGoldbarOrder:
+ * order_id=202109262257230100001
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "goldbar": {
+ *         "address": "address",
+ *         "asset": "YWE",
+ *         "create_time": 1632668243553,
+ *         "creator": 100005,
+ *         "fee": "8",
+ *         "order_id": "202109262257230100001",
+ *         "quantity": "1608",
+ *         "status": 100,
+ *         "tid": 1000,
+ *         "transaction": {
+ *             "address": "address",
+ *             "city": "city",
+ *             "code": "023812",
+ *             "fee_rate": "0.005",
+ *             "history": [
+ *                 {
+ *                     "create_time": 1632668243553,
+ *                     "note": "",
+ *                     "status": 100,
+ *                     "user_id": 100005
+ *                 }
+ *             ],
+ *             "quantity": 1,
+ *             "rate": "1600"
+ *         },
+ *         "type": 300,
+ *         "update_time": 1632668243553,
+ *         "user_id": 100005
+ *     }
+ * }
+ *
+ */
+func LoadGoldbarOrderH(s *web.Session) web.Result {
+	var orderID string
+	err := s.ValidFormat(`
+		order_id,R|S,L:0;
+	`, &orderID)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	targetUserID := userID
+	if AdminAccess(s) {
+		targetUserID = 0
+	}
+	goldbar, err := gexdb.LoadGoldbar(s.R.Context(), targetUserID, orderID)
+	if err != nil {
+		xlog.Errorf("LoadGoldbarOrderH load goldbar %v by user %v fail with %v", orderID, userID, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = define.NotFound
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	hideGoldbarCode(userID, goldbar)
+	return s.SendJSON(xmap.M{
+		"code":    define.Success,
+		"goldbar": goldbar,
+	})
+}
+
+//hideGoldbarCode will remove the pickup code from goldbar order when the viewer is not the order owner
+func hideGoldbarCode(viewer int64, orders ...*gexdb.Withdraw) {
+	for _, order := range orders {
+		if order.Type == gexdb.WithdrawTypeGoldbar && order.UserID != viewer && order.Transaction != nil {
+			delete(order.Transaction, "code")
+		}
+	}
+}
@@ -1,229 +1,102 @@
 package gexapi
 
-// import (
-// 	"fmt"
-// 	"testing"
+import (
+	"fmt"
+	"testing"
 
-// 	"github.com/codingeasygo/crud/pgx"
-// 	"github.com/codingeasygo/util/converter"
-// 	"github.com/codingeasygo/util/xmap"
-// 	"github.com/shopspring/decimal"
-// 	"github.com/gexservice/gexservice/base/define"
-// 	"github.com/gexservice/gexservice/gexdb"
-// )
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
 
-// func TestGlodbarOrder(t *testing.T) {
-// 	userYWE := testAddUser(gexdb.UserRoleNormal, "TestGlodbarOrder-YWE")
-// 	_, err := gexdb.TouchBalance(ctx, gexdb.BalanceAssetAll, userYWE.TID)
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{
-// 		UserID: userYWE.TID,
-// 		Asset:  gexdb.BalanceAssetYWE,
-// 		Free:   decimal.NewFromFloat(10000),
-// 		Status: gexdb.BalanceStatusNormal,
-// 	})
-// 	{ //create user cancel
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userYWE.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createGoldbarOrder, err := ts.GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
-// 		if err != nil || createGoldbarOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,createGoldbarOrder:%v", err, createGoldbarOrder)
-// 			return
-// 		}
-// 		fmt.Printf("createGoldbarOrder--->%v\n", converter.JSON(createGoldbarOrder))
-// 		orderID := createGoldbarOrder.StrDef("", "/order/order_id")
+func TestGoldbarOrder(t *testing.T) {
+	defer basedb.StoreConf(ctx, gexdb.ConfigGoldbarAddress, "[]")
+	basedb.StoreConf(ctx, gexdb.ConfigGoldbarRate, "1600")
+	basedb.StoreConf(ctx, gexdb.ConfigGoldbarFee, "0.005")
+	basedb.StoreConf(ctx, gexdb.ConfigGoldbarAddress, `[{"city":"city","address":"address"}]`)
+	user := testAddUser(gexdb.UserRoleNormal, "TestGoldbarOrder")
+	gexdb.TouchBalance(ctx, gexdb.BalanceAreaFunds, []string{gexdb.BalanceAssetGoldbar}, user.TID)
+	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{
+		UserID: user.TID,
+		Area:   gexdb.BalanceAreaFunds,
+		Asset:  gexdb.BalanceAssetGoldbar,
+		Free:   decimal.NewFromFloat(10000),
+		Status: gexdb.BalanceStatusNormal,
+	})
+	{ //create user cancel
+		clearCookie()
+		ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+		createGoldbarOrder, _ := ts.Should(t, "code", define.Success, "/goldbar/quantity", "1608").GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
+		fmt.Printf("createGoldbarOrder--->%v\n", converter.JSON(createGoldbarOrder))
+		orderID := createGoldbarOrder.StrDef("", "/goldbar/order_id")
+		cancelGoldbarOrder, _ := ts.Should(t, "code", define.Success, "/goldbar/status", gexdb.WithdrawStatusCanceled).GetMap("/usr/cancelGoldbarOrder?order_id=%v&note=%v", orderID, "cancel")
+		fmt.Printf("cancelGoldbarOrder--->%v\n", converter.JSON(cancelGoldbarOrder))
+		loadGoldbarOrder, _ := ts.Should(t, "code", define.Success, "/goldbar/transaction/history", xmap.ShouldIsNoEmpty, "/goldbar/transaction/code", xmap.ShouldIsNoEmpty).GetMap("/usr/loadGoldbarOrder?order_id=%v", orderID)
+		fmt.Printf("loadGoldbarOrder--->%v\n", converter.JSON(loadGoldbarOrder))
+		ts.Should(t, "code", define.ServerError).GetMap("/usr/cancelGoldbarOrder?order_id=%v", orderID)
+	}
+	{ //create admin cancel
+		clearCookie()
+		ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+		createGoldbarOrder, _ := ts.Should(t, "code", define.Success).GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
+		orderID := createGoldbarOrder.StrDef("", "/goldbar/order_id")
+		clearCookie()
+		ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+		ts.Should(t, "code", define.Success, "/goldbar/transaction/code", xmap.ShouldIsNil).GetMap("/usr/loadGoldbarOrder?order_id=%v", orderID)
+		ts.Should(t, "code", define.Success, "/goldbar/status", gexdb.WithdrawStatusCanceled, "/goldbar/transaction/code", xmap.ShouldIsNil).GetMap("/usr/cancelGoldbarOrder?order_id=%v", orderID)
+	}
+	{ //create admin verify
+		clearCookie()
+		ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+		createGoldbarOrder, _ := ts.Should(t, "code", define.Success).GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
+		orderID := createGoldbarOrder.StrDef("", "/goldbar/order_id")
+		code := createGoldbarOrder.StrDef("", "/goldbar/transaction/code")
+		ts.Should(t, "code", define.NotAccess).GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, code)
+		clearCookie()
+		ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+		ts.Should(t, "code", gexdb.CodeGoldbarCodeInvalid).GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, "xxx")
+		verifyGoldbarOrder, _ := ts.Should(t, "code", define.Success, "/goldbar/status", gexdb.WithdrawStatusDone, "/goldbar/transaction/code", xmap.ShouldIsNil).GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, code)
+		fmt.Printf("verifyGoldbarOrder--->%v\n", converter.JSON(verifyGoldbarOrder))
+		ts.Should(t, "code", define.Success, "withdraws", xmap.ShouldIsNoEmpty, "/withdraws/0/transaction/code", xmap.ShouldIsNil).GetMap("/usr/searchWithdrawOrder?type=%v", gexdb.WithdrawTypeGoldbar)
+	}
+	balance, err := gexdb.FindBalanceByAsset(ctx, user.TID, gexdb.BalanceAreaFunds, gexdb.BalanceAssetGoldbar)
+	if err != nil || !balance.Free.Equal(decimal.NewFromFloat(8392)) || !balance.Locked.IsZero() {
+		t.Errorf("err:%v,balance:%v", err, balance)
+		return
+	}
+	//
+	//test error
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/createGoldbarOrder?quantity=x")
+	ts.Should(t, "code", gexdb.CodeTradePassInvalid).GetMap("/usr/createGoldbarOrder?quantity=1&password=1234&city=city&address=address")
+	ts.Should(t, "code", gexdb.CodeBalanceNotEnought).GetMap("/usr/createGoldbarOrder?quantity=100&password=123&city=city&address=address")
+	ts.Should(t, "code", gexdb.CodeGoldbarAddressInvalid).GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=other")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/cancelGoldbarOrder?order_id=%v", "")
+	ts.Should(t, "code", define.NotFound).GetMap("/usr/cancelGoldbarOrder?order_id=%v", "none")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/loadGoldbarOrder?order_id=%v", "")
+	ts.Should(t, "code", define.NotFound).GetMap("/usr/loadGoldbarOrder?order_id=%v", "none")
+	createGoldbarOrder, _ := ts.Should(t, "code", define.Success).GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
+	orderID := createGoldbarOrder.StrDef("", "/goldbar/order_id")
+	code := createGoldbarOrder.StrDef("", "/goldbar/transaction/code")
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
 
-// 		cancelGoldbarOrder, err := ts.GetMap("/usr/cancelGoldbarOrder?order_id=%v", orderID)
-// 		if err != nil || cancelGoldbarOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,cancelGoldbarOrder:%v", err, cancelGoldbarOrder)
-// 			return
-// 		}
-// 		fmt.Printf("cancelGoldbarOrder--->%v\n", converter.JSON(cancelGoldbarOrder))
-// 	}
-// 	{ //create admin cancel
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userYWE.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createGoldbarOrder, err := ts.GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
-// 		if err != nil || createGoldbarOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,createGoldbarOrder:%v", err, createGoldbarOrder)
-// 			return
-// 		}
-// 		fmt.Printf("createGoldbarOrder--->%v\n", converter.JSON(createGoldbarOrder))
-// 		orderID := createGoldbarOrder.StrDef("", "/order/order_id")
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
+	pgx.MockerSetCall("Pool.Begin", 1).Should(t, "code", define.ServerError).GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
+	pgx.MockerSetCall("Pool.Begin", 1).Should(t, "code", define.ServerError).GetMap("/usr/cancelGoldbarOrder?order_id=%v", orderID)
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/usr/loadGoldbarOrder?order_id=%v", orderID)
+	pgx.MockerClear()
 
-// 		clearCookie()
-// 		login, err = ts.GetMap("/pub/login?username=%v&password=%v", "admin", "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		cancelGoldbarOrder, err := ts.GetMap("/usr/cancelGoldbarOrder?order_id=%v", orderID)
-// 		if err != nil || cancelGoldbarOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,cancelGoldbarOrder:%v", err, cancelGoldbarOrder)
-// 			return
-// 		}
-// 		fmt.Printf("cancelGoldbarOrder--->%v\n", converter.JSON(cancelGoldbarOrder))
-// 	}
-// 	{ //create admin verify
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userYWE.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createGoldbarOrder, err := ts.GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
-// 		if err != nil || createGoldbarOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,createGoldbarOrder:%v", err, createGoldbarOrder)
-// 			return
-// 		}
-// 		fmt.Printf("createGoldbarOrder--->%v\n", converter.JSON(createGoldbarOrder))
-// 		orderID := createGoldbarOrder.StrDef("", "/order/order_id")
-// 		code := createGoldbarOrder.StrDef("", "/order/transaction/code")
-
-// 		verifyGoldbarOrder, err := ts.GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, code)
-// 		if err != nil || verifyGoldbarOrder.Int64("code") != define.NotAccess {
-// 			t.Errorf("err:%v,verifyGoldbarOrder:%v", err, verifyGoldbarOrder)
-// 			return
-// 		}
-
-// 		clearCookie()
-// 		login, err = ts.GetMap("/pub/login?username=%v&password=%v", "admin", "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		verifyGoldbarOrder, err = ts.GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, code)
-// 		if err != nil || verifyGoldbarOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,verifyGoldbarOrder:%v", err, verifyGoldbarOrder)
-// 			return
-// 		}
-// 		fmt.Printf("verifyGoldbarOrder--->%v\n", converter.JSON(verifyGoldbarOrder))
-// 		searchOrderRes, err := ts.GetMap("/usr/searchOrder?type=%v", gexdb.OrderTypeGoldbar)
-// 		if err != nil || searchOrderRes.Int64("code") != 0 {
-// 			t.Errorf("err:%v,searchOrderRes:%v", err, searchOrderRes)
-// 			return
-// 		}
-// 	}
-// 	{ //normal error
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userYWE.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createGoldbarOrder, err := ts.GetMap("/usr/createGoldbarOrder?quantity=1&password=1234&city=city&address=address")
-// 		if err != nil || createGoldbarOrder.Int64("code") != 8000 {
-// 			t.Errorf("err:%v,createGoldbarOrder:%v", err, createGoldbarOrder)
-// 			return
-// 		}
-// 		createGoldbarOrder, err = ts.GetMap("/usr/createGoldbarOrder?quantity=100000&password=123&city=city&address=address")
-// 		if err != nil || createGoldbarOrder.Int64("code") != 9000 {
-// 			t.Errorf("err:%v,createGoldbarOrder:%v", err, createGoldbarOrder)
-// 			return
-// 		}
-// 	}
-// 	//
-// 	//test error
-// 	pgx.MockerStart()
-// 	defer pgx.MockerStop()
-// 	pgx.MockerClear()
-// 	var res xmap.M
-
-// 	//to user
-// 	clearCookie()
-// 	login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userYWE.Account, "123")
-// 	if err != nil || login.Int64("code") != 0 {
-// 		t.Errorf("err:%v,code:%v", err, login)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//create error
-// 	res, err = ts.GetMap("/usr/createGoldbarOrder?quantity=x")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Pool.Begin", 1)
-// 	res, err = ts.GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//create test data
-// 	createGoldbarOrder, err := ts.GetMap("/usr/createGoldbarOrder?quantity=1&password=123&city=city&address=address")
-// 	if err != nil || createGoldbarOrder.Int64("code") != 0 {
-// 		t.Errorf("err:%v,createGoldbarOrder:%v", err, createGoldbarOrder)
-// 		return
-// 	}
-// 	orderID := createGoldbarOrder.StrDef("", "/order/order_id")
-// 	code := createGoldbarOrder.StrDef("", "/order/transaction/code")
-// 	pgx.MockerClear()
-
-// 	//cancel error
-// 	res, err = ts.GetMap("/usr/cancelGoldbarOrder?order_id=%v", "")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Row.Scan", 1)
-// 	res, err = ts.GetMap("/usr/cancelGoldbarOrder?order_id=%v", orderID)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Pool.Begin", 1)
-// 	res, err = ts.GetMap("/usr/cancelGoldbarOrder?order_id=%v", orderID)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//to admin
-// 	clearCookie()
-// 	login, err = ts.GetMap("/pub/login?username=%v&password=%v", "admin", "123")
-// 	if err != nil || login.Int64("code") != 0 {
-// 		t.Errorf("err:%v,code:%v", err, login)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//verify error
-// 	res, err = ts.GetMap("/usr/verifyGoldbarOrder?order_id=%v", "")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Row.Scan", 1)
-// 	res, err = ts.GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, code)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Pool.Begin", 1)
-// 	res, err = ts.GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, code)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// }
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/verifyGoldbarOrder?order_id=%v", "")
+	ts.Should(t, "code", define.NotFound).GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", "none", code)
+	pgx.MockerSetCall("Pool.Begin", 1).Should(t, "code", define.ServerError).GetMap("/usr/verifyGoldbarOrder?order_id=%v&code=%v", orderID, code)
+	pgx.MockerClear()
+}
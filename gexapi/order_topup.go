@@ -1,15 +1,20 @@
 package gexapi
 
 import (
+	"fmt"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
 	"github.com/codingeasygo/util/xmap"
 	"github.com/codingeasygo/web"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/base/util"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/gexpay"
+	"github.com/shopspring/decimal"
 )
 
-var CreatePrepayOrder = func(merchOrderID, title string, totalAmount float64, transCurrency string) (result xmap.M, err error) {
-	result = xmap.New()
-	return
-}
-
 //CreateTopupOrderH is http handler
 /**
  *
@@ -17,111 +22,151 @@ var CreatePrepayOrder = func(merchOrderID, title string, totalAmount float64, tr
  * @apiName CreateTopupOrder
  * @apiGroup Order
  *
- * @apiParam  {Number} amount the top up amount
+ * @apiParam  {String} asset the topup asset, it must be not locked and deposit enabled on <a href="#api-Asset-ListAsset">Asset</a>
+ * @apiParam  {Number} quantity the topup quantity, it must be not out of asset display_precision
  *
  *
- * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
- * @apiSuccess (Success) {Object} order the created order info
- * @apiSuccess (Success) {Number} order.tid the int order id
- * @apiSuccess (Success) {String} order.type the order type, all type supported is <a href="#metadata-Order">OrderTypeAll</a>
- * @apiSuccess (Success) {String} order.order_id the string order id
- * @apiSuccess (Success) {String} order.in_balance the in balance key
- * @apiSuccess (Success) {String} order.in_filled the in balance filled amount
- * @apiSuccess (Success) {Number} order.create_time the order create time
- * @apiSuccess (Success) {Number} order.update_time the order update time
- * @apiSuccess (Success) {Number} order.status the order status, all status supported is <a href="#metadata-Order">OrderStatusAll</a>
- * @apiSuccess (Success) {Object} order.prepay_result the order prepay result argument, it will be used to start pay on minapp
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a> or <a href="#metadata-ExReturnCode">ExReturnCode</a>
+ * @apiSuccess (Withdraw) {Object} topup the created topup order info, the type is 200, the funds balance is increased after it is paid
+ * @apiUse WithdrawObject
+ * @apiSuccess (Success) {Object} topup.transaction.prepay the payment prepay argument which is created by provider, it will be used to start pay on client
  *
  * @apiParamExample  {Query} CreateTopupOrder:
- * amount=100
+ * asset=USDT&quantity=100
  *
  *
  * @apiSuccessExample {JSON} Success-Response:
  * {
  *     "code": 0,
- *     "order": {
- *         "avg_price": "0",
- *         "create_time": 1632572929348,
- *         "creator": 100002,
- *         "fee_balance": "",
- *         "fee_filled": "0",
- *         "filled": "100",
- *         "in_balance": "MMK",
- *         "in_filled": "100",
- *         "notify_result": null,
- *         "order_id": "202109252028490100001",
- *         "out_balance": "",
- *         "out_filled": "0",
- *         "prepay_result": {
- *             "order_info": "abc=1",
- *             "prepay_id": "KBZ00b9a7127afe8193e124e59460c7c4bf37102005062",
- *             "resp": {
- *                 "code": "0",
- *                 "merch_order_id": "613c276c285c666229000001",
- *                 "msg": "success",
- *                 "nonce_str": "MB7TQ7VMWBY0MBNBBEA7WGRNG3YSLQUJ",
- *                 "prepay_id": "KBZ00b9a7127afe8193e124e59460c7c4bf37102005062",
- *                 "result": "SUCCESS",
- *                 "sign": "BA4173F40D3789D3EE6929CBB663FA3400DDB35E4446634EAB4FFCF71121A892",
- *                 "sign_type": "SHA256"
- *             },
- *             "sign": "e8258d2fd2b54b8a9950e676707358d7b5efdf46da992738961c80165ddb62a6",
- *             "sign_type": "SHA256",
- *             "trade_type": "APPH5"
- *         },
- *         "price": "0",
+ *     "topup": {
+ *         "address": "",
+ *         "asset": "USDT",
+ *         "create_time": 1632668243553,
+ *         "creator": 100005,
+ *         "fee": "0",
+ *         "order_id": "202109262257230100001",
  *         "quantity": "100",
- *         "status": 300,
+ *         "status": 100,
  *         "tid": 1000,
- *         "total_price": "0",
- *         "transaction": {},
- *         "type": 100,
- *         "update_time": 1632572929348,
- *         "user_id": 100002
+ *         "transaction": {
+ *             "prepay": {
+ *                 "amount": "100",
+ *                 "asset": "USDT",
+ *                 "order_id": "202109262257230100001",
+ *                 "sign": "a2fd0c4b3e1b2d21f5a2c0d7f6c7e31f2e1d9b1e6a0b5c4d3e2f1a0b9c8d7e6f"
+ *             },
+ *             "provider": "fake"
+ *         },
+ *         "type": 200,
+ *         "update_time": 1632668243553,
+ *         "user_id": 100005
  *     }
  * }
  *
  */
 func CreateTopupOrderH(s *web.Session) web.Result {
-	// var amount float64
-	// err := s.ValidFormat(`
-	// 	amount,R|F,R:0;
-	// `, &amount)
-	// if err != nil {
-	// 	return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
-	// }
-	// totalAmount := decimal.NewFromFloat(amount)
-	// userID := s.Int64("user_id")
-	// order := &gexdb.Order{
-	// 	Type:      gexdb.OrderTypeTopup,
-	// 	UserID:    userID,
-	// 	Creator:   userID,
-	// 	Quantity:  totalAmount,
-	// 	InBalance: gexdb.BalanceAssetMMK,
-	// 	Status:    gexdb.OrderStatusPending,
-	// }
-	// err = gexdb.CreateOrder(order)
-	// if err != nil {
-	// 	xlog.Errorf("CreateTopupOrderH create db order fail with %v by %v", err, converter.JSON(order))
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// result, err := CreatePrepayOrder(order.OrderID, "Top UP", amount, order.InBalance)
-	// if err != nil {
-	// 	xlog.Errorf("CreateTopupOrderH create prepay order fail with %v by %v", err, converter.JSON(order))
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// order.PrepayResult = xsql.M(result)
-	// err = gexdb.UpdateOrderPrepay(order.TID, order.PrepayResult)
-	// if err != nil {
-	// 	xlog.Errorf("CreateTopupOrderH update prepay to db order fail with %v by %v", err, converter.JSON(order))
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// xlog.Infof("CreateTopupOrderH create order from %v success with %v", s.R.RemoteAddr, converter.JSON(order))
-	// return s.SendJSON(xmap.M{
-	// 	"code":  0,
-	// 	"order": order,
-	// })
-	return web.Return
+	var asset string
+	var quantity decimal.Decimal
+	err := s.ValidFormat(`
+		asset,R|S,L:0;
+		quantity,R|F,R:0;
+	`, &asset, &quantity)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if gexpay.Shared == nil {
+		err = fmt.Errorf("payment provider is not ready")
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	topup, err := gexdb.CreateTopup(s.R.Context(), userID, asset, quantity)
+	if err != nil {
+		xlog.Errorf("CreateTopupOrderH create user %v topup %v %v fail with %v", userID, quantity, asset, err)
+		code := define.ServerError
+		if gexdb.IsErrAssetInvalid(err) {
+			code = gexdb.CodeAssetInvalid
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	prepay, err := gexpay.Shared.CreatePayment(s.R.Context(), topup.OrderID, topup.Asset, topup.Quantity)
+	if err != nil {
+		xlog.Errorf("CreateTopupOrderH create payment by %v fail with %v", converter.JSON(topup), err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	err = gexdb.UpdateTopupPrepay(s.R.Context(), topup, gexpay.Shared.Name(), prepay)
+	if err != nil {
+		xlog.Errorf("CreateTopupOrderH update prepay by %v fail with %v", converter.JSON(topup), err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	xlog.Infof("CreateTopupOrderH create topup from %v success with %v", s.R.RemoteAddr, converter.JSON(topup))
+	return s.SendJSON(xmap.M{
+		"code":  define.Success,
+		"topup": topup,
+	})
+}
+
+//TopupCallbackH is http handler
+/**
+ *
+ * @api {POST} /pub/topupCallback Topup Callback
+ * @apiName TopupCallback
+ * @apiGroup Order
+ *
+ * @apiParam  {String} order_id the paid topup order id
+ * @apiParam  {Number} amount the paid amount, it must be equal to topup quantity
+ * @apiParam  {String} status the payment status, paid is success
+ * @apiParam  {String} sign the callback sign by payment provider
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>, the repeated callback is success and the balance is increased only once, the late paid topup after canceled is also credited
+ *
+ * @apiParamExample  {Query} TopupCallback:
+ * order_id=202109262257230100001&amount=100&status=paid&nonce=xx&sign=xxx
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0
+ * }
+ *
+ */
+func TopupCallbackH(s *web.Session) web.Result {
+	if gexpay.Shared == nil {
+		err := fmt.Errorf("payment provider is not ready")
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	err := s.R.ParseForm()
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	args := xmap.M{}
+	for key := range s.R.Form {
+		args[key] = s.R.Form.Get(key)
+	}
+	payment, err := gexpay.Shared.VerifyCallback(s.R.Context(), args)
+	if err != nil {
+		xlog.Warnf("TopupCallbackH verify callback from %v fail with %v by %v", s.R.RemoteAddr, err, converter.JSON(args))
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !payment.Paid {
+		xlog.Infof("TopupCallbackH receive not paid callback from %v by %v", s.R.RemoteAddr, converter.JSON(args))
+		return s.SendJSON(xmap.M{
+			"code": define.Success,
+		})
+	}
+	_, paid, err := gexdb.PayTopup(s.R.Context(), payment.OrderID, payment.Amount, payment.Result)
+	if err != nil {
+		xlog.Errorf("TopupCallbackH pay topup %v by %v fail with %v", payment.OrderID, payment.Amount, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = define.NotFound
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	xlog.Infof("TopupCallbackH pay topup %v by %v from %v success with paid:%v", payment.OrderID, payment.Amount, s.R.RemoteAddr, paid)
+	return s.SendJSON(xmap.M{
+		"code": define.Success,
+	})
 }
 
 //MockPayTopupOrderH is http handler
@@ -148,23 +193,22 @@ func CreateTopupOrderH(s *web.Session) web.Result {
  *
  */
 func MockPayTopupOrderH(s *web.Session) web.Result {
-	// var orderID string
-	// var amount float64
-	// err := s.ValidFormat(`
-	// 	order_id,R|S,L:0;
-	// 	amount,R|F,R:0;
-	// `, &orderID, &amount)
-	// if err != nil {
-	// 	return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
-	// }
-	// err = gexdb.PayTopupOrder(orderID, decimal.NewFromFloat(amount), xmap.M{"mock": 1, "amount": amount, "remote": s.R.RemoteAddr})
-	// if err != nil {
-	// 	xlog.Errorf("MockPayTopupOrderH mock pay topup order fail with %v by orderID:%v,amount:%v", err, orderID, amount)
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// xlog.Warnf("MockPayTopupOrderH mock pay topup order from %v success with orderID:%v,amount:%v", s.R.RemoteAddr, orderID, amount)
-	// return s.SendJSON(xmap.M{
-	// 	"code": 0,
-	// })
-	return web.Return
+	var orderID string
+	var amount decimal.Decimal
+	err := s.ValidFormat(`
+		order_id,R|S,L:0;
+		amount,R|F,R:0;
+	`, &orderID, &amount)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	_, paid, err := gexdb.PayTopup(s.R.Context(), orderID, amount, xmap.M{"mock": 1, "amount": amount, "remote": s.R.RemoteAddr})
+	if err != nil {
+		xlog.Errorf("MockPayTopupOrderH mock pay topup order fail with %v by orderID:%v,amount:%v", err, orderID, amount)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	xlog.Warnf("MockPayTopupOrderH mock pay topup order from %v success with orderID:%v,amount:%v,paid:%v", s.R.RemoteAddr, orderID, amount, paid)
+	return s.SendJSON(xmap.M{
+		"code": define.Success,
+	})
 }
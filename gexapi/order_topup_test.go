@@ -1,221 +1,109 @@
 package gexapi
 
-// import (
-// 	"fmt"
-// 	"testing"
-
-// 	"github.com/codingeasygo/crud/pgx"
-// 	"github.com/codingeasygo/util/converter"
-// 	"github.com/codingeasygo/util/xmap"
-// 	"github.com/gexservice/gexservice/base/define"
-// )
-
-// var CreatePrepayOrderErr error
-
-// func init() {
-// 	CreatePrepayOrder("", "", 100, "")
-// 	CreatePrepayOrder = func(merchOrderID, title string, totalAmount float64, transCurrency string) (result xmap.M, err error) {
-// 		result = xmap.New()
-// 		err = CreatePrepayOrderErr
-// 		return
-// 	}
-// }
-
-// func TestTopupOrder(t *testing.T) {
-// 	login, err := ts.GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
-// 	if err != nil || login.Int64("code") != 0 {
-// 		t.Errorf("err:%v,code:%v", err, login)
-// 		return
-// 	}
-// 	//
-// 	//test create popup order
-// 	createTopupOrderRes, err := ts.GetMap("/usr/createTopupOrder?amount=%v", 100)
-// 	if err != nil || createTopupOrderRes.Int64("code") != 0 {
-// 		t.Errorf("err:%v,createTopupOrderRes:%v", err, createTopupOrderRes)
-// 		return
-// 	}
-// 	fmt.Printf("createTopupOrderRes--->%v\n", converter.JSON(createTopupOrderRes))
-// 	topupOrderID := createTopupOrderRes.StrDef("", "/order/order_id")
-
-// 	mockPayTopupOrderRes, err := ts.GetMap("/usr/mockPayTopupOrder?order_id=%v&amount=%v", topupOrderID, 100)
-// 	if err != nil || mockPayTopupOrderRes.Int64("code") != 0 {
-// 		t.Errorf("err:%v,mockPayTopupOrderRes:%v", err, mockPayTopupOrderRes)
-// 		return
-// 	}
-// 	fmt.Printf("mockPayTopupOrderRes--->%v\n", converter.JSON(mockPayTopupOrderRes))
-
-// 	//
-// 	//test search order
-// 	searchOrderRes, err := ts.GetMap("/usr/searchOrder")
-// 	if err != nil || searchOrderRes.Int64("code") != 0 {
-// 		t.Errorf("err:%v,searchOrderRes:%v", err, searchOrderRes)
-// 		return
-// 	}
-// 	fmt.Printf("searchOrderRes--->%v\n", converter.JSON(searchOrderRes))
-
-// 	//
-// 	//test query order
-// 	queryOrderRes, err := ts.GetMap("/usr/queryOrder?order_id=%v", topupOrderID)
-// 	if err != nil || queryOrderRes.Int64("code") != 0 {
-// 		t.Errorf("err:%v,queryOrderRes:%v", err, queryOrderRes)
-// 		return
-// 	}
-// 	fmt.Printf("queryOrderRes--->%v\n", converter.JSON(queryOrderRes))
-
-// 	//login to other
-// 	clearCookie()
-// 	login, err = ts.GetMap("/pub/login?username=%v&password=%v", "abc2", "123")
-// 	if err != nil || login.Int64("code") != 0 {
-// 		t.Errorf("err:%v,code:%v", err, login)
-// 		return
-// 	}
-// 	queryOrderRes, err = ts.GetMap("/usr/queryOrder?order_id=%v", topupOrderID)
-// 	if err != nil || queryOrderRes.Int64("code") != define.NotAccess {
-// 		t.Errorf("err:%v,queryOrderRes:%v", err, queryOrderRes)
-// 		return
-// 	}
-
-// 	//login to admin
-// 	clearCookie()
-// 	login, err = ts.GetMap("/pub/login?username=%v&password=%v", "admin", "123")
-// 	if err != nil || login.Int64("code") != 0 {
-// 		t.Errorf("err:%v,code:%v", err, login)
-// 		return
-// 	}
-// 	queryOrderRes, err = ts.GetMap("/usr/queryOrder?order_id=%v", topupOrderID)
-// 	if err != nil || queryOrderRes.Int64("code") != define.Success {
-// 		t.Errorf("err:%v,queryOrderRes:%v", err, queryOrderRes)
-// 		return
-// 	}
-// 	searchOrderRes, err = ts.GetMap("/usr/searchOrder")
-// 	if err != nil || searchOrderRes.Int64("code") != 0 {
-// 		t.Errorf("err:%v,searchOrderRes:%v", err, searchOrderRes)
-// 		return
-// 	}
-// 	fmt.Printf("searchOrderRes--->%v\n", converter.JSON(searchOrderRes))
-
-// 	//
-// 	//test error
-// 	pgx.MockerStart()
-// 	defer pgx.MockerStop()
-// 	pgx.MockerClear()
-// 	var res xmap.M
-
-// 	//
-// 	//test create topup error
-// 	res, err = ts.GetMap("/usr/createTopupOrder?amount=%v", "xxxx")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	pgx.MockerSet("Row.Scan", 1)
-// 	res, err = ts.GetMap("/usr/createTopupOrder?amount=%v", 100)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	CreatePrepayOrderErr = fmt.Errorf("test error")
-// 	res, err = ts.GetMap("/usr/createTopupOrder?amount=%v", 100)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	CreatePrepayOrderErr = nil
-// 	pgx.MockerClear()
-
-// 	pgx.MockerSet("Pool.Exec", 1)
-// 	res, err = ts.GetMap("/usr/createTopupOrder?amount=%v", 100)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//
-// 	//test mock pay topup order error
-// 	res, err = ts.GetMap("/usr/mockPayTopupOrder?order_id=%v", "")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	pgx.MockerSet("Pool.Begin", 1)
-// 	res, err = ts.GetMap("/usr/mockPayTopupOrder?order_id=%v&amount=%v", topupOrderID, 100)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//
-// 	//test query order error
-// 	res, err = ts.GetMap("/usr/queryOrder?order_id=%v", "")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	pgx.MockerSet("Row.Scan", 1)
-// 	res, err = ts.GetMap("/usr/queryOrder?order_id=%v", topupOrderID)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	pgx.MockerSet("Row.Scan", 2)
-// 	res, err = ts.GetMap("/usr/queryOrder?order_id=%v", topupOrderID)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//
-// 	//test search order error
-// 	clearCookie() //to admin
-// 	login, err = ts.GetMap("/pub/login?username=%v&password=%v", "admin", "123")
-// 	if err != nil || login.Int64("code") != 0 {
-// 		t.Errorf("err:%v,code:%v", err, login)
-// 		return
-// 	}
-// 	res, err = ts.GetMap("/usr/searchOrder?user_id=xx")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	res, err = ts.GetMap("/usr/searchOrder?type=xx")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Row.Scan", 1)
-// 	res, err = ts.GetMap("/usr/searchOrder")
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Pool.Query", 1)
-// 	res, err = ts.GetMap("/usr/searchOrder")
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Pool.Query", 2)
-// 	res, err = ts.GetMap("/usr/searchOrder")
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// }
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/gexpay"
+	"github.com/shopspring/decimal"
+)
+
+func topupCallbackQuery(args xmap.M) string {
+	query := url.Values{}
+	for key, val := range args {
+		query.Set(key, fmt.Sprintf("%v", val))
+	}
+	return query.Encode()
+}
+
+func TestTopupOrder(t *testing.T) {
+	provider := gexpay.NewFakeProvider("123")
+	gexpay.Shared = provider
+	defer func() {
+		gexpay.Shared = nil
+	}()
+	user := testAddUser(gexdb.UserRoleNormal, "TestTopupOrder")
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	createTopupOrder, _ := ts.Should(t, "code", define.Success, "/topup/transaction/provider", "fake").GetMap("/usr/createTopupOrder?asset=%v&quantity=100", spotBalanceQuote)
+	fmt.Printf("createTopupOrder--->%v\n", converter.JSON(createTopupOrder))
+	orderID := createTopupOrder.StrDef("", "/topup/order_id")
+	args, err := provider.Pay(orderID, decimal.NewFromFloat(100))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//callback twice is paid once
+	ts.Should(t, "code", define.Success).GetMap("/pub/topupCallback?%v", topupCallbackQuery(args))
+	ts.Should(t, "code", define.Success).GetMap("/pub/topupCallback?%v", topupCallbackQuery(args))
+	balance, err := gexdb.FindBalanceByAsset(ctx, user.TID, gexdb.BalanceAreaFunds, spotBalanceQuote)
+	if err != nil || !balance.Free.Equal(decimal.NewFromFloat(100)) {
+		t.Errorf("err:%v,balance:%v", err, balance)
+		return
+	}
+	//not paid
+	unpaid := xmap.M{"order_id": orderID, "amount": "100", "status": "unpaid"}
+	unpaid["sign"] = provider.Sign(unpaid)
+	ts.Should(t, "code", define.Success).GetMap("/pub/topupCallback?%v", topupCallbackQuery(unpaid))
+	//mock pay
+	createTopupOrder, _ = ts.Should(t, "code", define.Success).GetMap("/usr/createTopupOrder?asset=%v&quantity=100", spotBalanceQuote)
+	mockOrderID := createTopupOrder.StrDef("", "/topup/order_id")
+	ts.Should(t, "code", define.Success).GetMap("/usr/mockPayTopupOrder?order_id=%v&amount=%v", mockOrderID, 100)
+	ts.Should(t, "code", define.Success).GetMap("/usr/mockPayTopupOrder?order_id=%v&amount=%v", mockOrderID, 100)
+	balance, err = gexdb.FindBalanceByAsset(ctx, user.TID, gexdb.BalanceAreaFunds, spotBalanceQuote)
+	if err != nil || !balance.Free.Equal(decimal.NewFromFloat(200)) {
+		t.Errorf("err:%v,balance:%v", err, balance)
+		return
+	}
+	//late paid after canceled
+	createTopupOrder, _ = ts.Should(t, "code", define.Success).GetMap("/usr/createTopupOrder?asset=%v&quantity=100", spotBalanceQuote)
+	lateOrderID := createTopupOrder.StrDef("", "/topup/order_id")
+	err = gexdb.CancelTopup(ctx, lateOrderID, "expired")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	lateArgs, err := provider.Pay(lateOrderID, decimal.NewFromFloat(100))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ts.Should(t, "code", define.Success).GetMap("/pub/topupCallback?%v", topupCallbackQuery(lateArgs))
+	balance, err = gexdb.FindBalanceByAsset(ctx, user.TID, gexdb.BalanceAreaFunds, spotBalanceQuote)
+	if err != nil || !balance.Free.Equal(decimal.NewFromFloat(300)) {
+		t.Errorf("err:%v,balance:%v", err, balance)
+		return
+	}
+	//
+	//test error
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/createTopupOrder?asset=%v", spotBalanceQuote)
+	ts.Should(t, "code", gexdb.CodeAssetInvalid).GetMap("/usr/createTopupOrder?asset=%v&quantity=0", spotBalanceQuote)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/mockPayTopupOrder?order_id=%v", mockOrderID)
+	ts.Should(t, "code", define.ServerError).GetMap("/usr/mockPayTopupOrder?order_id=%v&amount=%v", "none", 100)
+	badSign := xmap.M{}
+	for key, val := range args {
+		badSign[key] = val
+	}
+	badSign["amount"] = "1000"
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/pub/topupCallback?%v", topupCallbackQuery(badSign))
+	notFound := xmap.M{"order_id": "none", "amount": "100", "status": "paid"}
+	notFound["sign"] = provider.Sign(notFound)
+	ts.Should(t, "code", define.NotFound).GetMap("/pub/topupCallback?%v", topupCallbackQuery(notFound))
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Pool.Exec", 1).Should(t, "code", define.ServerError).GetMap("/usr/createTopupOrder?asset=%v&quantity=100", spotBalanceQuote)
+	pgx.MockerSetCall("Pool.Begin", 1).Should(t, "code", define.ServerError).GetMap("/pub/topupCallback?%v", topupCallbackQuery(args))
+	pgx.MockerClear()
+
+	gexpay.Shared = nil
+	ts.Should(t, "code", define.ServerError).GetMap("/usr/createTopupOrder?asset=%v&quantity=100", spotBalanceQuote)
+	ts.Should(t, "code", define.ServerError).GetMap("/pub/topupCallback?%v", topupCallbackQuery(args))
+}
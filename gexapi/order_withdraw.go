@@ -1,7 +1,20 @@
 package gexapi
 
 import (
+	"fmt"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xhash"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xsql"
 	"github.com/codingeasygo/web"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/base/util"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
 )
 
 //CreateWithdrawOrderH is http handler
@@ -11,51 +24,35 @@ import (
  * @apiName CreateWithdrawOrder
  * @apiGroup Order
  *
- * @apiParam  {Number} quantity the withdraw quantity
+ * @apiParam  {String} asset the withdraw asset, it must be not locked and withdraw enabled on <a href="#api-Asset-ListAsset">Asset</a>
+ * @apiParam  {Number} quantity the withdraw quantity, it must be not less than asset withdraw_min and not out of asset display_precision, the asset withdraw_fee is included in quantity
+ * @apiParam  {String} address the withdraw destination address, the saved address must be available, the not saved address is rejected when whitelist mode is enabled, else it can be confirmed after withdraw_address_delay, see <a href="#api-Withdraw-ListWithdrawAddress">ListWithdrawAddress</a>
  * @apiParam  {String} password the trade password
  *
  *
- * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>, 8000 is trade password error, 9000 is balance not enought
- * @apiSuccess (Success) {Object} order the created order info
- * @apiSuccess (Success) {Number} order.tid the int order id
- * @apiSuccess (Success) {String} order.type the order type, all type supported is <a href="#metadata-Order">OrderTypeAll</a>
- * @apiSuccess (Success) {String} order.order_id the string order id
- * @apiSuccess (Success) {String} order.transaction.code the order verify code
- * @apiSuccess (Success) {Number} order.create_time the order create time
- * @apiSuccess (Success) {Number} order.update_time the order update time
- * @apiSuccess (Success) {Number} order.status the order status, all status supported is <a href="#metadata-Order">OrderStatusAll</a>
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a> or <a href="#metadata-ExReturnCode">ExReturnCode</a>, 7510 is out of withdraw_max, 7520 is out of daily quota, 7530 is out of monthly quota, 7540 is address not allowed
+ * @apiSuccess (Withdraw) {Object} withdraw the created withdraw order info
+ * @apiUse WithdrawObject
  *
- * @apiParamExample  {Query} CreateTopupOrder:
- * quantity=1&password=11
+ * @apiParamExample  {Query} CreateWithdrawOrder:
+ * asset=YWE&address=xxx&quantity=1&password=11
  *
  *
  * @apiSuccessExample {JSON} Success-Response:
  * {
  *     "code": 0,
- *     "order": {
- *         "avg_price": "0",
+ *     "withdraw": {
+ *         "address": "xxx",
+ *         "asset": "YWE",
  *         "create_time": 1632668243553,
  *         "creator": 100005,
- *         "fee_balance": "",
- *         "fee_filled": "0",
- *         "filled": "0",
- *         "in_balance": "",
- *         "in_filled": "0",
- *         "notify_result": {},
+ *         "fee": "0.1",
  *         "order_id": "202109262257230100001",
- *         "out_balance": "YWE",
- *         "out_filled": "0",
- *         "prepay_result": {},
- *         "price": "0",
  *         "quantity": "1",
- *         "side": "",
  *         "status": 100,
  *         "tid": 1000,
- *         "total_price": "0",
- *         "transaction": {
- *             "code": "5E48A8"
- *         },
- *         "type": 400,
+ *         "transaction": {},
+ *         "type": 100,
  *         "update_time": 1632668243553,
  *         "user_id": 100005
  *     }
@@ -63,50 +60,186 @@ import (
  *
  */
 func CreateWithdrawOrderH(s *web.Session) web.Result {
-	// var quantity float64
-	// var password string
-	// err := s.ValidFormat(`
-	// 	quantity,R|I,R:0;
-	// 	password,R|S,L:0;
-	// `, &quantity, &password)
-	// if err != nil {
-	// 	return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
-	// }
-	// userID := s.Int64("user_id")
-	// err = gexdb.UserVerifyTradePassword(userID, xhash.SHA1([]byte(password)))
-	// if err != nil {
-	// 	xlog.Errorf("CreateWithdrawOrderH verify trad pass fail with %v", err)
-	// 	code := define.ServerError
-	// 	if err == pgx.ErrNoRows {
-	// 		code = 8000
-	// 	}
-	// 	return util.ReturnCodeLocalErr(s, code, "srv-err", err)
-	// }
-	// user, err := gexdb.FindUser(s.R.Context(), userID)
-	// if err != nil {
-	// 	xlog.Errorf("CreateWithdrawOrderH find user fail with %v", err)
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// if user.KbzOpenid == nil || len(*user.KbzOpenid) < 1 {
-	// 	err = fmt.Errorf("not kbzpay user")
-	// 	xlog.Errorf("CreateWithdrawOrderH check user fail with %v", err)
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// order, err := gexdb.CreateWithdrawOrder(userID, decimal.NewFromFloat(quantity).Truncate(2))
-	// if err != nil {
-	// 	xlog.Errorf("CreateWithdrawOrderH create withdraw order fail with %v", err)
-	// 	code := define.ServerError
-	// 	if err == gexdb.ErrBalanceNotEnought {
-	// 		code = 9000
-	// 	}
-	// 	return util.ReturnCodeLocalErr(s, code, "srv-err", err)
-	// }
-	// xlog.Infof("CreateWithdrawOrderH create order from %v success with %v", s.R.RemoteAddr, converter.JSON(order))
-	// return s.SendJSON(xmap.M{
-	// 	"code":  0,
-	// 	"order": order,
-	// })
-	return web.Return
+	var asset, address, password string
+	var quantity decimal.Decimal
+	err := s.ValidFormat(`
+		asset,R|S,L:0;
+		address,R|S,L:0;
+		quantity,R|F,R:0;
+		password,R|S,L:0;
+	`, &asset, &address, &quantity, &password)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	err = gexdb.UserVerifyTradePassword(s.R.Context(), userID, xhash.SHA1([]byte(password)))
+	if err != nil {
+		xlog.Errorf("CreateWithdrawOrderH verify user %v trade password fail with %v", userID, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = gexdb.CodeTradePassInvalid
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	withdraw, err := gexdb.CreateWithdraw(s.R.Context(), userID, asset, address, quantity)
+	if err != nil {
+		xlog.Errorf("CreateWithdrawOrderH create user %v withdraw %v %v to %v fail with %v", userID, quantity, asset, address, err)
+		code := define.ServerError
+		if gexdb.IsErrBalanceNotEnought(err) {
+			code = gexdb.CodeBalanceNotEnought
+		} else if gexdb.IsErrAssetInvalid(err) {
+			code = gexdb.CodeAssetInvalid
+		} else if gexdb.IsErrWithdrawMax(err) {
+			code = gexdb.CodeWithdrawMax
+		} else if gexdb.IsErrWithdrawDailyQuota(err) {
+			code = gexdb.CodeWithdrawDailyQuota
+		} else if gexdb.IsErrWithdrawMonthlyQuota(err) {
+			code = gexdb.CodeWithdrawMonthlyQuota
+		} else if gexdb.IsErrWithdrawAddressInvalid(err) {
+			code = gexdb.CodeWithdrawAddressInvalid
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	xlog.Infof("CreateWithdrawOrderH create withdraw from %v success with %v", s.R.RemoteAddr, converter.JSON(withdraw))
+	return s.SendJSON(xmap.M{
+		"code":     define.Success,
+		"withdraw": withdraw,
+	})
+}
+
+//LoadWithdrawFormH is http handler
+/**
+ *
+ * @api {GET} /usr/loadWithdrawForm Load Withdraw Form
+ * @apiName LoadWithdrawForm
+ * @apiGroup Order
+ *
+ * @apiParam  {String} asset the withdraw asset
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Success) {Object} asset the asset info on registry, it is null when asset is not registered
+ * @apiSuccess (Success) {Object} balance the funds balance of asset, it is null when balance is not found
+ * @apiSuccess (Success) {String} withdraw_max the max quantity of each withdraw, zero is not limited
+ * @apiSuccess (Success) {Boolean} whitelist whether withdraw whitelist mode is enabled
+ * @apiSuccess (Success) {Array} addresses the saved withdraw address of asset, see <a href="#api-Withdraw-ListWithdrawAddress">ListWithdrawAddress</a>
+ * @apiSuccess (Success) {Object} quota the withdraw quota of user on asset, zero limit is not limited
+ * @apiSuccess (Success) {String} quota.tier the user tier
+ * @apiSuccess (Success) {String} quota.daily the daily quota limit
+ * @apiSuccess (Success) {String} quota.daily_used the daily quota used by not canceled withdraw
+ * @apiSuccess (Success) {String} quota.daily_remain the daily quota remain, it is null when not limited
+ * @apiSuccess (Success) {String} quota.monthly the monthly quota limit
+ * @apiSuccess (Success) {String} quota.monthly_used the monthly quota used by not canceled withdraw
+ * @apiSuccess (Success) {String} quota.monthly_remain the monthly quota remain, it is null when not limited
+ *
+ * @apiParamExample  {Query} LoadWithdrawForm:
+ * asset=YWE
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "addresses": [
+ *         {
+ *             "address": "xxx",
+ *             "asset": "YWE",
+ *             "available_time": 1667561852051,
+ *             "create_time": 1667475452051,
+ *             "label": "home",
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "update_time": 1667475452051,
+ *             "user_id": 100005
+ *         }
+ *     ],
+ *     "asset": {
+ *         "asset": "YWE",
+ *         "create_time": 1667475452051,
+ *         "deposit": 100,
+ *         "display_precision": 4,
+ *         "name": "Gold",
+ *         "status": 100,
+ *         "tid": 1000,
+ *         "update_time": 1667475452051,
+ *         "withdraw": 100,
+ *         "withdraw_fee": "0.1",
+ *         "withdraw_min": "0.01"
+ *     },
+ *     "balance": {
+ *         "area": 100,
+ *         "asset": "YWE",
+ *         "create_time": 1667475452051,
+ *         "free": "99",
+ *         "locked": "1",
+ *         "status": 100,
+ *         "tid": 1000,
+ *         "update_time": 1667475452051,
+ *         "user_id": 100005
+ *     },
+ *     "code": 0,
+ *     "quota": {
+ *         "asset": "YWE",
+ *         "daily": "100",
+ *         "daily_remain": "99",
+ *         "daily_used": "1",
+ *         "monthly": "0",
+ *         "monthly_remain": null,
+ *         "monthly_used": "1",
+ *         "tier": ""
+ *     },
+ *     "whitelist": false,
+ *     "withdraw_max": "50000"
+ * }
+ *
+ */
+func LoadWithdrawFormH(s *web.Session) web.Result {
+	var asset string
+	err := s.ValidFormat(`
+		asset,R|S,L:0;
+	`, &asset)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	_, assetMap, err := gexdb.ListAsset(s.R.Context(), asset)
+	if err != nil {
+		xlog.Errorf("LoadWithdrawFormH list asset %v fail with %v", asset, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	balance, err := gexdb.FindBalanceByAsset(s.R.Context(), userID, gexdb.BalanceAreaFunds, asset)
+	if err != nil && err != pgx.ErrNoRows {
+		xlog.Errorf("LoadWithdrawFormH find user %v balance %v fail with %v", userID, asset, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	quota, err := gexdb.LoadWithdrawQuota(s.R.Context(), userID, asset)
+	if err != nil {
+		xlog.Errorf("LoadWithdrawFormH load user %v quota %v fail with %v", userID, asset, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	var withdrawMax float64
+	err = basedb.LoadConf(s.R.Context(), gexdb.ConfigWithdrawMax, &withdrawMax)
+	if err != nil && err != pgx.ErrNoRows {
+		xlog.Errorf("LoadWithdrawFormH load withdraw max fail with %v", err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	addressList, err := gexdb.ListWithdrawAddress(s.R.Context(), userID, asset)
+	if err != nil {
+		xlog.Errorf("LoadWithdrawFormH list user %v withdraw address %v fail with %v", userID, asset, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	whitelist, err := gexdb.FindUserWithdrawWhitelist(s.R.Context(), userID)
+	if err != nil {
+		xlog.Errorf("LoadWithdrawFormH find user %v withdraw whitelist fail with %v", userID, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":         define.Success,
+		"addresses":    addressList,
+		"whitelist":    whitelist,
+		"asset":        assetMap[asset],
+		"balance":      balance,
+		"quota":        quota,
+		"withdraw_max": decimal.NewFromFloat(withdrawMax),
+	})
 }
 
 //CancelWithdrawOrderH is http handler
@@ -120,79 +253,200 @@ func CreateWithdrawOrderH(s *web.Session) web.Result {
  *
  *
  * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
- * @apiSuccess (Success) {Object} order the created order info
- * @apiSuccess (Success) {Number} order.tid the int order id
- * @apiSuccess (Success) {String} order.type the order type, all type supported is <a href="#metadata-Order">OrderTypeAll</a>
- * @apiSuccess (Success) {String} order.order_id the string order id
- * @apiSuccess (Success) {String} order.transaction.code the order verify code
- * @apiSuccess (Success) {Number} order.create_time the order create time
- * @apiSuccess (Success) {Number} order.update_time the order update time
- * @apiSuccess (Success) {Number} order.status the order status, all status supported is <a href="#metadata-Order">OrderStatusAll</a>
+ * @apiSuccess (Withdraw) {Object} withdraw the canceled withdraw order info
+ * @apiUse WithdrawObject
  *
- * @apiParamExample  {Query} CreateTopupOrder:
- * order_id=100
+ * @apiParamExample  {Query} CancelWithdrawOrder:
+ * order_id=202109262257230100001
  *
  *
  * @apiSuccessExample {JSON} Success-Response:
  * {
  *     "code": 0,
- *     "order": {
- *         "avg_price": "0",
+ *     "withdraw": {
+ *         "asset": "YWE",
  *         "create_time": 1632668243553,
  *         "creator": 100005,
- *         "fee_balance": "",
- *         "fee_filled": "0",
- *         "filled": "0",
- *         "in_balance": "",
- *         "in_filled": "0",
- *         "notify_result": {},
  *         "order_id": "202109262257230100001",
- *         "out_balance": "YWE",
- *         "out_filled": "0",
- *         "prepay_result": {},
- *         "price": "0",
  *         "quantity": "1",
- *         "side": "",
- *         "status": 100,
+ *         "status": 320,
+ *         "tid": 1000,
+ *         "transaction": {},
+ *         "type": 100,
+ *         "update_time": 1632668243553,
+ *         "user_id": 100005
+ *     }
+ * }
+ *
+ */
+func CancelWithdrawOrderH(s *web.Session) web.Result {
+	var orderID string
+	err := s.ValidFormat(`
+		order_id,R|S,L:0;
+	`, &orderID)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	targetUserID := userID
+	if AdminAccess(s) {
+		targetUserID = 0
+	}
+	withdraw, err := gexdb.CancelWithdraw(s.R.Context(), targetUserID, orderID)
+	if err != nil {
+		xlog.Errorf("CancelWithdrawOrderH cancel withdraw %v by user %v fail with %v", orderID, targetUserID, err)
+		code := define.ServerError
+		if err == define.ErrNotAccess {
+			code = define.NotAccess
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	xlog.Infof("CancelWithdrawOrderH cancel withdraw by user %v from %v success with %v", targetUserID, s.R.RemoteAddr, converter.JSON(withdraw))
+	return s.SendJSON(xmap.M{
+		"code":     define.Success,
+		"withdraw": withdraw,
+	})
+}
+
+//SearchWithdrawOrderH is http handler
+/**
+ *
+ * @api {GET} /usr/searchWithdrawOrder Search Withdraw Order
+ * @apiName SearchWithdrawOrder
+ * @apiGroup Order
+ *
+ * @apiUse WithdrawUnifySearcher
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Withdraw) {Array} withdraws the withdraw order array, normal user only can see self withdraw order
+ * @apiUse WithdrawObject
+ * @apiSuccess (Success) {Number} total the total count of withdraw order
+ *
+ * @apiParamExample  {Query} PendingWithdraw:
+ * type=100&status=100
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "total": 1,
+ *     "withdraws": [
+ *         {
+ *             "asset": "YWE",
+ *             "create_time": 1632668243553,
+ *             "creator": 100005,
+ *             "order_id": "202109262257230100001",
+ *             "quantity": "1",
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "transaction": {},
+ *             "type": 100,
+ *             "update_time": 1632668243553,
+ *             "user_id": 100005
+ *         }
+ *     ]
+ * }
+ */
+func SearchWithdrawOrderH(s *web.Session) web.Result {
+	searcher := &gexdb.WithdrawUnifySearcher{}
+	err := s.Valid(searcher, "#all")
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !AdminAccess(s) {
+		userID := s.Value("user_id").(int64)
+		searcher.Where.UserID = xsql.Int64Array{userID}
+	}
+	err = searcher.Apply(s.R.Context())
+	if err != nil {
+		xlog.Errorf("SearchWithdrawOrderH search withdraw fail with %v by %v", err, converter.JSON(searcher))
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	hideGoldbarCode(s.Value("user_id").(int64), searcher.Query.Withdraws...)
+	return s.SendJSON(xmap.M{
+		"code":      define.Success,
+		"withdraws": searcher.Query.Withdraws,
+		"total":     searcher.Count.Total,
+	})
+}
+
+//ReviewWithdrawOrderH is http handler
+/**
+ *
+ * @api {GET} /usr/reviewWithdrawOrder Review Withdraw Order
+ * @apiName ReviewWithdrawOrder
+ * @apiGroup Order
+ *
+ * @apiParam  {String} order_id the withdraw order id
+ * @apiParam  {Number} status the review status, 200 is confirm pending order, 300 is done confirmed order, 320 is reject pending/confirmed order and refund the locked balance
+ * @apiParam  {String} [note] the review note, it is stored on withdraw transaction
+ * @apiParam  {String} [ref] the external transaction reference, it is required when status is 300
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Withdraw) {Object} withdraw the reviewed withdraw order info
+ * @apiUse WithdrawObject
+ *
+ * @apiParamExample  {Query} Confirm:
+ * order_id=202109262257230100001&status=200&note=checked
+ * @apiParamExample  {Query} Done:
+ * order_id=202109262257230100001&status=300&ref=0xabc
+ * @apiParamExample  {Query} Reject:
+ * order_id=202109262257230100001&status=320&note=invalid
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "withdraw": {
+ *         "asset": "YWE",
+ *         "create_time": 1632668243553,
+ *         "creator": 100005,
+ *         "order_id": "202109262257230100001",
+ *         "quantity": "1",
+ *         "status": 300,
  *         "tid": 1000,
- *         "total_price": "0",
  *         "transaction": {
- *             "code": "5E48A8"
+ *             "confirm_note": "checked",
+ *             "confirm_user": 100000,
+ *             "done_note": "",
+ *             "done_user": 100000,
+ *             "ref": "0xabc"
  *         },
- *         "type": 400,
+ *         "type": 100,
  *         "update_time": 1632668243553,
  *         "user_id": 100005
  *     }
  * }
  *
  */
-func CancelWithdrawOrderH(s *web.Session) web.Result {
-	// var orderID string
-	// err := s.ValidFormat(`
-	// 	order_id,R|S,L:0;
-	// `, &orderID)
-	// if err != nil {
-	// 	return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
-	// }
-	// userID := s.Int64("user_id")
-	// targetUserID := userID
-	// user, err := gexdb.FindUser(userID)
-	// if err != nil {
-	// 	xlog.Errorf("CancelWithdrawOrderH find target user(%v) err: %v", userID, err)
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// if user.Type == gexdb.UserTypeAdmin {
-	// 	targetUserID = 0
-	// }
-	// order, err := gexdb.CancelWithdrawOrder(targetUserID, orderID)
-	// if err != nil {
-	// 	xlog.Errorf("CancelWithdrawOrderH cancel withdraw order by user:%v fail with %v", targetUserID, err)
-	// 	return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
-	// }
-	// xlog.Infof("CancelWithdrawOrderH cancel order by user %v from %v success with %v", targetUserID, s.R.RemoteAddr, converter.JSON(order))
-	// return s.SendJSON(xmap.M{
-	// 	"code":  0,
-	// 	"order": order,
-	// })
-	return web.Return
+func ReviewWithdrawOrderH(s *web.Session) web.Result {
+	var orderID, note, ref string
+	var status gexdb.WithdrawStatus
+	err := s.ValidFormat(`
+		order_id,R|S,L:0;
+		status,R|I,O:200~300~320;
+		note,O|S,L:0;
+		ref,O|S,L:0;
+	`, &orderID, &status, &note, &ref)
+	if err == nil && status == gexdb.WithdrawStatusDone && len(ref) < 1 {
+		err = fmt.Errorf("ref is required when status is done")
+	}
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	if !AdminAccess(s) {
+		return util.ReturnCodeLocalErr(s, define.NotAccess, "srv-err", define.ErrNotAccess)
+	}
+	userID := s.Value("user_id").(int64)
+	withdraw, err := gexdb.ReviewWithdraw(s.R.Context(), userID, orderID, status, note, ref)
+	if err != nil {
+		xlog.Errorf("ReviewWithdrawOrderH review withdraw %v to %v by user %v fail with %v", orderID, status, userID, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	hideGoldbarCode(userID, withdraw)
+	xlog.Infof("ReviewWithdrawOrderH review withdraw to %v by user %v from %v success with %v", status, userID, s.R.RemoteAddr, converter.JSON(withdraw))
+	return s.SendJSON(xmap.M{
+		"code":     define.Success,
+		"withdraw": withdraw,
+	})
 }
@@ -1,179 +1,108 @@
 package gexapi
 
-// import (
-// 	"fmt"
-// 	"testing"
+import (
+	"fmt"
+	"testing"
 
-// 	"github.com/codingeasygo/crud/pgx"
-// 	"github.com/codingeasygo/util/converter"
-// 	"github.com/codingeasygo/util/uuid"
-// 	"github.com/codingeasygo/util/xmap"
-// 	"github.com/shopspring/decimal"
-// 	"github.com/gexservice/gexservice/base/define"
-// 	"github.com/gexservice/gexservice/gexdb"
-// )
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
 
-// func TestWithdrawOrder(t *testing.T) {
-// 	userMMK := testAddUser(gexdb.UserRoleNormal, "TestWithdrawOrder-MMK")
-// 	_, err := gexdb.TouchBalance(ctx, gexdb.BalanceAssetAll, userMMK.TID)
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{
-// 		UserID: userMMK.TID,
-// 		Asset:  gexdb.BalanceAssetMMK,
-// 		Free:   decimal.NewFromFloat(10000),
-// 		Status: gexdb.BalanceStatusNormal,
-// 	})
-// 	{ //user invalid
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userMMK.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createWithdrawOrder, err := ts.GetMap("/usr/createWithdrawOrder?quantity=1&password=123")
-// 		if err != nil || createWithdrawOrder.Int64("code") == 0 {
-// 			t.Errorf("err:%v,createWithdrawOrder:%v", err, createWithdrawOrder)
-// 			return
-// 		}
-// 	}
-// 	gexdb.Pool().ExecRow(ctx, `update exs_user set kbz_openid=$1 where tid=$2`, uuid.New(), userMMK.TID)
-// 	{ //create user cancel
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userMMK.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createWithdrawOrder, err := ts.GetMap("/usr/createWithdrawOrder?quantity=1&password=123")
-// 		if err != nil || createWithdrawOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,createWithdrawOrder:%v", err, createWithdrawOrder)
-// 			return
-// 		}
-// 		fmt.Printf("createWithdrawOrder--->%v\n", converter.JSON(createWithdrawOrder))
-// 		orderID := createWithdrawOrder.StrDef("", "/order/order_id")
+func TestWithdrawOrder(t *testing.T) {
+	user := testAddUser(gexdb.UserRoleNormal, "TestWithdrawOrder")
+	err := gexdb.UpsertAsset(ctx, &gexdb.Asset{Asset: "TESTW", Name: "Test", Withdraw: gexdb.AssetWithdrawDisabled})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	gexdb.TouchBalance(ctx, gexdb.BalanceAreaFunds, []string{spotBalanceQuote, "TESTW"}, user.TID)
+	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{
+		UserID: user.TID,
+		Area:   gexdb.BalanceAreaFunds,
+		Asset:  spotBalanceQuote,
+		Free:   decimal.NewFromFloat(100),
+		Status: gexdb.BalanceStatusNormal,
+	})
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	createWithdrawOrder, _ := ts.Should(t, "code", define.Success, "/withdraw/status", gexdb.WithdrawStatusPending).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", spotBalanceQuote)
+	fmt.Printf("createWithdrawOrder--->%v\n", converter.JSON(createWithdrawOrder))
+	orderID := createWithdrawOrder.StrDef("", "/withdraw/order_id")
+	//cancel by other
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "abc0", "123")
+	ts.Should(t, "code", define.NotAccess).GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
+	//cancel by self
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	cancelWithdrawOrder, _ := ts.Should(t, "code", define.Success, "/withdraw/status", gexdb.WithdrawStatusCanceled).GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
+	fmt.Printf("cancelWithdrawOrder--->%v\n", converter.JSON(cancelWithdrawOrder))
+	//cancel by admin
+	createWithdrawOrder, _ = ts.Should(t, "code", define.Success).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", spotBalanceQuote)
+	orderID = createWithdrawOrder.StrDef("", "/withdraw/order_id")
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+	ts.Should(t, "code", define.Success, "/withdraw/status", gexdb.WithdrawStatusCanceled).GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
+	//review by admin
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	createWithdrawOrder, _ = ts.Should(t, "code", define.Success).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", spotBalanceQuote)
+	reviewOrderID := createWithdrawOrder.StrDef("", "/withdraw/order_id")
+	searchWithdrawOrder, _ := ts.Should(t, "code", define.Success, "withdraws", xmap.ShouldIsNoEmpty).GetMap("/usr/searchWithdrawOrder?status=%v", gexdb.WithdrawStatusPending)
+	fmt.Printf("searchWithdrawOrder--->%v\n", converter.JSON(searchWithdrawOrder))
+	ts.Should(t, "code", define.NotAccess).GetMap("/usr/reviewWithdrawOrder?order_id=%v&status=%v", reviewOrderID, gexdb.WithdrawStatusConfirmed)
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", "admin", "123")
+	ts.Should(t, "code", define.Success, "withdraws", xmap.ShouldIsNoEmpty).GetMap("/usr/searchWithdrawOrder?user_id=%v&type=%v&status=%v", user.TID, gexdb.WithdrawTypeWithdraw, gexdb.WithdrawStatusPending)
+	ts.Should(t, "code", define.Success, "/withdraw/status", gexdb.WithdrawStatusConfirmed).GetMap("/usr/reviewWithdrawOrder?order_id=%v&status=%v&note=ok", reviewOrderID, gexdb.WithdrawStatusConfirmed)
+	reviewWithdrawOrder, _ := ts.Should(t, "code", define.Success, "/withdraw/status", gexdb.WithdrawStatusDone).GetMap("/usr/reviewWithdrawOrder?order_id=%v&status=%v&ref=0xabc", reviewOrderID, gexdb.WithdrawStatusDone)
+	fmt.Printf("reviewWithdrawOrder--->%v\n", converter.JSON(reviewWithdrawOrder))
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/reviewWithdrawOrder?order_id=%v&status=%v", reviewOrderID, gexdb.WithdrawStatusDone)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/reviewWithdrawOrder?order_id=%v&status=%v", reviewOrderID, gexdb.WithdrawStatusPending)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/searchWithdrawOrder?status=xx")
+	ts.Should(t, "code", define.ServerError).GetMap("/usr/reviewWithdrawOrder?order_id=%v&status=%v", reviewOrderID, gexdb.WithdrawStatusCanceled) //done
+	//withdraw form and quota
+	defer func() {
+		basedb.StoreConf(ctx, gexdb.ConfigWithdrawMax, "0")
+		basedb.StoreConf(ctx, gexdb.ConfigWithdrawQuota, "{}")
+	}()
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	basedb.StoreConf(ctx, gexdb.ConfigWithdrawMax, "50")
+	basedb.StoreConf(ctx, gexdb.ConfigWithdrawQuota, fmt.Sprintf(`{"":{"%v":{"daily":"1","monthly":"0"}}}`, spotBalanceQuote))
+	loadWithdrawForm, _ := ts.Should(t, "code", define.Success, "quota", xmap.ShouldIsNoNil, "balance", xmap.ShouldIsNoNil, "withdraw_max", "50").GetMap("/usr/loadWithdrawForm?asset=%v", spotBalanceQuote)
+	fmt.Printf("loadWithdrawForm--->%v\n", converter.JSON(loadWithdrawForm))
+	ts.Should(t, "code", gexdb.CodeWithdrawMax).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=60&password=123", spotBalanceQuote)
+	ts.Should(t, "code", gexdb.CodeWithdrawDailyQuota).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", spotBalanceQuote)
+	basedb.StoreConf(ctx, gexdb.ConfigWithdrawQuota, fmt.Sprintf(`{"":{"%v":{"daily":"0","monthly":"1"}}}`, spotBalanceQuote))
+	ts.Should(t, "code", gexdb.CodeWithdrawMonthlyQuota).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", spotBalanceQuote)
+	basedb.StoreConf(ctx, gexdb.ConfigWithdrawQuota, "{}")
+	//
+	//test error
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=x&password=123", spotBalanceQuote)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/cancelWithdrawOrder")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/loadWithdrawForm")
+	ts.Should(t, "code", gexdb.CodeTradePassInvalid).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=xxx", spotBalanceQuote)
+	ts.Should(t, "code", gexdb.CodeBalanceNotEnought).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=10000&password=123", spotBalanceQuote)
+	ts.Should(t, "code", gexdb.CodeAssetInvalid).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", "TESTW")
+	ts.Should(t, "code", define.ServerError).GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID) //not pending
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
 
-// 		cancelWithdrawOrder, err := ts.GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
-// 		if err != nil || cancelWithdrawOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,cancelWithdrawOrder:%v", err, cancelWithdrawOrder)
-// 			return
-// 		}
-// 		fmt.Printf("cancelWithdrawOrder--->%v\n", converter.JSON(cancelWithdrawOrder))
-// 	}
-// 	{ //create admin cancel
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userMMK.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createWithdrawOrder, err := ts.GetMap("/usr/createWithdrawOrder?quantity=1&password=123")
-// 		if err != nil || createWithdrawOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,createWithdrawOrder:%v", err, createWithdrawOrder)
-// 			return
-// 		}
-// 		fmt.Printf("createWithdrawOrder--->%v\n", converter.JSON(createWithdrawOrder))
-// 		orderID := createWithdrawOrder.StrDef("", "/order/order_id")
-
-// 		clearCookie()
-// 		login, err = ts.GetMap("/pub/login?username=%v&password=%v", "admin", "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		cancelWithdrawOrder, err := ts.GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
-// 		if err != nil || cancelWithdrawOrder.Int64("code") != 0 {
-// 			t.Errorf("err:%v,cancelWithdrawOrder:%v", err, cancelWithdrawOrder)
-// 			return
-// 		}
-// 		fmt.Printf("cancelWithdrawOrder--->%v\n", converter.JSON(cancelWithdrawOrder))
-// 	}
-// 	{ //normal error
-// 		clearCookie()
-// 		login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userMMK.Account, "123")
-// 		if err != nil || login.Int64("code") != 0 {
-// 			t.Errorf("err:%v,code:%v", err, login)
-// 			return
-// 		}
-// 		createWithdrawOrder, err := ts.GetMap("/usr/createWithdrawOrder?quantity=1&password=1234")
-// 		if err != nil || createWithdrawOrder.Int64("code") != 8000 {
-// 			t.Errorf("err:%v,createWithdrawOrder:%v", err, createWithdrawOrder)
-// 			return
-// 		}
-// 		createWithdrawOrder, err = ts.GetMap("/usr/createWithdrawOrder?quantity=100000&password=123")
-// 		if err != nil || createWithdrawOrder.Int64("code") != 9000 {
-// 			t.Errorf("err:%v,createWithdrawOrder:%v", err, createWithdrawOrder)
-// 			return
-// 		}
-// 	}
-// 	//
-// 	//test error
-// 	pgx.MockerStart()
-// 	defer pgx.MockerStop()
-// 	pgx.MockerClear()
-// 	var res xmap.M
-
-// 	//to user
-// 	clearCookie()
-// 	login, err := ts.GetMap("/pub/login?username=%v&password=%v", *userMMK.Account, "123")
-// 	if err != nil || login.Int64("code") != 0 {
-// 		t.Errorf("err:%v,code:%v", err, login)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//create error
-// 	res, err = ts.GetMap("/usr/createWithdrawOrder?quantity=x")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Row.Scan", 2)
-// 	res, err = ts.GetMap("/usr/createWithdrawOrder?quantity=1&password=123")
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Pool.Begin", 1)
-// 	res, err = ts.GetMap("/usr/createWithdrawOrder?quantity=1&password=123")
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	//create test data
-// 	createWithdrawOrder, err := ts.GetMap("/usr/createWithdrawOrder?quantity=1&password=123")
-// 	if err != nil || createWithdrawOrder.Int64("code") != 0 {
-// 		t.Errorf("err:%v,createWithdrawOrder:%v", err, createWithdrawOrder)
-// 		return
-// 	}
-// 	orderID := createWithdrawOrder.StrDef("", "/order/order_id")
-// 	pgx.MockerClear()
-
-// 	//cancel error
-// 	res, err = ts.GetMap("/usr/cancelWithdrawOrder?order_id=%v", "")
-// 	if err != nil || res.Int64("code") != define.ArgsInvalid {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Row.Scan", 1)
-// 	res, err = ts.GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Pool.Begin", 1)
-// 	res, err = ts.GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
-// 	if err != nil || res.Int64("code") != define.ServerError {
-// 		t.Errorf("err:%v,res:%v", err, res)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// }
+	pgx.MockerSetCall("Rows.Scan", 1).Should(t, "code", define.ServerError).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", spotBalanceQuote)
+	pgx.MockerSetCall("Pool.Begin", 1).Should(t, "code", define.ServerError).GetMap("/usr/createWithdrawOrder?asset=%v&address=addr&quantity=1&password=123", spotBalanceQuote)
+	pgx.MockerSetCall("Pool.Begin", 1).Should(t, "code", define.ServerError).GetMap("/usr/cancelWithdrawOrder?order_id=%v", orderID)
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/usr/searchWithdrawOrder")
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/usr/loadWithdrawForm?asset=%v", spotBalanceQuote)
+	pgx.MockerSetCall("Pool.Query", 2).Should(t, "code", define.ServerError).GetMap("/usr/loadWithdrawForm?asset=%v", spotBalanceQuote)
+	pgx.MockerSetCall("Rows.Scan", 2).Should(t, "code", define.ServerError).GetMap("/usr/loadWithdrawForm?asset=%v", spotBalanceQuote)
+	pgx.MockerSetCall("Rows.Scan", 5).Should(t, "code", define.ServerError).GetMap("/usr/loadWithdrawForm?asset=%v", spotBalanceQuote)
+}
@@ -0,0 +1,403 @@
+package gexapi
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/web"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/market"
+	"github.com/gexservice/gexservice/matcher"
+)
+
+//UDFExchange is the exchange name which is show on charting library
+var UDFExchange = "GEX"
+
+//udfResolutions is the charting library resolution supported, it is mapping to kline interval by udfIntervals
+var udfResolutions = []string{"1", "3", "5", "15", "30", "60", "120", "240", "360", "720", "1D", "3D", "1W", "1M"}
+
+var udfIntervals = map[string]string{
+	"1":   "1min",
+	"3":   "3min",
+	"5":   "5min",
+	"15":  "15min",
+	"30":  "30min",
+	"60":  "1hour",
+	"120": "2hour",
+	"240": "4hour",
+	"360": "6hour",
+	"720": "12hour",
+	"D":   "1day",
+	"1D":  "1day",
+	"3D":  "3day",
+	"W":   "1week",
+	"1W":  "1week",
+	"M":   "1mon",
+	"1M":  "1mon",
+}
+
+//udfTimezone will return the kline timezone name which is supported by charting library
+func udfTimezone() string {
+	loc := market.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	if loc != time.Local && strings.Contains(loc.String(), "/") {
+		return loc.String()
+	}
+	_, offset := time.Now().In(loc).Zone()
+	if offset == 0 || offset%3600 != 0 {
+		return "Etc/UTC"
+	}
+	return fmt.Sprintf("Etc/GMT%+d", -offset/3600)
+}
+
+func udfSymbol(info *matcher.SymbolInfo) xmap.M {
+	return xmap.M{
+		"symbol":      info.Symbol,
+		"full_name":   info.Symbol,
+		"description": info.Base + "/" + info.Quote + " " + info.Type,
+		"exchange":    UDFExchange,
+		"ticker":      info.Symbol,
+		"type":        info.Type,
+	}
+}
+
+//udfSymbolName will return the symbol name without exchange prefix, the charting library may request by EXCHANGE:SYMBOL
+func udfSymbolName(symbol string) string {
+	if parts := strings.SplitN(symbol, ":", 2); len(parts) == 2 {
+		symbol = parts[1]
+	}
+	return symbol
+}
+
+func udfError(s *web.Session, format string, args ...interface{}) web.Result {
+	return s.SendJSON(xmap.M{
+		"s":      "error",
+		"errmsg": fmt.Sprintf(format, args...),
+	})
+}
+
+//UDFConfigH is http handler
+/**
+ *
+ * @api {GET} /pub/udf/config UDF Config
+ * @apiName UDFConfig
+ * @apiGroup UDF
+ * @apiDescription the TradingView UDF datafeed configuration, the charting library can connect directly by datafeed url /pub/udf
+ *
+ * @apiSuccess (Success) {Array} supported_resolutions the supported resolution, it is mapping to kline interval
+ * @apiSuccess (Success) {Boolean} supports_search whether support search
+ * @apiSuccess (Success) {Boolean} supports_time whether support server time
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "exchanges": [
+ *         {
+ *             "desc": "GEX",
+ *             "name": "GEX",
+ *             "value": "GEX"
+ *         }
+ *     ],
+ *     "supported_resolutions": ["1", "3", "5", "15", "30", "60", "120", "240", "360", "720", "1D", "3D", "1W", "1M"],
+ *     "supports_group_request": false,
+ *     "supports_marks": false,
+ *     "supports_search": true,
+ *     "supports_time": true,
+ *     "supports_timescale_marks": false,
+ *     "symbols_types": [
+ *         {
+ *             "name": "All",
+ *             "value": ""
+ *         },
+ *         {
+ *             "name": "Spot",
+ *             "value": "spot"
+ *         },
+ *         {
+ *             "name": "Futures",
+ *             "value": "futures"
+ *         }
+ *     ]
+ * }
+ *
+ */
+func UDFConfigH(s *web.Session) web.Result {
+	return s.SendJSON(xmap.M{
+		"supported_resolutions":    udfResolutions,
+		"supports_group_request":   false,
+		"supports_marks":           false,
+		"supports_search":          true,
+		"supports_timescale_marks": false,
+		"supports_time":            true,
+		"exchanges": []xmap.M{
+			{"value": UDFExchange, "name": UDFExchange, "desc": UDFExchange},
+		},
+		"symbols_types": []xmap.M{
+			{"name": "All", "value": ""},
+			{"name": "Spot", "value": "spot"},
+			{"name": "Futures", "value": "futures"},
+		},
+	})
+}
+
+//UDFSymbolsH is http handler
+/**
+ *
+ * @api {GET} /pub/udf/symbols UDF Symbol Info
+ * @apiName UDFSymbols
+ * @apiGroup UDF
+ *
+ * @apiParam  {String} symbol the symbol name or ticker, the exchange prefix like GEX:spot.YWEUSDT is supported
+ *
+ * @apiSuccess (Success) {String} name the symbol name
+ * @apiSuccess (Success) {String} ticker the symbol ticker, it is same as symbol
+ * @apiSuccess (Success) {String} type the symbol type, spot/futures
+ * @apiSuccess (Success) {String} timezone the kline timezone
+ * @apiSuccess (Success) {Number} pricescale the price scale by matcher price precision
+ * @apiSuccess (Success) {Number} volume_precision the volume precision by matcher quantity precision
+ * @apiSuccess (Success) {String} s the error status, only having when symbol is not found
+ *
+ * @apiParamExample  {Query} QueryOrder:
+ * symbol=spot.YWEUSDT
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "data_status": "streaming",
+ *     "description": "YWE/USDT spot",
+ *     "exchange": "GEX",
+ *     "has_daily": true,
+ *     "has_intraday": true,
+ *     "has_weekly_and_monthly": true,
+ *     "listed_exchange": "GEX",
+ *     "minmov": 1,
+ *     "name": "spot.YWEUSDT",
+ *     "pricescale": 100,
+ *     "session": "24x7",
+ *     "supported_resolutions": ["1", "3", "5", "15", "30", "60", "120", "240", "360", "720", "1D", "3D", "1W", "1M"],
+ *     "ticker": "spot.YWEUSDT",
+ *     "timezone": "Asia/Shanghai",
+ *     "type": "spot",
+ *     "volume_precision": 2
+ * }
+ *
+ */
+func UDFSymbolsH(s *web.Session) web.Result {
+	var symbol string
+	var err = s.ValidFormat(`
+		symbol,R|S,L:0;
+	`, &symbol)
+	if err != nil {
+		return udfError(s, "%v", err)
+	}
+	symbol = udfSymbolName(symbol)
+	info := matcher.FindSymbol(symbol)
+	if info == nil {
+		return udfError(s, "unknown symbol %v", symbol)
+	}
+	return s.SendJSON(xmap.M{
+		"name":                   info.Symbol,
+		"ticker":                 info.Symbol,
+		"description":            info.Base + "/" + info.Quote + " " + info.Type,
+		"type":                   info.Type,
+		"session":                "24x7",
+		"exchange":               UDFExchange,
+		"listed_exchange":        UDFExchange,
+		"timezone":               udfTimezone(),
+		"minmov":                 1,
+		"pricescale":             int64(math.Pow10(int(info.PrecisionPrice))),
+		"has_intraday":           true,
+		"has_daily":              true,
+		"has_weekly_and_monthly": true,
+		"supported_resolutions":  udfResolutions,
+		"volume_precision":       info.PrecisionQuantity,
+		"data_status":            "streaming",
+	})
+}
+
+//UDFSearchH is http handler
+/**
+ *
+ * @api {GET} /pub/udf/search UDF Search Symbol
+ * @apiName UDFSearch
+ * @apiGroup UDF
+ *
+ * @apiParam  {String} [query] the search key, it is matched to symbol/base/quote ignore case
+ * @apiParam  {String} [type] the symbol type filter, spot/futures
+ * @apiParam  {String} [exchange] the exchange filter
+ * @apiParam  {Number} [limit] the max result, default is 30
+ *
+ * @apiSuccess (Success) {Array} _ the symbol array
+ * @apiSuccess (Success) {String} _.symbol the symbol name
+ * @apiSuccess (Success) {String} _.ticker the symbol ticker
+ * @apiSuccess (Success) {String} _.type the symbol type
+ *
+ * @apiParamExample  {Query} QueryOrder:
+ * query=YWE&type=spot&limit=10
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * [
+ *     {
+ *         "description": "YWE/USDT spot",
+ *         "exchange": "GEX",
+ *         "full_name": "spot.YWEUSDT",
+ *         "symbol": "spot.YWEUSDT",
+ *         "ticker": "spot.YWEUSDT",
+ *         "type": "spot"
+ *     }
+ * ]
+ *
+ */
+func UDFSearchH(s *web.Session) web.Result {
+	var query, symbolType, exchange string
+	var limit = 30
+	var err = s.ValidFormat(`
+		query,O|S,L:0;
+		type,O|S,L:0;
+		exchange,O|S,L:0;
+		limit,O|I,R:1;
+	`, &query, &symbolType, &exchange, &limit)
+	if err != nil {
+		return udfError(s, "%v", err)
+	}
+	query = strings.ToUpper(query)
+	symbols := []xmap.M{}
+	if len(exchange) > 0 && exchange != UDFExchange {
+		return s.SendJSON(symbols)
+	}
+	for _, info := range matcher.ListSymbol() {
+		if len(symbols) >= limit {
+			break
+		}
+		if len(symbolType) > 0 && info.Type != symbolType {
+			continue
+		}
+		if len(query) > 0 && !strings.Contains(strings.ToUpper(info.Symbol), query) && !strings.Contains(strings.ToUpper(info.Base+"/"+info.Quote), query) {
+			continue
+		}
+		symbols = append(symbols, udfSymbol(info))
+	}
+	return s.SendJSON(symbols)
+}
+
+//UDFHistoryH is http handler
+/**
+ *
+ * @api {GET} /pub/udf/history UDF History Bar
+ * @apiName UDFHistory
+ * @apiGroup UDF
+ *
+ * @apiParam  {String} symbol the symbol name or ticker, the exchange prefix like GEX:spot.YWEUSDT is supported
+ * @apiParam  {String} resolution the resolution, supported is in /pub/udf/config
+ * @apiParam  {Number} from the leftmost bar unix timestamp in seconds, including from
+ * @apiParam  {Number} to the rightmost bar unix timestamp in seconds, not including to
+ * @apiParam  {Number} [countback] the bar count required, it is prior to from
+ *
+ * @apiSuccess (Success) {String} s the status, ok/no_data/error
+ * @apiSuccess (Success) {Array} t the bar start time in seconds
+ * @apiSuccess (Success) {Array} o the bar open price
+ * @apiSuccess (Success) {Array} h the bar high price
+ * @apiSuccess (Success) {Array} l the bar low price
+ * @apiSuccess (Success) {Array} c the bar close price
+ * @apiSuccess (Success) {Array} v the bar traded quantity
+ * @apiSuccess (Success) {String} errmsg the error message when s is error
+ *
+ * @apiParamExample  {Query} QueryOrder:
+ * symbol=spot.YWEUSDT&resolution=5&from=1632578100&to=1632578700
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "s": "ok",
+ *     "t": [1632578100, 1632578400],
+ *     "o": [100, 100],
+ *     "h": [100, 110],
+ *     "l": [100, 100],
+ *     "c": [100, 110],
+ *     "v": [1, 2]
+ * }
+ *
+ */
+func UDFHistoryH(s *web.Session) web.Result {
+	var symbol, resolution string
+	var from, to int64
+	var countback int
+	var err = s.ValidFormat(`
+		symbol,R|S,L:0;
+		resolution,R|S,L:0;
+		from,R|I,R:0;
+		to,R|I,R:0;
+		countback,O|I,R:0;
+	`, &symbol, &resolution, &from, &to, &countback)
+	if err != nil {
+		return udfError(s, "%v", err)
+	}
+	interval := udfIntervals[strings.ToUpper(resolution)]
+	if len(interval) < 1 {
+		return udfError(s, "unsupported resolution %v", resolution)
+	}
+	symbol = udfSymbolName(symbol)
+	if matcher.FindSymbol(symbol) == nil {
+		return udfError(s, "unknown symbol %v", symbol)
+	}
+	startTime, endTime := time.Unix(from, 0), time.Unix(to, 0)
+	if countback > 0 {
+		startTime, _ = gexdb.KLineStartTime(interval, endTime, market.Location)
+		startTime, _ = gexdb.KLineAddTime(interval, startTime, -countback)
+	}
+	lines, err := market.ListKLine(s.R.Context(), symbol, interval, startTime, endTime)
+	if err != nil {
+		xlog.Warnf("UDFHistoryH list kline by %v/%v fail with %v", symbol, interval, err)
+		return udfError(s, "%v", err)
+	}
+	startTime, _ = gexdb.KLineStartTime(interval, startTime, market.Location)
+	bars := []*gexdb.KLine{}
+	for i := len(lines) - 1; i >= 0; i-- {
+		lineTime := lines[i].StartTime.AsTime()
+		if !lineTime.Before(startTime) && lineTime.Before(endTime) {
+			bars = append(bars, lines[i])
+		}
+	}
+	if countback > 0 && len(bars) > countback {
+		bars = bars[len(bars)-countback:]
+	}
+	if len(bars) < 1 {
+		return s.SendJSON(xmap.M{"s": "no_data"})
+	}
+	t, o, h, l, c, v := []int64{}, []float64{}, []float64{}, []float64{}, []float64{}, []float64{}
+	for _, bar := range bars {
+		t = append(t, bar.StartTime.AsTime().Unix())
+		o = append(o, bar.Open.InexactFloat64())
+		h = append(h, bar.High.InexactFloat64())
+		l = append(l, bar.Low.InexactFloat64())
+		c = append(c, bar.Close.InexactFloat64())
+		v = append(v, bar.Amount.InexactFloat64())
+	}
+	return s.SendJSON(xmap.M{
+		"s": "ok",
+		"t": t,
+		"o": o,
+		"h": h,
+		"l": l,
+		"c": c,
+		"v": v,
+	})
+}
+
+//UDFTimeH is http handler
+/**
+ *
+ * @api {GET} /pub/udf/time UDF Server Time
+ * @apiName UDFTime
+ * @apiGroup UDF
+ *
+ * @apiSuccessExample {Text} Success-Response:
+ * 1632578330
+ *
+ */
+func UDFTimeH(s *web.Session) web.Result {
+	return s.SendPlainText(fmt.Sprintf("%v", time.Now().Unix()))
+}
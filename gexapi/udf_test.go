@@ -0,0 +1,91 @@
+package gexapi
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/market"
+)
+
+func TestUDF(t *testing.T) {
+	symbol := "spot.YWEUSDT"
+	ts.Should(t, "supported_resolutions", xmap.ShouldIsNoEmpty).GetMap("/pub/udf/config")
+
+	//symbols
+	symbolInfo, _ := ts.Should(t, "name", symbol, "type", "spot").GetMap("/pub/udf/symbols?symbol=GEX:%v", symbol)
+	fmt.Printf("symbolInfo--->%v\n", converter.JSON(symbolInfo))
+	ts.Should(t, "s", "error").GetMap("/pub/udf/symbols?symbol=%v", "none")
+	ts.Should(t, "s", "error").GetMap("/pub/udf/symbols")
+
+	//search
+	var symbols []xmap.M
+	err := ts.GetJSON(&symbols, "/pub/udf/search?query=%v&type=%v", "ywe", "spot")
+	if err != nil || len(symbols) != 1 || symbols[0].Str("symbol") != symbol {
+		t.Errorf("err:%v,symbols:%v", err, converter.JSON(symbols))
+		return
+	}
+	err = ts.GetJSON(&symbols, "/pub/udf/search?query=%v&limit=%v", "none", 10)
+	if err != nil || len(symbols) != 0 {
+		t.Errorf("err:%v,symbols:%v", err, converter.JSON(symbols))
+		return
+	}
+	err = ts.GetJSON(&symbols, "/pub/udf/search?exchange=%v", "none")
+	if err != nil || len(symbols) != 0 {
+		t.Errorf("err:%v,symbols:%v", err, converter.JSON(symbols))
+		return
+	}
+	ts.Should(t, "s", "error").GetMap("/pub/udf/search?limit=%v", "xx")
+
+	//history
+	now := time.Now().Unix()
+	history, _ := ts.Should(t, "s", "ok", "t", xmap.ShouldIsNoEmpty, "c", xmap.ShouldIsNoEmpty).GetMap("/pub/udf/history?symbol=%v&resolution=5&from=%v&to=%v", symbol, now-3600, now+60)
+	fmt.Printf("history--->%v\n", converter.JSON(history))
+	ts.Should(t, "s", "ok", "t", xmap.ShouldIsNoEmpty).GetMap("/pub/udf/history?symbol=%v&resolution=1D&from=%v&to=%v&countback=%v", symbol, now-60, now+60, 1)
+	ts.Should(t, "s", "ok", "t", xmap.ShouldIsNoEmpty).GetMap("/pub/udf/history?symbol=GEX:%v&resolution=5&from=%v&to=%v", symbol, now-3600, now+60)
+	ts.Should(t, "s", "no_data").GetMap("/pub/udf/history?symbol=%v&resolution=5&from=%v&to=%v", symbol, 100, 200)
+	ts.Should(t, "s", "error").GetMap("/pub/udf/history?symbol=%v&resolution=5&from=%v&to=%v", symbol, "xx", now)
+	ts.Should(t, "s", "error").GetMap("/pub/udf/history?symbol=%v&resolution=xx&from=%v&to=%v", symbol, now-3600, now)
+	ts.Should(t, "s", "error").GetMap("/pub/udf/history?symbol=%v&resolution=5&from=%v&to=%v", "none", now-3600, now)
+
+	//time
+	serverTime, err := ts.GetText("/pub/udf/time")
+	if value, xerr := strconv.ParseInt(serverTime, 10, 64); err != nil || xerr != nil || value < now {
+		t.Errorf("err:%v,time:%v", err, serverTime)
+		return
+	}
+
+	//timezone
+	defer func() {
+		market.Location = time.Local
+	}()
+	market.Location = time.UTC
+	if tz := udfTimezone(); tz != "Etc/UTC" {
+		t.Error(tz)
+		return
+	}
+	market.Location = time.FixedZone("UTC+8", 8*60*60)
+	if tz := udfTimezone(); tz != "Etc/GMT-8" {
+		t.Error(tz)
+		return
+	}
+	if loc, xerr := time.LoadLocation("Asia/Shanghai"); xerr == nil {
+		market.Location = loc
+		if tz := udfTimezone(); tz != "Asia/Shanghai" {
+			t.Error(tz)
+			return
+		}
+	}
+
+	//
+	//test error
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "s", "error").GetMap("/pub/udf/history?symbol=%v&resolution=60&from=%v&to=%v", symbol, now-3600, now)
+}
@@ -0,0 +1,262 @@
+package gexapi
+
+import (
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xhash"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/web"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/base/util"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+)
+
+//AddWithdrawAddressH is http handler
+/**
+ *
+ * @api {GET} /usr/addWithdrawAddress Add Withdraw Address
+ * @apiName AddWithdrawAddress
+ * @apiGroup Withdraw
+ *
+ * @apiParam  {String} asset the address asset
+ * @apiParam  {String} address the withdraw destination address
+ * @apiParam  {String} [label] the address label
+ * @apiParam  {String} password the trade password
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a> or <a href="#metadata-ExReturnCode">ExReturnCode</a>
+ * @apiSuccess (WithdrawAddress) {Object} address the saved address info, it can be used to withdraw after available_time
+ * @apiUse WithdrawAddressObject
+ *
+ * @apiParamExample  {Query} AddWithdrawAddress:
+ * asset=YWE&address=xxx&label=home&password=123
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "address": {
+ *         "address": "xxx",
+ *         "asset": "YWE",
+ *         "available_time": 1667561852051,
+ *         "create_time": 1667475452051,
+ *         "label": "home",
+ *         "status": 100,
+ *         "tid": 1000,
+ *         "update_time": 1667475452051,
+ *         "user_id": 100005
+ *     },
+ *     "code": 0
+ * }
+ *
+ */
+func AddWithdrawAddressH(s *web.Session) web.Result {
+	var asset, address, label, password string
+	err := s.ValidFormat(`
+		asset,R|S,L:0;
+		address,R|S,L:0~255;
+		label,O|S,L:0~255;
+		password,R|S,L:0;
+	`, &asset, &address, &label, &password)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	err = gexdb.UserVerifyTradePassword(s.R.Context(), userID, xhash.SHA1([]byte(password)))
+	if err != nil {
+		xlog.Errorf("AddWithdrawAddressH verify user %v trade password fail with %v", userID, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = gexdb.CodeTradePassInvalid
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	withdrawAddress := &gexdb.WithdrawAddress{
+		UserID:  userID,
+		Asset:   asset,
+		Address: address,
+		Label:   label,
+	}
+	err = gexdb.UpsertWithdrawAddress(s.R.Context(), withdrawAddress)
+	if err != nil {
+		xlog.Errorf("AddWithdrawAddressH add user %v withdraw address %v fail with %v", userID, converter.JSON(withdrawAddress), err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	xlog.Infof("AddWithdrawAddressH add withdraw address from %v success with %v", s.R.RemoteAddr, converter.JSON(withdrawAddress))
+	return s.SendJSON(xmap.M{
+		"code":    define.Success,
+		"address": withdrawAddress,
+	})
+}
+
+//RemoveWithdrawAddressH is http handler
+/**
+ *
+ * @api {GET} /usr/removeWithdrawAddress Remove Withdraw Address
+ * @apiName RemoveWithdrawAddress
+ * @apiGroup Withdraw
+ *
+ * @apiParam  {Number} address_id the address id to remove
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ *
+ * @apiParamExample  {Query} RemoveWithdrawAddress:
+ * address_id=1000
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0
+ * }
+ *
+ */
+func RemoveWithdrawAddressH(s *web.Session) web.Result {
+	var addressID int64
+	err := s.ValidFormat(`
+		address_id,R|I,R:0;
+	`, &addressID)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	err = gexdb.RemoveWithdrawAddress(s.R.Context(), userID, addressID)
+	if err != nil {
+		xlog.Errorf("RemoveWithdrawAddressH remove user %v withdraw address %v fail with %v", userID, addressID, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = define.NotFound
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code": define.Success,
+	})
+}
+
+//ListWithdrawAddressH is http handler
+/**
+ *
+ * @api {GET} /usr/listWithdrawAddress List Withdraw Address
+ * @apiName ListWithdrawAddress
+ * @apiGroup Withdraw
+ *
+ * @apiParam  {String} [asset] the asset filter
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a>
+ * @apiSuccess (Success) {Boolean} whitelist whether withdraw whitelist mode is enabled, only saved address can be used when it is enabled
+ * @apiSuccess (WithdrawAddress) {Array} addresses the saved address list, it is order by update_time desc
+ * @apiUse WithdrawAddressObject
+ *
+ * @apiParamExample  {Query} ListWithdrawAddress:
+ * asset=YWE
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "addresses": [
+ *         {
+ *             "address": "xxx",
+ *             "asset": "YWE",
+ *             "available_time": 1667561852051,
+ *             "create_time": 1667475452051,
+ *             "label": "home",
+ *             "status": 100,
+ *             "tid": 1000,
+ *             "update_time": 1667475452051,
+ *             "user_id": 100005
+ *         }
+ *     ],
+ *     "code": 0,
+ *     "whitelist": false
+ * }
+ *
+ */
+func ListWithdrawAddressH(s *web.Session) web.Result {
+	var asset string
+	err := s.ValidFormat(`
+		asset,O|S,L:0;
+	`, &asset)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	addressList, err := gexdb.ListWithdrawAddress(s.R.Context(), userID, asset)
+	if err != nil {
+		xlog.Errorf("ListWithdrawAddressH list user %v withdraw address fail with %v", userID, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	whitelist, err := gexdb.FindUserWithdrawWhitelist(s.R.Context(), userID)
+	if err != nil {
+		xlog.Errorf("ListWithdrawAddressH find user %v withdraw whitelist fail with %v", userID, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":      define.Success,
+		"addresses": addressList,
+		"whitelist": whitelist,
+	})
+}
+
+//UpdateWithdrawWhitelistH is http handler
+/**
+ *
+ * @api {GET} /usr/updateWithdrawWhitelist Update Withdraw Whitelist
+ * @apiName UpdateWithdrawWhitelist
+ * @apiGroup Withdraw
+ *
+ * @apiParam  {Number} enabled 1 is enable whitelist mode, 0 is disable, only saved address can be used to withdraw when it is enabled, the disable is delayed by withdraw_address_delay
+ * @apiParam  {String} password the trade password
+ *
+ *
+ * @apiSuccess (Success) {Number} code the result code, see the common define <a href="#metadata-ReturnCode">ReturnCode</a> or <a href="#metadata-ExReturnCode">ExReturnCode</a>
+ * @apiSuccess (Success) {Boolean} whitelist whether withdraw whitelist mode is enabled, it is still true before disable delay
+ *
+ * @apiParamExample  {Query} UpdateWithdrawWhitelist:
+ * enabled=1&password=123
+ *
+ *
+ * @apiSuccessExample {JSON} Success-Response:
+ * {
+ *     "code": 0,
+ *     "whitelist": true
+ * }
+ *
+ */
+func UpdateWithdrawWhitelistH(s *web.Session) web.Result {
+	var enabled int
+	var password string
+	err := s.ValidFormat(`
+		enabled,R|I,O:0~1;
+		password,R|S,L:0;
+	`, &enabled, &password)
+	if err != nil {
+		return util.ReturnCodeLocalErr(s, define.ArgsInvalid, "arg-err", err)
+	}
+	userID := s.Value("user_id").(int64)
+	err = gexdb.UserVerifyTradePassword(s.R.Context(), userID, xhash.SHA1([]byte(password)))
+	if err != nil {
+		xlog.Errorf("UpdateWithdrawWhitelistH verify user %v trade password fail with %v", userID, err)
+		code := define.ServerError
+		if err == pgx.ErrNoRows {
+			code = gexdb.CodeTradePassInvalid
+		}
+		return util.ReturnCodeLocalErr(s, code, "srv-err", err)
+	}
+	err = gexdb.UpdateUserWithdrawWhitelist(s.R.Context(), userID, enabled == 1)
+	if err != nil {
+		xlog.Errorf("UpdateWithdrawWhitelistH update user %v withdraw whitelist to %v fail with %v", userID, enabled, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	xlog.Infof("UpdateWithdrawWhitelistH update user %v withdraw whitelist to %v from %v success", userID, enabled, s.R.RemoteAddr)
+	whitelist, err := gexdb.FindUserWithdrawWhitelist(s.R.Context(), userID)
+	if err != nil {
+		xlog.Errorf("UpdateWithdrawWhitelistH find user %v withdraw whitelist fail with %v", userID, err)
+		return util.ReturnCodeLocalErr(s, define.ServerError, "srv-err", err)
+	}
+	return s.SendJSON(xmap.M{
+		"code":      define.Success,
+		"whitelist": whitelist,
+	})
+}
@@ -0,0 +1,68 @@
+package gexapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+func TestWithdrawAddress(t *testing.T) {
+	defer basedb.StoreConf(ctx, gexdb.ConfigWithdrawAddressDelay, "0")
+	user := testAddUser(gexdb.UserRoleNormal, "TestWithdrawAddress")
+	gexdb.TouchBalance(ctx, gexdb.BalanceAreaFunds, []string{spotBalanceQuote}, user.TID)
+	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{
+		UserID: user.TID,
+		Area:   gexdb.BalanceAreaFunds,
+		Asset:  spotBalanceQuote,
+		Free:   decimal.NewFromFloat(100),
+		Status: gexdb.BalanceStatusNormal,
+	})
+	clearCookie()
+	ts.Should(t, "code", define.Success).GetMap("/pub/login?username=%v&password=%v", *user.Account, "123")
+	basedb.StoreConf(ctx, gexdb.ConfigWithdrawAddressDelay, "3600")
+	addWithdrawAddress, _ := ts.Should(t, "code", define.Success, "/address/tid", xmap.ShouldIsNoZero).GetMap("/usr/addWithdrawAddress?asset=%v&address=delayed&label=home&password=123", spotBalanceQuote)
+	fmt.Printf("addWithdrawAddress--->%v\n", converter.JSON(addWithdrawAddress))
+	addressID := addWithdrawAddress.Int64Def(0, "/address/tid")
+	basedb.StoreConf(ctx, gexdb.ConfigWithdrawAddressDelay, "0")
+	ts.Should(t, "code", define.Success).GetMap("/usr/addWithdrawAddress?asset=%v&address=available&password=123", spotBalanceQuote)
+	listWithdrawAddress, _ := ts.Should(t, "code", define.Success, "addresses", xmap.ShouldIsNoEmpty, "whitelist", false).GetMap("/usr/listWithdrawAddress?asset=%v", spotBalanceQuote)
+	fmt.Printf("listWithdrawAddress--->%v\n", converter.JSON(listWithdrawAddress))
+	ts.Should(t, "code", define.Success, "addresses", xmap.ShouldIsNoEmpty).GetMap("/usr/loadWithdrawForm?asset=%v", spotBalanceQuote)
+	//withdraw
+	ts.Should(t, "code", gexdb.CodeWithdrawAddressInvalid).GetMap("/usr/createWithdrawOrder?asset=%v&address=delayed&quantity=1&password=123", spotBalanceQuote)
+	ts.Should(t, "code", define.Success).GetMap("/usr/createWithdrawOrder?asset=%v&address=other&quantity=1&password=123", spotBalanceQuote)
+	ts.Should(t, "code", define.Success, "whitelist", true).GetMap("/usr/updateWithdrawWhitelist?enabled=1&password=123")
+	ts.Should(t, "code", gexdb.CodeWithdrawAddressInvalid).GetMap("/usr/createWithdrawOrder?asset=%v&address=other&quantity=1&password=123", spotBalanceQuote)
+	ts.Should(t, "code", define.Success, "/withdraw/address", "available").GetMap("/usr/createWithdrawOrder?asset=%v&address=available&quantity=1&password=123", spotBalanceQuote)
+	ts.Should(t, "code", define.Success, "whitelist", false).GetMap("/usr/updateWithdrawWhitelist?enabled=0&password=123")
+	//remove
+	ts.Should(t, "code", define.Success).GetMap("/usr/removeWithdrawAddress?address_id=%v", addressID)
+	ts.Should(t, "code", define.NotFound).GetMap("/usr/removeWithdrawAddress?address_id=%v", addressID)
+	//
+	//test error
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/addWithdrawAddress?asset=%v&password=123", spotBalanceQuote)
+	ts.Should(t, "code", gexdb.CodeTradePassInvalid).GetMap("/usr/addWithdrawAddress?asset=%v&address=xx&password=xxx", spotBalanceQuote)
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/removeWithdrawAddress")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/updateWithdrawWhitelist?enabled=2&password=123")
+	ts.Should(t, "code", gexdb.CodeTradePassInvalid).GetMap("/usr/updateWithdrawWhitelist?enabled=1&password=xxx")
+	ts.Should(t, "code", define.ArgsInvalid).GetMap("/usr/createWithdrawOrder?asset=%v&quantity=1&password=123", spotBalanceQuote)
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSetCall("Rows.Scan", 1).Should(t, "code", define.ServerError).GetMap("/usr/addWithdrawAddress?asset=%v&address=xx&password=123", spotBalanceQuote)
+	pgx.MockerSetCall("Rows.Scan", 2).Should(t, "code", define.ServerError).GetMap("/usr/addWithdrawAddress?asset=%v&address=xx&password=123", spotBalanceQuote)
+	pgx.MockerSetCall("Pool.Exec", 1).Should(t, "code", define.ServerError).GetMap("/usr/removeWithdrawAddress?address_id=%v", addressID)
+	pgx.MockerSetCall("Pool.Query", 1).Should(t, "code", define.ServerError).GetMap("/usr/listWithdrawAddress")
+	pgx.MockerSetCall("Rows.Scan", 2).Should(t, "code", define.ServerError).GetMap("/usr/listWithdrawAddress")
+	pgx.MockerSetCall("Rows.Scan", 1).Should(t, "code", define.ServerError).GetMap("/usr/updateWithdrawWhitelist?enabled=1&password=123")
+	pgx.MockerSetCall("Pool.Exec", 1).Should(t, "code", define.ServerError).GetMap("/usr/updateWithdrawWhitelist?enabled=1&password=123")
+	pgx.MockerSetCall("Rows.Scan", 3).Should(t, "code", define.ServerError).GetMap("/usr/updateWithdrawWhitelist?enabled=1&password=123")
+}
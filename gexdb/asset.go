@@ -0,0 +1,163 @@
+package gexdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/shopspring/decimal"
+)
+
+//UpsertAsset will add asset to registry or update all fields of it by asset key
+func UpsertAsset(ctx context.Context, asset *Asset) (err error) {
+	err = UpsertAssetFilter(ctx, asset, "name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,status")
+	return
+}
+
+//UpsertAssetFilter will add asset to registry or only update the fields in filter by asset key
+func UpsertAssetFilter(ctx context.Context, asset *Asset, filter string) (err error) {
+	if asset.Deposit == 0 {
+		asset.Deposit = AssetDepositEnabled
+	}
+	if asset.Withdraw == 0 {
+		asset.Withdraw = AssetWithdrawEnabled
+	}
+	if asset.Status == 0 {
+		asset.Status = AssetStatusNormal
+	}
+	asset.UpdateTime = xsql.TimeNow()
+	asset.CreateTime = asset.UpdateTime
+	updateArgs := map[string]string{
+		"name":              "$2",
+		"display_precision": "$3",
+		"withdraw_min":      "$4",
+		"withdraw_fee":      "$5",
+		"deposit":           "$6",
+		"withdraw":          "$7",
+		"status":            "$10",
+	}
+	sets := []string{"update_time=$8"}
+	for _, field := range strings.Split(filter, ",") {
+		if arg, ok := updateArgs[field]; ok {
+			sets = append(sets, field+"="+arg)
+		}
+	}
+	upsertSQL := `
+		insert into exs_asset(asset,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,update_time,create_time,status)
+		values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+		on conflict(asset) do update set ` + strings.Join(sets, ",") + `
+		returning tid,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,create_time,status
+	`
+	err = Pool().QueryRow(
+		ctx, upsertSQL,
+		asset.Asset, asset.Name, asset.DisplayPrecision, asset.WithdrawMin, asset.WithdrawFee, asset.Deposit, asset.Withdraw, asset.UpdateTime, asset.CreateTime, asset.Status,
+	).Scan(&asset.TID, &asset.Name, &asset.DisplayPrecision, &asset.WithdrawMin, &asset.WithdrawFee, &asset.Deposit, &asset.Withdraw, &asset.CreateTime, &asset.Status)
+	return
+}
+
+//UpdateAssetStatus will lock or unlock asset on registry by asset key
+func UpdateAssetStatus(ctx context.Context, asset string, status AssetStatus) (err error) {
+	_, affected, err := Pool().Exec(
+		ctx,
+		`update exs_asset set status=$1,update_time=$2 where asset=$3`,
+		status, xsql.TimeNow(), asset,
+	)
+	if err == nil && affected < 1 {
+		err = pgx.ErrNoRows
+	}
+	return
+}
+
+//ListAsset will list registered asset by asset key, all asset is returned when assets is empty
+func ListAsset(ctx context.Context, assets ...string) (assetList []*Asset, assetMap map[string]*Asset, err error) {
+	assetList, assetMap, err = ListAssetCall(Pool(), ctx, assets...)
+	return
+}
+
+func ListAssetCall(caller crud.Queryer, ctx context.Context, assets ...string) (assetList []*Asset, assetMap map[string]*Asset, err error) {
+	err = ScanAssetFilterWherefCall(
+		caller, ctx, "#all",
+		"asset=any($%v)", []interface{}{xsql.StringArray(assets)},
+		"order by asset asc", &assetList, &assetMap, "asset",
+	)
+	return
+}
+
+//CheckAssetCall will check all assets is registered and not locked on registry
+func CheckAssetCall(caller crud.Queryer, ctx context.Context, assets ...string) (assetMap map[string]*Asset, err error) {
+	if len(assets) < 1 {
+		assetMap = map[string]*Asset{}
+		return
+	}
+	_, assetMap, err = ListAssetCall(caller, ctx, assets...)
+	if err != nil {
+		return
+	}
+	for _, asset := range assets {
+		having := assetMap[asset]
+		if having == nil {
+			err = ErrAssetInvalid(fmt.Sprintf("asset %v is not registered", asset))
+			return
+		}
+		if having.Status != AssetStatusNormal {
+			err = ErrAssetInvalid(fmt.Sprintf("asset %v is locked", asset))
+			return
+		}
+	}
+	return
+}
+
+//CheckWithdrawAssetCall will check asset is allowed to withdraw by quantity on registry
+func CheckWithdrawAssetCall(caller crud.Queryer, ctx context.Context, asset string, quantity decimal.Decimal) (having *Asset, err error) {
+	if quantity.Sign() <= 0 {
+		err = ErrAssetInvalid(fmt.Sprintf("withdraw quantity %v is invalid", quantity))
+		return
+	}
+	assetMap, err := CheckAssetCall(caller, ctx, asset)
+	if err != nil {
+		return
+	}
+	having = assetMap[asset]
+	if having.Withdraw != AssetWithdrawEnabled {
+		err = ErrAssetInvalid(fmt.Sprintf("asset %v withdraw is disabled", asset))
+		return
+	}
+	if quantity.LessThan(having.WithdrawMin) {
+		err = ErrAssetInvalid(fmt.Sprintf("withdraw quantity %v is less than asset %v minimum %v", quantity, asset, having.WithdrawMin))
+		return
+	}
+	if !quantity.Equal(quantity.Truncate(int32(having.DisplayPrecision))) {
+		err = ErrAssetInvalid(fmt.Sprintf("withdraw quantity %v is out of asset %v precision %v", quantity, asset, having.DisplayPrecision))
+		return
+	}
+	if quantity.LessThanOrEqual(having.WithdrawFee) {
+		err = ErrAssetInvalid(fmt.Sprintf("withdraw quantity %v is not greater than asset %v fee %v", quantity, asset, having.WithdrawFee))
+		return
+	}
+	return
+}
+
+//CheckTopupAssetCall will check asset is allowed to topup on registry
+func CheckTopupAssetCall(caller crud.Queryer, ctx context.Context, asset string, quantity decimal.Decimal) (err error) {
+	if quantity.Sign() <= 0 {
+		err = ErrAssetInvalid(fmt.Sprintf("topup quantity %v is invalid", quantity))
+		return
+	}
+	assetMap, err := CheckAssetCall(caller, ctx, asset)
+	if err != nil {
+		return
+	}
+	having := assetMap[asset]
+	if having.Deposit != AssetDepositEnabled {
+		err = ErrAssetInvalid(fmt.Sprintf("asset %v deposit is disabled", asset))
+		return
+	}
+	if !quantity.Equal(quantity.Truncate(int32(having.DisplayPrecision))) {
+		err = ErrAssetInvalid(fmt.Sprintf("topup quantity %v is out of asset %v precision %v", quantity, asset, having.DisplayPrecision))
+		return
+	}
+	return
+}
@@ -0,0 +1,166 @@
+package gexdb
+
+import (
+	"testing"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/shopspring/decimal"
+)
+
+func TestAsset(t *testing.T) {
+	clear()
+	user := testAddUser("TestAsset")
+	asset := &Asset{
+		Asset:            "TESTA",
+		Name:             "Test",
+		DisplayPrecision: 2,
+		WithdrawMin:      decimal.NewFromFloat(1),
+	}
+	err := UpsertAsset(ctx, asset)
+	if err != nil || asset.TID < 1 || asset.Status != AssetStatusNormal || asset.Withdraw != AssetWithdrawEnabled || asset.Deposit != AssetDepositEnabled {
+		t.Error(err)
+		return
+	}
+	assetID := asset.TID
+	asset.Name = "Test2"
+	err = UpsertAsset(ctx, asset)
+	if err != nil || asset.TID != assetID {
+		t.Error(err)
+		return
+	}
+	assetList, assetMap, err := ListAsset(ctx)
+	if err != nil || len(assetList) < 1 || assetMap["TESTA"] == nil || assetMap["TESTA"].Name != "Test2" {
+		t.Error(err)
+		return
+	}
+	_, err = TouchBalance(ctx, BalanceAreaFunds, []string{"TESTA"}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaFunds, Asset: "TESTA", Free: decimal.NewFromFloat(100)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//withdraw
+	withdraw, err := CreateWithdraw(ctx, user.TID, "TESTA", "addr", decimal.NewFromFloat(1.5))
+	if err != nil || withdraw.Status != WithdrawStatusPending {
+		t.Error(err)
+		return
+	}
+	withdraw, err = CancelWithdraw(ctx, user.TID, withdraw.OrderID)
+	if err != nil || withdraw.Status != WithdrawStatusCanceled {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "TESTA", "addr", decimal.NewFromFloat(0.5)); !IsErrAssetInvalid(err) { //min
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "TESTA", "addr", decimal.NewFromFloat(1.555)); !IsErrAssetInvalid(err) { //precision
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "TESTA", "addr", decimal.Zero); !IsErrAssetInvalid(err) { //zero
+		t.Error(err)
+		return
+	}
+	asset.Withdraw = AssetWithdrawDisabled
+	err = UpsertAsset(ctx, asset)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "TESTA", "addr", decimal.NewFromFloat(1)); !IsErrAssetInvalid(err) { //disabled
+		t.Error(err)
+		return
+	}
+	//locked
+	asset.Status = AssetStatusLocked
+	err = UpsertAsset(ctx, asset)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = TouchBalance(ctx, BalanceAreaSpot, []string{"TESTA"}, user.TID); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "TESTA", "addr", decimal.NewFromFloat(1)); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if _, err = TransferBalance(ctx, user.TID, "TESTA", BalanceAreaFunds, BalanceAreaSpot, decimal.NewFromFloat(1)); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	//unlock
+	err = UpdateAssetStatus(ctx, "TESTA", AssetStatusNormal)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = TouchBalance(ctx, BalanceAreaSpot, []string{"TESTA"}, user.TID); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = UpdateAssetStatus(ctx, "NONE", AssetStatusLocked); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	//update filter
+	updateAsset := &Asset{Asset: "TESTA", Name: "Test3"}
+	err = UpsertAssetFilter(ctx, updateAsset, "name")
+	if err != nil || updateAsset.TID != assetID || updateAsset.Name != "Test3" || updateAsset.DisplayPrecision != 2 || !updateAsset.WithdrawMin.Equal(decimal.NewFromFloat(1)) || updateAsset.Withdraw != AssetWithdrawDisabled {
+		t.Errorf("err:%v,asset:%v", err, converter.JSON(updateAsset))
+		return
+	}
+	//not registered
+	if _, err = TouchBalance(ctx, BalanceAreaSpot, []string{"NONE"}, user.TID); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "NONE", "addr", decimal.NewFromFloat(1)); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if err = CheckTopupAssetCall(Pool(), ctx, "NONE", decimal.NewFromFloat(1)); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	//
+	//test error
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 1)
+	if err = UpsertAsset(ctx, asset); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Exec", 1)
+	if err = UpdateAssetStatus(ctx, "TESTA", AssetStatusLocked); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Query", 1)
+	if _, err = TouchBalance(ctx, BalanceAreaSpot, []string{"NONE"}, user.TID); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Query", 1)
+	if _, err = CreateWithdraw(ctx, user.TID, "NONE", "addr", decimal.NewFromFloat(1)); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
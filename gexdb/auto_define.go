@@ -1,6 +1,32 @@
 //auto gen func by autogen
 package gexdb
 
+/**
+ * @apiDefine AssetUpdate
+ * @apiParam (Asset) {String} Asset.asset only required when add, the asset key
+ * @apiParam (Asset) {String} Asset.name only required when add, the asset display name
+ * @apiParam (Asset) {Int} [Asset.display_precision] the asset display precision
+ * @apiParam (Asset) {Decimal} [Asset.withdraw_min] the asset minimum withdraw quantity
+ * @apiParam (Asset) {Decimal} [Asset.withdraw_fee] the asset withdraw fee for each withdraw
+ * @apiParam (Asset) {AssetDeposit} [Asset.deposit] the asset deposit status, all suported is <a href="#metadata-Asset">AssetDepositAll</a>
+ * @apiParam (Asset) {AssetWithdraw} [Asset.withdraw] the asset withdraw status, all suported is <a href="#metadata-Asset">AssetWithdrawAll</a>
+ * @apiParam (Asset) {AssetStatus} [Asset.status] the asset status, all suported is <a href="#metadata-Asset">AssetStatusAll</a>
+ */
+/**
+ * @apiDefine AssetObject
+ * @apiSuccess (Asset) {Int64} Asset.tid the primary key
+ * @apiSuccess (Asset) {String} Asset.asset the asset key
+ * @apiSuccess (Asset) {String} Asset.name the asset display name
+ * @apiSuccess (Asset) {Int} Asset.display_precision the asset display precision
+ * @apiSuccess (Asset) {Decimal} Asset.withdraw_min the asset minimum withdraw quantity
+ * @apiSuccess (Asset) {Decimal} Asset.withdraw_fee the asset withdraw fee for each withdraw
+ * @apiSuccess (Asset) {AssetDeposit} Asset.deposit the asset deposit status, all suported is <a href="#metadata-Asset">AssetDepositAll</a>
+ * @apiSuccess (Asset) {AssetWithdraw} Asset.withdraw the asset withdraw status, all suported is <a href="#metadata-Asset">AssetWithdrawAll</a>
+ * @apiSuccess (Asset) {Time} Asset.update_time the asset update time
+ * @apiSuccess (Asset) {Time} Asset.create_time the asset create time
+ * @apiSuccess (Asset) {AssetStatus} Asset.status the asset status, all suported is <a href="#metadata-Asset">AssetStatusAll</a>
+ */
+
 /**
  * @apiDefine BalanceUpdate
  */
@@ -32,6 +58,44 @@ package gexdb
  * @apiSuccess (BalanceHistory) {BalanceHistoryStatus} BalanceHistory.status the balance record status, all suported is <a href="#metadata-BalanceHistory">BalanceHistoryStatusAll</a>
  */
 
+/**
+ * @apiDefine BalanceRecordUpdate
+ */
+/**
+ * @apiDefine BalanceRecordObject
+ * @apiSuccess (BalanceRecord) {Int64} BalanceRecord.tid the primary key
+ * @apiSuccess (BalanceRecord) {Int64} BalanceRecord.user_id the balance user id
+ * @apiSuccess (BalanceRecord) {BalanceArea} BalanceRecord.area the balance area
+ * @apiSuccess (BalanceRecord) {String} BalanceRecord.asset the balance asset key
+ * @apiSuccess (BalanceRecord) {BalanceRecordType} BalanceRecord.type the balance record type, all suported is <a href="#metadata-BalanceRecord">BalanceRecordTypeAll</a>
+ * @apiSuccess (BalanceRecord) {String} BalanceRecord.ref_id the balance record reference id, it is order id, withdraw order id, transfer id or holding id by type
+ * @apiSuccess (BalanceRecord) {Decimal} BalanceRecord.free_delta the balance free changed
+ * @apiSuccess (BalanceRecord) {Decimal} BalanceRecord.locked_delta the balance locked changed
+ * @apiSuccess (BalanceRecord) {Decimal} BalanceRecord.margin_delta the balance margin changed
+ * @apiSuccess (BalanceRecord) {Decimal} BalanceRecord.free the balance free after changed
+ * @apiSuccess (BalanceRecord) {Decimal} BalanceRecord.locked the balance locked after changed
+ * @apiSuccess (BalanceRecord) {Decimal} BalanceRecord.margin the balance margin after changed
+ * @apiSuccess (BalanceRecord) {Time} BalanceRecord.update_time the balance record update time
+ * @apiSuccess (BalanceRecord) {Time} BalanceRecord.create_time the balance record create time
+ * @apiSuccess (BalanceRecord) {BalanceRecordStatus} BalanceRecord.status the balance record status, all suported is <a href="#metadata-BalanceRecord">BalanceRecordStatusAll</a>
+ */
+
+/**
+ * @apiDefine BalanceTransferUpdate
+ */
+/**
+ * @apiDefine BalanceTransferObject
+ * @apiSuccess (BalanceTransfer) {Int64} BalanceTransfer.tid the primary key
+ * @apiSuccess (BalanceTransfer) {Int64} BalanceTransfer.user_id the transfer user id
+ * @apiSuccess (BalanceTransfer) {String} BalanceTransfer.asset the transfer asset key
+ * @apiSuccess (BalanceTransfer) {BalanceArea} BalanceTransfer.from_area the transfer source balance area
+ * @apiSuccess (BalanceTransfer) {BalanceArea} BalanceTransfer.to_area the transfer target balance area
+ * @apiSuccess (BalanceTransfer) {Decimal} BalanceTransfer.quantity the transfer quantity
+ * @apiSuccess (BalanceTransfer) {Time} BalanceTransfer.update_time the transfer update time
+ * @apiSuccess (BalanceTransfer) {Time} BalanceTransfer.create_time the transfer create time
+ * @apiSuccess (BalanceTransfer) {BalanceTransferStatus} BalanceTransfer.status the transfer status, all suported is <a href="#metadata-BalanceTransfer">BalanceTransferStatusAll</a>
+ */
+
 /**
  * @apiDefine HoldingUpdate
  */
@@ -130,6 +194,34 @@ package gexdb
  * @apiSuccess (OrderComm) {OrderCommStatus} OrderComm.status the comm status, all suported is <a href="#metadata-OrderComm">OrderCommStatusAll</a>
  */
 
+/**
+ * @apiDefine OutboxUpdate
+ */
+/**
+ * @apiDefine OutboxObject
+ * @apiSuccess (Outbox) {Int64} Outbox.tid the primary key
+ * @apiSuccess (Outbox) {String} Outbox.symbol the outbox event symbol
+ * @apiSuccess (Outbox) {Int64} Outbox.sequence the outbox event sequence by symbol
+ * @apiSuccess (Outbox) {Object} Outbox.event the outbox matcher event
+ * @apiSuccess (Outbox) {Time} Outbox.update_time the outbox last update time
+ * @apiSuccess (Outbox) {Time} Outbox.create_time the outbox create time
+ * @apiSuccess (Outbox) {OutboxStatus} Outbox.status the outbox status, all suported is <a href="#metadata-Outbox">OutboxStatusAll</a>
+ */
+
+/**
+ * @apiDefine OutboxAckUpdate
+ */
+/**
+ * @apiDefine OutboxAckObject
+ * @apiSuccess (OutboxAck) {Int64} OutboxAck.tid the primary key
+ * @apiSuccess (OutboxAck) {String} OutboxAck.consumer the outbox consumer name
+ * @apiSuccess (OutboxAck) {String} OutboxAck.symbol the outbox event symbol
+ * @apiSuccess (OutboxAck) {Int64} OutboxAck.sequence the last acked outbox event sequence
+ * @apiSuccess (OutboxAck) {Time} OutboxAck.update_time the outbox ack last update time
+ * @apiSuccess (OutboxAck) {Time} OutboxAck.create_time the outbox ack create time
+ * @apiSuccess (OutboxAck) {OutboxAckStatus} OutboxAck.status the outbox ack status, all suported is <a href="#metadata-OutboxAck">OutboxAckStatusAll</a>
+ */
+
 /**
  * @apiDefine UserUpdate
  * @apiParam (User) {UserRole} [User.role] ther user role, all suported is <a href="#metadata-User">UserRoleAll</a>
@@ -170,9 +262,27 @@ package gexdb
  * @apiSuccess (Withdraw) {Int64} Withdraw.user_id the withdraw order user id
  * @apiSuccess (Withdraw) {Int64} Withdraw.creator the withdraw order creator user id
  * @apiSuccess (Withdraw) {String} Withdraw.asset the withdraw asset
+ * @apiSuccess (Withdraw) {String} Withdraw.address the withdraw destination address
  * @apiSuccess (Withdraw) {Decimal} Withdraw.quantity the withdraw order quantity
+ * @apiSuccess (Withdraw) {Decimal} Withdraw.fee the withdraw order fee, it is included in quantity
  * @apiSuccess (Withdraw) {Object} Withdraw.transaction the withdraw order transaction info
  * @apiSuccess (Withdraw) {Time} Withdraw.update_time the withdraw order update time
  * @apiSuccess (Withdraw) {Time} Withdraw.create_time the withdraw order create time
  * @apiSuccess (Withdraw) {WithdrawStatus} Withdraw.status the withdraw order status, all suported is <a href="#metadata-Withdraw">WithdrawStatusAll</a>
  */
+
+/**
+ * @apiDefine WithdrawAddressUpdate
+ */
+/**
+ * @apiDefine WithdrawAddressObject
+ * @apiSuccess (WithdrawAddress) {Int64} WithdrawAddress.tid the primary key
+ * @apiSuccess (WithdrawAddress) {Int64} WithdrawAddress.user_id the address owner user id
+ * @apiSuccess (WithdrawAddress) {String} WithdrawAddress.asset the address asset key
+ * @apiSuccess (WithdrawAddress) {String} WithdrawAddress.address the withdraw destination address
+ * @apiSuccess (WithdrawAddress) {String} WithdrawAddress.label the address label
+ * @apiSuccess (WithdrawAddress) {Time} WithdrawAddress.available_time the address available time, it can be used to withdraw after this time
+ * @apiSuccess (WithdrawAddress) {Time} WithdrawAddress.update_time the address update time
+ * @apiSuccess (WithdrawAddress) {Time} WithdrawAddress.create_time the address create time
+ * @apiSuccess (WithdrawAddress) {WithdrawAddressStatus} WithdrawAddress.status the address status, all suported is <a href="#metadata-WithdrawAddress">WithdrawAddressStatusAll</a>
+ */
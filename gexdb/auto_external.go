@@ -11,19 +11,22 @@ import (
 )
 
 const (
-	ConfigWelcomeMessage = "welcome_message"
-	ConfigWithdrawMax    = "withdraw_max"
-	ConfigGoldbarAddress = "goldbar_address"
-	ConfigGoldbarExplain = "goldbar_explain"
-	ConfigGoldbarRate    = "goldbar_rate"
-	ConfigGoldbarFee     = "goldbar_fee"
-	ConfigGoldbarTips    = "goldbar_tips"
-	ConfigBrokerCommRate = "broker_comm_rate"
-	ConfigBrokerDesc     = "broker_desc"
-	ConfigTradeRule      = "trade_rule"
+	ConfigWelcomeMessage       = "welcome_message"
+	ConfigWithdrawMax          = "withdraw_max"
+	ConfigGoldbarAddress       = "goldbar_address"
+	ConfigGoldbarExplain       = "goldbar_explain"
+	ConfigGoldbarRate          = "goldbar_rate"
+	ConfigGoldbarFee           = "goldbar_fee"
+	ConfigGoldbarTips          = "goldbar_tips"
+	ConfigBrokerCommRate       = "broker_comm_rate"
+	ConfigBrokerDesc           = "broker_desc"
+	ConfigTradeRule            = "trade_rule"
+	ConfigWithdrawQuota        = "withdraw_quota"
+	ConfigWithdrawAddressDelay = "withdraw_address_delay"
+	ConfigWithdrawFeeUser      = "withdraw_fee_user"
 )
 
-var ConfigAll = []string{ConfigWelcomeMessage, ConfigWithdrawMax, ConfigGoldbarAddress, ConfigGoldbarExplain, ConfigGoldbarRate, ConfigGoldbarFee, ConfigGoldbarTips, ConfigBrokerCommRate, ConfigTradeRule}
+var ConfigAll = []string{ConfigWelcomeMessage, ConfigWithdrawMax, ConfigGoldbarAddress, ConfigGoldbarExplain, ConfigGoldbarRate, ConfigGoldbarFee, ConfigGoldbarTips, ConfigBrokerCommRate, ConfigTradeRule, ConfigWithdrawQuota, ConfigWithdrawAddressDelay, ConfigWithdrawFeeUser}
 
 const (
 	// BalanceAssetYWE = "YWE"
@@ -50,6 +53,13 @@ func (b BalanceArea) String() string {
 
 type ErrBalanceNotEnought string
 type ErrBalanceNotFound string
+type ErrAssetInvalid string
+type ErrWithdrawMax string
+type ErrWithdrawDailyQuota string
+type ErrWithdrawMonthlyQuota string
+type ErrWithdrawAddressInvalid string
+type ErrGoldbarAddressInvalid string
+type ErrGoldbarCodeInvalid string
 
 func (e ErrBalanceNotEnought) Error() string {
 	return string(e)
@@ -59,6 +69,34 @@ func (e ErrBalanceNotFound) Error() string {
 	return string(e)
 }
 
+func (e ErrAssetInvalid) Error() string {
+	return string(e)
+}
+
+func (e ErrWithdrawMax) Error() string {
+	return string(e)
+}
+
+func (e ErrWithdrawDailyQuota) Error() string {
+	return string(e)
+}
+
+func (e ErrWithdrawMonthlyQuota) Error() string {
+	return string(e)
+}
+
+func (e ErrWithdrawAddressInvalid) Error() string {
+	return string(e)
+}
+
+func (e ErrGoldbarAddressInvalid) Error() string {
+	return string(e)
+}
+
+func (e ErrGoldbarCodeInvalid) Error() string {
+	return string(e)
+}
+
 func IsErrBalanceNotEnought(err error) bool {
 	_, ok := err.(ErrBalanceNotEnought)
 	return ok
@@ -69,6 +107,41 @@ func IsErrBalanceNotFound(err error) bool {
 	return ok
 }
 
+func IsErrAssetInvalid(err error) bool {
+	_, ok := err.(ErrAssetInvalid)
+	return ok
+}
+
+func IsErrWithdrawMax(err error) bool {
+	_, ok := err.(ErrWithdrawMax)
+	return ok
+}
+
+func IsErrWithdrawDailyQuota(err error) bool {
+	_, ok := err.(ErrWithdrawDailyQuota)
+	return ok
+}
+
+func IsErrWithdrawMonthlyQuota(err error) bool {
+	_, ok := err.(ErrWithdrawMonthlyQuota)
+	return ok
+}
+
+func IsErrWithdrawAddressInvalid(err error) bool {
+	_, ok := err.(ErrWithdrawAddressInvalid)
+	return ok
+}
+
+func IsErrGoldbarAddressInvalid(err error) bool {
+	_, ok := err.(ErrGoldbarAddressInvalid)
+	return ok
+}
+
+func IsErrGoldbarCodeInvalid(err error) bool {
+	_, ok := err.(ErrGoldbarCodeInvalid)
+	return ok
+}
+
 type OrderTransactionItem struct {
 	OrderID    string          `json:"order_id,omitempty"`
 	Filled     decimal.Decimal `json:"filled,omitempty"`
@@ -76,6 +149,7 @@ type OrderTransactionItem struct {
 	TotalPrice decimal.Decimal `json:"total_price,omitempty"`
 	FeeBalance string          `json:"fee_balance,omitempty"`
 	FeeFilled  decimal.Decimal `json:"fee_filled,omitempty"`
+	Maker      bool            `json:"maker,omitempty"` //the item is recorded on maker order by one match, the order id is taker order id and price is filled price
 	CreateTime xsql.Time       `json:"create_time"`
 }
 
@@ -150,8 +224,19 @@ func (o *Order) Info() string {
 /***** metadata:ExReturnCode *****/
 
 const (
-	CodeBalanceNotEnought  = 7100
-	CodeBalanceNotFound    = 7110
-	CodeOrderNotCancelable = 7200
-	CodeOldPasswordInvalid = 7300
+	CodeBalanceNotEnought      = 7100
+	CodeBalanceNotFound        = 7110
+	CodeOrderNotCancelable     = 7200
+	CodeOldPasswordInvalid     = 7300
+	CodeTradePassInvalid       = 7310
+	CodeOpenOrderLimit         = 7400
+	CodeOrderNotionalLimit     = 7410
+	CodePositionLimit          = 7420
+	CodeAssetInvalid           = 7500
+	CodeWithdrawMax            = 7510
+	CodeWithdrawDailyQuota     = 7520
+	CodeWithdrawMonthlyQuota   = 7530
+	CodeWithdrawAddressInvalid = 7540
+	CodeGoldbarAddressInvalid  = 7600
+	CodeGoldbarCodeInvalid     = 7610
 )
@@ -19,6 +19,414 @@ type Validable interface {
 	Valid() error
 }
 
+//AssetFilterOptional is crud filter
+const AssetFilterOptional = "display_precision,withdraw_min,withdraw_fee,deposit,withdraw,status"
+
+//AssetFilterRequired is crud filter
+const AssetFilterRequired = "asset,name"
+
+//AssetFilterInsert is crud filter
+const AssetFilterInsert = "display_precision,withdraw_min,withdraw_fee,deposit,withdraw,status,asset,name"
+
+//AssetFilterUpdate is crud filter
+const AssetFilterUpdate = "update_time,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,status"
+
+//AssetFilterFind is crud filter
+const AssetFilterFind = "#all"
+
+//AssetFilterScan is crud filter
+const AssetFilterScan = "#all"
+
+//EnumValid will valid value by AssetDeposit
+func (o *AssetDeposit) EnumValid(v interface{}) (err error) {
+	var target AssetDeposit
+	targetType := reflect.TypeOf(AssetDeposit(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(AssetDeposit)
+	}
+	for _, value := range AssetDepositAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", AssetDepositAll)
+}
+
+//EnumValid will valid value by AssetDepositArray
+func (o *AssetDepositArray) EnumValid(v interface{}) (err error) {
+	var target AssetDeposit
+	targetType := reflect.TypeOf(AssetDeposit(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(AssetDeposit)
+	}
+	for _, value := range AssetDepositAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", AssetDepositAll)
+}
+
+//DbArray will join value to database array
+func (o AssetDepositArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o AssetDepositArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//EnumValid will valid value by AssetWithdraw
+func (o *AssetWithdraw) EnumValid(v interface{}) (err error) {
+	var target AssetWithdraw
+	targetType := reflect.TypeOf(AssetWithdraw(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(AssetWithdraw)
+	}
+	for _, value := range AssetWithdrawAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", AssetWithdrawAll)
+}
+
+//EnumValid will valid value by AssetWithdrawArray
+func (o *AssetWithdrawArray) EnumValid(v interface{}) (err error) {
+	var target AssetWithdraw
+	targetType := reflect.TypeOf(AssetWithdraw(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(AssetWithdraw)
+	}
+	for _, value := range AssetWithdrawAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", AssetWithdrawAll)
+}
+
+//DbArray will join value to database array
+func (o AssetWithdrawArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o AssetWithdrawArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//EnumValid will valid value by AssetStatus
+func (o *AssetStatus) EnumValid(v interface{}) (err error) {
+	var target AssetStatus
+	targetType := reflect.TypeOf(AssetStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(AssetStatus)
+	}
+	for _, value := range AssetStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", AssetStatusAll)
+}
+
+//EnumValid will valid value by AssetStatusArray
+func (o *AssetStatusArray) EnumValid(v interface{}) (err error) {
+	var target AssetStatus
+	targetType := reflect.TypeOf(AssetStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(AssetStatus)
+	}
+	for _, value := range AssetStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", AssetStatusAll)
+}
+
+//DbArray will join value to database array
+func (o AssetStatusArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o AssetStatusArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//MetaWithAsset will return exs_asset meta data
+func MetaWithAsset(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_asset"), fields...)
+	return
+}
+
+//MetaWith will return exs_asset meta data
+func (asset *Asset) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_asset"), fields...)
+	return
+}
+
+//Meta will return exs_asset meta data
+func (asset *Asset) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(asset, "#all")
+	return
+}
+
+//Valid will valid by filter
+func (asset *Asset) Valid() (err error) {
+	if reflect.ValueOf(asset.TID).IsZero() {
+		err = attrvalid.Valid(asset, AssetFilterInsert+"#all", AssetFilterOptional)
+	} else {
+		err = attrvalid.Valid(asset, AssetFilterUpdate, "")
+	}
+	return
+}
+
+//Insert will add exs_asset to database
+func (asset *Asset) Insert(caller interface{}, ctx context.Context) (err error) {
+
+	if asset.UpdateTime.Timestamp() < 1 {
+		asset.UpdateTime = xsql.TimeNow()
+	}
+
+	if asset.CreateTime.Timestamp() < 1 {
+		asset.CreateTime = xsql.TimeNow()
+	}
+
+	_, err = crud.InsertFilter(caller, ctx, asset, "^tid#all", "returning", "tid#all")
+	return
+}
+
+//UpdateFilter will update exs_asset to database
+func (asset *Asset) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = asset.UpdateFilterWheref(caller, ctx, filter, "")
+	return
+}
+
+//UpdateWheref will update exs_asset to database
+func (asset *Asset) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = asset.UpdateFilterWheref(caller, ctx, AssetFilterUpdate, formats, formatArgs...)
+	return
+}
+
+//UpdateFilterWheref will update exs_asset to database
+func (asset *Asset) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	asset.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(asset, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", asset.TID)
+	if len(formats) > 0 {
+		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
+	}
+	err = crud.UpdateRow(caller, ctx, asset, sql, where, "and", args)
+	return
+}
+
+//AddAsset will add exs_asset to database
+func AddAsset(ctx context.Context, asset *Asset) (err error) {
+	err = AddAssetCall(GetQueryer, ctx, asset)
+	return
+}
+
+//AddAsset will add exs_asset to database
+func AddAssetCall(caller interface{}, ctx context.Context, asset *Asset) (err error) {
+	err = asset.Insert(caller, ctx)
+	return
+}
+
+//UpdateAssetFilter will update exs_asset to database
+func UpdateAssetFilter(ctx context.Context, asset *Asset, filter string) (err error) {
+	err = UpdateAssetFilterCall(GetQueryer, ctx, asset, filter)
+	return
+}
+
+//UpdateAssetFilterCall will update exs_asset to database
+func UpdateAssetFilterCall(caller interface{}, ctx context.Context, asset *Asset, filter string) (err error) {
+	err = asset.UpdateFilter(caller, ctx, filter)
+	return
+}
+
+//UpdateAssetWheref will update exs_asset to database
+func UpdateAssetWheref(ctx context.Context, asset *Asset, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateAssetWherefCall(GetQueryer, ctx, asset, formats, formatArgs...)
+	return
+}
+
+//UpdateAssetWherefCall will update exs_asset to database
+func UpdateAssetWherefCall(caller interface{}, ctx context.Context, asset *Asset, formats string, formatArgs ...interface{}) (err error) {
+	err = asset.UpdateWheref(caller, ctx, formats, formatArgs...)
+	return
+}
+
+//UpdateAssetFilterWheref will update exs_asset to database
+func UpdateAssetFilterWheref(ctx context.Context, asset *Asset, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateAssetFilterWherefCall(GetQueryer, ctx, asset, filter, formats, formatArgs...)
+	return
+}
+
+//UpdateAssetFilterWherefCall will update exs_asset to database
+func UpdateAssetFilterWherefCall(caller interface{}, ctx context.Context, asset *Asset, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = asset.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+	return
+}
+
+//FindAssetCall will find exs_asset by id from database
+func FindAsset(ctx context.Context, assetID int64) (asset *Asset, err error) {
+	asset, err = FindAssetCall(GetQueryer, ctx, assetID, false)
+	return
+}
+
+//FindAssetCall will find exs_asset by id from database
+func FindAssetCall(caller interface{}, ctx context.Context, assetID int64, lock bool) (asset *Asset, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", assetID)
+	asset, err = FindAssetWhereCall(caller, ctx, lock, "and", where, args)
+	return
+}
+
+//FindAssetWhereCall will find exs_asset by where from database
+func FindAssetWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (asset *Asset, err error) {
+	querySQL := crud.QuerySQL(&Asset{}, "#all")
+	querySQL = crud.JoinWhere(querySQL, where, join)
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &Asset{}, "#all", querySQL, args, &asset)
+	return
+}
+
+//FindAssetWheref will find exs_asset by where from database
+func FindAssetWheref(ctx context.Context, format string, args ...interface{}) (asset *Asset, err error) {
+	asset, err = FindAssetWherefCall(GetQueryer, ctx, false, format, args...)
+	return
+}
+
+//FindAssetWherefCall will find exs_asset by where from database
+func FindAssetWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (asset *Asset, err error) {
+	asset, err = FindAssetFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+	return
+}
+
+//FindAssetFilterWheref will find exs_asset by where from database
+func FindAssetFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (asset *Asset, err error) {
+	asset, err = FindAssetFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+	return
+}
+
+//FindAssetFilterWherefCall will find exs_asset by where from database
+func FindAssetFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (asset *Asset, err error) {
+	querySQL := crud.QuerySQL(&Asset{}, filter)
+	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
+	querySQL = crud.JoinWhere(querySQL, where, "and")
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &Asset{}, filter, querySQL, queryArgs, &asset)
+	return
+}
+
+//ListAssetByID will list exs_asset by id from database
+func ListAssetByID(ctx context.Context, assetIDs ...int64) (assetList []*Asset, assetMap map[int64]*Asset, err error) {
+	assetList, assetMap, err = ListAssetByIDCall(GetQueryer, ctx, assetIDs...)
+	return
+}
+
+//ListAssetByIDCall will list exs_asset by id from database
+func ListAssetByIDCall(caller interface{}, ctx context.Context, assetIDs ...int64) (assetList []*Asset, assetMap map[int64]*Asset, err error) {
+	if len(assetIDs) < 1 {
+		assetMap = map[int64]*Asset{}
+		return
+	}
+	err = ScanAssetByIDCall(caller, ctx, assetIDs, &assetList, &assetMap, "tid")
+	return
+}
+
+//ListAssetFilterByID will list exs_asset by id from database
+func ListAssetFilterByID(ctx context.Context, filter string, assetIDs ...int64) (assetList []*Asset, assetMap map[int64]*Asset, err error) {
+	assetList, assetMap, err = ListAssetFilterByIDCall(GetQueryer, ctx, filter, assetIDs...)
+	return
+}
+
+//ListAssetFilterByIDCall will list exs_asset by id from database
+func ListAssetFilterByIDCall(caller interface{}, ctx context.Context, filter string, assetIDs ...int64) (assetList []*Asset, assetMap map[int64]*Asset, err error) {
+	if len(assetIDs) < 1 {
+		assetMap = map[int64]*Asset{}
+		return
+	}
+	err = ScanAssetFilterByIDCall(caller, ctx, filter, assetIDs, &assetList, &assetMap, "tid")
+	return
+}
+
+//ScanAssetByID will list exs_asset by id from database
+func ScanAssetByID(ctx context.Context, assetIDs []int64, dest ...interface{}) (err error) {
+	err = ScanAssetByIDCall(GetQueryer, ctx, assetIDs, dest...)
+	return
+}
+
+//ScanAssetByIDCall will list exs_asset by id from database
+func ScanAssetByIDCall(caller interface{}, ctx context.Context, assetIDs []int64, dest ...interface{}) (err error) {
+	err = ScanAssetFilterByIDCall(caller, ctx, "#all", assetIDs, dest...)
+	return
+}
+
+//ScanAssetFilterByID will list exs_asset by id from database
+func ScanAssetFilterByID(ctx context.Context, filter string, assetIDs []int64, dest ...interface{}) (err error) {
+	err = ScanAssetFilterByIDCall(GetQueryer, ctx, filter, assetIDs, dest...)
+	return
+}
+
+//ScanAssetFilterByIDCall will list exs_asset by id from database
+func ScanAssetFilterByIDCall(caller interface{}, ctx context.Context, filter string, assetIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Asset{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(assetIDs).InArray()))
+	querySQL = crud.JoinWhere(querySQL, where, " and ")
+	err = crud.Query(caller, ctx, &Asset{}, filter, querySQL, nil, dest...)
+	return
+}
+
+//ScanAssetWherefCall will list exs_asset by format from database
+func ScanAssetWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanAssetWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+	return
+}
+
+//ScanAssetWherefCall will list exs_asset by format from database
+func ScanAssetWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanAssetFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+	return
+}
+
+//ScanAssetFilterWheref will list exs_asset by format from database
+func ScanAssetFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanAssetFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+	return
+}
+
+//ScanAssetFilterWherefCall will list exs_asset by format from database
+func ScanAssetFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Asset{}, filter)
+	var where []string
+	if len(format) > 0 {
+		where, args = crud.AppendWheref(nil, nil, format, args...)
+	}
+	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
+	err = crud.Query(caller, ctx, &Asset{}, filter, querySQL, args, dest...)
+	return
+}
+
 //BalanceFilterOptional is crud filter
 const BalanceFilterOptional = ""
 
@@ -703,1399 +1111,2723 @@ func ScanBalanceHistoryFilterWherefCall(caller interface{}, ctx context.Context,
 	return
 }
 
-//HoldingFilterOptional is crud filter
-const HoldingFilterOptional = ""
+//BalanceRecordFilterOptional is crud filter
+const BalanceRecordFilterOptional = ""
 
-//HoldingFilterRequired is crud filter
-const HoldingFilterRequired = ""
+//BalanceRecordFilterRequired is crud filter
+const BalanceRecordFilterRequired = ""
 
-//HoldingFilterInsert is crud filter
-const HoldingFilterInsert = ""
+//BalanceRecordFilterInsert is crud filter
+const BalanceRecordFilterInsert = ""
 
-//HoldingFilterUpdate is crud filter
-const HoldingFilterUpdate = "update_time"
+//BalanceRecordFilterUpdate is crud filter
+const BalanceRecordFilterUpdate = "update_time"
 
-//HoldingFilterFind is crud filter
-const HoldingFilterFind = "#all"
+//BalanceRecordFilterFind is crud filter
+const BalanceRecordFilterFind = "#all"
 
-//HoldingFilterScan is crud filter
-const HoldingFilterScan = "#all"
+//BalanceRecordFilterScan is crud filter
+const BalanceRecordFilterScan = "#all"
 
-//EnumValid will valid value by HoldingStatus
-func (o *HoldingStatus) EnumValid(v interface{}) (err error) {
-	var target HoldingStatus
-	targetType := reflect.TypeOf(HoldingStatus(0))
+//EnumValid will valid value by BalanceRecordType
+func (o *BalanceRecordType) EnumValid(v interface{}) (err error) {
+	var target BalanceRecordType
+	targetType := reflect.TypeOf(BalanceRecordType(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(HoldingStatus)
+		target = targetValue.Convert(targetType).Interface().(BalanceRecordType)
 	}
-	for _, value := range HoldingStatusAll {
+	for _, value := range BalanceRecordTypeAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", HoldingStatusAll)
+	return fmt.Errorf("must be in %v", BalanceRecordTypeAll)
 }
 
-//EnumValid will valid value by HoldingStatusArray
-func (o *HoldingStatusArray) EnumValid(v interface{}) (err error) {
-	var target HoldingStatus
-	targetType := reflect.TypeOf(HoldingStatus(0))
+//EnumValid will valid value by BalanceRecordTypeArray
+func (o *BalanceRecordTypeArray) EnumValid(v interface{}) (err error) {
+	var target BalanceRecordType
+	targetType := reflect.TypeOf(BalanceRecordType(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(HoldingStatus)
+		target = targetValue.Convert(targetType).Interface().(BalanceRecordType)
 	}
-	for _, value := range HoldingStatusAll {
+	for _, value := range BalanceRecordTypeAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", HoldingStatusAll)
+	return fmt.Errorf("must be in %v", BalanceRecordTypeAll)
 }
 
 //DbArray will join value to database array
-func (o HoldingStatusArray) DbArray() (res string) {
+func (o BalanceRecordTypeArray) DbArray() (res string) {
 	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
 	return
 }
 
 //InArray will join value to database array
-func (o HoldingStatusArray) InArray() (res string) {
+func (o BalanceRecordTypeArray) InArray() (res string) {
 	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
 	return
 }
 
-//MetaWithHolding will return exs_holding meta data
-func MetaWithHolding(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_holding"), fields...)
+//EnumValid will valid value by BalanceRecordStatus
+func (o *BalanceRecordStatus) EnumValid(v interface{}) (err error) {
+	var target BalanceRecordStatus
+	targetType := reflect.TypeOf(BalanceRecordStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(BalanceRecordStatus)
+	}
+	for _, value := range BalanceRecordStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", BalanceRecordStatusAll)
+}
+
+//EnumValid will valid value by BalanceRecordStatusArray
+func (o *BalanceRecordStatusArray) EnumValid(v interface{}) (err error) {
+	var target BalanceRecordStatus
+	targetType := reflect.TypeOf(BalanceRecordStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(BalanceRecordStatus)
+	}
+	for _, value := range BalanceRecordStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", BalanceRecordStatusAll)
+}
+
+//DbArray will join value to database array
+func (o BalanceRecordStatusArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
 	return
 }
 
-//MetaWith will return exs_holding meta data
-func (holding *Holding) MetaWith(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_holding"), fields...)
+//InArray will join value to database array
+func (o BalanceRecordStatusArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
 	return
 }
 
-//Meta will return exs_holding meta data
-func (holding *Holding) Meta() (table string, fileds []string) {
-	table, fileds = crud.QueryField(holding, "#all")
+//MetaWithBalanceRecord will return exs_balance_record meta data
+func MetaWithBalanceRecord(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_balance_record"), fields...)
+	return
+}
+
+//MetaWith will return exs_balance_record meta data
+func (balanceRecord *BalanceRecord) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_balance_record"), fields...)
+	return
+}
+
+//Meta will return exs_balance_record meta data
+func (balanceRecord *BalanceRecord) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(balanceRecord, "#all")
 	return
 }
 
 //Valid will valid by filter
-func (holding *Holding) Valid() (err error) {
-	if reflect.ValueOf(holding.TID).IsZero() {
-		err = attrvalid.Valid(holding, HoldingFilterInsert+"#all", HoldingFilterOptional)
+func (balanceRecord *BalanceRecord) Valid() (err error) {
+	if reflect.ValueOf(balanceRecord.TID).IsZero() {
+		err = attrvalid.Valid(balanceRecord, BalanceRecordFilterInsert+"#all", BalanceRecordFilterOptional)
 	} else {
-		err = attrvalid.Valid(holding, HoldingFilterUpdate, "")
+		err = attrvalid.Valid(balanceRecord, BalanceRecordFilterUpdate, "")
 	}
 	return
 }
 
-//Insert will add exs_holding to database
-func (holding *Holding) Insert(caller interface{}, ctx context.Context) (err error) {
+//Insert will add exs_balance_record to database
+func (balanceRecord *BalanceRecord) Insert(caller interface{}, ctx context.Context) (err error) {
 
-	if holding.UpdateTime.Timestamp() < 1 {
-		holding.UpdateTime = xsql.TimeNow()
+	if balanceRecord.UpdateTime.Timestamp() < 1 {
+		balanceRecord.UpdateTime = xsql.TimeNow()
 	}
 
-	if holding.CreateTime.Timestamp() < 1 {
-		holding.CreateTime = xsql.TimeNow()
+	if balanceRecord.CreateTime.Timestamp() < 1 {
+		balanceRecord.CreateTime = xsql.TimeNow()
 	}
 
-	_, err = crud.InsertFilter(caller, ctx, holding, "^tid#all", "returning", "tid#all")
+	_, err = crud.InsertFilter(caller, ctx, balanceRecord, "^tid#all", "returning", "tid#all")
 	return
 }
 
-//UpdateFilter will update exs_holding to database
-func (holding *Holding) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
-	err = holding.UpdateFilterWheref(caller, ctx, filter, "")
+//UpdateFilter will update exs_balance_record to database
+func (balanceRecord *BalanceRecord) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = balanceRecord.UpdateFilterWheref(caller, ctx, filter, "")
 	return
 }
 
-//UpdateWheref will update exs_holding to database
-func (holding *Holding) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
-	err = holding.UpdateFilterWheref(caller, ctx, HoldingFilterUpdate, formats, formatArgs...)
+//UpdateWheref will update exs_balance_record to database
+func (balanceRecord *BalanceRecord) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = balanceRecord.UpdateFilterWheref(caller, ctx, BalanceRecordFilterUpdate, formats, formatArgs...)
 	return
 }
 
-//UpdateFilterWheref will update exs_holding to database
-func (holding *Holding) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
-	holding.UpdateTime = xsql.TimeNow()
-	sql, args := crud.UpdateSQL(holding, filter, nil)
-	where, args := crud.AppendWheref(nil, args, "tid=$%v", holding.TID)
+//UpdateFilterWheref will update exs_balance_record to database
+func (balanceRecord *BalanceRecord) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	balanceRecord.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(balanceRecord, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", balanceRecord.TID)
 	if len(formats) > 0 {
 		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
 	}
-	err = crud.UpdateRow(caller, ctx, holding, sql, where, "and", args)
+	err = crud.UpdateRow(caller, ctx, balanceRecord, sql, where, "and", args)
 	return
 }
 
-//UpdateHoldingFilter will update exs_holding to database
-func UpdateHoldingFilter(ctx context.Context, holding *Holding, filter string) (err error) {
-	err = UpdateHoldingFilterCall(GetQueryer, ctx, holding, filter)
+//AddBalanceRecord will add exs_balance_record to database
+func AddBalanceRecord(ctx context.Context, balanceRecord *BalanceRecord) (err error) {
+	err = AddBalanceRecordCall(GetQueryer, ctx, balanceRecord)
 	return
 }
 
-//UpdateHoldingFilterCall will update exs_holding to database
-func UpdateHoldingFilterCall(caller interface{}, ctx context.Context, holding *Holding, filter string) (err error) {
-	err = holding.UpdateFilter(caller, ctx, filter)
+//AddBalanceRecord will add exs_balance_record to database
+func AddBalanceRecordCall(caller interface{}, ctx context.Context, balanceRecord *BalanceRecord) (err error) {
+	err = balanceRecord.Insert(caller, ctx)
 	return
 }
 
-//UpdateHoldingWheref will update exs_holding to database
-func UpdateHoldingWheref(ctx context.Context, holding *Holding, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateHoldingWherefCall(GetQueryer, ctx, holding, formats, formatArgs...)
+//UpdateBalanceRecordFilter will update exs_balance_record to database
+func UpdateBalanceRecordFilter(ctx context.Context, balanceRecord *BalanceRecord, filter string) (err error) {
+	err = UpdateBalanceRecordFilterCall(GetQueryer, ctx, balanceRecord, filter)
 	return
 }
 
-//UpdateHoldingWherefCall will update exs_holding to database
-func UpdateHoldingWherefCall(caller interface{}, ctx context.Context, holding *Holding, formats string, formatArgs ...interface{}) (err error) {
-	err = holding.UpdateWheref(caller, ctx, formats, formatArgs...)
+//UpdateBalanceRecordFilterCall will update exs_balance_record to database
+func UpdateBalanceRecordFilterCall(caller interface{}, ctx context.Context, balanceRecord *BalanceRecord, filter string) (err error) {
+	err = balanceRecord.UpdateFilter(caller, ctx, filter)
 	return
 }
 
-//UpdateHoldingFilterWheref will update exs_holding to database
-func UpdateHoldingFilterWheref(ctx context.Context, holding *Holding, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateHoldingFilterWherefCall(GetQueryer, ctx, holding, filter, formats, formatArgs...)
+//UpdateBalanceRecordWheref will update exs_balance_record to database
+func UpdateBalanceRecordWheref(ctx context.Context, balanceRecord *BalanceRecord, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateBalanceRecordWherefCall(GetQueryer, ctx, balanceRecord, formats, formatArgs...)
 	return
 }
 
-//UpdateHoldingFilterWherefCall will update exs_holding to database
-func UpdateHoldingFilterWherefCall(caller interface{}, ctx context.Context, holding *Holding, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = holding.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+//UpdateBalanceRecordWherefCall will update exs_balance_record to database
+func UpdateBalanceRecordWherefCall(caller interface{}, ctx context.Context, balanceRecord *BalanceRecord, formats string, formatArgs ...interface{}) (err error) {
+	err = balanceRecord.UpdateWheref(caller, ctx, formats, formatArgs...)
 	return
 }
 
-//FindHoldingCall will find exs_holding by id from database
-func FindHolding(ctx context.Context, holdingID int64) (holding *Holding, err error) {
-	holding, err = FindHoldingCall(GetQueryer, ctx, holdingID, false)
+//UpdateBalanceRecordFilterWheref will update exs_balance_record to database
+func UpdateBalanceRecordFilterWheref(ctx context.Context, balanceRecord *BalanceRecord, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateBalanceRecordFilterWherefCall(GetQueryer, ctx, balanceRecord, filter, formats, formatArgs...)
 	return
 }
 
-//FindHoldingCall will find exs_holding by id from database
-func FindHoldingCall(caller interface{}, ctx context.Context, holdingID int64, lock bool) (holding *Holding, err error) {
-	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", holdingID)
-	holding, err = FindHoldingWhereCall(caller, ctx, lock, "and", where, args)
+//UpdateBalanceRecordFilterWherefCall will update exs_balance_record to database
+func UpdateBalanceRecordFilterWherefCall(caller interface{}, ctx context.Context, balanceRecord *BalanceRecord, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = balanceRecord.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
 	return
 }
 
-//FindHoldingWhereCall will find exs_holding by where from database
-func FindHoldingWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (holding *Holding, err error) {
-	querySQL := crud.QuerySQL(&Holding{}, "#all")
+//FindBalanceRecordCall will find exs_balance_record by id from database
+func FindBalanceRecord(ctx context.Context, balanceRecordID int64) (balanceRecord *BalanceRecord, err error) {
+	balanceRecord, err = FindBalanceRecordCall(GetQueryer, ctx, balanceRecordID, false)
+	return
+}
+
+//FindBalanceRecordCall will find exs_balance_record by id from database
+func FindBalanceRecordCall(caller interface{}, ctx context.Context, balanceRecordID int64, lock bool) (balanceRecord *BalanceRecord, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", balanceRecordID)
+	balanceRecord, err = FindBalanceRecordWhereCall(caller, ctx, lock, "and", where, args)
+	return
+}
+
+//FindBalanceRecordWhereCall will find exs_balance_record by where from database
+func FindBalanceRecordWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (balanceRecord *BalanceRecord, err error) {
+	querySQL := crud.QuerySQL(&BalanceRecord{}, "#all")
 	querySQL = crud.JoinWhere(querySQL, where, join)
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &Holding{}, "#all", querySQL, args, &holding)
+	err = crud.QueryRow(caller, ctx, &BalanceRecord{}, "#all", querySQL, args, &balanceRecord)
 	return
 }
 
-//FindHoldingWheref will find exs_holding by where from database
-func FindHoldingWheref(ctx context.Context, format string, args ...interface{}) (holding *Holding, err error) {
-	holding, err = FindHoldingWherefCall(GetQueryer, ctx, false, format, args...)
+//FindBalanceRecordWheref will find exs_balance_record by where from database
+func FindBalanceRecordWheref(ctx context.Context, format string, args ...interface{}) (balanceRecord *BalanceRecord, err error) {
+	balanceRecord, err = FindBalanceRecordWherefCall(GetQueryer, ctx, false, format, args...)
 	return
 }
 
-//FindHoldingWherefCall will find exs_holding by where from database
-func FindHoldingWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (holding *Holding, err error) {
-	holding, err = FindHoldingFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+//FindBalanceRecordWherefCall will find exs_balance_record by where from database
+func FindBalanceRecordWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (balanceRecord *BalanceRecord, err error) {
+	balanceRecord, err = FindBalanceRecordFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
 	return
 }
 
-//FindHoldingFilterWheref will find exs_holding by where from database
-func FindHoldingFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (holding *Holding, err error) {
-	holding, err = FindHoldingFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+//FindBalanceRecordFilterWheref will find exs_balance_record by where from database
+func FindBalanceRecordFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (balanceRecord *BalanceRecord, err error) {
+	balanceRecord, err = FindBalanceRecordFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
 	return
 }
 
-//FindHoldingFilterWherefCall will find exs_holding by where from database
-func FindHoldingFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (holding *Holding, err error) {
-	querySQL := crud.QuerySQL(&Holding{}, filter)
+//FindBalanceRecordFilterWherefCall will find exs_balance_record by where from database
+func FindBalanceRecordFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (balanceRecord *BalanceRecord, err error) {
+	querySQL := crud.QuerySQL(&BalanceRecord{}, filter)
 	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
 	querySQL = crud.JoinWhere(querySQL, where, "and")
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &Holding{}, filter, querySQL, queryArgs, &holding)
+	err = crud.QueryRow(caller, ctx, &BalanceRecord{}, filter, querySQL, queryArgs, &balanceRecord)
 	return
 }
 
-//ListHoldingByID will list exs_holding by id from database
-func ListHoldingByID(ctx context.Context, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
-	holdingList, holdingMap, err = ListHoldingByIDCall(GetQueryer, ctx, holdingIDs...)
+//ListBalanceRecordByID will list exs_balance_record by id from database
+func ListBalanceRecordByID(ctx context.Context, balanceRecordIDs ...int64) (balanceRecordList []*BalanceRecord, balanceRecordMap map[int64]*BalanceRecord, err error) {
+	balanceRecordList, balanceRecordMap, err = ListBalanceRecordByIDCall(GetQueryer, ctx, balanceRecordIDs...)
 	return
 }
 
-//ListHoldingByIDCall will list exs_holding by id from database
-func ListHoldingByIDCall(caller interface{}, ctx context.Context, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
-	if len(holdingIDs) < 1 {
-		holdingMap = map[int64]*Holding{}
+//ListBalanceRecordByIDCall will list exs_balance_record by id from database
+func ListBalanceRecordByIDCall(caller interface{}, ctx context.Context, balanceRecordIDs ...int64) (balanceRecordList []*BalanceRecord, balanceRecordMap map[int64]*BalanceRecord, err error) {
+	if len(balanceRecordIDs) < 1 {
+		balanceRecordMap = map[int64]*BalanceRecord{}
 		return
 	}
-	err = ScanHoldingByIDCall(caller, ctx, holdingIDs, &holdingList, &holdingMap, "tid")
+	err = ScanBalanceRecordByIDCall(caller, ctx, balanceRecordIDs, &balanceRecordList, &balanceRecordMap, "tid")
 	return
 }
 
-//ListHoldingFilterByID will list exs_holding by id from database
-func ListHoldingFilterByID(ctx context.Context, filter string, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
-	holdingList, holdingMap, err = ListHoldingFilterByIDCall(GetQueryer, ctx, filter, holdingIDs...)
+//ListBalanceRecordFilterByID will list exs_balance_record by id from database
+func ListBalanceRecordFilterByID(ctx context.Context, filter string, balanceRecordIDs ...int64) (balanceRecordList []*BalanceRecord, balanceRecordMap map[int64]*BalanceRecord, err error) {
+	balanceRecordList, balanceRecordMap, err = ListBalanceRecordFilterByIDCall(GetQueryer, ctx, filter, balanceRecordIDs...)
 	return
 }
 
-//ListHoldingFilterByIDCall will list exs_holding by id from database
-func ListHoldingFilterByIDCall(caller interface{}, ctx context.Context, filter string, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
-	if len(holdingIDs) < 1 {
-		holdingMap = map[int64]*Holding{}
+//ListBalanceRecordFilterByIDCall will list exs_balance_record by id from database
+func ListBalanceRecordFilterByIDCall(caller interface{}, ctx context.Context, filter string, balanceRecordIDs ...int64) (balanceRecordList []*BalanceRecord, balanceRecordMap map[int64]*BalanceRecord, err error) {
+	if len(balanceRecordIDs) < 1 {
+		balanceRecordMap = map[int64]*BalanceRecord{}
 		return
 	}
-	err = ScanHoldingFilterByIDCall(caller, ctx, filter, holdingIDs, &holdingList, &holdingMap, "tid")
+	err = ScanBalanceRecordFilterByIDCall(caller, ctx, filter, balanceRecordIDs, &balanceRecordList, &balanceRecordMap, "tid")
 	return
 }
 
-//ScanHoldingByID will list exs_holding by id from database
-func ScanHoldingByID(ctx context.Context, holdingIDs []int64, dest ...interface{}) (err error) {
-	err = ScanHoldingByIDCall(GetQueryer, ctx, holdingIDs, dest...)
+//ScanBalanceRecordByID will list exs_balance_record by id from database
+func ScanBalanceRecordByID(ctx context.Context, balanceRecordIDs []int64, dest ...interface{}) (err error) {
+	err = ScanBalanceRecordByIDCall(GetQueryer, ctx, balanceRecordIDs, dest...)
 	return
 }
 
-//ScanHoldingByIDCall will list exs_holding by id from database
-func ScanHoldingByIDCall(caller interface{}, ctx context.Context, holdingIDs []int64, dest ...interface{}) (err error) {
-	err = ScanHoldingFilterByIDCall(caller, ctx, "#all", holdingIDs, dest...)
+//ScanBalanceRecordByIDCall will list exs_balance_record by id from database
+func ScanBalanceRecordByIDCall(caller interface{}, ctx context.Context, balanceRecordIDs []int64, dest ...interface{}) (err error) {
+	err = ScanBalanceRecordFilterByIDCall(caller, ctx, "#all", balanceRecordIDs, dest...)
 	return
 }
 
-//ScanHoldingFilterByID will list exs_holding by id from database
-func ScanHoldingFilterByID(ctx context.Context, filter string, holdingIDs []int64, dest ...interface{}) (err error) {
-	err = ScanHoldingFilterByIDCall(GetQueryer, ctx, filter, holdingIDs, dest...)
+//ScanBalanceRecordFilterByID will list exs_balance_record by id from database
+func ScanBalanceRecordFilterByID(ctx context.Context, filter string, balanceRecordIDs []int64, dest ...interface{}) (err error) {
+	err = ScanBalanceRecordFilterByIDCall(GetQueryer, ctx, filter, balanceRecordIDs, dest...)
 	return
 }
 
-//ScanHoldingFilterByIDCall will list exs_holding by id from database
-func ScanHoldingFilterByIDCall(caller interface{}, ctx context.Context, filter string, holdingIDs []int64, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&Holding{}, filter)
-	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(holdingIDs).InArray()))
+//ScanBalanceRecordFilterByIDCall will list exs_balance_record by id from database
+func ScanBalanceRecordFilterByIDCall(caller interface{}, ctx context.Context, filter string, balanceRecordIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&BalanceRecord{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(balanceRecordIDs).InArray()))
 	querySQL = crud.JoinWhere(querySQL, where, " and ")
-	err = crud.Query(caller, ctx, &Holding{}, filter, querySQL, nil, dest...)
+	err = crud.Query(caller, ctx, &BalanceRecord{}, filter, querySQL, nil, dest...)
 	return
 }
 
-//ScanHoldingWherefCall will list exs_holding by format from database
-func ScanHoldingWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanHoldingWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+//ScanBalanceRecordWherefCall will list exs_balance_record by format from database
+func ScanBalanceRecordWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanBalanceRecordWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
 	return
 }
 
-//ScanHoldingWherefCall will list exs_holding by format from database
-func ScanHoldingWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanHoldingFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+//ScanBalanceRecordWherefCall will list exs_balance_record by format from database
+func ScanBalanceRecordWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanBalanceRecordFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
 	return
 }
 
-//ScanHoldingFilterWheref will list exs_holding by format from database
-func ScanHoldingFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanHoldingFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+//ScanBalanceRecordFilterWheref will list exs_balance_record by format from database
+func ScanBalanceRecordFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanBalanceRecordFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
 	return
 }
 
-//ScanHoldingFilterWherefCall will list exs_holding by format from database
-func ScanHoldingFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&Holding{}, filter)
+//ScanBalanceRecordFilterWherefCall will list exs_balance_record by format from database
+func ScanBalanceRecordFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&BalanceRecord{}, filter)
 	var where []string
 	if len(format) > 0 {
 		where, args = crud.AppendWheref(nil, nil, format, args...)
 	}
 	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
-	err = crud.Query(caller, ctx, &Holding{}, filter, querySQL, args, dest...)
+	err = crud.Query(caller, ctx, &BalanceRecord{}, filter, querySQL, args, dest...)
 	return
 }
 
-//KLineFilterOptional is crud filter
-const KLineFilterOptional = ""
+//BalanceTransferFilterOptional is crud filter
+const BalanceTransferFilterOptional = ""
 
-//KLineFilterRequired is crud filter
-const KLineFilterRequired = ""
+//BalanceTransferFilterRequired is crud filter
+const BalanceTransferFilterRequired = ""
 
-//KLineFilterInsert is crud filter
-const KLineFilterInsert = ""
+//BalanceTransferFilterInsert is crud filter
+const BalanceTransferFilterInsert = ""
 
-//KLineFilterUpdate is crud filter
-const KLineFilterUpdate = "update_time"
+//BalanceTransferFilterUpdate is crud filter
+const BalanceTransferFilterUpdate = "update_time"
 
-//KLineFilterFind is crud filter
-const KLineFilterFind = "#all"
+//BalanceTransferFilterFind is crud filter
+const BalanceTransferFilterFind = "#all"
 
-//KLineFilterScan is crud filter
-const KLineFilterScan = "#all"
+//BalanceTransferFilterScan is crud filter
+const BalanceTransferFilterScan = "#all"
 
-//MetaWithKLine will return exs_kline meta data
-func MetaWithKLine(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_kline"), fields...)
+//EnumValid will valid value by BalanceTransferStatus
+func (o *BalanceTransferStatus) EnumValid(v interface{}) (err error) {
+	var target BalanceTransferStatus
+	targetType := reflect.TypeOf(BalanceTransferStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(BalanceTransferStatus)
+	}
+	for _, value := range BalanceTransferStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", BalanceTransferStatusAll)
+}
+
+//EnumValid will valid value by BalanceTransferStatusArray
+func (o *BalanceTransferStatusArray) EnumValid(v interface{}) (err error) {
+	var target BalanceTransferStatus
+	targetType := reflect.TypeOf(BalanceTransferStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(BalanceTransferStatus)
+	}
+	for _, value := range BalanceTransferStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", BalanceTransferStatusAll)
+}
+
+//DbArray will join value to database array
+func (o BalanceTransferStatusArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
 	return
 }
 
-//MetaWith will return exs_kline meta data
-func (kLine *KLine) MetaWith(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_kline"), fields...)
+//InArray will join value to database array
+func (o BalanceTransferStatusArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
 	return
 }
 
-//Meta will return exs_kline meta data
-func (kLine *KLine) Meta() (table string, fileds []string) {
-	table, fileds = crud.QueryField(kLine, "#all")
+//MetaWithBalanceTransfer will return exs_balance_transfer meta data
+func MetaWithBalanceTransfer(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_balance_transfer"), fields...)
+	return
+}
+
+//MetaWith will return exs_balance_transfer meta data
+func (balanceTransfer *BalanceTransfer) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_balance_transfer"), fields...)
+	return
+}
+
+//Meta will return exs_balance_transfer meta data
+func (balanceTransfer *BalanceTransfer) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(balanceTransfer, "#all")
 	return
 }
 
 //Valid will valid by filter
-func (kLine *KLine) Valid() (err error) {
-	if reflect.ValueOf(kLine.TID).IsZero() {
-		err = attrvalid.Valid(kLine, KLineFilterInsert+"#all", KLineFilterOptional)
+func (balanceTransfer *BalanceTransfer) Valid() (err error) {
+	if reflect.ValueOf(balanceTransfer.TID).IsZero() {
+		err = attrvalid.Valid(balanceTransfer, BalanceTransferFilterInsert+"#all", BalanceTransferFilterOptional)
 	} else {
-		err = attrvalid.Valid(kLine, KLineFilterUpdate, "")
+		err = attrvalid.Valid(balanceTransfer, BalanceTransferFilterUpdate, "")
 	}
 	return
 }
 
-//Insert will add exs_kline to database
-func (kLine *KLine) Insert(caller interface{}, ctx context.Context) (err error) {
+//Insert will add exs_balance_transfer to database
+func (balanceTransfer *BalanceTransfer) Insert(caller interface{}, ctx context.Context) (err error) {
 
-	if kLine.UpdateTime.Timestamp() < 1 {
-		kLine.UpdateTime = xsql.TimeNow()
+	if balanceTransfer.UpdateTime.Timestamp() < 1 {
+		balanceTransfer.UpdateTime = xsql.TimeNow()
 	}
 
-	_, err = crud.InsertFilter(caller, ctx, kLine, "^tid#all", "returning", "tid#all")
+	if balanceTransfer.CreateTime.Timestamp() < 1 {
+		balanceTransfer.CreateTime = xsql.TimeNow()
+	}
+
+	_, err = crud.InsertFilter(caller, ctx, balanceTransfer, "^tid#all", "returning", "tid#all")
 	return
 }
 
-//UpdateFilter will update exs_kline to database
-func (kLine *KLine) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
-	err = kLine.UpdateFilterWheref(caller, ctx, filter, "")
+//UpdateFilter will update exs_balance_transfer to database
+func (balanceTransfer *BalanceTransfer) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = balanceTransfer.UpdateFilterWheref(caller, ctx, filter, "")
 	return
 }
 
-//UpdateWheref will update exs_kline to database
-func (kLine *KLine) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
-	err = kLine.UpdateFilterWheref(caller, ctx, KLineFilterUpdate, formats, formatArgs...)
+//UpdateWheref will update exs_balance_transfer to database
+func (balanceTransfer *BalanceTransfer) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = balanceTransfer.UpdateFilterWheref(caller, ctx, BalanceTransferFilterUpdate, formats, formatArgs...)
 	return
 }
 
-//UpdateFilterWheref will update exs_kline to database
-func (kLine *KLine) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
-	kLine.UpdateTime = xsql.TimeNow()
-	sql, args := crud.UpdateSQL(kLine, filter, nil)
-	where, args := crud.AppendWheref(nil, args, "tid=$%v", kLine.TID)
+//UpdateFilterWheref will update exs_balance_transfer to database
+func (balanceTransfer *BalanceTransfer) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	balanceTransfer.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(balanceTransfer, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", balanceTransfer.TID)
 	if len(formats) > 0 {
 		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
 	}
-	err = crud.UpdateRow(caller, ctx, kLine, sql, where, "and", args)
+	err = crud.UpdateRow(caller, ctx, balanceTransfer, sql, where, "and", args)
 	return
 }
 
-//AddKLine will add exs_kline to database
-func AddKLine(ctx context.Context, kLine *KLine) (err error) {
-	err = AddKLineCall(GetQueryer, ctx, kLine)
+//AddBalanceTransfer will add exs_balance_transfer to database
+func AddBalanceTransfer(ctx context.Context, balanceTransfer *BalanceTransfer) (err error) {
+	err = AddBalanceTransferCall(GetQueryer, ctx, balanceTransfer)
 	return
 }
 
-//AddKLine will add exs_kline to database
-func AddKLineCall(caller interface{}, ctx context.Context, kLine *KLine) (err error) {
-	err = kLine.Insert(caller, ctx)
+//AddBalanceTransfer will add exs_balance_transfer to database
+func AddBalanceTransferCall(caller interface{}, ctx context.Context, balanceTransfer *BalanceTransfer) (err error) {
+	err = balanceTransfer.Insert(caller, ctx)
 	return
 }
 
-//UpdateKLineFilter will update exs_kline to database
-func UpdateKLineFilter(ctx context.Context, kLine *KLine, filter string) (err error) {
-	err = UpdateKLineFilterCall(GetQueryer, ctx, kLine, filter)
+//UpdateBalanceTransferFilter will update exs_balance_transfer to database
+func UpdateBalanceTransferFilter(ctx context.Context, balanceTransfer *BalanceTransfer, filter string) (err error) {
+	err = UpdateBalanceTransferFilterCall(GetQueryer, ctx, balanceTransfer, filter)
 	return
 }
 
-//UpdateKLineFilterCall will update exs_kline to database
-func UpdateKLineFilterCall(caller interface{}, ctx context.Context, kLine *KLine, filter string) (err error) {
-	err = kLine.UpdateFilter(caller, ctx, filter)
+//UpdateBalanceTransferFilterCall will update exs_balance_transfer to database
+func UpdateBalanceTransferFilterCall(caller interface{}, ctx context.Context, balanceTransfer *BalanceTransfer, filter string) (err error) {
+	err = balanceTransfer.UpdateFilter(caller, ctx, filter)
 	return
 }
 
-//UpdateKLineWheref will update exs_kline to database
-func UpdateKLineWheref(ctx context.Context, kLine *KLine, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateKLineWherefCall(GetQueryer, ctx, kLine, formats, formatArgs...)
+//UpdateBalanceTransferWheref will update exs_balance_transfer to database
+func UpdateBalanceTransferWheref(ctx context.Context, balanceTransfer *BalanceTransfer, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateBalanceTransferWherefCall(GetQueryer, ctx, balanceTransfer, formats, formatArgs...)
 	return
 }
 
-//UpdateKLineWherefCall will update exs_kline to database
-func UpdateKLineWherefCall(caller interface{}, ctx context.Context, kLine *KLine, formats string, formatArgs ...interface{}) (err error) {
-	err = kLine.UpdateWheref(caller, ctx, formats, formatArgs...)
+//UpdateBalanceTransferWherefCall will update exs_balance_transfer to database
+func UpdateBalanceTransferWherefCall(caller interface{}, ctx context.Context, balanceTransfer *BalanceTransfer, formats string, formatArgs ...interface{}) (err error) {
+	err = balanceTransfer.UpdateWheref(caller, ctx, formats, formatArgs...)
 	return
 }
 
-//UpdateKLineFilterWheref will update exs_kline to database
-func UpdateKLineFilterWheref(ctx context.Context, kLine *KLine, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateKLineFilterWherefCall(GetQueryer, ctx, kLine, filter, formats, formatArgs...)
+//UpdateBalanceTransferFilterWheref will update exs_balance_transfer to database
+func UpdateBalanceTransferFilterWheref(ctx context.Context, balanceTransfer *BalanceTransfer, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateBalanceTransferFilterWherefCall(GetQueryer, ctx, balanceTransfer, filter, formats, formatArgs...)
 	return
 }
 
-//UpdateKLineFilterWherefCall will update exs_kline to database
-func UpdateKLineFilterWherefCall(caller interface{}, ctx context.Context, kLine *KLine, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = kLine.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+//UpdateBalanceTransferFilterWherefCall will update exs_balance_transfer to database
+func UpdateBalanceTransferFilterWherefCall(caller interface{}, ctx context.Context, balanceTransfer *BalanceTransfer, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = balanceTransfer.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
 	return
 }
 
-//FindKLineCall will find exs_kline by id from database
-func FindKLine(ctx context.Context, kLineID int64) (kLine *KLine, err error) {
-	kLine, err = FindKLineCall(GetQueryer, ctx, kLineID, false)
+//FindBalanceTransferCall will find exs_balance_transfer by id from database
+func FindBalanceTransfer(ctx context.Context, balanceTransferID int64) (balanceTransfer *BalanceTransfer, err error) {
+	balanceTransfer, err = FindBalanceTransferCall(GetQueryer, ctx, balanceTransferID, false)
 	return
 }
 
-//FindKLineCall will find exs_kline by id from database
-func FindKLineCall(caller interface{}, ctx context.Context, kLineID int64, lock bool) (kLine *KLine, err error) {
-	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", kLineID)
-	kLine, err = FindKLineWhereCall(caller, ctx, lock, "and", where, args)
+//FindBalanceTransferCall will find exs_balance_transfer by id from database
+func FindBalanceTransferCall(caller interface{}, ctx context.Context, balanceTransferID int64, lock bool) (balanceTransfer *BalanceTransfer, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", balanceTransferID)
+	balanceTransfer, err = FindBalanceTransferWhereCall(caller, ctx, lock, "and", where, args)
 	return
 }
 
-//FindKLineWhereCall will find exs_kline by where from database
-func FindKLineWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (kLine *KLine, err error) {
-	querySQL := crud.QuerySQL(&KLine{}, "#all")
+//FindBalanceTransferWhereCall will find exs_balance_transfer by where from database
+func FindBalanceTransferWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (balanceTransfer *BalanceTransfer, err error) {
+	querySQL := crud.QuerySQL(&BalanceTransfer{}, "#all")
 	querySQL = crud.JoinWhere(querySQL, where, join)
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &KLine{}, "#all", querySQL, args, &kLine)
+	err = crud.QueryRow(caller, ctx, &BalanceTransfer{}, "#all", querySQL, args, &balanceTransfer)
 	return
 }
 
-//FindKLineWheref will find exs_kline by where from database
-func FindKLineWheref(ctx context.Context, format string, args ...interface{}) (kLine *KLine, err error) {
-	kLine, err = FindKLineWherefCall(GetQueryer, ctx, false, format, args...)
+//FindBalanceTransferWheref will find exs_balance_transfer by where from database
+func FindBalanceTransferWheref(ctx context.Context, format string, args ...interface{}) (balanceTransfer *BalanceTransfer, err error) {
+	balanceTransfer, err = FindBalanceTransferWherefCall(GetQueryer, ctx, false, format, args...)
 	return
 }
 
-//FindKLineWherefCall will find exs_kline by where from database
-func FindKLineWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (kLine *KLine, err error) {
-	kLine, err = FindKLineFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+//FindBalanceTransferWherefCall will find exs_balance_transfer by where from database
+func FindBalanceTransferWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (balanceTransfer *BalanceTransfer, err error) {
+	balanceTransfer, err = FindBalanceTransferFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
 	return
 }
 
-//FindKLineFilterWheref will find exs_kline by where from database
-func FindKLineFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (kLine *KLine, err error) {
-	kLine, err = FindKLineFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+//FindBalanceTransferFilterWheref will find exs_balance_transfer by where from database
+func FindBalanceTransferFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (balanceTransfer *BalanceTransfer, err error) {
+	balanceTransfer, err = FindBalanceTransferFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
 	return
 }
 
-//FindKLineFilterWherefCall will find exs_kline by where from database
-func FindKLineFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (kLine *KLine, err error) {
-	querySQL := crud.QuerySQL(&KLine{}, filter)
+//FindBalanceTransferFilterWherefCall will find exs_balance_transfer by where from database
+func FindBalanceTransferFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (balanceTransfer *BalanceTransfer, err error) {
+	querySQL := crud.QuerySQL(&BalanceTransfer{}, filter)
 	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
 	querySQL = crud.JoinWhere(querySQL, where, "and")
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &KLine{}, filter, querySQL, queryArgs, &kLine)
+	err = crud.QueryRow(caller, ctx, &BalanceTransfer{}, filter, querySQL, queryArgs, &balanceTransfer)
 	return
 }
 
-//ListKLineByID will list exs_kline by id from database
-func ListKLineByID(ctx context.Context, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
-	kLineList, kLineMap, err = ListKLineByIDCall(GetQueryer, ctx, kLineIDs...)
+//ListBalanceTransferByID will list exs_balance_transfer by id from database
+func ListBalanceTransferByID(ctx context.Context, balanceTransferIDs ...int64) (balanceTransferList []*BalanceTransfer, balanceTransferMap map[int64]*BalanceTransfer, err error) {
+	balanceTransferList, balanceTransferMap, err = ListBalanceTransferByIDCall(GetQueryer, ctx, balanceTransferIDs...)
 	return
 }
 
-//ListKLineByIDCall will list exs_kline by id from database
-func ListKLineByIDCall(caller interface{}, ctx context.Context, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
-	if len(kLineIDs) < 1 {
-		kLineMap = map[int64]*KLine{}
+//ListBalanceTransferByIDCall will list exs_balance_transfer by id from database
+func ListBalanceTransferByIDCall(caller interface{}, ctx context.Context, balanceTransferIDs ...int64) (balanceTransferList []*BalanceTransfer, balanceTransferMap map[int64]*BalanceTransfer, err error) {
+	if len(balanceTransferIDs) < 1 {
+		balanceTransferMap = map[int64]*BalanceTransfer{}
 		return
 	}
-	err = ScanKLineByIDCall(caller, ctx, kLineIDs, &kLineList, &kLineMap, "tid")
+	err = ScanBalanceTransferByIDCall(caller, ctx, balanceTransferIDs, &balanceTransferList, &balanceTransferMap, "tid")
 	return
 }
 
-//ListKLineFilterByID will list exs_kline by id from database
-func ListKLineFilterByID(ctx context.Context, filter string, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
-	kLineList, kLineMap, err = ListKLineFilterByIDCall(GetQueryer, ctx, filter, kLineIDs...)
+//ListBalanceTransferFilterByID will list exs_balance_transfer by id from database
+func ListBalanceTransferFilterByID(ctx context.Context, filter string, balanceTransferIDs ...int64) (balanceTransferList []*BalanceTransfer, balanceTransferMap map[int64]*BalanceTransfer, err error) {
+	balanceTransferList, balanceTransferMap, err = ListBalanceTransferFilterByIDCall(GetQueryer, ctx, filter, balanceTransferIDs...)
 	return
 }
 
-//ListKLineFilterByIDCall will list exs_kline by id from database
-func ListKLineFilterByIDCall(caller interface{}, ctx context.Context, filter string, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
-	if len(kLineIDs) < 1 {
-		kLineMap = map[int64]*KLine{}
+//ListBalanceTransferFilterByIDCall will list exs_balance_transfer by id from database
+func ListBalanceTransferFilterByIDCall(caller interface{}, ctx context.Context, filter string, balanceTransferIDs ...int64) (balanceTransferList []*BalanceTransfer, balanceTransferMap map[int64]*BalanceTransfer, err error) {
+	if len(balanceTransferIDs) < 1 {
+		balanceTransferMap = map[int64]*BalanceTransfer{}
 		return
 	}
-	err = ScanKLineFilterByIDCall(caller, ctx, filter, kLineIDs, &kLineList, &kLineMap, "tid")
+	err = ScanBalanceTransferFilterByIDCall(caller, ctx, filter, balanceTransferIDs, &balanceTransferList, &balanceTransferMap, "tid")
 	return
 }
 
-//ScanKLineByID will list exs_kline by id from database
-func ScanKLineByID(ctx context.Context, kLineIDs []int64, dest ...interface{}) (err error) {
-	err = ScanKLineByIDCall(GetQueryer, ctx, kLineIDs, dest...)
+//ScanBalanceTransferByID will list exs_balance_transfer by id from database
+func ScanBalanceTransferByID(ctx context.Context, balanceTransferIDs []int64, dest ...interface{}) (err error) {
+	err = ScanBalanceTransferByIDCall(GetQueryer, ctx, balanceTransferIDs, dest...)
 	return
 }
 
-//ScanKLineByIDCall will list exs_kline by id from database
-func ScanKLineByIDCall(caller interface{}, ctx context.Context, kLineIDs []int64, dest ...interface{}) (err error) {
-	err = ScanKLineFilterByIDCall(caller, ctx, "#all", kLineIDs, dest...)
+//ScanBalanceTransferByIDCall will list exs_balance_transfer by id from database
+func ScanBalanceTransferByIDCall(caller interface{}, ctx context.Context, balanceTransferIDs []int64, dest ...interface{}) (err error) {
+	err = ScanBalanceTransferFilterByIDCall(caller, ctx, "#all", balanceTransferIDs, dest...)
 	return
 }
 
-//ScanKLineFilterByID will list exs_kline by id from database
-func ScanKLineFilterByID(ctx context.Context, filter string, kLineIDs []int64, dest ...interface{}) (err error) {
-	err = ScanKLineFilterByIDCall(GetQueryer, ctx, filter, kLineIDs, dest...)
+//ScanBalanceTransferFilterByID will list exs_balance_transfer by id from database
+func ScanBalanceTransferFilterByID(ctx context.Context, filter string, balanceTransferIDs []int64, dest ...interface{}) (err error) {
+	err = ScanBalanceTransferFilterByIDCall(GetQueryer, ctx, filter, balanceTransferIDs, dest...)
 	return
 }
 
-//ScanKLineFilterByIDCall will list exs_kline by id from database
-func ScanKLineFilterByIDCall(caller interface{}, ctx context.Context, filter string, kLineIDs []int64, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&KLine{}, filter)
-	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(kLineIDs).InArray()))
+//ScanBalanceTransferFilterByIDCall will list exs_balance_transfer by id from database
+func ScanBalanceTransferFilterByIDCall(caller interface{}, ctx context.Context, filter string, balanceTransferIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&BalanceTransfer{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(balanceTransferIDs).InArray()))
 	querySQL = crud.JoinWhere(querySQL, where, " and ")
-	err = crud.Query(caller, ctx, &KLine{}, filter, querySQL, nil, dest...)
+	err = crud.Query(caller, ctx, &BalanceTransfer{}, filter, querySQL, nil, dest...)
 	return
 }
 
-//ScanKLineWherefCall will list exs_kline by format from database
-func ScanKLineWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanKLineWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+//ScanBalanceTransferWherefCall will list exs_balance_transfer by format from database
+func ScanBalanceTransferWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanBalanceTransferWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
 	return
 }
 
-//ScanKLineWherefCall will list exs_kline by format from database
-func ScanKLineWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanKLineFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+//ScanBalanceTransferWherefCall will list exs_balance_transfer by format from database
+func ScanBalanceTransferWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanBalanceTransferFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
 	return
 }
 
-//ScanKLineFilterWheref will list exs_kline by format from database
-func ScanKLineFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanKLineFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+//ScanBalanceTransferFilterWheref will list exs_balance_transfer by format from database
+func ScanBalanceTransferFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanBalanceTransferFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
 	return
 }
 
-//ScanKLineFilterWherefCall will list exs_kline by format from database
-func ScanKLineFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&KLine{}, filter)
+//ScanBalanceTransferFilterWherefCall will list exs_balance_transfer by format from database
+func ScanBalanceTransferFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&BalanceTransfer{}, filter)
 	var where []string
 	if len(format) > 0 {
 		where, args = crud.AppendWheref(nil, nil, format, args...)
 	}
 	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
-	err = crud.Query(caller, ctx, &KLine{}, filter, querySQL, args, dest...)
+	err = crud.Query(caller, ctx, &BalanceTransfer{}, filter, querySQL, args, dest...)
 	return
 }
 
-//OrderFilterOptional is crud filter
-const OrderFilterOptional = "tid,quantity,price,total_price,trigger_type,trigger_price,status"
+//HoldingFilterOptional is crud filter
+const HoldingFilterOptional = ""
 
-//OrderFilterRequired is crud filter
-const OrderFilterRequired = ""
+//HoldingFilterRequired is crud filter
+const HoldingFilterRequired = ""
 
-//OrderFilterInsert is crud filter
-const OrderFilterInsert = "tid,quantity,price,total_price,trigger_type,trigger_price,status"
+//HoldingFilterInsert is crud filter
+const HoldingFilterInsert = ""
 
-//OrderFilterUpdate is crud filter
-const OrderFilterUpdate = "update_time,tid,quantity,price,total_price,trigger_type,trigger_price,status"
+//HoldingFilterUpdate is crud filter
+const HoldingFilterUpdate = "update_time"
 
-//OrderFilterFind is crud filter
-const OrderFilterFind = "#all"
+//HoldingFilterFind is crud filter
+const HoldingFilterFind = "#all"
 
-//OrderFilterScan is crud filter
-const OrderFilterScan = "^transaction#all"
+//HoldingFilterScan is crud filter
+const HoldingFilterScan = "#all"
 
-//EnumValid will valid value by OrderType
-func (o *OrderType) EnumValid(v interface{}) (err error) {
-	var target OrderType
-	targetType := reflect.TypeOf(OrderType(0))
+//EnumValid will valid value by HoldingStatus
+func (o *HoldingStatus) EnumValid(v interface{}) (err error) {
+	var target HoldingStatus
+	targetType := reflect.TypeOf(HoldingStatus(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderType)
+		target = targetValue.Convert(targetType).Interface().(HoldingStatus)
 	}
-	for _, value := range OrderTypeAll {
+	for _, value := range HoldingStatusAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", OrderTypeAll)
+	return fmt.Errorf("must be in %v", HoldingStatusAll)
 }
 
-//EnumValid will valid value by OrderTypeArray
-func (o *OrderTypeArray) EnumValid(v interface{}) (err error) {
-	var target OrderType
-	targetType := reflect.TypeOf(OrderType(0))
+//EnumValid will valid value by HoldingStatusArray
+func (o *HoldingStatusArray) EnumValid(v interface{}) (err error) {
+	var target HoldingStatus
+	targetType := reflect.TypeOf(HoldingStatus(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderType)
+		target = targetValue.Convert(targetType).Interface().(HoldingStatus)
 	}
-	for _, value := range OrderTypeAll {
+	for _, value := range HoldingStatusAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", OrderTypeAll)
+	return fmt.Errorf("must be in %v", HoldingStatusAll)
 }
 
 //DbArray will join value to database array
-func (o OrderTypeArray) DbArray() (res string) {
+func (o HoldingStatusArray) DbArray() (res string) {
 	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
 	return
 }
 
 //InArray will join value to database array
-func (o OrderTypeArray) InArray() (res string) {
+func (o HoldingStatusArray) InArray() (res string) {
 	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
 	return
 }
 
-//EnumValid will valid value by OrderSide
-func (o *OrderSide) EnumValid(v interface{}) (err error) {
-	var target OrderSide
-	targetType := reflect.TypeOf(OrderSide(""))
-	targetValue := reflect.ValueOf(v)
-	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderSide)
-	}
-	for _, value := range OrderSideAll {
-		if target == value {
-			return nil
-		}
+//MetaWithHolding will return exs_holding meta data
+func MetaWithHolding(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_holding"), fields...)
+	return
+}
+
+//MetaWith will return exs_holding meta data
+func (holding *Holding) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_holding"), fields...)
+	return
+}
+
+//Meta will return exs_holding meta data
+func (holding *Holding) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(holding, "#all")
+	return
+}
+
+//Valid will valid by filter
+func (holding *Holding) Valid() (err error) {
+	if reflect.ValueOf(holding.TID).IsZero() {
+		err = attrvalid.Valid(holding, HoldingFilterInsert+"#all", HoldingFilterOptional)
+	} else {
+		err = attrvalid.Valid(holding, HoldingFilterUpdate, "")
 	}
-	return fmt.Errorf("must be in %v", OrderSideAll)
+	return
 }
 
-//EnumValid will valid value by OrderSideArray
-func (o *OrderSideArray) EnumValid(v interface{}) (err error) {
-	var target OrderSide
-	targetType := reflect.TypeOf(OrderSide(""))
-	targetValue := reflect.ValueOf(v)
-	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderSide)
+//Insert will add exs_holding to database
+func (holding *Holding) Insert(caller interface{}, ctx context.Context) (err error) {
+
+	if holding.UpdateTime.Timestamp() < 1 {
+		holding.UpdateTime = xsql.TimeNow()
 	}
-	for _, value := range OrderSideAll {
-		if target == value {
-			return nil
-		}
+
+	if holding.CreateTime.Timestamp() < 1 {
+		holding.CreateTime = xsql.TimeNow()
 	}
-	return fmt.Errorf("must be in %v", OrderSideAll)
+
+	_, err = crud.InsertFilter(caller, ctx, holding, "^tid#all", "returning", "tid#all")
+	return
 }
 
-//DbArray will join value to database array
-func (o OrderSideArray) DbArray() (res string) {
-	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+//UpdateFilter will update exs_holding to database
+func (holding *Holding) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = holding.UpdateFilterWheref(caller, ctx, filter, "")
 	return
 }
 
-//InArray will join value to database array
-func (o OrderSideArray) InArray() (res string) {
-	res = "'" + converter.JoinSafe(o, "','", converter.JoinPolicyDefault) + "'"
+//UpdateWheref will update exs_holding to database
+func (holding *Holding) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = holding.UpdateFilterWheref(caller, ctx, HoldingFilterUpdate, formats, formatArgs...)
 	return
 }
 
-//EnumValid will valid value by OrderTriggerType
-func (o *OrderTriggerType) EnumValid(v interface{}) (err error) {
-	var target OrderTriggerType
-	targetType := reflect.TypeOf(OrderTriggerType(0))
-	targetValue := reflect.ValueOf(v)
-	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderTriggerType)
-	}
-	for _, value := range OrderTriggerTypeAll {
-		if target == value {
-			return nil
-		}
+//UpdateFilterWheref will update exs_holding to database
+func (holding *Holding) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	holding.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(holding, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", holding.TID)
+	if len(formats) > 0 {
+		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
 	}
-	return fmt.Errorf("must be in %v", OrderTriggerTypeAll)
+	err = crud.UpdateRow(caller, ctx, holding, sql, where, "and", args)
+	return
 }
 
-//EnumValid will valid value by OrderTriggerTypeArray
-func (o *OrderTriggerTypeArray) EnumValid(v interface{}) (err error) {
-	var target OrderTriggerType
-	targetType := reflect.TypeOf(OrderTriggerType(0))
-	targetValue := reflect.ValueOf(v)
-	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderTriggerType)
-	}
-	for _, value := range OrderTriggerTypeAll {
-		if target == value {
-			return nil
-		}
-	}
-	return fmt.Errorf("must be in %v", OrderTriggerTypeAll)
+//UpdateHoldingFilter will update exs_holding to database
+func UpdateHoldingFilter(ctx context.Context, holding *Holding, filter string) (err error) {
+	err = UpdateHoldingFilterCall(GetQueryer, ctx, holding, filter)
+	return
 }
 
-//DbArray will join value to database array
-func (o OrderTriggerTypeArray) DbArray() (res string) {
-	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+//UpdateHoldingFilterCall will update exs_holding to database
+func UpdateHoldingFilterCall(caller interface{}, ctx context.Context, holding *Holding, filter string) (err error) {
+	err = holding.UpdateFilter(caller, ctx, filter)
+	return
+}
+
+//UpdateHoldingWheref will update exs_holding to database
+func UpdateHoldingWheref(ctx context.Context, holding *Holding, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateHoldingWherefCall(GetQueryer, ctx, holding, formats, formatArgs...)
+	return
+}
+
+//UpdateHoldingWherefCall will update exs_holding to database
+func UpdateHoldingWherefCall(caller interface{}, ctx context.Context, holding *Holding, formats string, formatArgs ...interface{}) (err error) {
+	err = holding.UpdateWheref(caller, ctx, formats, formatArgs...)
+	return
+}
+
+//UpdateHoldingFilterWheref will update exs_holding to database
+func UpdateHoldingFilterWheref(ctx context.Context, holding *Holding, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateHoldingFilterWherefCall(GetQueryer, ctx, holding, filter, formats, formatArgs...)
 	return
 }
 
-//InArray will join value to database array
-func (o OrderTriggerTypeArray) InArray() (res string) {
-	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
-	return
-}
+//UpdateHoldingFilterWherefCall will update exs_holding to database
+func UpdateHoldingFilterWherefCall(caller interface{}, ctx context.Context, holding *Holding, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = holding.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+	return
+}
+
+//FindHoldingCall will find exs_holding by id from database
+func FindHolding(ctx context.Context, holdingID int64) (holding *Holding, err error) {
+	holding, err = FindHoldingCall(GetQueryer, ctx, holdingID, false)
+	return
+}
+
+//FindHoldingCall will find exs_holding by id from database
+func FindHoldingCall(caller interface{}, ctx context.Context, holdingID int64, lock bool) (holding *Holding, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", holdingID)
+	holding, err = FindHoldingWhereCall(caller, ctx, lock, "and", where, args)
+	return
+}
+
+//FindHoldingWhereCall will find exs_holding by where from database
+func FindHoldingWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (holding *Holding, err error) {
+	querySQL := crud.QuerySQL(&Holding{}, "#all")
+	querySQL = crud.JoinWhere(querySQL, where, join)
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &Holding{}, "#all", querySQL, args, &holding)
+	return
+}
+
+//FindHoldingWheref will find exs_holding by where from database
+func FindHoldingWheref(ctx context.Context, format string, args ...interface{}) (holding *Holding, err error) {
+	holding, err = FindHoldingWherefCall(GetQueryer, ctx, false, format, args...)
+	return
+}
+
+//FindHoldingWherefCall will find exs_holding by where from database
+func FindHoldingWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (holding *Holding, err error) {
+	holding, err = FindHoldingFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+	return
+}
+
+//FindHoldingFilterWheref will find exs_holding by where from database
+func FindHoldingFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (holding *Holding, err error) {
+	holding, err = FindHoldingFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+	return
+}
+
+//FindHoldingFilterWherefCall will find exs_holding by where from database
+func FindHoldingFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (holding *Holding, err error) {
+	querySQL := crud.QuerySQL(&Holding{}, filter)
+	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
+	querySQL = crud.JoinWhere(querySQL, where, "and")
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &Holding{}, filter, querySQL, queryArgs, &holding)
+	return
+}
+
+//ListHoldingByID will list exs_holding by id from database
+func ListHoldingByID(ctx context.Context, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
+	holdingList, holdingMap, err = ListHoldingByIDCall(GetQueryer, ctx, holdingIDs...)
+	return
+}
+
+//ListHoldingByIDCall will list exs_holding by id from database
+func ListHoldingByIDCall(caller interface{}, ctx context.Context, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
+	if len(holdingIDs) < 1 {
+		holdingMap = map[int64]*Holding{}
+		return
+	}
+	err = ScanHoldingByIDCall(caller, ctx, holdingIDs, &holdingList, &holdingMap, "tid")
+	return
+}
+
+//ListHoldingFilterByID will list exs_holding by id from database
+func ListHoldingFilterByID(ctx context.Context, filter string, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
+	holdingList, holdingMap, err = ListHoldingFilterByIDCall(GetQueryer, ctx, filter, holdingIDs...)
+	return
+}
+
+//ListHoldingFilterByIDCall will list exs_holding by id from database
+func ListHoldingFilterByIDCall(caller interface{}, ctx context.Context, filter string, holdingIDs ...int64) (holdingList []*Holding, holdingMap map[int64]*Holding, err error) {
+	if len(holdingIDs) < 1 {
+		holdingMap = map[int64]*Holding{}
+		return
+	}
+	err = ScanHoldingFilterByIDCall(caller, ctx, filter, holdingIDs, &holdingList, &holdingMap, "tid")
+	return
+}
+
+//ScanHoldingByID will list exs_holding by id from database
+func ScanHoldingByID(ctx context.Context, holdingIDs []int64, dest ...interface{}) (err error) {
+	err = ScanHoldingByIDCall(GetQueryer, ctx, holdingIDs, dest...)
+	return
+}
+
+//ScanHoldingByIDCall will list exs_holding by id from database
+func ScanHoldingByIDCall(caller interface{}, ctx context.Context, holdingIDs []int64, dest ...interface{}) (err error) {
+	err = ScanHoldingFilterByIDCall(caller, ctx, "#all", holdingIDs, dest...)
+	return
+}
+
+//ScanHoldingFilterByID will list exs_holding by id from database
+func ScanHoldingFilterByID(ctx context.Context, filter string, holdingIDs []int64, dest ...interface{}) (err error) {
+	err = ScanHoldingFilterByIDCall(GetQueryer, ctx, filter, holdingIDs, dest...)
+	return
+}
+
+//ScanHoldingFilterByIDCall will list exs_holding by id from database
+func ScanHoldingFilterByIDCall(caller interface{}, ctx context.Context, filter string, holdingIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Holding{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(holdingIDs).InArray()))
+	querySQL = crud.JoinWhere(querySQL, where, " and ")
+	err = crud.Query(caller, ctx, &Holding{}, filter, querySQL, nil, dest...)
+	return
+}
+
+//ScanHoldingWherefCall will list exs_holding by format from database
+func ScanHoldingWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanHoldingWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+	return
+}
+
+//ScanHoldingWherefCall will list exs_holding by format from database
+func ScanHoldingWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanHoldingFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+	return
+}
+
+//ScanHoldingFilterWheref will list exs_holding by format from database
+func ScanHoldingFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanHoldingFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+	return
+}
+
+//ScanHoldingFilterWherefCall will list exs_holding by format from database
+func ScanHoldingFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Holding{}, filter)
+	var where []string
+	if len(format) > 0 {
+		where, args = crud.AppendWheref(nil, nil, format, args...)
+	}
+	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
+	err = crud.Query(caller, ctx, &Holding{}, filter, querySQL, args, dest...)
+	return
+}
+
+//KLineFilterOptional is crud filter
+const KLineFilterOptional = ""
+
+//KLineFilterRequired is crud filter
+const KLineFilterRequired = ""
+
+//KLineFilterInsert is crud filter
+const KLineFilterInsert = ""
+
+//KLineFilterUpdate is crud filter
+const KLineFilterUpdate = "update_time"
+
+//KLineFilterFind is crud filter
+const KLineFilterFind = "#all"
+
+//KLineFilterScan is crud filter
+const KLineFilterScan = "#all"
+
+//MetaWithKLine will return exs_kline meta data
+func MetaWithKLine(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_kline"), fields...)
+	return
+}
+
+//MetaWith will return exs_kline meta data
+func (kLine *KLine) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_kline"), fields...)
+	return
+}
+
+//Meta will return exs_kline meta data
+func (kLine *KLine) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(kLine, "#all")
+	return
+}
+
+//Valid will valid by filter
+func (kLine *KLine) Valid() (err error) {
+	if reflect.ValueOf(kLine.TID).IsZero() {
+		err = attrvalid.Valid(kLine, KLineFilterInsert+"#all", KLineFilterOptional)
+	} else {
+		err = attrvalid.Valid(kLine, KLineFilterUpdate, "")
+	}
+	return
+}
+
+//Insert will add exs_kline to database
+func (kLine *KLine) Insert(caller interface{}, ctx context.Context) (err error) {
+
+	if kLine.UpdateTime.Timestamp() < 1 {
+		kLine.UpdateTime = xsql.TimeNow()
+	}
+
+	_, err = crud.InsertFilter(caller, ctx, kLine, "^tid#all", "returning", "tid#all")
+	return
+}
+
+//UpdateFilter will update exs_kline to database
+func (kLine *KLine) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = kLine.UpdateFilterWheref(caller, ctx, filter, "")
+	return
+}
+
+//UpdateWheref will update exs_kline to database
+func (kLine *KLine) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = kLine.UpdateFilterWheref(caller, ctx, KLineFilterUpdate, formats, formatArgs...)
+	return
+}
+
+//UpdateFilterWheref will update exs_kline to database
+func (kLine *KLine) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	kLine.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(kLine, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", kLine.TID)
+	if len(formats) > 0 {
+		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
+	}
+	err = crud.UpdateRow(caller, ctx, kLine, sql, where, "and", args)
+	return
+}
+
+//AddKLine will add exs_kline to database
+func AddKLine(ctx context.Context, kLine *KLine) (err error) {
+	err = AddKLineCall(GetQueryer, ctx, kLine)
+	return
+}
+
+//AddKLine will add exs_kline to database
+func AddKLineCall(caller interface{}, ctx context.Context, kLine *KLine) (err error) {
+	err = kLine.Insert(caller, ctx)
+	return
+}
+
+//UpdateKLineFilter will update exs_kline to database
+func UpdateKLineFilter(ctx context.Context, kLine *KLine, filter string) (err error) {
+	err = UpdateKLineFilterCall(GetQueryer, ctx, kLine, filter)
+	return
+}
+
+//UpdateKLineFilterCall will update exs_kline to database
+func UpdateKLineFilterCall(caller interface{}, ctx context.Context, kLine *KLine, filter string) (err error) {
+	err = kLine.UpdateFilter(caller, ctx, filter)
+	return
+}
+
+//UpdateKLineWheref will update exs_kline to database
+func UpdateKLineWheref(ctx context.Context, kLine *KLine, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateKLineWherefCall(GetQueryer, ctx, kLine, formats, formatArgs...)
+	return
+}
+
+//UpdateKLineWherefCall will update exs_kline to database
+func UpdateKLineWherefCall(caller interface{}, ctx context.Context, kLine *KLine, formats string, formatArgs ...interface{}) (err error) {
+	err = kLine.UpdateWheref(caller, ctx, formats, formatArgs...)
+	return
+}
+
+//UpdateKLineFilterWheref will update exs_kline to database
+func UpdateKLineFilterWheref(ctx context.Context, kLine *KLine, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateKLineFilterWherefCall(GetQueryer, ctx, kLine, filter, formats, formatArgs...)
+	return
+}
+
+//UpdateKLineFilterWherefCall will update exs_kline to database
+func UpdateKLineFilterWherefCall(caller interface{}, ctx context.Context, kLine *KLine, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = kLine.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+	return
+}
+
+//FindKLineCall will find exs_kline by id from database
+func FindKLine(ctx context.Context, kLineID int64) (kLine *KLine, err error) {
+	kLine, err = FindKLineCall(GetQueryer, ctx, kLineID, false)
+	return
+}
+
+//FindKLineCall will find exs_kline by id from database
+func FindKLineCall(caller interface{}, ctx context.Context, kLineID int64, lock bool) (kLine *KLine, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", kLineID)
+	kLine, err = FindKLineWhereCall(caller, ctx, lock, "and", where, args)
+	return
+}
+
+//FindKLineWhereCall will find exs_kline by where from database
+func FindKLineWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (kLine *KLine, err error) {
+	querySQL := crud.QuerySQL(&KLine{}, "#all")
+	querySQL = crud.JoinWhere(querySQL, where, join)
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &KLine{}, "#all", querySQL, args, &kLine)
+	return
+}
+
+//FindKLineWheref will find exs_kline by where from database
+func FindKLineWheref(ctx context.Context, format string, args ...interface{}) (kLine *KLine, err error) {
+	kLine, err = FindKLineWherefCall(GetQueryer, ctx, false, format, args...)
+	return
+}
+
+//FindKLineWherefCall will find exs_kline by where from database
+func FindKLineWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (kLine *KLine, err error) {
+	kLine, err = FindKLineFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+	return
+}
+
+//FindKLineFilterWheref will find exs_kline by where from database
+func FindKLineFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (kLine *KLine, err error) {
+	kLine, err = FindKLineFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+	return
+}
+
+//FindKLineFilterWherefCall will find exs_kline by where from database
+func FindKLineFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (kLine *KLine, err error) {
+	querySQL := crud.QuerySQL(&KLine{}, filter)
+	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
+	querySQL = crud.JoinWhere(querySQL, where, "and")
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &KLine{}, filter, querySQL, queryArgs, &kLine)
+	return
+}
+
+//ListKLineByID will list exs_kline by id from database
+func ListKLineByID(ctx context.Context, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
+	kLineList, kLineMap, err = ListKLineByIDCall(GetQueryer, ctx, kLineIDs...)
+	return
+}
+
+//ListKLineByIDCall will list exs_kline by id from database
+func ListKLineByIDCall(caller interface{}, ctx context.Context, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
+	if len(kLineIDs) < 1 {
+		kLineMap = map[int64]*KLine{}
+		return
+	}
+	err = ScanKLineByIDCall(caller, ctx, kLineIDs, &kLineList, &kLineMap, "tid")
+	return
+}
+
+//ListKLineFilterByID will list exs_kline by id from database
+func ListKLineFilterByID(ctx context.Context, filter string, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
+	kLineList, kLineMap, err = ListKLineFilterByIDCall(GetQueryer, ctx, filter, kLineIDs...)
+	return
+}
+
+//ListKLineFilterByIDCall will list exs_kline by id from database
+func ListKLineFilterByIDCall(caller interface{}, ctx context.Context, filter string, kLineIDs ...int64) (kLineList []*KLine, kLineMap map[int64]*KLine, err error) {
+	if len(kLineIDs) < 1 {
+		kLineMap = map[int64]*KLine{}
+		return
+	}
+	err = ScanKLineFilterByIDCall(caller, ctx, filter, kLineIDs, &kLineList, &kLineMap, "tid")
+	return
+}
+
+//ScanKLineByID will list exs_kline by id from database
+func ScanKLineByID(ctx context.Context, kLineIDs []int64, dest ...interface{}) (err error) {
+	err = ScanKLineByIDCall(GetQueryer, ctx, kLineIDs, dest...)
+	return
+}
+
+//ScanKLineByIDCall will list exs_kline by id from database
+func ScanKLineByIDCall(caller interface{}, ctx context.Context, kLineIDs []int64, dest ...interface{}) (err error) {
+	err = ScanKLineFilterByIDCall(caller, ctx, "#all", kLineIDs, dest...)
+	return
+}
+
+//ScanKLineFilterByID will list exs_kline by id from database
+func ScanKLineFilterByID(ctx context.Context, filter string, kLineIDs []int64, dest ...interface{}) (err error) {
+	err = ScanKLineFilterByIDCall(GetQueryer, ctx, filter, kLineIDs, dest...)
+	return
+}
+
+//ScanKLineFilterByIDCall will list exs_kline by id from database
+func ScanKLineFilterByIDCall(caller interface{}, ctx context.Context, filter string, kLineIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&KLine{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(kLineIDs).InArray()))
+	querySQL = crud.JoinWhere(querySQL, where, " and ")
+	err = crud.Query(caller, ctx, &KLine{}, filter, querySQL, nil, dest...)
+	return
+}
+
+//ScanKLineWherefCall will list exs_kline by format from database
+func ScanKLineWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanKLineWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+	return
+}
+
+//ScanKLineWherefCall will list exs_kline by format from database
+func ScanKLineWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanKLineFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+	return
+}
+
+//ScanKLineFilterWheref will list exs_kline by format from database
+func ScanKLineFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanKLineFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+	return
+}
+
+//ScanKLineFilterWherefCall will list exs_kline by format from database
+func ScanKLineFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&KLine{}, filter)
+	var where []string
+	if len(format) > 0 {
+		where, args = crud.AppendWheref(nil, nil, format, args...)
+	}
+	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
+	err = crud.Query(caller, ctx, &KLine{}, filter, querySQL, args, dest...)
+	return
+}
+
+//OrderFilterOptional is crud filter
+const OrderFilterOptional = "tid,quantity,price,total_price,trigger_type,trigger_price,status"
+
+//OrderFilterRequired is crud filter
+const OrderFilterRequired = ""
+
+//OrderFilterInsert is crud filter
+const OrderFilterInsert = "tid,quantity,price,total_price,trigger_type,trigger_price,status"
+
+//OrderFilterUpdate is crud filter
+const OrderFilterUpdate = "update_time,tid,quantity,price,total_price,trigger_type,trigger_price,status"
+
+//OrderFilterFind is crud filter
+const OrderFilterFind = "#all"
+
+//OrderFilterScan is crud filter
+const OrderFilterScan = "^transaction#all"
+
+//EnumValid will valid value by OrderType
+func (o *OrderType) EnumValid(v interface{}) (err error) {
+	var target OrderType
+	targetType := reflect.TypeOf(OrderType(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderType)
+	}
+	for _, value := range OrderTypeAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderTypeAll)
+}
+
+//EnumValid will valid value by OrderTypeArray
+func (o *OrderTypeArray) EnumValid(v interface{}) (err error) {
+	var target OrderType
+	targetType := reflect.TypeOf(OrderType(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderType)
+	}
+	for _, value := range OrderTypeAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderTypeAll)
+}
+
+//DbArray will join value to database array
+func (o OrderTypeArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o OrderTypeArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//EnumValid will valid value by OrderSide
+func (o *OrderSide) EnumValid(v interface{}) (err error) {
+	var target OrderSide
+	targetType := reflect.TypeOf(OrderSide(""))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderSide)
+	}
+	for _, value := range OrderSideAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderSideAll)
+}
+
+//EnumValid will valid value by OrderSideArray
+func (o *OrderSideArray) EnumValid(v interface{}) (err error) {
+	var target OrderSide
+	targetType := reflect.TypeOf(OrderSide(""))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderSide)
+	}
+	for _, value := range OrderSideAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderSideAll)
+}
+
+//DbArray will join value to database array
+func (o OrderSideArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o OrderSideArray) InArray() (res string) {
+	res = "'" + converter.JoinSafe(o, "','", converter.JoinPolicyDefault) + "'"
+	return
+}
+
+//EnumValid will valid value by OrderTriggerType
+func (o *OrderTriggerType) EnumValid(v interface{}) (err error) {
+	var target OrderTriggerType
+	targetType := reflect.TypeOf(OrderTriggerType(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderTriggerType)
+	}
+	for _, value := range OrderTriggerTypeAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderTriggerTypeAll)
+}
+
+//EnumValid will valid value by OrderTriggerTypeArray
+func (o *OrderTriggerTypeArray) EnumValid(v interface{}) (err error) {
+	var target OrderTriggerType
+	targetType := reflect.TypeOf(OrderTriggerType(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderTriggerType)
+	}
+	for _, value := range OrderTriggerTypeAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderTriggerTypeAll)
+}
+
+//DbArray will join value to database array
+func (o OrderTriggerTypeArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o OrderTriggerTypeArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//EnumValid will valid value by OrderStatus
+func (o *OrderStatus) EnumValid(v interface{}) (err error) {
+	var target OrderStatus
+	targetType := reflect.TypeOf(OrderStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderStatus)
+	}
+	for _, value := range OrderStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderStatusAll)
+}
+
+//EnumValid will valid value by OrderStatusArray
+func (o *OrderStatusArray) EnumValid(v interface{}) (err error) {
+	var target OrderStatus
+	targetType := reflect.TypeOf(OrderStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderStatus)
+	}
+	for _, value := range OrderStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderStatusAll)
+}
+
+//DbArray will join value to database array
+func (o OrderStatusArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o OrderStatusArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//MetaWithOrder will return exs_order meta data
+func MetaWithOrder(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_order"), fields...)
+	return
+}
+
+//MetaWith will return exs_order meta data
+func (order *Order) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_order"), fields...)
+	return
+}
+
+//Meta will return exs_order meta data
+func (order *Order) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(order, "#all")
+	return
+}
+
+//Valid will valid by filter
+func (order *Order) Valid() (err error) {
+	if reflect.ValueOf(order.TID).IsZero() {
+		err = attrvalid.Valid(order, OrderFilterInsert+"#all", OrderFilterOptional)
+	} else {
+		err = attrvalid.Valid(order, OrderFilterUpdate, "")
+	}
+	return
+}
+
+//Insert will add exs_order to database
+func (order *Order) Insert(caller interface{}, ctx context.Context) (err error) {
+
+	if order.UpdateTime.Timestamp() < 1 {
+		order.UpdateTime = xsql.TimeNow()
+	}
+
+	if order.CreateTime.Timestamp() < 1 {
+		order.CreateTime = xsql.TimeNow()
+	}
+
+	_, err = crud.InsertFilter(caller, ctx, order, "^tid#all", "returning", "tid#all")
+	return
+}
+
+//UpdateFilter will update exs_order to database
+func (order *Order) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = order.UpdateFilterWheref(caller, ctx, filter, "")
+	return
+}
+
+//UpdateWheref will update exs_order to database
+func (order *Order) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = order.UpdateFilterWheref(caller, ctx, OrderFilterUpdate, formats, formatArgs...)
+	return
+}
+
+//UpdateFilterWheref will update exs_order to database
+func (order *Order) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	order.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(order, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", order.TID)
+	if len(formats) > 0 {
+		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
+	}
+	err = crud.UpdateRow(caller, ctx, order, sql, where, "and", args)
+	return
+}
+
+//AddOrder will add exs_order to database
+func AddOrder(ctx context.Context, order *Order) (err error) {
+	err = AddOrderCall(GetQueryer, ctx, order)
+	return
+}
+
+//AddOrder will add exs_order to database
+func AddOrderCall(caller interface{}, ctx context.Context, order *Order) (err error) {
+	err = order.Insert(caller, ctx)
+	return
+}
+
+//UpdateOrderFilter will update exs_order to database
+func UpdateOrderFilter(ctx context.Context, order *Order, filter string) (err error) {
+	err = UpdateOrderFilterCall(GetQueryer, ctx, order, filter)
+	return
+}
+
+//UpdateOrderFilterCall will update exs_order to database
+func UpdateOrderFilterCall(caller interface{}, ctx context.Context, order *Order, filter string) (err error) {
+	err = order.UpdateFilter(caller, ctx, filter)
+	return
+}
+
+//UpdateOrderWheref will update exs_order to database
+func UpdateOrderWheref(ctx context.Context, order *Order, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOrderWherefCall(GetQueryer, ctx, order, formats, formatArgs...)
+	return
+}
+
+//UpdateOrderWherefCall will update exs_order to database
+func UpdateOrderWherefCall(caller interface{}, ctx context.Context, order *Order, formats string, formatArgs ...interface{}) (err error) {
+	err = order.UpdateWheref(caller, ctx, formats, formatArgs...)
+	return
+}
+
+//UpdateOrderFilterWheref will update exs_order to database
+func UpdateOrderFilterWheref(ctx context.Context, order *Order, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOrderFilterWherefCall(GetQueryer, ctx, order, filter, formats, formatArgs...)
+	return
+}
+
+//UpdateOrderFilterWherefCall will update exs_order to database
+func UpdateOrderFilterWherefCall(caller interface{}, ctx context.Context, order *Order, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = order.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+	return
+}
+
+//FindOrderCall will find exs_order by id from database
+func FindOrder(ctx context.Context, orderID int64) (order *Order, err error) {
+	order, err = FindOrderCall(GetQueryer, ctx, orderID, false)
+	return
+}
+
+//FindOrderCall will find exs_order by id from database
+func FindOrderCall(caller interface{}, ctx context.Context, orderID int64, lock bool) (order *Order, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", orderID)
+	order, err = FindOrderWhereCall(caller, ctx, lock, "and", where, args)
+	return
+}
+
+//FindOrderWhereCall will find exs_order by where from database
+func FindOrderWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (order *Order, err error) {
+	querySQL := crud.QuerySQL(&Order{}, "#all")
+	querySQL = crud.JoinWhere(querySQL, where, join)
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &Order{}, "#all", querySQL, args, &order)
+	return
+}
+
+//FindOrderWheref will find exs_order by where from database
+func FindOrderWheref(ctx context.Context, format string, args ...interface{}) (order *Order, err error) {
+	order, err = FindOrderWherefCall(GetQueryer, ctx, false, format, args...)
+	return
+}
+
+//FindOrderWherefCall will find exs_order by where from database
+func FindOrderWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (order *Order, err error) {
+	order, err = FindOrderFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+	return
+}
+
+//FindOrderFilterWheref will find exs_order by where from database
+func FindOrderFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (order *Order, err error) {
+	order, err = FindOrderFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+	return
+}
+
+//FindOrderFilterWherefCall will find exs_order by where from database
+func FindOrderFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (order *Order, err error) {
+	querySQL := crud.QuerySQL(&Order{}, filter)
+	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
+	querySQL = crud.JoinWhere(querySQL, where, "and")
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &Order{}, filter, querySQL, queryArgs, &order)
+	return
+}
+
+//ListOrderByID will list exs_order by id from database
+func ListOrderByID(ctx context.Context, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
+	orderList, orderMap, err = ListOrderByIDCall(GetQueryer, ctx, orderIDs...)
+	return
+}
+
+//ListOrderByIDCall will list exs_order by id from database
+func ListOrderByIDCall(caller interface{}, ctx context.Context, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
+	if len(orderIDs) < 1 {
+		orderMap = map[int64]*Order{}
+		return
+	}
+	err = ScanOrderByIDCall(caller, ctx, orderIDs, &orderList, &orderMap, "tid")
+	return
+}
+
+//ListOrderFilterByID will list exs_order by id from database
+func ListOrderFilterByID(ctx context.Context, filter string, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
+	orderList, orderMap, err = ListOrderFilterByIDCall(GetQueryer, ctx, filter, orderIDs...)
+	return
+}
+
+//ListOrderFilterByIDCall will list exs_order by id from database
+func ListOrderFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
+	if len(orderIDs) < 1 {
+		orderMap = map[int64]*Order{}
+		return
+	}
+	err = ScanOrderFilterByIDCall(caller, ctx, filter, orderIDs, &orderList, &orderMap, "tid")
+	return
+}
+
+//ScanOrderByID will list exs_order by id from database
+func ScanOrderByID(ctx context.Context, orderIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOrderByIDCall(GetQueryer, ctx, orderIDs, dest...)
+	return
+}
+
+//ScanOrderByIDCall will list exs_order by id from database
+func ScanOrderByIDCall(caller interface{}, ctx context.Context, orderIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOrderFilterByIDCall(caller, ctx, "^transaction#all", orderIDs, dest...)
+	return
+}
+
+//ScanOrderFilterByID will list exs_order by id from database
+func ScanOrderFilterByID(ctx context.Context, filter string, orderIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOrderFilterByIDCall(GetQueryer, ctx, filter, orderIDs, dest...)
+	return
+}
+
+//ScanOrderFilterByIDCall will list exs_order by id from database
+func ScanOrderFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Order{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(orderIDs).InArray()))
+	querySQL = crud.JoinWhere(querySQL, where, " and ")
+	err = crud.Query(caller, ctx, &Order{}, filter, querySQL, nil, dest...)
+	return
+}
+
+//ScanOrderWherefCall will list exs_order by format from database
+func ScanOrderWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOrderWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+	return
+}
+
+//ScanOrderWherefCall will list exs_order by format from database
+func ScanOrderWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOrderFilterWherefCall(caller, ctx, "^transaction#all", format, args, suffix, dest...)
+	return
+}
+
+//ScanOrderFilterWheref will list exs_order by format from database
+func ScanOrderFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOrderFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+	return
+}
+
+//ScanOrderFilterWherefCall will list exs_order by format from database
+func ScanOrderFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Order{}, filter)
+	var where []string
+	if len(format) > 0 {
+		where, args = crud.AppendWheref(nil, nil, format, args...)
+	}
+	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
+	err = crud.Query(caller, ctx, &Order{}, filter, querySQL, args, dest...)
+	return
+}
+
+//OrderCommFilterOptional is crud filter
+const OrderCommFilterOptional = ""
+
+//OrderCommFilterRequired is crud filter
+const OrderCommFilterRequired = ""
+
+//OrderCommFilterInsert is crud filter
+const OrderCommFilterInsert = ""
+
+//OrderCommFilterUpdate is crud filter
+const OrderCommFilterUpdate = "update_time"
+
+//OrderCommFilterFind is crud filter
+const OrderCommFilterFind = "#all"
+
+//OrderCommFilterScan is crud filter
+const OrderCommFilterScan = "#all"
+
+//EnumValid will valid value by OrderCommType
+func (o *OrderCommType) EnumValid(v interface{}) (err error) {
+	var target OrderCommType
+	targetType := reflect.TypeOf(OrderCommType(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderCommType)
+	}
+	for _, value := range OrderCommTypeAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderCommTypeAll)
+}
+
+//EnumValid will valid value by OrderCommTypeArray
+func (o *OrderCommTypeArray) EnumValid(v interface{}) (err error) {
+	var target OrderCommType
+	targetType := reflect.TypeOf(OrderCommType(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderCommType)
+	}
+	for _, value := range OrderCommTypeAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderCommTypeAll)
+}
+
+//DbArray will join value to database array
+func (o OrderCommTypeArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o OrderCommTypeArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//EnumValid will valid value by OrderCommStatus
+func (o *OrderCommStatus) EnumValid(v interface{}) (err error) {
+	var target OrderCommStatus
+	targetType := reflect.TypeOf(OrderCommStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderCommStatus)
+	}
+	for _, value := range OrderCommStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderCommStatusAll)
+}
+
+//EnumValid will valid value by OrderCommStatusArray
+func (o *OrderCommStatusArray) EnumValid(v interface{}) (err error) {
+	var target OrderCommStatus
+	targetType := reflect.TypeOf(OrderCommStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(OrderCommStatus)
+	}
+	for _, value := range OrderCommStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", OrderCommStatusAll)
+}
+
+//DbArray will join value to database array
+func (o OrderCommStatusArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o OrderCommStatusArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//MetaWithOrderComm will return exs_order_comm meta data
+func MetaWithOrderComm(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_order_comm"), fields...)
+	return
+}
+
+//MetaWith will return exs_order_comm meta data
+func (orderComm *OrderComm) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_order_comm"), fields...)
+	return
+}
+
+//Meta will return exs_order_comm meta data
+func (orderComm *OrderComm) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(orderComm, "#all")
+	return
+}
+
+//Valid will valid by filter
+func (orderComm *OrderComm) Valid() (err error) {
+	if reflect.ValueOf(orderComm.TID).IsZero() {
+		err = attrvalid.Valid(orderComm, OrderCommFilterInsert+"#all", OrderCommFilterOptional)
+	} else {
+		err = attrvalid.Valid(orderComm, OrderCommFilterUpdate, "")
+	}
+	return
+}
+
+//Insert will add exs_order_comm to database
+func (orderComm *OrderComm) Insert(caller interface{}, ctx context.Context) (err error) {
+
+	if orderComm.UpdateTime.Timestamp() < 1 {
+		orderComm.UpdateTime = xsql.TimeNow()
+	}
+
+	if orderComm.CreateTime.Timestamp() < 1 {
+		orderComm.CreateTime = xsql.TimeNow()
+	}
+
+	_, err = crud.InsertFilter(caller, ctx, orderComm, "^tid#all", "returning", "tid#all")
+	return
+}
+
+//UpdateFilter will update exs_order_comm to database
+func (orderComm *OrderComm) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = orderComm.UpdateFilterWheref(caller, ctx, filter, "")
+	return
+}
+
+//UpdateWheref will update exs_order_comm to database
+func (orderComm *OrderComm) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = orderComm.UpdateFilterWheref(caller, ctx, OrderCommFilterUpdate, formats, formatArgs...)
+	return
+}
+
+//UpdateFilterWheref will update exs_order_comm to database
+func (orderComm *OrderComm) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	orderComm.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(orderComm, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", orderComm.TID)
+	if len(formats) > 0 {
+		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
+	}
+	err = crud.UpdateRow(caller, ctx, orderComm, sql, where, "and", args)
+	return
+}
+
+//AddOrderComm will add exs_order_comm to database
+func AddOrderComm(ctx context.Context, orderComm *OrderComm) (err error) {
+	err = AddOrderCommCall(GetQueryer, ctx, orderComm)
+	return
+}
+
+//AddOrderComm will add exs_order_comm to database
+func AddOrderCommCall(caller interface{}, ctx context.Context, orderComm *OrderComm) (err error) {
+	err = orderComm.Insert(caller, ctx)
+	return
+}
+
+//UpdateOrderCommFilter will update exs_order_comm to database
+func UpdateOrderCommFilter(ctx context.Context, orderComm *OrderComm, filter string) (err error) {
+	err = UpdateOrderCommFilterCall(GetQueryer, ctx, orderComm, filter)
+	return
+}
+
+//UpdateOrderCommFilterCall will update exs_order_comm to database
+func UpdateOrderCommFilterCall(caller interface{}, ctx context.Context, orderComm *OrderComm, filter string) (err error) {
+	err = orderComm.UpdateFilter(caller, ctx, filter)
+	return
+}
+
+//UpdateOrderCommWheref will update exs_order_comm to database
+func UpdateOrderCommWheref(ctx context.Context, orderComm *OrderComm, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOrderCommWherefCall(GetQueryer, ctx, orderComm, formats, formatArgs...)
+	return
+}
+
+//UpdateOrderCommWherefCall will update exs_order_comm to database
+func UpdateOrderCommWherefCall(caller interface{}, ctx context.Context, orderComm *OrderComm, formats string, formatArgs ...interface{}) (err error) {
+	err = orderComm.UpdateWheref(caller, ctx, formats, formatArgs...)
+	return
+}
+
+//UpdateOrderCommFilterWheref will update exs_order_comm to database
+func UpdateOrderCommFilterWheref(ctx context.Context, orderComm *OrderComm, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOrderCommFilterWherefCall(GetQueryer, ctx, orderComm, filter, formats, formatArgs...)
+	return
+}
+
+//UpdateOrderCommFilterWherefCall will update exs_order_comm to database
+func UpdateOrderCommFilterWherefCall(caller interface{}, ctx context.Context, orderComm *OrderComm, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = orderComm.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+	return
+}
+
+//FindOrderCommCall will find exs_order_comm by id from database
+func FindOrderComm(ctx context.Context, orderCommID int64) (orderComm *OrderComm, err error) {
+	orderComm, err = FindOrderCommCall(GetQueryer, ctx, orderCommID, false)
+	return
+}
+
+//FindOrderCommCall will find exs_order_comm by id from database
+func FindOrderCommCall(caller interface{}, ctx context.Context, orderCommID int64, lock bool) (orderComm *OrderComm, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", orderCommID)
+	orderComm, err = FindOrderCommWhereCall(caller, ctx, lock, "and", where, args)
+	return
+}
+
+//FindOrderCommWhereCall will find exs_order_comm by where from database
+func FindOrderCommWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (orderComm *OrderComm, err error) {
+	querySQL := crud.QuerySQL(&OrderComm{}, "#all")
+	querySQL = crud.JoinWhere(querySQL, where, join)
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &OrderComm{}, "#all", querySQL, args, &orderComm)
+	return
+}
+
+//FindOrderCommWheref will find exs_order_comm by where from database
+func FindOrderCommWheref(ctx context.Context, format string, args ...interface{}) (orderComm *OrderComm, err error) {
+	orderComm, err = FindOrderCommWherefCall(GetQueryer, ctx, false, format, args...)
+	return
+}
+
+//FindOrderCommWherefCall will find exs_order_comm by where from database
+func FindOrderCommWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (orderComm *OrderComm, err error) {
+	orderComm, err = FindOrderCommFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+	return
+}
+
+//FindOrderCommFilterWheref will find exs_order_comm by where from database
+func FindOrderCommFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (orderComm *OrderComm, err error) {
+	orderComm, err = FindOrderCommFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+	return
+}
+
+//FindOrderCommFilterWherefCall will find exs_order_comm by where from database
+func FindOrderCommFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (orderComm *OrderComm, err error) {
+	querySQL := crud.QuerySQL(&OrderComm{}, filter)
+	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
+	querySQL = crud.JoinWhere(querySQL, where, "and")
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &OrderComm{}, filter, querySQL, queryArgs, &orderComm)
+	return
+}
+
+//ListOrderCommByID will list exs_order_comm by id from database
+func ListOrderCommByID(ctx context.Context, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
+	orderCommList, orderCommMap, err = ListOrderCommByIDCall(GetQueryer, ctx, orderCommIDs...)
+	return
+}
+
+//ListOrderCommByIDCall will list exs_order_comm by id from database
+func ListOrderCommByIDCall(caller interface{}, ctx context.Context, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
+	if len(orderCommIDs) < 1 {
+		orderCommMap = map[int64]*OrderComm{}
+		return
+	}
+	err = ScanOrderCommByIDCall(caller, ctx, orderCommIDs, &orderCommList, &orderCommMap, "tid")
+	return
+}
+
+//ListOrderCommFilterByID will list exs_order_comm by id from database
+func ListOrderCommFilterByID(ctx context.Context, filter string, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
+	orderCommList, orderCommMap, err = ListOrderCommFilterByIDCall(GetQueryer, ctx, filter, orderCommIDs...)
+	return
+}
+
+//ListOrderCommFilterByIDCall will list exs_order_comm by id from database
+func ListOrderCommFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
+	if len(orderCommIDs) < 1 {
+		orderCommMap = map[int64]*OrderComm{}
+		return
+	}
+	err = ScanOrderCommFilterByIDCall(caller, ctx, filter, orderCommIDs, &orderCommList, &orderCommMap, "tid")
+	return
+}
+
+//ScanOrderCommByID will list exs_order_comm by id from database
+func ScanOrderCommByID(ctx context.Context, orderCommIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOrderCommByIDCall(GetQueryer, ctx, orderCommIDs, dest...)
+	return
+}
+
+//ScanOrderCommByIDCall will list exs_order_comm by id from database
+func ScanOrderCommByIDCall(caller interface{}, ctx context.Context, orderCommIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOrderCommFilterByIDCall(caller, ctx, "#all", orderCommIDs, dest...)
+	return
+}
+
+//ScanOrderCommFilterByID will list exs_order_comm by id from database
+func ScanOrderCommFilterByID(ctx context.Context, filter string, orderCommIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOrderCommFilterByIDCall(GetQueryer, ctx, filter, orderCommIDs, dest...)
+	return
+}
+
+//ScanOrderCommFilterByIDCall will list exs_order_comm by id from database
+func ScanOrderCommFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderCommIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&OrderComm{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(orderCommIDs).InArray()))
+	querySQL = crud.JoinWhere(querySQL, where, " and ")
+	err = crud.Query(caller, ctx, &OrderComm{}, filter, querySQL, nil, dest...)
+	return
+}
+
+//ScanOrderCommWherefCall will list exs_order_comm by format from database
+func ScanOrderCommWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOrderCommWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+	return
+}
+
+//ScanOrderCommWherefCall will list exs_order_comm by format from database
+func ScanOrderCommWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOrderCommFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+	return
+}
+
+//ScanOrderCommFilterWheref will list exs_order_comm by format from database
+func ScanOrderCommFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOrderCommFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+	return
+}
+
+//ScanOrderCommFilterWherefCall will list exs_order_comm by format from database
+func ScanOrderCommFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&OrderComm{}, filter)
+	var where []string
+	if len(format) > 0 {
+		where, args = crud.AppendWheref(nil, nil, format, args...)
+	}
+	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
+	err = crud.Query(caller, ctx, &OrderComm{}, filter, querySQL, args, dest...)
+	return
+}
+
+//OutboxFilterOptional is crud filter
+const OutboxFilterOptional = ""
+
+//OutboxFilterRequired is crud filter
+const OutboxFilterRequired = ""
+
+//OutboxFilterInsert is crud filter
+const OutboxFilterInsert = ""
+
+//OutboxFilterUpdate is crud filter
+const OutboxFilterUpdate = "update_time"
+
+//OutboxFilterFind is crud filter
+const OutboxFilterFind = "#all"
+
+//OutboxFilterScan is crud filter
+const OutboxFilterScan = "#all"
 
-//EnumValid will valid value by OrderStatus
-func (o *OrderStatus) EnumValid(v interface{}) (err error) {
-	var target OrderStatus
-	targetType := reflect.TypeOf(OrderStatus(0))
+//EnumValid will valid value by OutboxStatus
+func (o *OutboxStatus) EnumValid(v interface{}) (err error) {
+	var target OutboxStatus
+	targetType := reflect.TypeOf(OutboxStatus(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderStatus)
+		target = targetValue.Convert(targetType).Interface().(OutboxStatus)
 	}
-	for _, value := range OrderStatusAll {
+	for _, value := range OutboxStatusAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", OrderStatusAll)
+	return fmt.Errorf("must be in %v", OutboxStatusAll)
 }
 
-//EnumValid will valid value by OrderStatusArray
-func (o *OrderStatusArray) EnumValid(v interface{}) (err error) {
-	var target OrderStatus
-	targetType := reflect.TypeOf(OrderStatus(0))
+//EnumValid will valid value by OutboxStatusArray
+func (o *OutboxStatusArray) EnumValid(v interface{}) (err error) {
+	var target OutboxStatus
+	targetType := reflect.TypeOf(OutboxStatus(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderStatus)
+		target = targetValue.Convert(targetType).Interface().(OutboxStatus)
 	}
-	for _, value := range OrderStatusAll {
+	for _, value := range OutboxStatusAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", OrderStatusAll)
+	return fmt.Errorf("must be in %v", OutboxStatusAll)
 }
 
 //DbArray will join value to database array
-func (o OrderStatusArray) DbArray() (res string) {
+func (o OutboxStatusArray) DbArray() (res string) {
 	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
 	return
 }
 
 //InArray will join value to database array
-func (o OrderStatusArray) InArray() (res string) {
+func (o OutboxStatusArray) InArray() (res string) {
 	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
 	return
 }
 
-//MetaWithOrder will return exs_order meta data
-func MetaWithOrder(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_order"), fields...)
+//MetaWithOutbox will return exs_outbox meta data
+func MetaWithOutbox(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_outbox"), fields...)
 	return
 }
 
-//MetaWith will return exs_order meta data
-func (order *Order) MetaWith(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_order"), fields...)
+//MetaWith will return exs_outbox meta data
+func (outbox *Outbox) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_outbox"), fields...)
 	return
 }
 
-//Meta will return exs_order meta data
-func (order *Order) Meta() (table string, fileds []string) {
-	table, fileds = crud.QueryField(order, "#all")
+//Meta will return exs_outbox meta data
+func (outbox *Outbox) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(outbox, "#all")
 	return
 }
 
 //Valid will valid by filter
-func (order *Order) Valid() (err error) {
-	if reflect.ValueOf(order.TID).IsZero() {
-		err = attrvalid.Valid(order, OrderFilterInsert+"#all", OrderFilterOptional)
+func (outbox *Outbox) Valid() (err error) {
+	if reflect.ValueOf(outbox.TID).IsZero() {
+		err = attrvalid.Valid(outbox, OutboxFilterInsert+"#all", OutboxFilterOptional)
 	} else {
-		err = attrvalid.Valid(order, OrderFilterUpdate, "")
+		err = attrvalid.Valid(outbox, OutboxFilterUpdate, "")
 	}
 	return
 }
 
-//Insert will add exs_order to database
-func (order *Order) Insert(caller interface{}, ctx context.Context) (err error) {
+//Insert will add exs_outbox to database
+func (outbox *Outbox) Insert(caller interface{}, ctx context.Context) (err error) {
 
-	if order.UpdateTime.Timestamp() < 1 {
-		order.UpdateTime = xsql.TimeNow()
+	if outbox.UpdateTime.Timestamp() < 1 {
+		outbox.UpdateTime = xsql.TimeNow()
 	}
 
-	if order.CreateTime.Timestamp() < 1 {
-		order.CreateTime = xsql.TimeNow()
+	if outbox.CreateTime.Timestamp() < 1 {
+		outbox.CreateTime = xsql.TimeNow()
 	}
 
-	_, err = crud.InsertFilter(caller, ctx, order, "^tid#all", "returning", "tid#all")
+	_, err = crud.InsertFilter(caller, ctx, outbox, "^tid#all", "returning", "tid#all")
 	return
 }
 
-//UpdateFilter will update exs_order to database
-func (order *Order) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
-	err = order.UpdateFilterWheref(caller, ctx, filter, "")
+//UpdateFilter will update exs_outbox to database
+func (outbox *Outbox) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = outbox.UpdateFilterWheref(caller, ctx, filter, "")
 	return
 }
 
-//UpdateWheref will update exs_order to database
-func (order *Order) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
-	err = order.UpdateFilterWheref(caller, ctx, OrderFilterUpdate, formats, formatArgs...)
+//UpdateWheref will update exs_outbox to database
+func (outbox *Outbox) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = outbox.UpdateFilterWheref(caller, ctx, OutboxFilterUpdate, formats, formatArgs...)
 	return
 }
 
-//UpdateFilterWheref will update exs_order to database
-func (order *Order) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
-	order.UpdateTime = xsql.TimeNow()
-	sql, args := crud.UpdateSQL(order, filter, nil)
-	where, args := crud.AppendWheref(nil, args, "tid=$%v", order.TID)
+//UpdateFilterWheref will update exs_outbox to database
+func (outbox *Outbox) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	outbox.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(outbox, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", outbox.TID)
 	if len(formats) > 0 {
 		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
 	}
-	err = crud.UpdateRow(caller, ctx, order, sql, where, "and", args)
+	err = crud.UpdateRow(caller, ctx, outbox, sql, where, "and", args)
 	return
 }
 
-//AddOrder will add exs_order to database
-func AddOrder(ctx context.Context, order *Order) (err error) {
-	err = AddOrderCall(GetQueryer, ctx, order)
+//AddOutbox will add exs_outbox to database
+func AddOutbox(ctx context.Context, outbox *Outbox) (err error) {
+	err = AddOutboxCall(GetQueryer, ctx, outbox)
 	return
 }
 
-//AddOrder will add exs_order to database
-func AddOrderCall(caller interface{}, ctx context.Context, order *Order) (err error) {
-	err = order.Insert(caller, ctx)
+//AddOutbox will add exs_outbox to database
+func AddOutboxCall(caller interface{}, ctx context.Context, outbox *Outbox) (err error) {
+	err = outbox.Insert(caller, ctx)
 	return
 }
 
-//UpdateOrderFilter will update exs_order to database
-func UpdateOrderFilter(ctx context.Context, order *Order, filter string) (err error) {
-	err = UpdateOrderFilterCall(GetQueryer, ctx, order, filter)
+//UpdateOutboxFilter will update exs_outbox to database
+func UpdateOutboxFilter(ctx context.Context, outbox *Outbox, filter string) (err error) {
+	err = UpdateOutboxFilterCall(GetQueryer, ctx, outbox, filter)
 	return
 }
 
-//UpdateOrderFilterCall will update exs_order to database
-func UpdateOrderFilterCall(caller interface{}, ctx context.Context, order *Order, filter string) (err error) {
-	err = order.UpdateFilter(caller, ctx, filter)
+//UpdateOutboxFilterCall will update exs_outbox to database
+func UpdateOutboxFilterCall(caller interface{}, ctx context.Context, outbox *Outbox, filter string) (err error) {
+	err = outbox.UpdateFilter(caller, ctx, filter)
 	return
 }
 
-//UpdateOrderWheref will update exs_order to database
-func UpdateOrderWheref(ctx context.Context, order *Order, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateOrderWherefCall(GetQueryer, ctx, order, formats, formatArgs...)
+//UpdateOutboxWheref will update exs_outbox to database
+func UpdateOutboxWheref(ctx context.Context, outbox *Outbox, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOutboxWherefCall(GetQueryer, ctx, outbox, formats, formatArgs...)
 	return
 }
 
-//UpdateOrderWherefCall will update exs_order to database
-func UpdateOrderWherefCall(caller interface{}, ctx context.Context, order *Order, formats string, formatArgs ...interface{}) (err error) {
-	err = order.UpdateWheref(caller, ctx, formats, formatArgs...)
+//UpdateOutboxWherefCall will update exs_outbox to database
+func UpdateOutboxWherefCall(caller interface{}, ctx context.Context, outbox *Outbox, formats string, formatArgs ...interface{}) (err error) {
+	err = outbox.UpdateWheref(caller, ctx, formats, formatArgs...)
 	return
 }
 
-//UpdateOrderFilterWheref will update exs_order to database
-func UpdateOrderFilterWheref(ctx context.Context, order *Order, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateOrderFilterWherefCall(GetQueryer, ctx, order, filter, formats, formatArgs...)
+//UpdateOutboxFilterWheref will update exs_outbox to database
+func UpdateOutboxFilterWheref(ctx context.Context, outbox *Outbox, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOutboxFilterWherefCall(GetQueryer, ctx, outbox, filter, formats, formatArgs...)
 	return
 }
 
-//UpdateOrderFilterWherefCall will update exs_order to database
-func UpdateOrderFilterWherefCall(caller interface{}, ctx context.Context, order *Order, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = order.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+//UpdateOutboxFilterWherefCall will update exs_outbox to database
+func UpdateOutboxFilterWherefCall(caller interface{}, ctx context.Context, outbox *Outbox, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = outbox.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
 	return
 }
 
-//FindOrderCall will find exs_order by id from database
-func FindOrder(ctx context.Context, orderID int64) (order *Order, err error) {
-	order, err = FindOrderCall(GetQueryer, ctx, orderID, false)
+//FindOutboxCall will find exs_outbox by id from database
+func FindOutbox(ctx context.Context, outboxID int64) (outbox *Outbox, err error) {
+	outbox, err = FindOutboxCall(GetQueryer, ctx, outboxID, false)
 	return
 }
 
-//FindOrderCall will find exs_order by id from database
-func FindOrderCall(caller interface{}, ctx context.Context, orderID int64, lock bool) (order *Order, err error) {
-	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", orderID)
-	order, err = FindOrderWhereCall(caller, ctx, lock, "and", where, args)
+//FindOutboxCall will find exs_outbox by id from database
+func FindOutboxCall(caller interface{}, ctx context.Context, outboxID int64, lock bool) (outbox *Outbox, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", outboxID)
+	outbox, err = FindOutboxWhereCall(caller, ctx, lock, "and", where, args)
 	return
 }
 
-//FindOrderWhereCall will find exs_order by where from database
-func FindOrderWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (order *Order, err error) {
-	querySQL := crud.QuerySQL(&Order{}, "#all")
+//FindOutboxWhereCall will find exs_outbox by where from database
+func FindOutboxWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (outbox *Outbox, err error) {
+	querySQL := crud.QuerySQL(&Outbox{}, "#all")
 	querySQL = crud.JoinWhere(querySQL, where, join)
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &Order{}, "#all", querySQL, args, &order)
+	err = crud.QueryRow(caller, ctx, &Outbox{}, "#all", querySQL, args, &outbox)
 	return
 }
 
-//FindOrderWheref will find exs_order by where from database
-func FindOrderWheref(ctx context.Context, format string, args ...interface{}) (order *Order, err error) {
-	order, err = FindOrderWherefCall(GetQueryer, ctx, false, format, args...)
+//FindOutboxWheref will find exs_outbox by where from database
+func FindOutboxWheref(ctx context.Context, format string, args ...interface{}) (outbox *Outbox, err error) {
+	outbox, err = FindOutboxWherefCall(GetQueryer, ctx, false, format, args...)
 	return
 }
 
-//FindOrderWherefCall will find exs_order by where from database
-func FindOrderWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (order *Order, err error) {
-	order, err = FindOrderFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+//FindOutboxWherefCall will find exs_outbox by where from database
+func FindOutboxWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (outbox *Outbox, err error) {
+	outbox, err = FindOutboxFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
 	return
 }
 
-//FindOrderFilterWheref will find exs_order by where from database
-func FindOrderFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (order *Order, err error) {
-	order, err = FindOrderFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+//FindOutboxFilterWheref will find exs_outbox by where from database
+func FindOutboxFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (outbox *Outbox, err error) {
+	outbox, err = FindOutboxFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
 	return
 }
 
-//FindOrderFilterWherefCall will find exs_order by where from database
-func FindOrderFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (order *Order, err error) {
-	querySQL := crud.QuerySQL(&Order{}, filter)
+//FindOutboxFilterWherefCall will find exs_outbox by where from database
+func FindOutboxFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (outbox *Outbox, err error) {
+	querySQL := crud.QuerySQL(&Outbox{}, filter)
 	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
 	querySQL = crud.JoinWhere(querySQL, where, "and")
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &Order{}, filter, querySQL, queryArgs, &order)
+	err = crud.QueryRow(caller, ctx, &Outbox{}, filter, querySQL, queryArgs, &outbox)
 	return
 }
 
-//ListOrderByID will list exs_order by id from database
-func ListOrderByID(ctx context.Context, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
-	orderList, orderMap, err = ListOrderByIDCall(GetQueryer, ctx, orderIDs...)
+//ListOutboxByID will list exs_outbox by id from database
+func ListOutboxByID(ctx context.Context, outboxIDs ...int64) (outboxList []*Outbox, outboxMap map[int64]*Outbox, err error) {
+	outboxList, outboxMap, err = ListOutboxByIDCall(GetQueryer, ctx, outboxIDs...)
 	return
 }
 
-//ListOrderByIDCall will list exs_order by id from database
-func ListOrderByIDCall(caller interface{}, ctx context.Context, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
-	if len(orderIDs) < 1 {
-		orderMap = map[int64]*Order{}
+//ListOutboxByIDCall will list exs_outbox by id from database
+func ListOutboxByIDCall(caller interface{}, ctx context.Context, outboxIDs ...int64) (outboxList []*Outbox, outboxMap map[int64]*Outbox, err error) {
+	if len(outboxIDs) < 1 {
+		outboxMap = map[int64]*Outbox{}
 		return
 	}
-	err = ScanOrderByIDCall(caller, ctx, orderIDs, &orderList, &orderMap, "tid")
+	err = ScanOutboxByIDCall(caller, ctx, outboxIDs, &outboxList, &outboxMap, "tid")
 	return
 }
 
-//ListOrderFilterByID will list exs_order by id from database
-func ListOrderFilterByID(ctx context.Context, filter string, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
-	orderList, orderMap, err = ListOrderFilterByIDCall(GetQueryer, ctx, filter, orderIDs...)
+//ListOutboxFilterByID will list exs_outbox by id from database
+func ListOutboxFilterByID(ctx context.Context, filter string, outboxIDs ...int64) (outboxList []*Outbox, outboxMap map[int64]*Outbox, err error) {
+	outboxList, outboxMap, err = ListOutboxFilterByIDCall(GetQueryer, ctx, filter, outboxIDs...)
 	return
 }
 
-//ListOrderFilterByIDCall will list exs_order by id from database
-func ListOrderFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderIDs ...int64) (orderList []*Order, orderMap map[int64]*Order, err error) {
-	if len(orderIDs) < 1 {
-		orderMap = map[int64]*Order{}
+//ListOutboxFilterByIDCall will list exs_outbox by id from database
+func ListOutboxFilterByIDCall(caller interface{}, ctx context.Context, filter string, outboxIDs ...int64) (outboxList []*Outbox, outboxMap map[int64]*Outbox, err error) {
+	if len(outboxIDs) < 1 {
+		outboxMap = map[int64]*Outbox{}
 		return
 	}
-	err = ScanOrderFilterByIDCall(caller, ctx, filter, orderIDs, &orderList, &orderMap, "tid")
+	err = ScanOutboxFilterByIDCall(caller, ctx, filter, outboxIDs, &outboxList, &outboxMap, "tid")
 	return
 }
 
-//ScanOrderByID will list exs_order by id from database
-func ScanOrderByID(ctx context.Context, orderIDs []int64, dest ...interface{}) (err error) {
-	err = ScanOrderByIDCall(GetQueryer, ctx, orderIDs, dest...)
+//ScanOutboxByID will list exs_outbox by id from database
+func ScanOutboxByID(ctx context.Context, outboxIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOutboxByIDCall(GetQueryer, ctx, outboxIDs, dest...)
 	return
 }
 
-//ScanOrderByIDCall will list exs_order by id from database
-func ScanOrderByIDCall(caller interface{}, ctx context.Context, orderIDs []int64, dest ...interface{}) (err error) {
-	err = ScanOrderFilterByIDCall(caller, ctx, "^transaction#all", orderIDs, dest...)
+//ScanOutboxByIDCall will list exs_outbox by id from database
+func ScanOutboxByIDCall(caller interface{}, ctx context.Context, outboxIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOutboxFilterByIDCall(caller, ctx, "#all", outboxIDs, dest...)
 	return
 }
 
-//ScanOrderFilterByID will list exs_order by id from database
-func ScanOrderFilterByID(ctx context.Context, filter string, orderIDs []int64, dest ...interface{}) (err error) {
-	err = ScanOrderFilterByIDCall(GetQueryer, ctx, filter, orderIDs, dest...)
+//ScanOutboxFilterByID will list exs_outbox by id from database
+func ScanOutboxFilterByID(ctx context.Context, filter string, outboxIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOutboxFilterByIDCall(GetQueryer, ctx, filter, outboxIDs, dest...)
 	return
 }
 
-//ScanOrderFilterByIDCall will list exs_order by id from database
-func ScanOrderFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderIDs []int64, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&Order{}, filter)
-	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(orderIDs).InArray()))
+//ScanOutboxFilterByIDCall will list exs_outbox by id from database
+func ScanOutboxFilterByIDCall(caller interface{}, ctx context.Context, filter string, outboxIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Outbox{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(outboxIDs).InArray()))
 	querySQL = crud.JoinWhere(querySQL, where, " and ")
-	err = crud.Query(caller, ctx, &Order{}, filter, querySQL, nil, dest...)
+	err = crud.Query(caller, ctx, &Outbox{}, filter, querySQL, nil, dest...)
 	return
 }
 
-//ScanOrderWherefCall will list exs_order by format from database
-func ScanOrderWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanOrderWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+//ScanOutboxWherefCall will list exs_outbox by format from database
+func ScanOutboxWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOutboxWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
 	return
 }
 
-//ScanOrderWherefCall will list exs_order by format from database
-func ScanOrderWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanOrderFilterWherefCall(caller, ctx, "^transaction#all", format, args, suffix, dest...)
+//ScanOutboxWherefCall will list exs_outbox by format from database
+func ScanOutboxWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOutboxFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
 	return
 }
 
-//ScanOrderFilterWheref will list exs_order by format from database
-func ScanOrderFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanOrderFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+//ScanOutboxFilterWheref will list exs_outbox by format from database
+func ScanOutboxFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOutboxFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
 	return
 }
 
-//ScanOrderFilterWherefCall will list exs_order by format from database
-func ScanOrderFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&Order{}, filter)
+//ScanOutboxFilterWherefCall will list exs_outbox by format from database
+func ScanOutboxFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&Outbox{}, filter)
 	var where []string
 	if len(format) > 0 {
 		where, args = crud.AppendWheref(nil, nil, format, args...)
 	}
 	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
-	err = crud.Query(caller, ctx, &Order{}, filter, querySQL, args, dest...)
+	err = crud.Query(caller, ctx, &Outbox{}, filter, querySQL, args, dest...)
 	return
 }
 
-//OrderCommFilterOptional is crud filter
-const OrderCommFilterOptional = ""
-
-//OrderCommFilterRequired is crud filter
-const OrderCommFilterRequired = ""
-
-//OrderCommFilterInsert is crud filter
-const OrderCommFilterInsert = ""
+//OutboxAckFilterOptional is crud filter
+const OutboxAckFilterOptional = ""
 
-//OrderCommFilterUpdate is crud filter
-const OrderCommFilterUpdate = "update_time"
-
-//OrderCommFilterFind is crud filter
-const OrderCommFilterFind = "#all"
-
-//OrderCommFilterScan is crud filter
-const OrderCommFilterScan = "#all"
+//OutboxAckFilterRequired is crud filter
+const OutboxAckFilterRequired = ""
 
-//EnumValid will valid value by OrderCommType
-func (o *OrderCommType) EnumValid(v interface{}) (err error) {
-	var target OrderCommType
-	targetType := reflect.TypeOf(OrderCommType(0))
-	targetValue := reflect.ValueOf(v)
-	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderCommType)
-	}
-	for _, value := range OrderCommTypeAll {
-		if target == value {
-			return nil
-		}
-	}
-	return fmt.Errorf("must be in %v", OrderCommTypeAll)
-}
+//OutboxAckFilterInsert is crud filter
+const OutboxAckFilterInsert = ""
 
-//EnumValid will valid value by OrderCommTypeArray
-func (o *OrderCommTypeArray) EnumValid(v interface{}) (err error) {
-	var target OrderCommType
-	targetType := reflect.TypeOf(OrderCommType(0))
-	targetValue := reflect.ValueOf(v)
-	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderCommType)
-	}
-	for _, value := range OrderCommTypeAll {
-		if target == value {
-			return nil
-		}
-	}
-	return fmt.Errorf("must be in %v", OrderCommTypeAll)
-}
+//OutboxAckFilterUpdate is crud filter
+const OutboxAckFilterUpdate = "update_time"
 
-//DbArray will join value to database array
-func (o OrderCommTypeArray) DbArray() (res string) {
-	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
-	return
-}
+//OutboxAckFilterFind is crud filter
+const OutboxAckFilterFind = "#all"
 
-//InArray will join value to database array
-func (o OrderCommTypeArray) InArray() (res string) {
-	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
-	return
-}
+//OutboxAckFilterScan is crud filter
+const OutboxAckFilterScan = "#all"
 
-//EnumValid will valid value by OrderCommStatus
-func (o *OrderCommStatus) EnumValid(v interface{}) (err error) {
-	var target OrderCommStatus
-	targetType := reflect.TypeOf(OrderCommStatus(0))
+//EnumValid will valid value by OutboxAckStatus
+func (o *OutboxAckStatus) EnumValid(v interface{}) (err error) {
+	var target OutboxAckStatus
+	targetType := reflect.TypeOf(OutboxAckStatus(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderCommStatus)
+		target = targetValue.Convert(targetType).Interface().(OutboxAckStatus)
 	}
-	for _, value := range OrderCommStatusAll {
+	for _, value := range OutboxAckStatusAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", OrderCommStatusAll)
+	return fmt.Errorf("must be in %v", OutboxAckStatusAll)
 }
 
-//EnumValid will valid value by OrderCommStatusArray
-func (o *OrderCommStatusArray) EnumValid(v interface{}) (err error) {
-	var target OrderCommStatus
-	targetType := reflect.TypeOf(OrderCommStatus(0))
+//EnumValid will valid value by OutboxAckStatusArray
+func (o *OutboxAckStatusArray) EnumValid(v interface{}) (err error) {
+	var target OutboxAckStatus
+	targetType := reflect.TypeOf(OutboxAckStatus(0))
 	targetValue := reflect.ValueOf(v)
 	if targetValue.CanConvert(targetType) {
-		target = targetValue.Convert(targetType).Interface().(OrderCommStatus)
+		target = targetValue.Convert(targetType).Interface().(OutboxAckStatus)
 	}
-	for _, value := range OrderCommStatusAll {
+	for _, value := range OutboxAckStatusAll {
 		if target == value {
 			return nil
 		}
 	}
-	return fmt.Errorf("must be in %v", OrderCommStatusAll)
+	return fmt.Errorf("must be in %v", OutboxAckStatusAll)
 }
 
 //DbArray will join value to database array
-func (o OrderCommStatusArray) DbArray() (res string) {
+func (o OutboxAckStatusArray) DbArray() (res string) {
 	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
 	return
 }
 
 //InArray will join value to database array
-func (o OrderCommStatusArray) InArray() (res string) {
+func (o OutboxAckStatusArray) InArray() (res string) {
 	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
 	return
 }
 
-//MetaWithOrderComm will return exs_order_comm meta data
-func MetaWithOrderComm(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_order_comm"), fields...)
+//MetaWithOutboxAck will return exs_outbox_ack meta data
+func MetaWithOutboxAck(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_outbox_ack"), fields...)
 	return
 }
 
-//MetaWith will return exs_order_comm meta data
-func (orderComm *OrderComm) MetaWith(fields ...interface{}) (v []interface{}) {
-	v = crud.MetaWith(string("exs_order_comm"), fields...)
+//MetaWith will return exs_outbox_ack meta data
+func (outboxAck *OutboxAck) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_outbox_ack"), fields...)
 	return
 }
 
-//Meta will return exs_order_comm meta data
-func (orderComm *OrderComm) Meta() (table string, fileds []string) {
-	table, fileds = crud.QueryField(orderComm, "#all")
+//Meta will return exs_outbox_ack meta data
+func (outboxAck *OutboxAck) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(outboxAck, "#all")
 	return
 }
 
 //Valid will valid by filter
-func (orderComm *OrderComm) Valid() (err error) {
-	if reflect.ValueOf(orderComm.TID).IsZero() {
-		err = attrvalid.Valid(orderComm, OrderCommFilterInsert+"#all", OrderCommFilterOptional)
+func (outboxAck *OutboxAck) Valid() (err error) {
+	if reflect.ValueOf(outboxAck.TID).IsZero() {
+		err = attrvalid.Valid(outboxAck, OutboxAckFilterInsert+"#all", OutboxAckFilterOptional)
 	} else {
-		err = attrvalid.Valid(orderComm, OrderCommFilterUpdate, "")
+		err = attrvalid.Valid(outboxAck, OutboxAckFilterUpdate, "")
 	}
 	return
 }
 
-//Insert will add exs_order_comm to database
-func (orderComm *OrderComm) Insert(caller interface{}, ctx context.Context) (err error) {
+//Insert will add exs_outbox_ack to database
+func (outboxAck *OutboxAck) Insert(caller interface{}, ctx context.Context) (err error) {
 
-	if orderComm.UpdateTime.Timestamp() < 1 {
-		orderComm.UpdateTime = xsql.TimeNow()
+	if outboxAck.UpdateTime.Timestamp() < 1 {
+		outboxAck.UpdateTime = xsql.TimeNow()
 	}
 
-	if orderComm.CreateTime.Timestamp() < 1 {
-		orderComm.CreateTime = xsql.TimeNow()
+	if outboxAck.CreateTime.Timestamp() < 1 {
+		outboxAck.CreateTime = xsql.TimeNow()
 	}
 
-	_, err = crud.InsertFilter(caller, ctx, orderComm, "^tid#all", "returning", "tid#all")
+	_, err = crud.InsertFilter(caller, ctx, outboxAck, "^tid#all", "returning", "tid#all")
 	return
 }
 
-//UpdateFilter will update exs_order_comm to database
-func (orderComm *OrderComm) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
-	err = orderComm.UpdateFilterWheref(caller, ctx, filter, "")
+//UpdateFilter will update exs_outbox_ack to database
+func (outboxAck *OutboxAck) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = outboxAck.UpdateFilterWheref(caller, ctx, filter, "")
 	return
 }
 
-//UpdateWheref will update exs_order_comm to database
-func (orderComm *OrderComm) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
-	err = orderComm.UpdateFilterWheref(caller, ctx, OrderCommFilterUpdate, formats, formatArgs...)
+//UpdateWheref will update exs_outbox_ack to database
+func (outboxAck *OutboxAck) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = outboxAck.UpdateFilterWheref(caller, ctx, OutboxAckFilterUpdate, formats, formatArgs...)
 	return
 }
 
-//UpdateFilterWheref will update exs_order_comm to database
-func (orderComm *OrderComm) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
-	orderComm.UpdateTime = xsql.TimeNow()
-	sql, args := crud.UpdateSQL(orderComm, filter, nil)
-	where, args := crud.AppendWheref(nil, args, "tid=$%v", orderComm.TID)
+//UpdateFilterWheref will update exs_outbox_ack to database
+func (outboxAck *OutboxAck) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	outboxAck.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(outboxAck, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", outboxAck.TID)
 	if len(formats) > 0 {
 		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
 	}
-	err = crud.UpdateRow(caller, ctx, orderComm, sql, where, "and", args)
+	err = crud.UpdateRow(caller, ctx, outboxAck, sql, where, "and", args)
 	return
 }
 
-//AddOrderComm will add exs_order_comm to database
-func AddOrderComm(ctx context.Context, orderComm *OrderComm) (err error) {
-	err = AddOrderCommCall(GetQueryer, ctx, orderComm)
+//AddOutboxAck will add exs_outbox_ack to database
+func AddOutboxAck(ctx context.Context, outboxAck *OutboxAck) (err error) {
+	err = AddOutboxAckCall(GetQueryer, ctx, outboxAck)
 	return
-}
-
-//AddOrderComm will add exs_order_comm to database
-func AddOrderCommCall(caller interface{}, ctx context.Context, orderComm *OrderComm) (err error) {
-	err = orderComm.Insert(caller, ctx)
+}
+
+//AddOutboxAck will add exs_outbox_ack to database
+func AddOutboxAckCall(caller interface{}, ctx context.Context, outboxAck *OutboxAck) (err error) {
+	err = outboxAck.Insert(caller, ctx)
 	return
 }
 
-//UpdateOrderCommFilter will update exs_order_comm to database
-func UpdateOrderCommFilter(ctx context.Context, orderComm *OrderComm, filter string) (err error) {
-	err = UpdateOrderCommFilterCall(GetQueryer, ctx, orderComm, filter)
+//UpdateOutboxAckFilter will update exs_outbox_ack to database
+func UpdateOutboxAckFilter(ctx context.Context, outboxAck *OutboxAck, filter string) (err error) {
+	err = UpdateOutboxAckFilterCall(GetQueryer, ctx, outboxAck, filter)
 	return
 }
 
-//UpdateOrderCommFilterCall will update exs_order_comm to database
-func UpdateOrderCommFilterCall(caller interface{}, ctx context.Context, orderComm *OrderComm, filter string) (err error) {
-	err = orderComm.UpdateFilter(caller, ctx, filter)
+//UpdateOutboxAckFilterCall will update exs_outbox_ack to database
+func UpdateOutboxAckFilterCall(caller interface{}, ctx context.Context, outboxAck *OutboxAck, filter string) (err error) {
+	err = outboxAck.UpdateFilter(caller, ctx, filter)
 	return
 }
 
-//UpdateOrderCommWheref will update exs_order_comm to database
-func UpdateOrderCommWheref(ctx context.Context, orderComm *OrderComm, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateOrderCommWherefCall(GetQueryer, ctx, orderComm, formats, formatArgs...)
+//UpdateOutboxAckWheref will update exs_outbox_ack to database
+func UpdateOutboxAckWheref(ctx context.Context, outboxAck *OutboxAck, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOutboxAckWherefCall(GetQueryer, ctx, outboxAck, formats, formatArgs...)
 	return
 }
 
-//UpdateOrderCommWherefCall will update exs_order_comm to database
-func UpdateOrderCommWherefCall(caller interface{}, ctx context.Context, orderComm *OrderComm, formats string, formatArgs ...interface{}) (err error) {
-	err = orderComm.UpdateWheref(caller, ctx, formats, formatArgs...)
+//UpdateOutboxAckWherefCall will update exs_outbox_ack to database
+func UpdateOutboxAckWherefCall(caller interface{}, ctx context.Context, outboxAck *OutboxAck, formats string, formatArgs ...interface{}) (err error) {
+	err = outboxAck.UpdateWheref(caller, ctx, formats, formatArgs...)
 	return
 }
 
-//UpdateOrderCommFilterWheref will update exs_order_comm to database
-func UpdateOrderCommFilterWheref(ctx context.Context, orderComm *OrderComm, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = UpdateOrderCommFilterWherefCall(GetQueryer, ctx, orderComm, filter, formats, formatArgs...)
+//UpdateOutboxAckFilterWheref will update exs_outbox_ack to database
+func UpdateOutboxAckFilterWheref(ctx context.Context, outboxAck *OutboxAck, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateOutboxAckFilterWherefCall(GetQueryer, ctx, outboxAck, filter, formats, formatArgs...)
 	return
 }
 
-//UpdateOrderCommFilterWherefCall will update exs_order_comm to database
-func UpdateOrderCommFilterWherefCall(caller interface{}, ctx context.Context, orderComm *OrderComm, filter string, formats string, formatArgs ...interface{}) (err error) {
-	err = orderComm.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+//UpdateOutboxAckFilterWherefCall will update exs_outbox_ack to database
+func UpdateOutboxAckFilterWherefCall(caller interface{}, ctx context.Context, outboxAck *OutboxAck, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = outboxAck.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
 	return
 }
 
-//FindOrderCommCall will find exs_order_comm by id from database
-func FindOrderComm(ctx context.Context, orderCommID int64) (orderComm *OrderComm, err error) {
-	orderComm, err = FindOrderCommCall(GetQueryer, ctx, orderCommID, false)
+//FindOutboxAckCall will find exs_outbox_ack by id from database
+func FindOutboxAck(ctx context.Context, outboxAckID int64) (outboxAck *OutboxAck, err error) {
+	outboxAck, err = FindOutboxAckCall(GetQueryer, ctx, outboxAckID, false)
 	return
 }
 
-//FindOrderCommCall will find exs_order_comm by id from database
-func FindOrderCommCall(caller interface{}, ctx context.Context, orderCommID int64, lock bool) (orderComm *OrderComm, err error) {
-	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", orderCommID)
-	orderComm, err = FindOrderCommWhereCall(caller, ctx, lock, "and", where, args)
+//FindOutboxAckCall will find exs_outbox_ack by id from database
+func FindOutboxAckCall(caller interface{}, ctx context.Context, outboxAckID int64, lock bool) (outboxAck *OutboxAck, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", outboxAckID)
+	outboxAck, err = FindOutboxAckWhereCall(caller, ctx, lock, "and", where, args)
 	return
 }
 
-//FindOrderCommWhereCall will find exs_order_comm by where from database
-func FindOrderCommWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (orderComm *OrderComm, err error) {
-	querySQL := crud.QuerySQL(&OrderComm{}, "#all")
+//FindOutboxAckWhereCall will find exs_outbox_ack by where from database
+func FindOutboxAckWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (outboxAck *OutboxAck, err error) {
+	querySQL := crud.QuerySQL(&OutboxAck{}, "#all")
 	querySQL = crud.JoinWhere(querySQL, where, join)
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &OrderComm{}, "#all", querySQL, args, &orderComm)
+	err = crud.QueryRow(caller, ctx, &OutboxAck{}, "#all", querySQL, args, &outboxAck)
 	return
 }
 
-//FindOrderCommWheref will find exs_order_comm by where from database
-func FindOrderCommWheref(ctx context.Context, format string, args ...interface{}) (orderComm *OrderComm, err error) {
-	orderComm, err = FindOrderCommWherefCall(GetQueryer, ctx, false, format, args...)
+//FindOutboxAckWheref will find exs_outbox_ack by where from database
+func FindOutboxAckWheref(ctx context.Context, format string, args ...interface{}) (outboxAck *OutboxAck, err error) {
+	outboxAck, err = FindOutboxAckWherefCall(GetQueryer, ctx, false, format, args...)
 	return
 }
 
-//FindOrderCommWherefCall will find exs_order_comm by where from database
-func FindOrderCommWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (orderComm *OrderComm, err error) {
-	orderComm, err = FindOrderCommFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+//FindOutboxAckWherefCall will find exs_outbox_ack by where from database
+func FindOutboxAckWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (outboxAck *OutboxAck, err error) {
+	outboxAck, err = FindOutboxAckFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
 	return
 }
 
-//FindOrderCommFilterWheref will find exs_order_comm by where from database
-func FindOrderCommFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (orderComm *OrderComm, err error) {
-	orderComm, err = FindOrderCommFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+//FindOutboxAckFilterWheref will find exs_outbox_ack by where from database
+func FindOutboxAckFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (outboxAck *OutboxAck, err error) {
+	outboxAck, err = FindOutboxAckFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
 	return
 }
 
-//FindOrderCommFilterWherefCall will find exs_order_comm by where from database
-func FindOrderCommFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (orderComm *OrderComm, err error) {
-	querySQL := crud.QuerySQL(&OrderComm{}, filter)
+//FindOutboxAckFilterWherefCall will find exs_outbox_ack by where from database
+func FindOutboxAckFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (outboxAck *OutboxAck, err error) {
+	querySQL := crud.QuerySQL(&OutboxAck{}, filter)
 	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
 	querySQL = crud.JoinWhere(querySQL, where, "and")
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &OrderComm{}, filter, querySQL, queryArgs, &orderComm)
+	err = crud.QueryRow(caller, ctx, &OutboxAck{}, filter, querySQL, queryArgs, &outboxAck)
 	return
 }
 
-//ListOrderCommByID will list exs_order_comm by id from database
-func ListOrderCommByID(ctx context.Context, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
-	orderCommList, orderCommMap, err = ListOrderCommByIDCall(GetQueryer, ctx, orderCommIDs...)
+//ListOutboxAckByID will list exs_outbox_ack by id from database
+func ListOutboxAckByID(ctx context.Context, outboxAckIDs ...int64) (outboxAckList []*OutboxAck, outboxAckMap map[int64]*OutboxAck, err error) {
+	outboxAckList, outboxAckMap, err = ListOutboxAckByIDCall(GetQueryer, ctx, outboxAckIDs...)
 	return
 }
 
-//ListOrderCommByIDCall will list exs_order_comm by id from database
-func ListOrderCommByIDCall(caller interface{}, ctx context.Context, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
-	if len(orderCommIDs) < 1 {
-		orderCommMap = map[int64]*OrderComm{}
+//ListOutboxAckByIDCall will list exs_outbox_ack by id from database
+func ListOutboxAckByIDCall(caller interface{}, ctx context.Context, outboxAckIDs ...int64) (outboxAckList []*OutboxAck, outboxAckMap map[int64]*OutboxAck, err error) {
+	if len(outboxAckIDs) < 1 {
+		outboxAckMap = map[int64]*OutboxAck{}
 		return
 	}
-	err = ScanOrderCommByIDCall(caller, ctx, orderCommIDs, &orderCommList, &orderCommMap, "tid")
+	err = ScanOutboxAckByIDCall(caller, ctx, outboxAckIDs, &outboxAckList, &outboxAckMap, "tid")
 	return
 }
 
-//ListOrderCommFilterByID will list exs_order_comm by id from database
-func ListOrderCommFilterByID(ctx context.Context, filter string, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
-	orderCommList, orderCommMap, err = ListOrderCommFilterByIDCall(GetQueryer, ctx, filter, orderCommIDs...)
+//ListOutboxAckFilterByID will list exs_outbox_ack by id from database
+func ListOutboxAckFilterByID(ctx context.Context, filter string, outboxAckIDs ...int64) (outboxAckList []*OutboxAck, outboxAckMap map[int64]*OutboxAck, err error) {
+	outboxAckList, outboxAckMap, err = ListOutboxAckFilterByIDCall(GetQueryer, ctx, filter, outboxAckIDs...)
 	return
 }
 
-//ListOrderCommFilterByIDCall will list exs_order_comm by id from database
-func ListOrderCommFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderCommIDs ...int64) (orderCommList []*OrderComm, orderCommMap map[int64]*OrderComm, err error) {
-	if len(orderCommIDs) < 1 {
-		orderCommMap = map[int64]*OrderComm{}
+//ListOutboxAckFilterByIDCall will list exs_outbox_ack by id from database
+func ListOutboxAckFilterByIDCall(caller interface{}, ctx context.Context, filter string, outboxAckIDs ...int64) (outboxAckList []*OutboxAck, outboxAckMap map[int64]*OutboxAck, err error) {
+	if len(outboxAckIDs) < 1 {
+		outboxAckMap = map[int64]*OutboxAck{}
 		return
 	}
-	err = ScanOrderCommFilterByIDCall(caller, ctx, filter, orderCommIDs, &orderCommList, &orderCommMap, "tid")
+	err = ScanOutboxAckFilterByIDCall(caller, ctx, filter, outboxAckIDs, &outboxAckList, &outboxAckMap, "tid")
 	return
 }
 
-//ScanOrderCommByID will list exs_order_comm by id from database
-func ScanOrderCommByID(ctx context.Context, orderCommIDs []int64, dest ...interface{}) (err error) {
-	err = ScanOrderCommByIDCall(GetQueryer, ctx, orderCommIDs, dest...)
+//ScanOutboxAckByID will list exs_outbox_ack by id from database
+func ScanOutboxAckByID(ctx context.Context, outboxAckIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOutboxAckByIDCall(GetQueryer, ctx, outboxAckIDs, dest...)
 	return
 }
 
-//ScanOrderCommByIDCall will list exs_order_comm by id from database
-func ScanOrderCommByIDCall(caller interface{}, ctx context.Context, orderCommIDs []int64, dest ...interface{}) (err error) {
-	err = ScanOrderCommFilterByIDCall(caller, ctx, "#all", orderCommIDs, dest...)
+//ScanOutboxAckByIDCall will list exs_outbox_ack by id from database
+func ScanOutboxAckByIDCall(caller interface{}, ctx context.Context, outboxAckIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOutboxAckFilterByIDCall(caller, ctx, "#all", outboxAckIDs, dest...)
 	return
 }
 
-//ScanOrderCommFilterByID will list exs_order_comm by id from database
-func ScanOrderCommFilterByID(ctx context.Context, filter string, orderCommIDs []int64, dest ...interface{}) (err error) {
-	err = ScanOrderCommFilterByIDCall(GetQueryer, ctx, filter, orderCommIDs, dest...)
+//ScanOutboxAckFilterByID will list exs_outbox_ack by id from database
+func ScanOutboxAckFilterByID(ctx context.Context, filter string, outboxAckIDs []int64, dest ...interface{}) (err error) {
+	err = ScanOutboxAckFilterByIDCall(GetQueryer, ctx, filter, outboxAckIDs, dest...)
 	return
 }
 
-//ScanOrderCommFilterByIDCall will list exs_order_comm by id from database
-func ScanOrderCommFilterByIDCall(caller interface{}, ctx context.Context, filter string, orderCommIDs []int64, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&OrderComm{}, filter)
-	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(orderCommIDs).InArray()))
+//ScanOutboxAckFilterByIDCall will list exs_outbox_ack by id from database
+func ScanOutboxAckFilterByIDCall(caller interface{}, ctx context.Context, filter string, outboxAckIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&OutboxAck{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(outboxAckIDs).InArray()))
 	querySQL = crud.JoinWhere(querySQL, where, " and ")
-	err = crud.Query(caller, ctx, &OrderComm{}, filter, querySQL, nil, dest...)
+	err = crud.Query(caller, ctx, &OutboxAck{}, filter, querySQL, nil, dest...)
 	return
 }
 
-//ScanOrderCommWherefCall will list exs_order_comm by format from database
-func ScanOrderCommWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanOrderCommWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+//ScanOutboxAckWherefCall will list exs_outbox_ack by format from database
+func ScanOutboxAckWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOutboxAckWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
 	return
 }
 
-//ScanOrderCommWherefCall will list exs_order_comm by format from database
-func ScanOrderCommWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanOrderCommFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+//ScanOutboxAckWherefCall will list exs_outbox_ack by format from database
+func ScanOutboxAckWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOutboxAckFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
 	return
 }
 
-//ScanOrderCommFilterWheref will list exs_order_comm by format from database
-func ScanOrderCommFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	err = ScanOrderCommFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+//ScanOutboxAckFilterWheref will list exs_outbox_ack by format from database
+func ScanOutboxAckFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanOutboxAckFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
 	return
 }
 
-//ScanOrderCommFilterWherefCall will list exs_order_comm by format from database
-func ScanOrderCommFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
-	querySQL := crud.QuerySQL(&OrderComm{}, filter)
+//ScanOutboxAckFilterWherefCall will list exs_outbox_ack by format from database
+func ScanOutboxAckFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&OutboxAck{}, filter)
 	var where []string
 	if len(format) > 0 {
 		where, args = crud.AppendWheref(nil, nil, format, args...)
 	}
 	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
-	err = crud.Query(caller, ctx, &OrderComm{}, filter, querySQL, args, dest...)
+	err = crud.Query(caller, ctx, &OutboxAck{}, filter, querySQL, args, dest...)
 	return
 }
 
@@ -2878,3 +4610,323 @@ func ScanWithdrawFilterWherefCall(caller interface{}, ctx context.Context, filte
 	err = crud.Query(caller, ctx, &Withdraw{}, filter, querySQL, args, dest...)
 	return
 }
+
+//WithdrawAddressFilterOptional is crud filter
+const WithdrawAddressFilterOptional = ""
+
+//WithdrawAddressFilterRequired is crud filter
+const WithdrawAddressFilterRequired = ""
+
+//WithdrawAddressFilterInsert is crud filter
+const WithdrawAddressFilterInsert = ""
+
+//WithdrawAddressFilterUpdate is crud filter
+const WithdrawAddressFilterUpdate = "update_time"
+
+//WithdrawAddressFilterFind is crud filter
+const WithdrawAddressFilterFind = "#all"
+
+//WithdrawAddressFilterScan is crud filter
+const WithdrawAddressFilterScan = "#all"
+
+//EnumValid will valid value by WithdrawAddressStatus
+func (o *WithdrawAddressStatus) EnumValid(v interface{}) (err error) {
+	var target WithdrawAddressStatus
+	targetType := reflect.TypeOf(WithdrawAddressStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(WithdrawAddressStatus)
+	}
+	for _, value := range WithdrawAddressStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", WithdrawAddressStatusAll)
+}
+
+//EnumValid will valid value by WithdrawAddressStatusArray
+func (o *WithdrawAddressStatusArray) EnumValid(v interface{}) (err error) {
+	var target WithdrawAddressStatus
+	targetType := reflect.TypeOf(WithdrawAddressStatus(0))
+	targetValue := reflect.ValueOf(v)
+	if targetValue.CanConvert(targetType) {
+		target = targetValue.Convert(targetType).Interface().(WithdrawAddressStatus)
+	}
+	for _, value := range WithdrawAddressStatusAll {
+		if target == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be in %v", WithdrawAddressStatusAll)
+}
+
+//DbArray will join value to database array
+func (o WithdrawAddressStatusArray) DbArray() (res string) {
+	res = "{" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + "}"
+	return
+}
+
+//InArray will join value to database array
+func (o WithdrawAddressStatusArray) InArray() (res string) {
+	res = "" + converter.JoinSafe(o, ",", converter.JoinPolicyDefault) + ""
+	return
+}
+
+//MetaWithWithdrawAddress will return exs_withdraw_address meta data
+func MetaWithWithdrawAddress(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_withdraw_address"), fields...)
+	return
+}
+
+//MetaWith will return exs_withdraw_address meta data
+func (withdrawAddress *WithdrawAddress) MetaWith(fields ...interface{}) (v []interface{}) {
+	v = crud.MetaWith(string("exs_withdraw_address"), fields...)
+	return
+}
+
+//Meta will return exs_withdraw_address meta data
+func (withdrawAddress *WithdrawAddress) Meta() (table string, fileds []string) {
+	table, fileds = crud.QueryField(withdrawAddress, "#all")
+	return
+}
+
+//Valid will valid by filter
+func (withdrawAddress *WithdrawAddress) Valid() (err error) {
+	if reflect.ValueOf(withdrawAddress.TID).IsZero() {
+		err = attrvalid.Valid(withdrawAddress, WithdrawAddressFilterInsert+"#all", WithdrawAddressFilterOptional)
+	} else {
+		err = attrvalid.Valid(withdrawAddress, WithdrawAddressFilterUpdate, "")
+	}
+	return
+}
+
+//Insert will add exs_withdraw_address to database
+func (withdrawAddress *WithdrawAddress) Insert(caller interface{}, ctx context.Context) (err error) {
+
+	if withdrawAddress.UpdateTime.Timestamp() < 1 {
+		withdrawAddress.UpdateTime = xsql.TimeNow()
+	}
+
+	if withdrawAddress.CreateTime.Timestamp() < 1 {
+		withdrawAddress.CreateTime = xsql.TimeNow()
+	}
+
+	_, err = crud.InsertFilter(caller, ctx, withdrawAddress, "^tid#all", "returning", "tid#all")
+	return
+}
+
+//UpdateFilter will update exs_withdraw_address to database
+func (withdrawAddress *WithdrawAddress) UpdateFilter(caller interface{}, ctx context.Context, filter string) (err error) {
+	err = withdrawAddress.UpdateFilterWheref(caller, ctx, filter, "")
+	return
+}
+
+//UpdateWheref will update exs_withdraw_address to database
+func (withdrawAddress *WithdrawAddress) UpdateWheref(caller interface{}, ctx context.Context, formats string, formatArgs ...interface{}) (err error) {
+	err = withdrawAddress.UpdateFilterWheref(caller, ctx, WithdrawAddressFilterUpdate, formats, formatArgs...)
+	return
+}
+
+//UpdateFilterWheref will update exs_withdraw_address to database
+func (withdrawAddress *WithdrawAddress) UpdateFilterWheref(caller interface{}, ctx context.Context, filter string, formats string, formatArgs ...interface{}) (err error) {
+	withdrawAddress.UpdateTime = xsql.TimeNow()
+	sql, args := crud.UpdateSQL(withdrawAddress, filter, nil)
+	where, args := crud.AppendWheref(nil, args, "tid=$%v", withdrawAddress.TID)
+	if len(formats) > 0 {
+		where, args = crud.AppendWheref(where, args, formats, formatArgs...)
+	}
+	err = crud.UpdateRow(caller, ctx, withdrawAddress, sql, where, "and", args)
+	return
+}
+
+//AddWithdrawAddress will add exs_withdraw_address to database
+func AddWithdrawAddress(ctx context.Context, withdrawAddress *WithdrawAddress) (err error) {
+	err = AddWithdrawAddressCall(GetQueryer, ctx, withdrawAddress)
+	return
+}
+
+//AddWithdrawAddress will add exs_withdraw_address to database
+func AddWithdrawAddressCall(caller interface{}, ctx context.Context, withdrawAddress *WithdrawAddress) (err error) {
+	err = withdrawAddress.Insert(caller, ctx)
+	return
+}
+
+//UpdateWithdrawAddressFilter will update exs_withdraw_address to database
+func UpdateWithdrawAddressFilter(ctx context.Context, withdrawAddress *WithdrawAddress, filter string) (err error) {
+	err = UpdateWithdrawAddressFilterCall(GetQueryer, ctx, withdrawAddress, filter)
+	return
+}
+
+//UpdateWithdrawAddressFilterCall will update exs_withdraw_address to database
+func UpdateWithdrawAddressFilterCall(caller interface{}, ctx context.Context, withdrawAddress *WithdrawAddress, filter string) (err error) {
+	err = withdrawAddress.UpdateFilter(caller, ctx, filter)
+	return
+}
+
+//UpdateWithdrawAddressWheref will update exs_withdraw_address to database
+func UpdateWithdrawAddressWheref(ctx context.Context, withdrawAddress *WithdrawAddress, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateWithdrawAddressWherefCall(GetQueryer, ctx, withdrawAddress, formats, formatArgs...)
+	return
+}
+
+//UpdateWithdrawAddressWherefCall will update exs_withdraw_address to database
+func UpdateWithdrawAddressWherefCall(caller interface{}, ctx context.Context, withdrawAddress *WithdrawAddress, formats string, formatArgs ...interface{}) (err error) {
+	err = withdrawAddress.UpdateWheref(caller, ctx, formats, formatArgs...)
+	return
+}
+
+//UpdateWithdrawAddressFilterWheref will update exs_withdraw_address to database
+func UpdateWithdrawAddressFilterWheref(ctx context.Context, withdrawAddress *WithdrawAddress, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = UpdateWithdrawAddressFilterWherefCall(GetQueryer, ctx, withdrawAddress, filter, formats, formatArgs...)
+	return
+}
+
+//UpdateWithdrawAddressFilterWherefCall will update exs_withdraw_address to database
+func UpdateWithdrawAddressFilterWherefCall(caller interface{}, ctx context.Context, withdrawAddress *WithdrawAddress, filter string, formats string, formatArgs ...interface{}) (err error) {
+	err = withdrawAddress.UpdateFilterWheref(caller, ctx, filter, formats, formatArgs...)
+	return
+}
+
+//FindWithdrawAddressCall will find exs_withdraw_address by id from database
+func FindWithdrawAddress(ctx context.Context, withdrawAddressID int64) (withdrawAddress *WithdrawAddress, err error) {
+	withdrawAddress, err = FindWithdrawAddressCall(GetQueryer, ctx, withdrawAddressID, false)
+	return
+}
+
+//FindWithdrawAddressCall will find exs_withdraw_address by id from database
+func FindWithdrawAddressCall(caller interface{}, ctx context.Context, withdrawAddressID int64, lock bool) (withdrawAddress *WithdrawAddress, err error) {
+	where, args := crud.AppendWhere(nil, nil, true, "tid=$%v", withdrawAddressID)
+	withdrawAddress, err = FindWithdrawAddressWhereCall(caller, ctx, lock, "and", where, args)
+	return
+}
+
+//FindWithdrawAddressWhereCall will find exs_withdraw_address by where from database
+func FindWithdrawAddressWhereCall(caller interface{}, ctx context.Context, lock bool, join string, where []string, args []interface{}) (withdrawAddress *WithdrawAddress, err error) {
+	querySQL := crud.QuerySQL(&WithdrawAddress{}, "#all")
+	querySQL = crud.JoinWhere(querySQL, where, join)
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &WithdrawAddress{}, "#all", querySQL, args, &withdrawAddress)
+	return
+}
+
+//FindWithdrawAddressWheref will find exs_withdraw_address by where from database
+func FindWithdrawAddressWheref(ctx context.Context, format string, args ...interface{}) (withdrawAddress *WithdrawAddress, err error) {
+	withdrawAddress, err = FindWithdrawAddressWherefCall(GetQueryer, ctx, false, format, args...)
+	return
+}
+
+//FindWithdrawAddressWherefCall will find exs_withdraw_address by where from database
+func FindWithdrawAddressWherefCall(caller interface{}, ctx context.Context, lock bool, format string, args ...interface{}) (withdrawAddress *WithdrawAddress, err error) {
+	withdrawAddress, err = FindWithdrawAddressFilterWherefCall(GetQueryer, ctx, lock, "#all", format, args...)
+	return
+}
+
+//FindWithdrawAddressFilterWheref will find exs_withdraw_address by where from database
+func FindWithdrawAddressFilterWheref(ctx context.Context, filter string, format string, args ...interface{}) (withdrawAddress *WithdrawAddress, err error) {
+	withdrawAddress, err = FindWithdrawAddressFilterWherefCall(GetQueryer, ctx, false, filter, format, args...)
+	return
+}
+
+//FindWithdrawAddressFilterWherefCall will find exs_withdraw_address by where from database
+func FindWithdrawAddressFilterWherefCall(caller interface{}, ctx context.Context, lock bool, filter string, format string, args ...interface{}) (withdrawAddress *WithdrawAddress, err error) {
+	querySQL := crud.QuerySQL(&WithdrawAddress{}, filter)
+	where, queryArgs := crud.AppendWheref(nil, nil, format, args...)
+	querySQL = crud.JoinWhere(querySQL, where, "and")
+	if lock {
+		querySQL += " for update "
+	}
+	err = crud.QueryRow(caller, ctx, &WithdrawAddress{}, filter, querySQL, queryArgs, &withdrawAddress)
+	return
+}
+
+//ListWithdrawAddressByID will list exs_withdraw_address by id from database
+func ListWithdrawAddressByID(ctx context.Context, withdrawAddressIDs ...int64) (withdrawAddressList []*WithdrawAddress, withdrawAddressMap map[int64]*WithdrawAddress, err error) {
+	withdrawAddressList, withdrawAddressMap, err = ListWithdrawAddressByIDCall(GetQueryer, ctx, withdrawAddressIDs...)
+	return
+}
+
+//ListWithdrawAddressByIDCall will list exs_withdraw_address by id from database
+func ListWithdrawAddressByIDCall(caller interface{}, ctx context.Context, withdrawAddressIDs ...int64) (withdrawAddressList []*WithdrawAddress, withdrawAddressMap map[int64]*WithdrawAddress, err error) {
+	if len(withdrawAddressIDs) < 1 {
+		withdrawAddressMap = map[int64]*WithdrawAddress{}
+		return
+	}
+	err = ScanWithdrawAddressByIDCall(caller, ctx, withdrawAddressIDs, &withdrawAddressList, &withdrawAddressMap, "tid")
+	return
+}
+
+//ListWithdrawAddressFilterByID will list exs_withdraw_address by id from database
+func ListWithdrawAddressFilterByID(ctx context.Context, filter string, withdrawAddressIDs ...int64) (withdrawAddressList []*WithdrawAddress, withdrawAddressMap map[int64]*WithdrawAddress, err error) {
+	withdrawAddressList, withdrawAddressMap, err = ListWithdrawAddressFilterByIDCall(GetQueryer, ctx, filter, withdrawAddressIDs...)
+	return
+}
+
+//ListWithdrawAddressFilterByIDCall will list exs_withdraw_address by id from database
+func ListWithdrawAddressFilterByIDCall(caller interface{}, ctx context.Context, filter string, withdrawAddressIDs ...int64) (withdrawAddressList []*WithdrawAddress, withdrawAddressMap map[int64]*WithdrawAddress, err error) {
+	if len(withdrawAddressIDs) < 1 {
+		withdrawAddressMap = map[int64]*WithdrawAddress{}
+		return
+	}
+	err = ScanWithdrawAddressFilterByIDCall(caller, ctx, filter, withdrawAddressIDs, &withdrawAddressList, &withdrawAddressMap, "tid")
+	return
+}
+
+//ScanWithdrawAddressByID will list exs_withdraw_address by id from database
+func ScanWithdrawAddressByID(ctx context.Context, withdrawAddressIDs []int64, dest ...interface{}) (err error) {
+	err = ScanWithdrawAddressByIDCall(GetQueryer, ctx, withdrawAddressIDs, dest...)
+	return
+}
+
+//ScanWithdrawAddressByIDCall will list exs_withdraw_address by id from database
+func ScanWithdrawAddressByIDCall(caller interface{}, ctx context.Context, withdrawAddressIDs []int64, dest ...interface{}) (err error) {
+	err = ScanWithdrawAddressFilterByIDCall(caller, ctx, "#all", withdrawAddressIDs, dest...)
+	return
+}
+
+//ScanWithdrawAddressFilterByID will list exs_withdraw_address by id from database
+func ScanWithdrawAddressFilterByID(ctx context.Context, filter string, withdrawAddressIDs []int64, dest ...interface{}) (err error) {
+	err = ScanWithdrawAddressFilterByIDCall(GetQueryer, ctx, filter, withdrawAddressIDs, dest...)
+	return
+}
+
+//ScanWithdrawAddressFilterByIDCall will list exs_withdraw_address by id from database
+func ScanWithdrawAddressFilterByIDCall(caller interface{}, ctx context.Context, filter string, withdrawAddressIDs []int64, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&WithdrawAddress{}, filter)
+	where := append([]string{}, fmt.Sprintf("tid in (%v)", xsql.Int64Array(withdrawAddressIDs).InArray()))
+	querySQL = crud.JoinWhere(querySQL, where, " and ")
+	err = crud.Query(caller, ctx, &WithdrawAddress{}, filter, querySQL, nil, dest...)
+	return
+}
+
+//ScanWithdrawAddressWherefCall will list exs_withdraw_address by format from database
+func ScanWithdrawAddressWheref(ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanWithdrawAddressWherefCall(GetQueryer, ctx, format, args, suffix, dest...)
+	return
+}
+
+//ScanWithdrawAddressWherefCall will list exs_withdraw_address by format from database
+func ScanWithdrawAddressWherefCall(caller interface{}, ctx context.Context, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanWithdrawAddressFilterWherefCall(caller, ctx, "#all", format, args, suffix, dest...)
+	return
+}
+
+//ScanWithdrawAddressFilterWheref will list exs_withdraw_address by format from database
+func ScanWithdrawAddressFilterWheref(ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	err = ScanWithdrawAddressFilterWherefCall(GetQueryer, ctx, filter, format, args, suffix, dest...)
+	return
+}
+
+//ScanWithdrawAddressFilterWherefCall will list exs_withdraw_address by format from database
+func ScanWithdrawAddressFilterWherefCall(caller interface{}, ctx context.Context, filter string, format string, args []interface{}, suffix string, dest ...interface{}) (err error) {
+	querySQL := crud.QuerySQL(&WithdrawAddress{}, filter)
+	var where []string
+	if len(format) > 0 {
+		where, args = crud.AppendWheref(nil, nil, format, args...)
+	}
+	querySQL = crud.JoinWhere(querySQL, where, " and ", suffix)
+	err = crud.Query(caller, ctx, &WithdrawAddress{}, filter, querySQL, args, dest...)
+	return
+}
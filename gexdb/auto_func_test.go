@@ -11,6 +11,248 @@ import (
 	"github.com/codingeasygo/crud"
 )
 
+func TestAutoAsset(t *testing.T) {
+	var err error
+	for _, value := range AssetDepositAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if AssetDepositAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if AssetDepositAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(AssetDepositAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(AssetDepositAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	for _, value := range AssetWithdrawAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if AssetWithdrawAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if AssetWithdrawAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(AssetWithdrawAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(AssetWithdrawAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	for _, value := range AssetStatusAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if AssetStatusAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if AssetStatusAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(AssetStatusAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(AssetStatusAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	metav := MetaWithAsset()
+	if len(metav) < 1 {
+		t.Error("not meta")
+		return
+	}
+	asset := &Asset{}
+	asset.Valid()
+
+	table, fields := asset.Meta()
+	if len(table) < 1 || len(fields) < 1 {
+		t.Error("not meta")
+		return
+	}
+	fmt.Println(table, "---->", strings.Join(fields, ","))
+	if table := crud.Table(asset.MetaWith(int64(0))); len(table) < 1 {
+		t.Error("not table")
+		return
+	}
+	err = AddAsset(context.Background(), asset)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if reflect.ValueOf(asset.TID).IsZero() {
+		t.Error("not id")
+		return
+	}
+	asset.Valid()
+	err = UpdateAssetFilter(context.Background(), asset, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateAssetWheref(context.Background(), asset, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateAssetFilterWheref(context.Background(), asset, AssetFilterUpdate, "tid=$%v", asset.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	findAsset, err := FindAsset(context.Background(), asset.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if asset.TID != findAsset.TID {
+		t.Error("find id error")
+		return
+	}
+	findAsset, err = FindAssetWheref(context.Background(), "tid=$%v", asset.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if asset.TID != findAsset.TID {
+		t.Error("find id error")
+		return
+	}
+	findAsset, err = FindAssetFilterWheref(context.Background(), "#all", "tid=$%v", asset.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if asset.TID != findAsset.TID {
+		t.Error("find id error")
+		return
+	}
+	findAsset, err = FindAssetWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{asset.TID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if asset.TID != findAsset.TID {
+		t.Error("find id error")
+		return
+	}
+	findAsset, err = FindAssetWherefCall(GetQueryer, context.Background(), true, "tid=$%v", asset.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if asset.TID != findAsset.TID {
+		t.Error("find id error")
+		return
+	}
+	assetList, assetMap, err := ListAssetByID(context.Background())
+	if err != nil || len(assetList) > 0 || assetMap == nil || len(assetMap) > 0 {
+		t.Error(err)
+		return
+	}
+	assetList, assetMap, err = ListAssetByID(context.Background(), asset.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(assetList) != 1 || assetList[0].TID != asset.TID || len(assetMap) != 1 || assetMap[asset.TID] == nil || assetMap[asset.TID].TID != asset.TID {
+		t.Error("list id error")
+		return
+	}
+	assetList, assetMap, err = ListAssetFilterByID(context.Background(), "#all")
+	if err != nil || len(assetList) > 0 || assetMap == nil || len(assetMap) > 0 {
+		t.Error(err)
+		return
+	}
+	assetList, assetMap, err = ListAssetFilterByID(context.Background(), "#all", asset.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(assetList) != 1 || assetList[0].TID != asset.TID || len(assetMap) != 1 || assetMap[asset.TID] == nil || assetMap[asset.TID].TID != asset.TID {
+		t.Error("list id error")
+		return
+	}
+	assetList = nil
+	assetMap = nil
+	err = ScanAssetByID(context.Background(), []int64{asset.TID}, &assetList, &assetMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(assetList) != 1 || assetList[0].TID != asset.TID || len(assetMap) != 1 || assetMap[asset.TID] == nil || assetMap[asset.TID].TID != asset.TID {
+		t.Error("list id error")
+		return
+	}
+	assetList = nil
+	assetMap = nil
+	err = ScanAssetFilterByID(context.Background(), "#all", []int64{asset.TID}, &assetList, &assetMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(assetList) != 1 || assetList[0].TID != asset.TID || len(assetMap) != 1 || assetMap[asset.TID] == nil || assetMap[asset.TID].TID != asset.TID {
+		t.Error("list id error")
+		return
+	}
+	assetList = nil
+	assetMap = nil
+	err = ScanAssetWheref(context.Background(), "tid=$%v", []interface{}{asset.TID}, "", &assetList, &assetMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(assetList) != 1 || assetList[0].TID != asset.TID || len(assetMap) != 1 || assetMap[asset.TID] == nil || assetMap[asset.TID].TID != asset.TID {
+		t.Error("list id error")
+		return
+	}
+	assetList = nil
+	assetMap = nil
+	err = ScanAssetFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{asset.TID}, "", &assetList, &assetMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(assetList) != 1 || assetList[0].TID != asset.TID || len(assetMap) != 1 || assetMap[asset.TID] == nil || assetMap[asset.TID].TID != asset.TID {
+		t.Error("list id error")
+		return
+	}
+}
+
 func TestAutoBalance(t *testing.T) {
 	var err error
 	for _, value := range BalanceAreaAll {
@@ -417,9 +659,9 @@ func TestAutoBalanceHistory(t *testing.T) {
 	}
 }
 
-func TestAutoHolding(t *testing.T) {
+func TestAutoBalanceRecord(t *testing.T) {
 	var err error
-	for _, value := range HoldingStatusAll {
+	for _, value := range BalanceRecordTypeAll {
 		if value.EnumValid(int(value)) != nil {
 			t.Error("not enum valid")
 			return
@@ -428,352 +670,404 @@ func TestAutoHolding(t *testing.T) {
 			t.Error("not enum valid")
 			return
 		}
-		if HoldingStatusAll.EnumValid(int(value)) != nil {
+		if BalanceRecordTypeAll.EnumValid(int(value)) != nil {
 			t.Error("not enum valid")
 			return
 		}
-		if HoldingStatusAll.EnumValid(int(-321654)) == nil {
+		if BalanceRecordTypeAll.EnumValid(int(-321654)) == nil {
 			t.Error("not enum valid")
 			return
 		}
 	}
-	if len(HoldingStatusAll.DbArray()) < 1 {
+	if len(BalanceRecordTypeAll.DbArray()) < 1 {
 		t.Error("not array")
 		return
 	}
-	if len(HoldingStatusAll.InArray()) < 1 {
+	if len(BalanceRecordTypeAll.InArray()) < 1 {
 		t.Error("not array")
 		return
 	}
-	metav := MetaWithHolding()
+	for _, value := range BalanceRecordStatusAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if BalanceRecordStatusAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if BalanceRecordStatusAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(BalanceRecordStatusAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(BalanceRecordStatusAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	metav := MetaWithBalanceRecord()
 	if len(metav) < 1 {
 		t.Error("not meta")
 		return
 	}
-	holding := &Holding{}
-	holding.Valid()
+	balanceRecord := &BalanceRecord{}
+	balanceRecord.Valid()
 
-	table, fields := holding.Meta()
+	table, fields := balanceRecord.Meta()
 	if len(table) < 1 || len(fields) < 1 {
 		t.Error("not meta")
 		return
 	}
 	fmt.Println(table, "---->", strings.Join(fields, ","))
-	if table := crud.Table(holding.MetaWith(int64(0))); len(table) < 1 {
+	if table := crud.Table(balanceRecord.MetaWith(int64(0))); len(table) < 1 {
 		t.Error("not table")
 		return
 	}
-	err = holding.Insert(GetQueryer, context.Background())
+	err = AddBalanceRecord(context.Background(), balanceRecord)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if reflect.ValueOf(holding.TID).IsZero() {
+	if reflect.ValueOf(balanceRecord.TID).IsZero() {
 		t.Error("not id")
 		return
 	}
-	holding.Valid()
-	err = UpdateHoldingFilter(context.Background(), holding, "")
+	balanceRecord.Valid()
+	err = UpdateBalanceRecordFilter(context.Background(), balanceRecord, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateHoldingWheref(context.Background(), holding, "")
+	err = UpdateBalanceRecordWheref(context.Background(), balanceRecord, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateHoldingFilterWheref(context.Background(), holding, HoldingFilterUpdate, "tid=$%v", holding.TID)
+	err = UpdateBalanceRecordFilterWheref(context.Background(), balanceRecord, BalanceRecordFilterUpdate, "tid=$%v", balanceRecord.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	findHolding, err := FindHolding(context.Background(), holding.TID)
+	findBalanceRecord, err := FindBalanceRecord(context.Background(), balanceRecord.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if holding.TID != findHolding.TID {
+	if balanceRecord.TID != findBalanceRecord.TID {
 		t.Error("find id error")
 		return
 	}
-	findHolding, err = FindHoldingWheref(context.Background(), "tid=$%v", holding.TID)
+	findBalanceRecord, err = FindBalanceRecordWheref(context.Background(), "tid=$%v", balanceRecord.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if holding.TID != findHolding.TID {
+	if balanceRecord.TID != findBalanceRecord.TID {
 		t.Error("find id error")
 		return
 	}
-	findHolding, err = FindHoldingFilterWheref(context.Background(), "#all", "tid=$%v", holding.TID)
+	findBalanceRecord, err = FindBalanceRecordFilterWheref(context.Background(), "#all", "tid=$%v", balanceRecord.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if holding.TID != findHolding.TID {
+	if balanceRecord.TID != findBalanceRecord.TID {
 		t.Error("find id error")
 		return
 	}
-	findHolding, err = FindHoldingWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{holding.TID})
+	findBalanceRecord, err = FindBalanceRecordWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{balanceRecord.TID})
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if holding.TID != findHolding.TID {
+	if balanceRecord.TID != findBalanceRecord.TID {
 		t.Error("find id error")
 		return
 	}
-	findHolding, err = FindHoldingWherefCall(GetQueryer, context.Background(), true, "tid=$%v", holding.TID)
+	findBalanceRecord, err = FindBalanceRecordWherefCall(GetQueryer, context.Background(), true, "tid=$%v", balanceRecord.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if holding.TID != findHolding.TID {
+	if balanceRecord.TID != findBalanceRecord.TID {
 		t.Error("find id error")
 		return
 	}
-	holdingList, holdingMap, err := ListHoldingByID(context.Background())
-	if err != nil || len(holdingList) > 0 || holdingMap == nil || len(holdingMap) > 0 {
+	balanceRecordList, balanceRecordMap, err := ListBalanceRecordByID(context.Background())
+	if err != nil || len(balanceRecordList) > 0 || balanceRecordMap == nil || len(balanceRecordMap) > 0 {
 		t.Error(err)
 		return
 	}
-	holdingList, holdingMap, err = ListHoldingByID(context.Background(), holding.TID)
+	balanceRecordList, balanceRecordMap, err = ListBalanceRecordByID(context.Background(), balanceRecord.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+	if len(balanceRecordList) != 1 || balanceRecordList[0].TID != balanceRecord.TID || len(balanceRecordMap) != 1 || balanceRecordMap[balanceRecord.TID] == nil || balanceRecordMap[balanceRecord.TID].TID != balanceRecord.TID {
 		t.Error("list id error")
 		return
 	}
-	holdingList, holdingMap, err = ListHoldingFilterByID(context.Background(), "#all")
-	if err != nil || len(holdingList) > 0 || holdingMap == nil || len(holdingMap) > 0 {
+	balanceRecordList, balanceRecordMap, err = ListBalanceRecordFilterByID(context.Background(), "#all")
+	if err != nil || len(balanceRecordList) > 0 || balanceRecordMap == nil || len(balanceRecordMap) > 0 {
 		t.Error(err)
 		return
 	}
-	holdingList, holdingMap, err = ListHoldingFilterByID(context.Background(), "#all", holding.TID)
+	balanceRecordList, balanceRecordMap, err = ListBalanceRecordFilterByID(context.Background(), "#all", balanceRecord.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+	if len(balanceRecordList) != 1 || balanceRecordList[0].TID != balanceRecord.TID || len(balanceRecordMap) != 1 || balanceRecordMap[balanceRecord.TID] == nil || balanceRecordMap[balanceRecord.TID].TID != balanceRecord.TID {
 		t.Error("list id error")
 		return
 	}
-	holdingList = nil
-	holdingMap = nil
-	err = ScanHoldingByID(context.Background(), []int64{holding.TID}, &holdingList, &holdingMap, "tid")
+	balanceRecordList = nil
+	balanceRecordMap = nil
+	err = ScanBalanceRecordByID(context.Background(), []int64{balanceRecord.TID}, &balanceRecordList, &balanceRecordMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+	if len(balanceRecordList) != 1 || balanceRecordList[0].TID != balanceRecord.TID || len(balanceRecordMap) != 1 || balanceRecordMap[balanceRecord.TID] == nil || balanceRecordMap[balanceRecord.TID].TID != balanceRecord.TID {
 		t.Error("list id error")
 		return
 	}
-	holdingList = nil
-	holdingMap = nil
-	err = ScanHoldingFilterByID(context.Background(), "#all", []int64{holding.TID}, &holdingList, &holdingMap, "tid")
+	balanceRecordList = nil
+	balanceRecordMap = nil
+	err = ScanBalanceRecordFilterByID(context.Background(), "#all", []int64{balanceRecord.TID}, &balanceRecordList, &balanceRecordMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+	if len(balanceRecordList) != 1 || balanceRecordList[0].TID != balanceRecord.TID || len(balanceRecordMap) != 1 || balanceRecordMap[balanceRecord.TID] == nil || balanceRecordMap[balanceRecord.TID].TID != balanceRecord.TID {
 		t.Error("list id error")
 		return
 	}
-	holdingList = nil
-	holdingMap = nil
-	err = ScanHoldingWheref(context.Background(), "tid=$%v", []interface{}{holding.TID}, "", &holdingList, &holdingMap, "tid")
+	balanceRecordList = nil
+	balanceRecordMap = nil
+	err = ScanBalanceRecordWheref(context.Background(), "tid=$%v", []interface{}{balanceRecord.TID}, "", &balanceRecordList, &balanceRecordMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+	if len(balanceRecordList) != 1 || balanceRecordList[0].TID != balanceRecord.TID || len(balanceRecordMap) != 1 || balanceRecordMap[balanceRecord.TID] == nil || balanceRecordMap[balanceRecord.TID].TID != balanceRecord.TID {
 		t.Error("list id error")
 		return
 	}
-	holdingList = nil
-	holdingMap = nil
-	err = ScanHoldingFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{holding.TID}, "", &holdingList, &holdingMap, "tid")
+	balanceRecordList = nil
+	balanceRecordMap = nil
+	err = ScanBalanceRecordFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{balanceRecord.TID}, "", &balanceRecordList, &balanceRecordMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+	if len(balanceRecordList) != 1 || balanceRecordList[0].TID != balanceRecord.TID || len(balanceRecordMap) != 1 || balanceRecordMap[balanceRecord.TID] == nil || balanceRecordMap[balanceRecord.TID].TID != balanceRecord.TID {
 		t.Error("list id error")
 		return
 	}
 }
 
-func TestAutoKLine(t *testing.T) {
+func TestAutoBalanceTransfer(t *testing.T) {
 	var err error
-	metav := MetaWithKLine()
-	if len(metav) < 1 {
+	for _, value := range BalanceTransferStatusAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if BalanceTransferStatusAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if BalanceTransferStatusAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(BalanceTransferStatusAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(BalanceTransferStatusAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	metav := MetaWithBalanceTransfer()
+	if len(metav) < 1 {
 		t.Error("not meta")
 		return
 	}
-	kLine := &KLine{}
-	kLine.Valid()
+	balanceTransfer := &BalanceTransfer{}
+	balanceTransfer.Valid()
 
-	table, fields := kLine.Meta()
+	table, fields := balanceTransfer.Meta()
 	if len(table) < 1 || len(fields) < 1 {
 		t.Error("not meta")
 		return
 	}
 	fmt.Println(table, "---->", strings.Join(fields, ","))
-	if table := crud.Table(kLine.MetaWith(int64(0))); len(table) < 1 {
+	if table := crud.Table(balanceTransfer.MetaWith(int64(0))); len(table) < 1 {
 		t.Error("not table")
 		return
 	}
-	err = AddKLine(context.Background(), kLine)
+	err = AddBalanceTransfer(context.Background(), balanceTransfer)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if reflect.ValueOf(kLine.TID).IsZero() {
+	if reflect.ValueOf(balanceTransfer.TID).IsZero() {
 		t.Error("not id")
 		return
 	}
-	kLine.Valid()
-	err = UpdateKLineFilter(context.Background(), kLine, "")
+	balanceTransfer.Valid()
+	err = UpdateBalanceTransferFilter(context.Background(), balanceTransfer, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateKLineWheref(context.Background(), kLine, "")
+	err = UpdateBalanceTransferWheref(context.Background(), balanceTransfer, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateKLineFilterWheref(context.Background(), kLine, KLineFilterUpdate, "tid=$%v", kLine.TID)
+	err = UpdateBalanceTransferFilterWheref(context.Background(), balanceTransfer, BalanceTransferFilterUpdate, "tid=$%v", balanceTransfer.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	findKLine, err := FindKLine(context.Background(), kLine.TID)
+	findBalanceTransfer, err := FindBalanceTransfer(context.Background(), balanceTransfer.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if kLine.TID != findKLine.TID {
+	if balanceTransfer.TID != findBalanceTransfer.TID {
 		t.Error("find id error")
 		return
 	}
-	findKLine, err = FindKLineWheref(context.Background(), "tid=$%v", kLine.TID)
+	findBalanceTransfer, err = FindBalanceTransferWheref(context.Background(), "tid=$%v", balanceTransfer.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if kLine.TID != findKLine.TID {
+	if balanceTransfer.TID != findBalanceTransfer.TID {
 		t.Error("find id error")
 		return
 	}
-	findKLine, err = FindKLineFilterWheref(context.Background(), "#all", "tid=$%v", kLine.TID)
+	findBalanceTransfer, err = FindBalanceTransferFilterWheref(context.Background(), "#all", "tid=$%v", balanceTransfer.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if kLine.TID != findKLine.TID {
+	if balanceTransfer.TID != findBalanceTransfer.TID {
 		t.Error("find id error")
 		return
 	}
-	findKLine, err = FindKLineWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{kLine.TID})
+	findBalanceTransfer, err = FindBalanceTransferWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{balanceTransfer.TID})
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if kLine.TID != findKLine.TID {
+	if balanceTransfer.TID != findBalanceTransfer.TID {
 		t.Error("find id error")
 		return
 	}
-	findKLine, err = FindKLineWherefCall(GetQueryer, context.Background(), true, "tid=$%v", kLine.TID)
+	findBalanceTransfer, err = FindBalanceTransferWherefCall(GetQueryer, context.Background(), true, "tid=$%v", balanceTransfer.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if kLine.TID != findKLine.TID {
+	if balanceTransfer.TID != findBalanceTransfer.TID {
 		t.Error("find id error")
 		return
 	}
-	kLineList, kLineMap, err := ListKLineByID(context.Background())
-	if err != nil || len(kLineList) > 0 || kLineMap == nil || len(kLineMap) > 0 {
+	balanceTransferList, balanceTransferMap, err := ListBalanceTransferByID(context.Background())
+	if err != nil || len(balanceTransferList) > 0 || balanceTransferMap == nil || len(balanceTransferMap) > 0 {
 		t.Error(err)
 		return
 	}
-	kLineList, kLineMap, err = ListKLineByID(context.Background(), kLine.TID)
+	balanceTransferList, balanceTransferMap, err = ListBalanceTransferByID(context.Background(), balanceTransfer.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+	if len(balanceTransferList) != 1 || balanceTransferList[0].TID != balanceTransfer.TID || len(balanceTransferMap) != 1 || balanceTransferMap[balanceTransfer.TID] == nil || balanceTransferMap[balanceTransfer.TID].TID != balanceTransfer.TID {
 		t.Error("list id error")
 		return
 	}
-	kLineList, kLineMap, err = ListKLineFilterByID(context.Background(), "#all")
-	if err != nil || len(kLineList) > 0 || kLineMap == nil || len(kLineMap) > 0 {
+	balanceTransferList, balanceTransferMap, err = ListBalanceTransferFilterByID(context.Background(), "#all")
+	if err != nil || len(balanceTransferList) > 0 || balanceTransferMap == nil || len(balanceTransferMap) > 0 {
 		t.Error(err)
 		return
 	}
-	kLineList, kLineMap, err = ListKLineFilterByID(context.Background(), "#all", kLine.TID)
+	balanceTransferList, balanceTransferMap, err = ListBalanceTransferFilterByID(context.Background(), "#all", balanceTransfer.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+	if len(balanceTransferList) != 1 || balanceTransferList[0].TID != balanceTransfer.TID || len(balanceTransferMap) != 1 || balanceTransferMap[balanceTransfer.TID] == nil || balanceTransferMap[balanceTransfer.TID].TID != balanceTransfer.TID {
 		t.Error("list id error")
 		return
 	}
-	kLineList = nil
-	kLineMap = nil
-	err = ScanKLineByID(context.Background(), []int64{kLine.TID}, &kLineList, &kLineMap, "tid")
+	balanceTransferList = nil
+	balanceTransferMap = nil
+	err = ScanBalanceTransferByID(context.Background(), []int64{balanceTransfer.TID}, &balanceTransferList, &balanceTransferMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+	if len(balanceTransferList) != 1 || balanceTransferList[0].TID != balanceTransfer.TID || len(balanceTransferMap) != 1 || balanceTransferMap[balanceTransfer.TID] == nil || balanceTransferMap[balanceTransfer.TID].TID != balanceTransfer.TID {
 		t.Error("list id error")
 		return
 	}
-	kLineList = nil
-	kLineMap = nil
-	err = ScanKLineFilterByID(context.Background(), "#all", []int64{kLine.TID}, &kLineList, &kLineMap, "tid")
+	balanceTransferList = nil
+	balanceTransferMap = nil
+	err = ScanBalanceTransferFilterByID(context.Background(), "#all", []int64{balanceTransfer.TID}, &balanceTransferList, &balanceTransferMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+	if len(balanceTransferList) != 1 || balanceTransferList[0].TID != balanceTransfer.TID || len(balanceTransferMap) != 1 || balanceTransferMap[balanceTransfer.TID] == nil || balanceTransferMap[balanceTransfer.TID].TID != balanceTransfer.TID {
 		t.Error("list id error")
 		return
 	}
-	kLineList = nil
-	kLineMap = nil
-	err = ScanKLineWheref(context.Background(), "tid=$%v", []interface{}{kLine.TID}, "", &kLineList, &kLineMap, "tid")
+	balanceTransferList = nil
+	balanceTransferMap = nil
+	err = ScanBalanceTransferWheref(context.Background(), "tid=$%v", []interface{}{balanceTransfer.TID}, "", &balanceTransferList, &balanceTransferMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+	if len(balanceTransferList) != 1 || balanceTransferList[0].TID != balanceTransfer.TID || len(balanceTransferMap) != 1 || balanceTransferMap[balanceTransfer.TID] == nil || balanceTransferMap[balanceTransfer.TID].TID != balanceTransfer.TID {
 		t.Error("list id error")
 		return
 	}
-	kLineList = nil
-	kLineMap = nil
-	err = ScanKLineFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{kLine.TID}, "", &kLineList, &kLineMap, "tid")
+	balanceTransferList = nil
+	balanceTransferMap = nil
+	err = ScanBalanceTransferFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{balanceTransfer.TID}, "", &balanceTransferList, &balanceTransferMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+	if len(balanceTransferList) != 1 || balanceTransferList[0].TID != balanceTransfer.TID || len(balanceTransferMap) != 1 || balanceTransferMap[balanceTransfer.TID] == nil || balanceTransferMap[balanceTransfer.TID].TID != balanceTransfer.TID {
 		t.Error("list id error")
 		return
 	}
 }
 
-func TestAutoOrder(t *testing.T) {
+func TestAutoHolding(t *testing.T) {
 	var err error
-	for _, value := range OrderTypeAll {
+	for _, value := range HoldingStatusAll {
 		if value.EnumValid(int(value)) != nil {
 			t.Error("not enum valid")
 			return
@@ -782,292 +1076,1026 @@ func TestAutoOrder(t *testing.T) {
 			t.Error("not enum valid")
 			return
 		}
-		if OrderTypeAll.EnumValid(int(value)) != nil {
+		if HoldingStatusAll.EnumValid(int(value)) != nil {
 			t.Error("not enum valid")
 			return
 		}
-		if OrderTypeAll.EnumValid(int(-321654)) == nil {
+		if HoldingStatusAll.EnumValid(int(-321654)) == nil {
 			t.Error("not enum valid")
 			return
 		}
 	}
-	if len(OrderTypeAll.DbArray()) < 1 {
+	if len(HoldingStatusAll.DbArray()) < 1 {
 		t.Error("not array")
 		return
 	}
-	if len(OrderTypeAll.InArray()) < 1 {
+	if len(HoldingStatusAll.InArray()) < 1 {
 		t.Error("not array")
 		return
 	}
-	for _, value := range OrderSideAll {
-		if value.EnumValid(string(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if value.EnumValid(string("this should invalid")) == nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderSideAll.EnumValid(string(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderSideAll.EnumValid(string("this should invalid")) == nil {
-			t.Error("not enum valid")
-			return
-		}
+	metav := MetaWithHolding()
+	if len(metav) < 1 {
+		t.Error("not meta")
+		return
 	}
-	if len(OrderSideAll.DbArray()) < 1 {
-		t.Error("not array")
+	holding := &Holding{}
+	holding.Valid()
+
+	table, fields := holding.Meta()
+	if len(table) < 1 || len(fields) < 1 {
+		t.Error("not meta")
 		return
 	}
-	if len(OrderSideAll.InArray()) < 1 {
-		t.Error("not array")
+	fmt.Println(table, "---->", strings.Join(fields, ","))
+	if table := crud.Table(holding.MetaWith(int64(0))); len(table) < 1 {
+		t.Error("not table")
 		return
 	}
-	for _, value := range OrderTriggerTypeAll {
-		if value.EnumValid(int(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if value.EnumValid(int(-321654)) == nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderTriggerTypeAll.EnumValid(int(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderTriggerTypeAll.EnumValid(int(-321654)) == nil {
-			t.Error("not enum valid")
-			return
-		}
+	err = holding.Insert(GetQueryer, context.Background())
+	if err != nil {
+		t.Error(err)
+		return
 	}
-	if len(OrderTriggerTypeAll.DbArray()) < 1 {
-		t.Error("not array")
+	if reflect.ValueOf(holding.TID).IsZero() {
+		t.Error("not id")
 		return
 	}
-	if len(OrderTriggerTypeAll.InArray()) < 1 {
-		t.Error("not array")
+	holding.Valid()
+	err = UpdateHoldingFilter(context.Background(), holding, "")
+	if err != nil {
+		t.Error(err)
 		return
 	}
-	for _, value := range OrderStatusAll {
-		if value.EnumValid(int(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if value.EnumValid(int(-321654)) == nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderStatusAll.EnumValid(int(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderStatusAll.EnumValid(int(-321654)) == nil {
-			t.Error("not enum valid")
-			return
-		}
+	err = UpdateHoldingWheref(context.Background(), holding, "")
+	if err != nil {
+		t.Error(err)
+		return
 	}
-	if len(OrderStatusAll.DbArray()) < 1 {
-		t.Error("not array")
+	err = UpdateHoldingFilterWheref(context.Background(), holding, HoldingFilterUpdate, "tid=$%v", holding.TID)
+	if err != nil {
+		t.Error(err)
 		return
 	}
-	if len(OrderStatusAll.InArray()) < 1 {
-		t.Error("not array")
+	findHolding, err := FindHolding(context.Background(), holding.TID)
+	if err != nil {
+		t.Error(err)
 		return
 	}
-	metav := MetaWithOrder()
-	if len(metav) < 1 {
-		t.Error("not meta")
+	if holding.TID != findHolding.TID {
+		t.Error("find id error")
 		return
 	}
-	order := &Order{}
-	order.Valid()
-
-	table, fields := order.Meta()
-	if len(table) < 1 || len(fields) < 1 {
-		t.Error("not meta")
+	findHolding, err = FindHoldingWheref(context.Background(), "tid=$%v", holding.TID)
+	if err != nil {
+		t.Error(err)
 		return
 	}
-	fmt.Println(table, "---->", strings.Join(fields, ","))
-	if table := crud.Table(order.MetaWith(int64(0))); len(table) < 1 {
+	if holding.TID != findHolding.TID {
+		t.Error("find id error")
+		return
+	}
+	findHolding, err = FindHoldingFilterWheref(context.Background(), "#all", "tid=$%v", holding.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if holding.TID != findHolding.TID {
+		t.Error("find id error")
+		return
+	}
+	findHolding, err = FindHoldingWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{holding.TID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if holding.TID != findHolding.TID {
+		t.Error("find id error")
+		return
+	}
+	findHolding, err = FindHoldingWherefCall(GetQueryer, context.Background(), true, "tid=$%v", holding.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if holding.TID != findHolding.TID {
+		t.Error("find id error")
+		return
+	}
+	holdingList, holdingMap, err := ListHoldingByID(context.Background())
+	if err != nil || len(holdingList) > 0 || holdingMap == nil || len(holdingMap) > 0 {
+		t.Error(err)
+		return
+	}
+	holdingList, holdingMap, err = ListHoldingByID(context.Background(), holding.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+		t.Error("list id error")
+		return
+	}
+	holdingList, holdingMap, err = ListHoldingFilterByID(context.Background(), "#all")
+	if err != nil || len(holdingList) > 0 || holdingMap == nil || len(holdingMap) > 0 {
+		t.Error(err)
+		return
+	}
+	holdingList, holdingMap, err = ListHoldingFilterByID(context.Background(), "#all", holding.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+		t.Error("list id error")
+		return
+	}
+	holdingList = nil
+	holdingMap = nil
+	err = ScanHoldingByID(context.Background(), []int64{holding.TID}, &holdingList, &holdingMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+		t.Error("list id error")
+		return
+	}
+	holdingList = nil
+	holdingMap = nil
+	err = ScanHoldingFilterByID(context.Background(), "#all", []int64{holding.TID}, &holdingList, &holdingMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+		t.Error("list id error")
+		return
+	}
+	holdingList = nil
+	holdingMap = nil
+	err = ScanHoldingWheref(context.Background(), "tid=$%v", []interface{}{holding.TID}, "", &holdingList, &holdingMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+		t.Error("list id error")
+		return
+	}
+	holdingList = nil
+	holdingMap = nil
+	err = ScanHoldingFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{holding.TID}, "", &holdingList, &holdingMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(holdingList) != 1 || holdingList[0].TID != holding.TID || len(holdingMap) != 1 || holdingMap[holding.TID] == nil || holdingMap[holding.TID].TID != holding.TID {
+		t.Error("list id error")
+		return
+	}
+}
+
+func TestAutoKLine(t *testing.T) {
+	var err error
+	metav := MetaWithKLine()
+	if len(metav) < 1 {
+		t.Error("not meta")
+		return
+	}
+	kLine := &KLine{}
+	kLine.Valid()
+
+	table, fields := kLine.Meta()
+	if len(table) < 1 || len(fields) < 1 {
+		t.Error("not meta")
+		return
+	}
+	fmt.Println(table, "---->", strings.Join(fields, ","))
+	if table := crud.Table(kLine.MetaWith(int64(0))); len(table) < 1 {
+		t.Error("not table")
+		return
+	}
+	err = AddKLine(context.Background(), kLine)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if reflect.ValueOf(kLine.TID).IsZero() {
+		t.Error("not id")
+		return
+	}
+	kLine.Valid()
+	err = UpdateKLineFilter(context.Background(), kLine, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateKLineWheref(context.Background(), kLine, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateKLineFilterWheref(context.Background(), kLine, KLineFilterUpdate, "tid=$%v", kLine.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	findKLine, err := FindKLine(context.Background(), kLine.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if kLine.TID != findKLine.TID {
+		t.Error("find id error")
+		return
+	}
+	findKLine, err = FindKLineWheref(context.Background(), "tid=$%v", kLine.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if kLine.TID != findKLine.TID {
+		t.Error("find id error")
+		return
+	}
+	findKLine, err = FindKLineFilterWheref(context.Background(), "#all", "tid=$%v", kLine.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if kLine.TID != findKLine.TID {
+		t.Error("find id error")
+		return
+	}
+	findKLine, err = FindKLineWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{kLine.TID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if kLine.TID != findKLine.TID {
+		t.Error("find id error")
+		return
+	}
+	findKLine, err = FindKLineWherefCall(GetQueryer, context.Background(), true, "tid=$%v", kLine.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if kLine.TID != findKLine.TID {
+		t.Error("find id error")
+		return
+	}
+	kLineList, kLineMap, err := ListKLineByID(context.Background())
+	if err != nil || len(kLineList) > 0 || kLineMap == nil || len(kLineMap) > 0 {
+		t.Error(err)
+		return
+	}
+	kLineList, kLineMap, err = ListKLineByID(context.Background(), kLine.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+		t.Error("list id error")
+		return
+	}
+	kLineList, kLineMap, err = ListKLineFilterByID(context.Background(), "#all")
+	if err != nil || len(kLineList) > 0 || kLineMap == nil || len(kLineMap) > 0 {
+		t.Error(err)
+		return
+	}
+	kLineList, kLineMap, err = ListKLineFilterByID(context.Background(), "#all", kLine.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+		t.Error("list id error")
+		return
+	}
+	kLineList = nil
+	kLineMap = nil
+	err = ScanKLineByID(context.Background(), []int64{kLine.TID}, &kLineList, &kLineMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+		t.Error("list id error")
+		return
+	}
+	kLineList = nil
+	kLineMap = nil
+	err = ScanKLineFilterByID(context.Background(), "#all", []int64{kLine.TID}, &kLineList, &kLineMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+		t.Error("list id error")
+		return
+	}
+	kLineList = nil
+	kLineMap = nil
+	err = ScanKLineWheref(context.Background(), "tid=$%v", []interface{}{kLine.TID}, "", &kLineList, &kLineMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+		t.Error("list id error")
+		return
+	}
+	kLineList = nil
+	kLineMap = nil
+	err = ScanKLineFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{kLine.TID}, "", &kLineList, &kLineMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kLineList) != 1 || kLineList[0].TID != kLine.TID || len(kLineMap) != 1 || kLineMap[kLine.TID] == nil || kLineMap[kLine.TID].TID != kLine.TID {
+		t.Error("list id error")
+		return
+	}
+}
+
+func TestAutoOrder(t *testing.T) {
+	var err error
+	for _, value := range OrderTypeAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderTypeAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderTypeAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(OrderTypeAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(OrderTypeAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	for _, value := range OrderSideAll {
+		if value.EnumValid(string(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(string("this should invalid")) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderSideAll.EnumValid(string(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderSideAll.EnumValid(string("this should invalid")) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(OrderSideAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(OrderSideAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	for _, value := range OrderTriggerTypeAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderTriggerTypeAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderTriggerTypeAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(OrderTriggerTypeAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(OrderTriggerTypeAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	for _, value := range OrderStatusAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderStatusAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderStatusAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(OrderStatusAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(OrderStatusAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	metav := MetaWithOrder()
+	if len(metav) < 1 {
+		t.Error("not meta")
+		return
+	}
+	order := &Order{}
+	order.Valid()
+
+	table, fields := order.Meta()
+	if len(table) < 1 || len(fields) < 1 {
+		t.Error("not meta")
+		return
+	}
+	fmt.Println(table, "---->", strings.Join(fields, ","))
+	if table := crud.Table(order.MetaWith(int64(0))); len(table) < 1 {
+		t.Error("not table")
+		return
+	}
+	err = AddOrder(context.Background(), order)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if reflect.ValueOf(order.TID).IsZero() {
+		t.Error("not id")
+		return
+	}
+	order.Valid()
+	err = UpdateOrderFilter(context.Background(), order, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateOrderWheref(context.Background(), order, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateOrderFilterWheref(context.Background(), order, OrderFilterUpdate, "tid=$%v", order.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	findOrder, err := FindOrder(context.Background(), order.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if order.TID != findOrder.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrder, err = FindOrderWheref(context.Background(), "tid=$%v", order.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if order.TID != findOrder.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrder, err = FindOrderFilterWheref(context.Background(), "#all", "tid=$%v", order.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if order.TID != findOrder.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrder, err = FindOrderWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{order.TID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if order.TID != findOrder.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrder, err = FindOrderWherefCall(GetQueryer, context.Background(), true, "tid=$%v", order.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if order.TID != findOrder.TID {
+		t.Error("find id error")
+		return
+	}
+	orderList, orderMap, err := ListOrderByID(context.Background())
+	if err != nil || len(orderList) > 0 || orderMap == nil || len(orderMap) > 0 {
+		t.Error(err)
+		return
+	}
+	orderList, orderMap, err = ListOrderByID(context.Background(), order.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+		t.Error("list id error")
+		return
+	}
+	orderList, orderMap, err = ListOrderFilterByID(context.Background(), "#all")
+	if err != nil || len(orderList) > 0 || orderMap == nil || len(orderMap) > 0 {
+		t.Error(err)
+		return
+	}
+	orderList, orderMap, err = ListOrderFilterByID(context.Background(), "#all", order.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+		t.Error("list id error")
+		return
+	}
+	orderList = nil
+	orderMap = nil
+	err = ScanOrderByID(context.Background(), []int64{order.TID}, &orderList, &orderMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+		t.Error("list id error")
+		return
+	}
+	orderList = nil
+	orderMap = nil
+	err = ScanOrderFilterByID(context.Background(), "#all", []int64{order.TID}, &orderList, &orderMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+		t.Error("list id error")
+		return
+	}
+	orderList = nil
+	orderMap = nil
+	err = ScanOrderWheref(context.Background(), "tid=$%v", []interface{}{order.TID}, "", &orderList, &orderMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+		t.Error("list id error")
+		return
+	}
+	orderList = nil
+	orderMap = nil
+	err = ScanOrderFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{order.TID}, "", &orderList, &orderMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+		t.Error("list id error")
+		return
+	}
+}
+
+func TestAutoOrderComm(t *testing.T) {
+	var err error
+	for _, value := range OrderCommTypeAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderCommTypeAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderCommTypeAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(OrderCommTypeAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(OrderCommTypeAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	for _, value := range OrderCommStatusAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderCommStatusAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OrderCommStatusAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(OrderCommStatusAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(OrderCommStatusAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	metav := MetaWithOrderComm()
+	if len(metav) < 1 {
+		t.Error("not meta")
+		return
+	}
+	orderComm := &OrderComm{}
+	orderComm.Valid()
+
+	table, fields := orderComm.Meta()
+	if len(table) < 1 || len(fields) < 1 {
+		t.Error("not meta")
+		return
+	}
+	fmt.Println(table, "---->", strings.Join(fields, ","))
+	if table := crud.Table(orderComm.MetaWith(int64(0))); len(table) < 1 {
+		t.Error("not table")
+		return
+	}
+	err = AddOrderComm(context.Background(), orderComm)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if reflect.ValueOf(orderComm.TID).IsZero() {
+		t.Error("not id")
+		return
+	}
+	orderComm.Valid()
+	err = UpdateOrderCommFilter(context.Background(), orderComm, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateOrderCommWheref(context.Background(), orderComm, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateOrderCommFilterWheref(context.Background(), orderComm, OrderCommFilterUpdate, "tid=$%v", orderComm.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	findOrderComm, err := FindOrderComm(context.Background(), orderComm.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if orderComm.TID != findOrderComm.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrderComm, err = FindOrderCommWheref(context.Background(), "tid=$%v", orderComm.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if orderComm.TID != findOrderComm.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrderComm, err = FindOrderCommFilterWheref(context.Background(), "#all", "tid=$%v", orderComm.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if orderComm.TID != findOrderComm.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrderComm, err = FindOrderCommWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{orderComm.TID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if orderComm.TID != findOrderComm.TID {
+		t.Error("find id error")
+		return
+	}
+	findOrderComm, err = FindOrderCommWherefCall(GetQueryer, context.Background(), true, "tid=$%v", orderComm.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if orderComm.TID != findOrderComm.TID {
+		t.Error("find id error")
+		return
+	}
+	orderCommList, orderCommMap, err := ListOrderCommByID(context.Background())
+	if err != nil || len(orderCommList) > 0 || orderCommMap == nil || len(orderCommMap) > 0 {
+		t.Error(err)
+		return
+	}
+	orderCommList, orderCommMap, err = ListOrderCommByID(context.Background(), orderComm.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+		t.Error("list id error")
+		return
+	}
+	orderCommList, orderCommMap, err = ListOrderCommFilterByID(context.Background(), "#all")
+	if err != nil || len(orderCommList) > 0 || orderCommMap == nil || len(orderCommMap) > 0 {
+		t.Error(err)
+		return
+	}
+	orderCommList, orderCommMap, err = ListOrderCommFilterByID(context.Background(), "#all", orderComm.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+		t.Error("list id error")
+		return
+	}
+	orderCommList = nil
+	orderCommMap = nil
+	err = ScanOrderCommByID(context.Background(), []int64{orderComm.TID}, &orderCommList, &orderCommMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+		t.Error("list id error")
+		return
+	}
+	orderCommList = nil
+	orderCommMap = nil
+	err = ScanOrderCommFilterByID(context.Background(), "#all", []int64{orderComm.TID}, &orderCommList, &orderCommMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+		t.Error("list id error")
+		return
+	}
+	orderCommList = nil
+	orderCommMap = nil
+	err = ScanOrderCommWheref(context.Background(), "tid=$%v", []interface{}{orderComm.TID}, "", &orderCommList, &orderCommMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+		t.Error("list id error")
+		return
+	}
+	orderCommList = nil
+	orderCommMap = nil
+	err = ScanOrderCommFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{orderComm.TID}, "", &orderCommList, &orderCommMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+		t.Error("list id error")
+		return
+	}
+}
+
+func TestAutoOutbox(t *testing.T) {
+	var err error
+	for _, value := range OutboxStatusAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OutboxStatusAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if OutboxStatusAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(OutboxStatusAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(OutboxStatusAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	metav := MetaWithOutbox()
+	if len(metav) < 1 {
+		t.Error("not meta")
+		return
+	}
+	outbox := &Outbox{}
+	outbox.Valid()
+
+	table, fields := outbox.Meta()
+	if len(table) < 1 || len(fields) < 1 {
+		t.Error("not meta")
+		return
+	}
+	fmt.Println(table, "---->", strings.Join(fields, ","))
+	if table := crud.Table(outbox.MetaWith(int64(0))); len(table) < 1 {
 		t.Error("not table")
 		return
 	}
-	err = AddOrder(context.Background(), order)
+	err = AddOutbox(context.Background(), outbox)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if reflect.ValueOf(order.TID).IsZero() {
+	if reflect.ValueOf(outbox.TID).IsZero() {
 		t.Error("not id")
 		return
 	}
-	order.Valid()
-	err = UpdateOrderFilter(context.Background(), order, "")
+	outbox.Valid()
+	err = UpdateOutboxFilter(context.Background(), outbox, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateOrderWheref(context.Background(), order, "")
+	err = UpdateOutboxWheref(context.Background(), outbox, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateOrderFilterWheref(context.Background(), order, OrderFilterUpdate, "tid=$%v", order.TID)
+	err = UpdateOutboxFilterWheref(context.Background(), outbox, OutboxFilterUpdate, "tid=$%v", outbox.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	findOrder, err := FindOrder(context.Background(), order.TID)
+	findOutbox, err := FindOutbox(context.Background(), outbox.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if order.TID != findOrder.TID {
+	if outbox.TID != findOutbox.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrder, err = FindOrderWheref(context.Background(), "tid=$%v", order.TID)
+	findOutbox, err = FindOutboxWheref(context.Background(), "tid=$%v", outbox.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if order.TID != findOrder.TID {
+	if outbox.TID != findOutbox.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrder, err = FindOrderFilterWheref(context.Background(), "#all", "tid=$%v", order.TID)
+	findOutbox, err = FindOutboxFilterWheref(context.Background(), "#all", "tid=$%v", outbox.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if order.TID != findOrder.TID {
+	if outbox.TID != findOutbox.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrder, err = FindOrderWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{order.TID})
+	findOutbox, err = FindOutboxWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{outbox.TID})
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if order.TID != findOrder.TID {
+	if outbox.TID != findOutbox.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrder, err = FindOrderWherefCall(GetQueryer, context.Background(), true, "tid=$%v", order.TID)
+	findOutbox, err = FindOutboxWherefCall(GetQueryer, context.Background(), true, "tid=$%v", outbox.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if order.TID != findOrder.TID {
+	if outbox.TID != findOutbox.TID {
 		t.Error("find id error")
 		return
 	}
-	orderList, orderMap, err := ListOrderByID(context.Background())
-	if err != nil || len(orderList) > 0 || orderMap == nil || len(orderMap) > 0 {
+	outboxList, outboxMap, err := ListOutboxByID(context.Background())
+	if err != nil || len(outboxList) > 0 || outboxMap == nil || len(outboxMap) > 0 {
 		t.Error(err)
 		return
 	}
-	orderList, orderMap, err = ListOrderByID(context.Background(), order.TID)
+	outboxList, outboxMap, err = ListOutboxByID(context.Background(), outbox.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+	if len(outboxList) != 1 || outboxList[0].TID != outbox.TID || len(outboxMap) != 1 || outboxMap[outbox.TID] == nil || outboxMap[outbox.TID].TID != outbox.TID {
 		t.Error("list id error")
 		return
 	}
-	orderList, orderMap, err = ListOrderFilterByID(context.Background(), "#all")
-	if err != nil || len(orderList) > 0 || orderMap == nil || len(orderMap) > 0 {
+	outboxList, outboxMap, err = ListOutboxFilterByID(context.Background(), "#all")
+	if err != nil || len(outboxList) > 0 || outboxMap == nil || len(outboxMap) > 0 {
 		t.Error(err)
 		return
 	}
-	orderList, orderMap, err = ListOrderFilterByID(context.Background(), "#all", order.TID)
+	outboxList, outboxMap, err = ListOutboxFilterByID(context.Background(), "#all", outbox.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+	if len(outboxList) != 1 || outboxList[0].TID != outbox.TID || len(outboxMap) != 1 || outboxMap[outbox.TID] == nil || outboxMap[outbox.TID].TID != outbox.TID {
 		t.Error("list id error")
 		return
 	}
-	orderList = nil
-	orderMap = nil
-	err = ScanOrderByID(context.Background(), []int64{order.TID}, &orderList, &orderMap, "tid")
+	outboxList = nil
+	outboxMap = nil
+	err = ScanOutboxByID(context.Background(), []int64{outbox.TID}, &outboxList, &outboxMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+	if len(outboxList) != 1 || outboxList[0].TID != outbox.TID || len(outboxMap) != 1 || outboxMap[outbox.TID] == nil || outboxMap[outbox.TID].TID != outbox.TID {
 		t.Error("list id error")
 		return
 	}
-	orderList = nil
-	orderMap = nil
-	err = ScanOrderFilterByID(context.Background(), "#all", []int64{order.TID}, &orderList, &orderMap, "tid")
+	outboxList = nil
+	outboxMap = nil
+	err = ScanOutboxFilterByID(context.Background(), "#all", []int64{outbox.TID}, &outboxList, &outboxMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+	if len(outboxList) != 1 || outboxList[0].TID != outbox.TID || len(outboxMap) != 1 || outboxMap[outbox.TID] == nil || outboxMap[outbox.TID].TID != outbox.TID {
 		t.Error("list id error")
 		return
 	}
-	orderList = nil
-	orderMap = nil
-	err = ScanOrderWheref(context.Background(), "tid=$%v", []interface{}{order.TID}, "", &orderList, &orderMap, "tid")
+	outboxList = nil
+	outboxMap = nil
+	err = ScanOutboxWheref(context.Background(), "tid=$%v", []interface{}{outbox.TID}, "", &outboxList, &outboxMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+	if len(outboxList) != 1 || outboxList[0].TID != outbox.TID || len(outboxMap) != 1 || outboxMap[outbox.TID] == nil || outboxMap[outbox.TID].TID != outbox.TID {
 		t.Error("list id error")
 		return
 	}
-	orderList = nil
-	orderMap = nil
-	err = ScanOrderFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{order.TID}, "", &orderList, &orderMap, "tid")
+	outboxList = nil
+	outboxMap = nil
+	err = ScanOutboxFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{outbox.TID}, "", &outboxList, &outboxMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderList) != 1 || orderList[0].TID != order.TID || len(orderMap) != 1 || orderMap[order.TID] == nil || orderMap[order.TID].TID != order.TID {
+	if len(outboxList) != 1 || outboxList[0].TID != outbox.TID || len(outboxMap) != 1 || outboxMap[outbox.TID] == nil || outboxMap[outbox.TID].TID != outbox.TID {
 		t.Error("list id error")
 		return
 	}
 }
 
-func TestAutoOrderComm(t *testing.T) {
+func TestAutoOutboxAck(t *testing.T) {
 	var err error
-	for _, value := range OrderCommTypeAll {
-		if value.EnumValid(int(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if value.EnumValid(int(-321654)) == nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderCommTypeAll.EnumValid(int(value)) != nil {
-			t.Error("not enum valid")
-			return
-		}
-		if OrderCommTypeAll.EnumValid(int(-321654)) == nil {
-			t.Error("not enum valid")
-			return
-		}
-	}
-	if len(OrderCommTypeAll.DbArray()) < 1 {
-		t.Error("not array")
-		return
-	}
-	if len(OrderCommTypeAll.InArray()) < 1 {
-		t.Error("not array")
-		return
-	}
-	for _, value := range OrderCommStatusAll {
+	for _, value := range OutboxAckStatusAll {
 		if value.EnumValid(int(value)) != nil {
 			t.Error("not enum valid")
 			return
@@ -1076,180 +2104,180 @@ func TestAutoOrderComm(t *testing.T) {
 			t.Error("not enum valid")
 			return
 		}
-		if OrderCommStatusAll.EnumValid(int(value)) != nil {
+		if OutboxAckStatusAll.EnumValid(int(value)) != nil {
 			t.Error("not enum valid")
 			return
 		}
-		if OrderCommStatusAll.EnumValid(int(-321654)) == nil {
+		if OutboxAckStatusAll.EnumValid(int(-321654)) == nil {
 			t.Error("not enum valid")
 			return
 		}
 	}
-	if len(OrderCommStatusAll.DbArray()) < 1 {
+	if len(OutboxAckStatusAll.DbArray()) < 1 {
 		t.Error("not array")
 		return
 	}
-	if len(OrderCommStatusAll.InArray()) < 1 {
+	if len(OutboxAckStatusAll.InArray()) < 1 {
 		t.Error("not array")
 		return
 	}
-	metav := MetaWithOrderComm()
+	metav := MetaWithOutboxAck()
 	if len(metav) < 1 {
 		t.Error("not meta")
 		return
 	}
-	orderComm := &OrderComm{}
-	orderComm.Valid()
+	outboxAck := &OutboxAck{}
+	outboxAck.Valid()
 
-	table, fields := orderComm.Meta()
+	table, fields := outboxAck.Meta()
 	if len(table) < 1 || len(fields) < 1 {
 		t.Error("not meta")
 		return
 	}
 	fmt.Println(table, "---->", strings.Join(fields, ","))
-	if table := crud.Table(orderComm.MetaWith(int64(0))); len(table) < 1 {
+	if table := crud.Table(outboxAck.MetaWith(int64(0))); len(table) < 1 {
 		t.Error("not table")
 		return
 	}
-	err = AddOrderComm(context.Background(), orderComm)
+	err = AddOutboxAck(context.Background(), outboxAck)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if reflect.ValueOf(orderComm.TID).IsZero() {
+	if reflect.ValueOf(outboxAck.TID).IsZero() {
 		t.Error("not id")
 		return
 	}
-	orderComm.Valid()
-	err = UpdateOrderCommFilter(context.Background(), orderComm, "")
+	outboxAck.Valid()
+	err = UpdateOutboxAckFilter(context.Background(), outboxAck, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateOrderCommWheref(context.Background(), orderComm, "")
+	err = UpdateOutboxAckWheref(context.Background(), outboxAck, "")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	err = UpdateOrderCommFilterWheref(context.Background(), orderComm, OrderCommFilterUpdate, "tid=$%v", orderComm.TID)
+	err = UpdateOutboxAckFilterWheref(context.Background(), outboxAck, OutboxAckFilterUpdate, "tid=$%v", outboxAck.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	findOrderComm, err := FindOrderComm(context.Background(), orderComm.TID)
+	findOutboxAck, err := FindOutboxAck(context.Background(), outboxAck.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if orderComm.TID != findOrderComm.TID {
+	if outboxAck.TID != findOutboxAck.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrderComm, err = FindOrderCommWheref(context.Background(), "tid=$%v", orderComm.TID)
+	findOutboxAck, err = FindOutboxAckWheref(context.Background(), "tid=$%v", outboxAck.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if orderComm.TID != findOrderComm.TID {
+	if outboxAck.TID != findOutboxAck.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrderComm, err = FindOrderCommFilterWheref(context.Background(), "#all", "tid=$%v", orderComm.TID)
+	findOutboxAck, err = FindOutboxAckFilterWheref(context.Background(), "#all", "tid=$%v", outboxAck.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if orderComm.TID != findOrderComm.TID {
+	if outboxAck.TID != findOutboxAck.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrderComm, err = FindOrderCommWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{orderComm.TID})
+	findOutboxAck, err = FindOutboxAckWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{outboxAck.TID})
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if orderComm.TID != findOrderComm.TID {
+	if outboxAck.TID != findOutboxAck.TID {
 		t.Error("find id error")
 		return
 	}
-	findOrderComm, err = FindOrderCommWherefCall(GetQueryer, context.Background(), true, "tid=$%v", orderComm.TID)
+	findOutboxAck, err = FindOutboxAckWherefCall(GetQueryer, context.Background(), true, "tid=$%v", outboxAck.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if orderComm.TID != findOrderComm.TID {
+	if outboxAck.TID != findOutboxAck.TID {
 		t.Error("find id error")
 		return
 	}
-	orderCommList, orderCommMap, err := ListOrderCommByID(context.Background())
-	if err != nil || len(orderCommList) > 0 || orderCommMap == nil || len(orderCommMap) > 0 {
+	outboxAckList, outboxAckMap, err := ListOutboxAckByID(context.Background())
+	if err != nil || len(outboxAckList) > 0 || outboxAckMap == nil || len(outboxAckMap) > 0 {
 		t.Error(err)
 		return
 	}
-	orderCommList, orderCommMap, err = ListOrderCommByID(context.Background(), orderComm.TID)
+	outboxAckList, outboxAckMap, err = ListOutboxAckByID(context.Background(), outboxAck.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+	if len(outboxAckList) != 1 || outboxAckList[0].TID != outboxAck.TID || len(outboxAckMap) != 1 || outboxAckMap[outboxAck.TID] == nil || outboxAckMap[outboxAck.TID].TID != outboxAck.TID {
 		t.Error("list id error")
 		return
 	}
-	orderCommList, orderCommMap, err = ListOrderCommFilterByID(context.Background(), "#all")
-	if err != nil || len(orderCommList) > 0 || orderCommMap == nil || len(orderCommMap) > 0 {
+	outboxAckList, outboxAckMap, err = ListOutboxAckFilterByID(context.Background(), "#all")
+	if err != nil || len(outboxAckList) > 0 || outboxAckMap == nil || len(outboxAckMap) > 0 {
 		t.Error(err)
 		return
 	}
-	orderCommList, orderCommMap, err = ListOrderCommFilterByID(context.Background(), "#all", orderComm.TID)
+	outboxAckList, outboxAckMap, err = ListOutboxAckFilterByID(context.Background(), "#all", outboxAck.TID)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+	if len(outboxAckList) != 1 || outboxAckList[0].TID != outboxAck.TID || len(outboxAckMap) != 1 || outboxAckMap[outboxAck.TID] == nil || outboxAckMap[outboxAck.TID].TID != outboxAck.TID {
 		t.Error("list id error")
 		return
 	}
-	orderCommList = nil
-	orderCommMap = nil
-	err = ScanOrderCommByID(context.Background(), []int64{orderComm.TID}, &orderCommList, &orderCommMap, "tid")
+	outboxAckList = nil
+	outboxAckMap = nil
+	err = ScanOutboxAckByID(context.Background(), []int64{outboxAck.TID}, &outboxAckList, &outboxAckMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+	if len(outboxAckList) != 1 || outboxAckList[0].TID != outboxAck.TID || len(outboxAckMap) != 1 || outboxAckMap[outboxAck.TID] == nil || outboxAckMap[outboxAck.TID].TID != outboxAck.TID {
 		t.Error("list id error")
 		return
 	}
-	orderCommList = nil
-	orderCommMap = nil
-	err = ScanOrderCommFilterByID(context.Background(), "#all", []int64{orderComm.TID}, &orderCommList, &orderCommMap, "tid")
+	outboxAckList = nil
+	outboxAckMap = nil
+	err = ScanOutboxAckFilterByID(context.Background(), "#all", []int64{outboxAck.TID}, &outboxAckList, &outboxAckMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+	if len(outboxAckList) != 1 || outboxAckList[0].TID != outboxAck.TID || len(outboxAckMap) != 1 || outboxAckMap[outboxAck.TID] == nil || outboxAckMap[outboxAck.TID].TID != outboxAck.TID {
 		t.Error("list id error")
 		return
 	}
-	orderCommList = nil
-	orderCommMap = nil
-	err = ScanOrderCommWheref(context.Background(), "tid=$%v", []interface{}{orderComm.TID}, "", &orderCommList, &orderCommMap, "tid")
+	outboxAckList = nil
+	outboxAckMap = nil
+	err = ScanOutboxAckWheref(context.Background(), "tid=$%v", []interface{}{outboxAck.TID}, "", &outboxAckList, &outboxAckMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+	if len(outboxAckList) != 1 || outboxAckList[0].TID != outboxAck.TID || len(outboxAckMap) != 1 || outboxAckMap[outboxAck.TID] == nil || outboxAckMap[outboxAck.TID].TID != outboxAck.TID {
 		t.Error("list id error")
 		return
 	}
-	orderCommList = nil
-	orderCommMap = nil
-	err = ScanOrderCommFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{orderComm.TID}, "", &orderCommList, &orderCommMap, "tid")
+	outboxAckList = nil
+	outboxAckMap = nil
+	err = ScanOutboxAckFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{outboxAck.TID}, "", &outboxAckList, &outboxAckMap, "tid")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	if len(orderCommList) != 1 || orderCommList[0].TID != orderComm.TID || len(orderCommMap) != 1 || orderCommMap[orderComm.TID] == nil || orderCommMap[orderComm.TID].TID != orderComm.TID {
+	if len(outboxAckList) != 1 || outboxAckList[0].TID != outboxAck.TID || len(outboxAckMap) != 1 || outboxAckMap[outboxAck.TID] == nil || outboxAckMap[outboxAck.TID].TID != outboxAck.TID {
 		t.Error("list id error")
 		return
 	}
@@ -1712,3 +2740,193 @@ func TestAutoWithdraw(t *testing.T) {
 		return
 	}
 }
+
+func TestAutoWithdrawAddress(t *testing.T) {
+	var err error
+	for _, value := range WithdrawAddressStatusAll {
+		if value.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if value.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+		if WithdrawAddressStatusAll.EnumValid(int(value)) != nil {
+			t.Error("not enum valid")
+			return
+		}
+		if WithdrawAddressStatusAll.EnumValid(int(-321654)) == nil {
+			t.Error("not enum valid")
+			return
+		}
+	}
+	if len(WithdrawAddressStatusAll.DbArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	if len(WithdrawAddressStatusAll.InArray()) < 1 {
+		t.Error("not array")
+		return
+	}
+	metav := MetaWithWithdrawAddress()
+	if len(metav) < 1 {
+		t.Error("not meta")
+		return
+	}
+	withdrawAddress := &WithdrawAddress{}
+	withdrawAddress.Valid()
+
+	table, fields := withdrawAddress.Meta()
+	if len(table) < 1 || len(fields) < 1 {
+		t.Error("not meta")
+		return
+	}
+	fmt.Println(table, "---->", strings.Join(fields, ","))
+	if table := crud.Table(withdrawAddress.MetaWith(int64(0))); len(table) < 1 {
+		t.Error("not table")
+		return
+	}
+	err = AddWithdrawAddress(context.Background(), withdrawAddress)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if reflect.ValueOf(withdrawAddress.TID).IsZero() {
+		t.Error("not id")
+		return
+	}
+	withdrawAddress.Valid()
+	err = UpdateWithdrawAddressFilter(context.Background(), withdrawAddress, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateWithdrawAddressWheref(context.Background(), withdrawAddress, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateWithdrawAddressFilterWheref(context.Background(), withdrawAddress, WithdrawAddressFilterUpdate, "tid=$%v", withdrawAddress.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	findWithdrawAddress, err := FindWithdrawAddress(context.Background(), withdrawAddress.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if withdrawAddress.TID != findWithdrawAddress.TID {
+		t.Error("find id error")
+		return
+	}
+	findWithdrawAddress, err = FindWithdrawAddressWheref(context.Background(), "tid=$%v", withdrawAddress.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if withdrawAddress.TID != findWithdrawAddress.TID {
+		t.Error("find id error")
+		return
+	}
+	findWithdrawAddress, err = FindWithdrawAddressFilterWheref(context.Background(), "#all", "tid=$%v", withdrawAddress.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if withdrawAddress.TID != findWithdrawAddress.TID {
+		t.Error("find id error")
+		return
+	}
+	findWithdrawAddress, err = FindWithdrawAddressWhereCall(GetQueryer, context.Background(), true, "and", []string{"tid=$1"}, []interface{}{withdrawAddress.TID})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if withdrawAddress.TID != findWithdrawAddress.TID {
+		t.Error("find id error")
+		return
+	}
+	findWithdrawAddress, err = FindWithdrawAddressWherefCall(GetQueryer, context.Background(), true, "tid=$%v", withdrawAddress.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if withdrawAddress.TID != findWithdrawAddress.TID {
+		t.Error("find id error")
+		return
+	}
+	withdrawAddressList, withdrawAddressMap, err := ListWithdrawAddressByID(context.Background())
+	if err != nil || len(withdrawAddressList) > 0 || withdrawAddressMap == nil || len(withdrawAddressMap) > 0 {
+		t.Error(err)
+		return
+	}
+	withdrawAddressList, withdrawAddressMap, err = ListWithdrawAddressByID(context.Background(), withdrawAddress.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(withdrawAddressList) != 1 || withdrawAddressList[0].TID != withdrawAddress.TID || len(withdrawAddressMap) != 1 || withdrawAddressMap[withdrawAddress.TID] == nil || withdrawAddressMap[withdrawAddress.TID].TID != withdrawAddress.TID {
+		t.Error("list id error")
+		return
+	}
+	withdrawAddressList, withdrawAddressMap, err = ListWithdrawAddressFilterByID(context.Background(), "#all")
+	if err != nil || len(withdrawAddressList) > 0 || withdrawAddressMap == nil || len(withdrawAddressMap) > 0 {
+		t.Error(err)
+		return
+	}
+	withdrawAddressList, withdrawAddressMap, err = ListWithdrawAddressFilterByID(context.Background(), "#all", withdrawAddress.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(withdrawAddressList) != 1 || withdrawAddressList[0].TID != withdrawAddress.TID || len(withdrawAddressMap) != 1 || withdrawAddressMap[withdrawAddress.TID] == nil || withdrawAddressMap[withdrawAddress.TID].TID != withdrawAddress.TID {
+		t.Error("list id error")
+		return
+	}
+	withdrawAddressList = nil
+	withdrawAddressMap = nil
+	err = ScanWithdrawAddressByID(context.Background(), []int64{withdrawAddress.TID}, &withdrawAddressList, &withdrawAddressMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(withdrawAddressList) != 1 || withdrawAddressList[0].TID != withdrawAddress.TID || len(withdrawAddressMap) != 1 || withdrawAddressMap[withdrawAddress.TID] == nil || withdrawAddressMap[withdrawAddress.TID].TID != withdrawAddress.TID {
+		t.Error("list id error")
+		return
+	}
+	withdrawAddressList = nil
+	withdrawAddressMap = nil
+	err = ScanWithdrawAddressFilterByID(context.Background(), "#all", []int64{withdrawAddress.TID}, &withdrawAddressList, &withdrawAddressMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(withdrawAddressList) != 1 || withdrawAddressList[0].TID != withdrawAddress.TID || len(withdrawAddressMap) != 1 || withdrawAddressMap[withdrawAddress.TID] == nil || withdrawAddressMap[withdrawAddress.TID].TID != withdrawAddress.TID {
+		t.Error("list id error")
+		return
+	}
+	withdrawAddressList = nil
+	withdrawAddressMap = nil
+	err = ScanWithdrawAddressWheref(context.Background(), "tid=$%v", []interface{}{withdrawAddress.TID}, "", &withdrawAddressList, &withdrawAddressMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(withdrawAddressList) != 1 || withdrawAddressList[0].TID != withdrawAddress.TID || len(withdrawAddressMap) != 1 || withdrawAddressMap[withdrawAddress.TID] == nil || withdrawAddressMap[withdrawAddress.TID].TID != withdrawAddress.TID {
+		t.Error("list id error")
+		return
+	}
+	withdrawAddressList = nil
+	withdrawAddressMap = nil
+	err = ScanWithdrawAddressFilterWheref(context.Background(), "#all", "tid=$%v", []interface{}{withdrawAddress.TID}, "", &withdrawAddressList, &withdrawAddressMap, "tid")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(withdrawAddressList) != 1 || withdrawAddressList[0].TID != withdrawAddress.TID || len(withdrawAddressMap) != 1 || withdrawAddressMap[withdrawAddress.TID] == nil || withdrawAddressMap[withdrawAddress.TID].TID != withdrawAddress.TID {
+		t.Error("list id error")
+		return
+	}
+}
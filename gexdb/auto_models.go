@@ -6,6 +6,71 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+/***** metadata:Asset *****/
+type AssetDeposit int
+type AssetDepositArray []AssetDeposit
+
+const (
+	AssetDepositEnabled  AssetDeposit = 100 //is enabled
+	AssetDepositDisabled AssetDeposit = 200 //is disabled
+)
+
+//AssetDepositAll is the asset deposit status
+var AssetDepositAll = AssetDepositArray{AssetDepositEnabled, AssetDepositDisabled}
+
+//AssetDepositShow is the asset deposit status
+var AssetDepositShow = AssetDepositArray{AssetDepositEnabled, AssetDepositDisabled}
+
+type AssetWithdraw int
+type AssetWithdrawArray []AssetWithdraw
+
+const (
+	AssetWithdrawEnabled  AssetWithdraw = 100 //is enabled
+	AssetWithdrawDisabled AssetWithdraw = 200 //is disabled
+)
+
+//AssetWithdrawAll is the asset withdraw status
+var AssetWithdrawAll = AssetWithdrawArray{AssetWithdrawEnabled, AssetWithdrawDisabled}
+
+//AssetWithdrawShow is the asset withdraw status
+var AssetWithdrawShow = AssetWithdrawArray{AssetWithdrawEnabled, AssetWithdrawDisabled}
+
+type AssetStatus int
+type AssetStatusArray []AssetStatus
+
+const (
+	AssetStatusNormal AssetStatus = 100 //is normal
+	AssetStatusLocked AssetStatus = 200 //is locked
+)
+
+//AssetStatusAll is the asset status
+var AssetStatusAll = AssetStatusArray{AssetStatusNormal, AssetStatusLocked}
+
+//AssetStatusShow is the asset status
+var AssetStatusShow = AssetStatusArray{AssetStatusNormal, AssetStatusLocked}
+
+//AssetOrderbyAll is crud filter
+const AssetOrderbyAll = "asset,update_time,create_time"
+
+/*
+ * Asset  represents exs_asset
+ * Asset Fields:tid,asset,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,update_time,create_time,status,
+ */
+type Asset struct {
+	T                string          `json:"-" table:"exs_asset"`                                            /* the table name tag */
+	TID              int64           `json:"tid,omitempty" valid:"tid,r|i,r:0;"`                             /* the primary key */
+	Asset            string          `json:"asset,omitempty" valid:"asset,r|s,l:0;"`                         /* the asset key */
+	Name             string          `json:"name,omitempty" valid:"name,r|s,l:0;"`                           /* the asset display name */
+	DisplayPrecision int             `json:"display_precision,omitempty" valid:"display_precision,o|i,r:0;"` /* the asset display precision */
+	WithdrawMin      decimal.Decimal `json:"withdraw_min,omitempty" valid:"withdraw_min,o|f,r:0;"`           /* the asset minimum withdraw quantity */
+	WithdrawFee      decimal.Decimal `json:"withdraw_fee,omitempty" valid:"withdraw_fee,o|f,r:0;"`           /* the asset withdraw fee for each withdraw */
+	Deposit          AssetDeposit    `json:"deposit,omitempty" valid:"deposit,o|i,e:0;"`                     /* the asset deposit status, Enabled=100: is enabled, Disabled=200: is disabled */
+	Withdraw         AssetWithdraw   `json:"withdraw,omitempty" valid:"withdraw,o|i,e:0;"`                   /* the asset withdraw status, Enabled=100: is enabled, Disabled=200: is disabled */
+	UpdateTime       xsql.Time       `json:"update_time,omitempty" valid:"update_time,r|i,r:1;"`             /* the asset update time */
+	CreateTime       xsql.Time       `json:"create_time,omitempty" valid:"create_time,r|i,r:1;"`             /* the asset create time */
+	Status           AssetStatus     `json:"status,omitempty" valid:"status,o|i,e:0;"`                       /* the asset status, Normal=100: is normal, Locked=200: is locked */
+}
+
 /***** metadata:Balance *****/
 type BalanceArea int
 type BalanceAreaArray []BalanceArea
@@ -83,6 +148,102 @@ type BalanceHistory struct {
 	Status     BalanceHistoryStatus `json:"status,omitempty" valid:"status,r|i,e:0;"`           /* the balance record status, Normal=100: is normal status */
 }
 
+/***** metadata:BalanceRecord *****/
+type BalanceRecordType int
+type BalanceRecordTypeArray []BalanceRecordType
+
+const (
+	BalanceRecordTypeChange    BalanceRecordType = 100 //is manual change
+	BalanceRecordTypeWithdraw  BalanceRecordType = 110 //is withdraw
+	BalanceRecordTypeTransfer  BalanceRecordType = 120 //is transfer between area
+	BalanceRecordTypeTopup     BalanceRecordType = 130 //is topup
+	BalanceRecordTypeGoldbar   BalanceRecordType = 140 //is goldbar redemption
+	BalanceRecordTypeTradeLock BalanceRecordType = 200 //is lock by place order
+	BalanceRecordTypeTrade     BalanceRecordType = 210 //is trade filled or canceled
+	BalanceRecordTypeFee       BalanceRecordType = 220 //is trade or withdraw fee
+	BalanceRecordTypeMargin    BalanceRecordType = 300 //is margin add or free on futures
+	BalanceRecordTypeBlowup    BalanceRecordType = 310 //is blowup on futures
+)
+
+//BalanceRecordTypeAll is the balance record type
+var BalanceRecordTypeAll = BalanceRecordTypeArray{BalanceRecordTypeChange, BalanceRecordTypeWithdraw, BalanceRecordTypeTransfer, BalanceRecordTypeTopup, BalanceRecordTypeGoldbar, BalanceRecordTypeTradeLock, BalanceRecordTypeTrade, BalanceRecordTypeFee, BalanceRecordTypeMargin, BalanceRecordTypeBlowup}
+
+//BalanceRecordTypeShow is the balance record type
+var BalanceRecordTypeShow = BalanceRecordTypeArray{BalanceRecordTypeChange, BalanceRecordTypeWithdraw, BalanceRecordTypeTransfer, BalanceRecordTypeTopup, BalanceRecordTypeGoldbar, BalanceRecordTypeTradeLock, BalanceRecordTypeTrade, BalanceRecordTypeFee, BalanceRecordTypeMargin, BalanceRecordTypeBlowup}
+
+type BalanceRecordStatus int
+type BalanceRecordStatusArray []BalanceRecordStatus
+
+const (
+	BalanceRecordStatusNormal BalanceRecordStatus = 100 //is normal status
+)
+
+//BalanceRecordStatusAll is the balance record status
+var BalanceRecordStatusAll = BalanceRecordStatusArray{BalanceRecordStatusNormal}
+
+//BalanceRecordStatusShow is the balance record status
+var BalanceRecordStatusShow = BalanceRecordStatusArray{BalanceRecordStatusNormal}
+
+//BalanceRecordOrderbyAll is crud filter
+const BalanceRecordOrderbyAll = "update_time,create_time"
+
+/*
+ * BalanceRecord  represents exs_balance_record
+ * BalanceRecord Fields:tid,user_id,area,asset,type,ref_id,free_delta,locked_delta,margin_delta,free,locked,margin,update_time,create_time,status,
+ */
+type BalanceRecord struct {
+	T           string              `json:"-" table:"exs_balance_record"`                         /* the table name tag */
+	TID         int64               `json:"tid,omitempty" valid:"tid,r|i,r:0;"`                   /* the primary key */
+	UserID      int64               `json:"user_id,omitempty" valid:"user_id,r|i,r:0;"`           /* the balance user id */
+	Area        BalanceArea         `json:"area,omitempty" valid:"area,r|i,r:0;"`                 /* the balance area */
+	Asset       string              `json:"asset,omitempty" valid:"asset,r|s,l:0;"`               /* the balance asset key */
+	Type        BalanceRecordType   `json:"type,omitempty" valid:"type,r|i,e:0;"`                 /* the balance record type, Change=100:is manual change, Withdraw=110:is withdraw, Transfer=120:is transfer between area, Topup=130:is topup, Goldbar=140:is goldbar redemption, TradeLock=200:is lock by place order, Trade=210:is trade filled or canceled, Fee=220:is trade or withdraw fee, Margin=300:is margin add or free on futures, Blowup=310:is blowup on futures */
+	RefID       string              `json:"ref_id,omitempty" valid:"ref_id,r|s,l:0;"`             /* the balance record reference id, it is order id, withdraw order id, transfer id or holding id by type */
+	FreeDelta   decimal.Decimal     `json:"free_delta,omitempty" valid:"free_delta,r|f,r:0;"`     /* the balance free changed */
+	LockedDelta decimal.Decimal     `json:"locked_delta,omitempty" valid:"locked_delta,r|f,r:0;"` /* the balance locked changed */
+	MarginDelta decimal.Decimal     `json:"margin_delta,omitempty" valid:"margin_delta,r|f,r:0;"` /* the balance margin changed */
+	Free        decimal.Decimal     `json:"free,omitempty" valid:"free,r|f,r:0;"`                 /* the balance free after changed */
+	Locked      decimal.Decimal     `json:"locked,omitempty" valid:"locked,r|f,r:0;"`             /* the balance locked after changed */
+	Margin      decimal.Decimal     `json:"margin,omitempty" valid:"margin,r|f,r:0;"`             /* the balance margin after changed */
+	UpdateTime  xsql.Time           `json:"update_time,omitempty" valid:"update_time,r|i,r:1;"`   /* the balance record update time */
+	CreateTime  xsql.Time           `json:"create_time,omitempty" valid:"create_time,r|i,r:1;"`   /* the balance record create time */
+	Status      BalanceRecordStatus `json:"status,omitempty" valid:"status,r|i,e:0;"`             /* the balance record status, Normal=100: is normal status */
+}
+
+/***** metadata:BalanceTransfer *****/
+type BalanceTransferStatus int
+type BalanceTransferStatusArray []BalanceTransferStatus
+
+const (
+	BalanceTransferStatusNormal BalanceTransferStatus = 100 //is normal status
+)
+
+//BalanceTransferStatusAll is the transfer status
+var BalanceTransferStatusAll = BalanceTransferStatusArray{BalanceTransferStatusNormal}
+
+//BalanceTransferStatusShow is the transfer status
+var BalanceTransferStatusShow = BalanceTransferStatusArray{BalanceTransferStatusNormal}
+
+//BalanceTransferOrderbyAll is crud filter
+const BalanceTransferOrderbyAll = "update_time,create_time"
+
+/*
+ * BalanceTransfer  represents exs_balance_transfer
+ * BalanceTransfer Fields:tid,user_id,asset,from_area,to_area,quantity,update_time,create_time,status,
+ */
+type BalanceTransfer struct {
+	T          string                `json:"-" table:"exs_balance_transfer"`                     /* the table name tag */
+	TID        int64                 `json:"tid,omitempty" valid:"tid,r|i,r:0;"`                 /* the primary key */
+	UserID     int64                 `json:"user_id,omitempty" valid:"user_id,r|i,r:0;"`         /* the transfer user id */
+	Asset      string                `json:"asset,omitempty" valid:"asset,r|s,l:0;"`             /* the transfer asset key */
+	FromArea   BalanceArea           `json:"from_area,omitempty" valid:"from_area,r|i,r:0;"`     /* the transfer source balance area */
+	ToArea     BalanceArea           `json:"to_area,omitempty" valid:"to_area,r|i,r:0;"`         /* the transfer target balance area */
+	Quantity   decimal.Decimal       `json:"quantity,omitempty" valid:"quantity,r|f,r:0;"`       /* the transfer quantity */
+	UpdateTime xsql.Time             `json:"update_time,omitempty" valid:"update_time,r|i,r:1;"` /* the transfer update time */
+	CreateTime xsql.Time             `json:"create_time,omitempty" valid:"create_time,r|i,r:1;"` /* the transfer create time */
+	Status     BalanceTransferStatus `json:"status,omitempty" valid:"status,r|i,e:0;"`           /* the transfer status, Normal=100: is normal status */
+}
+
 /***** metadata:Holding *****/
 type HoldingStatus int
 type HoldingStatusArray []HoldingStatus
@@ -288,6 +449,64 @@ type OrderComm struct {
 	Status     OrderCommStatus `json:"status,omitempty" valid:"status,r|i,e:0;"`           /* the comm status, Normal=100:is normal */
 }
 
+/***** metadata:Outbox *****/
+type OutboxStatus int
+type OutboxStatusArray []OutboxStatus
+
+const (
+	OutboxStatusNormal OutboxStatus = 100 //is normal
+)
+
+//OutboxStatusAll is the outbox status
+var OutboxStatusAll = OutboxStatusArray{OutboxStatusNormal}
+
+//OutboxStatusShow is the outbox status
+var OutboxStatusShow = OutboxStatusArray{OutboxStatusNormal}
+
+/*
+ * Outbox  represents exs_outbox
+ * Outbox Fields:tid,symbol,sequence,event,update_time,create_time,status,
+ */
+type Outbox struct {
+	T          string       `json:"-" table:"exs_outbox"`                               /* the table name tag */
+	TID        int64        `json:"tid,omitempty" valid:"tid,r|i,r:0;"`                 /* the primary key */
+	Symbol     string       `json:"symbol,omitempty" valid:"symbol,r|s,l:0;"`           /* the outbox event symbol */
+	Sequence   int64        `json:"sequence,omitempty" valid:"sequence,r|i,r:0;"`       /* the outbox event sequence by symbol */
+	Event      xsql.M       `json:"event,omitempty" valid:"event,r|s,l:0;"`             /* the outbox matcher event */
+	UpdateTime xsql.Time    `json:"update_time,omitempty" valid:"update_time,r|i,r:1;"` /* the outbox last update time */
+	CreateTime xsql.Time    `json:"create_time,omitempty" valid:"create_time,r|i,r:1;"` /* the outbox create time */
+	Status     OutboxStatus `json:"status,omitempty" valid:"status,r|i,e:0;"`           /* the outbox status, Normal=100:is normal */
+}
+
+/***** metadata:OutboxAck *****/
+type OutboxAckStatus int
+type OutboxAckStatusArray []OutboxAckStatus
+
+const (
+	OutboxAckStatusNormal OutboxAckStatus = 100 //is normal
+)
+
+//OutboxAckStatusAll is the outbox ack status
+var OutboxAckStatusAll = OutboxAckStatusArray{OutboxAckStatusNormal}
+
+//OutboxAckStatusShow is the outbox ack status
+var OutboxAckStatusShow = OutboxAckStatusArray{OutboxAckStatusNormal}
+
+/*
+ * OutboxAck  represents exs_outbox_ack
+ * OutboxAck Fields:tid,consumer,symbol,sequence,update_time,create_time,status,
+ */
+type OutboxAck struct {
+	T          string          `json:"-" table:"exs_outbox_ack"`                           /* the table name tag */
+	TID        int64           `json:"tid,omitempty" valid:"tid,r|i,r:0;"`                 /* the primary key */
+	Consumer   string          `json:"consumer,omitempty" valid:"consumer,r|s,l:0;"`       /* the outbox consumer name */
+	Symbol     string          `json:"symbol,omitempty" valid:"symbol,r|s,l:0;"`           /* the outbox event symbol */
+	Sequence   int64           `json:"sequence,omitempty" valid:"sequence,r|i,r:0;"`       /* the last acked outbox event sequence */
+	UpdateTime xsql.Time       `json:"update_time,omitempty" valid:"update_time,r|i,r:1;"` /* the outbox ack last update time */
+	CreateTime xsql.Time       `json:"create_time,omitempty" valid:"create_time,r|i,r:1;"` /* the outbox ack create time */
+	Status     OutboxAckStatus `json:"status,omitempty" valid:"status,r|i,e:0;"`           /* the outbox ack status, Normal=100:is normal */
+}
+
 /***** metadata:User *****/
 type UserType int
 type UserTypeArray []UserType
@@ -388,9 +607,12 @@ var WithdrawStatusAll = WithdrawStatusArray{WithdrawStatusPending, WithdrawStatu
 //WithdrawStatusShow is the withdraw order status
 var WithdrawStatusShow = WithdrawStatusArray{WithdrawStatusPending, WithdrawStatusConfirmed, WithdrawStatusDone, WithdrawStatusCanceled}
 
+//WithdrawOrderbyAll is crud filter
+const WithdrawOrderbyAll = "update_time,create_time"
+
 /*
  * Withdraw  represents exs_withdraw
- * Withdraw Fields:tid,order_id,type,user_id,creator,asset,quantity,transaction,update_time,create_time,status,
+ * Withdraw Fields:tid,order_id,type,user_id,creator,asset,address,quantity,fee,transaction,update_time,create_time,status,
  */
 type Withdraw struct {
 	T           string          `json:"-" table:"exs_withdraw"`                             /* the table name tag */
@@ -400,9 +622,46 @@ type Withdraw struct {
 	UserID      int64           `json:"user_id,omitempty" valid:"user_id,r|i,r:0;"`         /* the withdraw order user id */
 	Creator     int64           `json:"creator,omitempty" valid:"creator,r|i,r:0;"`         /* the withdraw order creator user id */
 	Asset       string          `json:"asset,omitempty" valid:"asset,r|s,l:0;"`             /* the withdraw asset */
+	Address     string          `json:"address,omitempty" valid:"address,r|s,l:0;"`         /* the withdraw destination address */
 	Quantity    decimal.Decimal `json:"quantity,omitempty" valid:"quantity,r|f,r:0;"`       /* the withdraw order quantity */
+	Fee         decimal.Decimal `json:"fee,omitempty" valid:"fee,r|f,r:0;"`                 /* the withdraw order fee, it is included in quantity */
 	Transaction xsql.M          `json:"transaction,omitempty" valid:"transaction,r|s,l:0;"` /* the withdraw order transaction info */
 	UpdateTime  xsql.Time       `json:"update_time,omitempty" valid:"update_time,r|i,r:1;"` /* the withdraw order update time */
 	CreateTime  xsql.Time       `json:"create_time,omitempty" valid:"create_time,r|i,r:1;"` /* the withdraw order create time */
 	Status      WithdrawStatus  `json:"status,omitempty" valid:"status,r|i,e:0;"`           /* the withdraw order status, Pending=100:is pending, Confirmed=200:is confirmed, Done=300:is done, Canceled=320: is canceled */
 }
+
+/***** metadata:WithdrawAddress *****/
+type WithdrawAddressStatus int
+type WithdrawAddressStatusArray []WithdrawAddressStatus
+
+const (
+	WithdrawAddressStatusNormal  WithdrawAddressStatus = 100 //is normal
+	WithdrawAddressStatusRemoved WithdrawAddressStatus = -1  //is removed
+)
+
+//WithdrawAddressStatusAll is the address status
+var WithdrawAddressStatusAll = WithdrawAddressStatusArray{WithdrawAddressStatusNormal, WithdrawAddressStatusRemoved}
+
+//WithdrawAddressStatusShow is the address status
+var WithdrawAddressStatusShow = WithdrawAddressStatusArray{WithdrawAddressStatusNormal}
+
+//WithdrawAddressOrderbyAll is crud filter
+const WithdrawAddressOrderbyAll = "update_time,create_time"
+
+/*
+ * WithdrawAddress  represents exs_withdraw_address
+ * WithdrawAddress Fields:tid,user_id,asset,address,label,available_time,update_time,create_time,status,
+ */
+type WithdrawAddress struct {
+	T             string                `json:"-" table:"exs_withdraw_address"`                           /* the table name tag */
+	TID           int64                 `json:"tid,omitempty" valid:"tid,r|i,r:0;"`                       /* the primary key */
+	UserID        int64                 `json:"user_id,omitempty" valid:"user_id,r|i,r:0;"`               /* the address owner user id */
+	Asset         string                `json:"asset,omitempty" valid:"asset,r|s,l:0;"`                   /* the address asset key */
+	Address       string                `json:"address,omitempty" valid:"address,r|s,l:0;"`               /* the withdraw destination address */
+	Label         string                `json:"label,omitempty" valid:"label,r|s,l:0;"`                   /* the address label */
+	AvailableTime xsql.Time             `json:"available_time,omitempty" valid:"available_time,r|i,r:1;"` /* the address available time, it can be used to withdraw after this time */
+	UpdateTime    xsql.Time             `json:"update_time,omitempty" valid:"update_time,r|i,r:1;"`       /* the address update time */
+	CreateTime    xsql.Time             `json:"create_time,omitempty" valid:"create_time,r|i,r:1;"`       /* the address create time */
+	Status        WithdrawAddressStatus `json:"status,omitempty" valid:"status,r|i,e:0;"`                 /* the address status, Normal=100: is normal, Removed=-1: is removed */
+}
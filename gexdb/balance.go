@@ -3,6 +3,7 @@ package gexdb
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,7 +18,12 @@ func TouchBalance(ctx context.Context, area BalanceArea, assets []string, userID
 	return
 }
 
+//TouchBalanceCall will add zero balance to user when it is not exists, ErrAssetInvalid is returned when asset is not registered or locked on registry
 func TouchBalanceCall(caller crud.Queryer, ctx context.Context, area BalanceArea, assets []string, userIDs ...int64) (added int64, err error) {
+	_, err = CheckAssetCall(caller, ctx, assets...)
+	if err != nil {
+		return
+	}
 	upsertArg := []interface{}{0, 0, time.Now(), time.Now(), BalanceStatusNormal, area}
 	values := []string{}
 
@@ -43,6 +49,12 @@ func IncreaseBalance(ctx context.Context, balance *Balance) (err error) {
 }
 
 func IncreaseBalanceCall(caller crud.Queryer, ctx context.Context, balance *Balance) (err error) {
+	err = IncreaseBalanceRecordCall(caller, ctx, balance, BalanceRecordTypeChange, "")
+	return
+}
+
+//IncreaseBalanceRecordCall will increase balance and add balance record by type and reference id, the record is skipped when nothing changed
+func IncreaseBalanceRecordCall(caller crud.Queryer, ctx context.Context, balance *Balance, recordType BalanceRecordType, refID string) (err error) {
 	var free, locked, margin decimal.Decimal
 	err = caller.QueryRow(
 		ctx,
@@ -73,11 +85,93 @@ func IncreaseBalanceCall(caller crud.Queryer, ctx context.Context, balance *Bala
 		err = ErrBalanceNotEnought(fmt.Sprintf("%v balance %v.margin %v in %v not enought to %v", balance.UserID, balance.Asset, balance.Area, margin, balance.Margin))
 		return
 	}
+	record := &BalanceRecord{
+		UserID:      balance.UserID,
+		Area:        balance.Area,
+		Asset:       balance.Asset,
+		Type:        recordType,
+		RefID:       refID,
+		FreeDelta:   balance.Free,
+		LockedDelta: balance.Locked,
+		MarginDelta: balance.Margin,
+		Status:      BalanceRecordStatusNormal,
+	}
 	balance.Free = free.Add(balance.Free)
 	balance.Locked = locked.Add(balance.Locked)
 	balance.Margin = margin.Add(balance.Margin)
 	balance.UpdateTime = xsql.TimeNow()
 	err = balance.UpdateFilter(caller, ctx, "free,locked,margin,update_time#all")
+	if err != nil || (record.FreeDelta.IsZero() && record.LockedDelta.IsZero() && record.MarginDelta.IsZero()) {
+		return
+	}
+	record.Free, record.Locked, record.Margin = balance.Free, balance.Locked, balance.Margin
+	err = AddBalanceRecordCall(caller, ctx, record)
+	return
+}
+
+func TransferBalance(ctx context.Context, userID int64, asset string, from, to BalanceArea, quantity decimal.Decimal) (transfer *BalanceTransfer, err error) {
+	tx, err := Pool().Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit(ctx)
+		} else {
+			tx.Rollback(ctx)
+		}
+	}()
+	transfer, err = TransferBalanceCall(tx, ctx, userID, asset, from, to, quantity)
+	return
+}
+
+//TransferBalanceCall will move free balance from area to other area, the locked and margin balance is not changed, so margin locked by futures is kept
+func TransferBalanceCall(caller crud.Queryer, ctx context.Context, userID int64, asset string, from, to BalanceArea, quantity decimal.Decimal) (transfer *BalanceTransfer, err error) {
+	if from == to || from.EnumValid(from) != nil || to.EnumValid(to) != nil {
+		err = fmt.Errorf("transfer area from %v to %v is invalid", from, to)
+		return
+	}
+	if quantity.Sign() <= 0 {
+		err = fmt.Errorf("transfer quantity %v is invalid", quantity)
+		return
+	}
+	_, err = TouchBalanceCall(caller, ctx, to, []string{asset}, userID)
+	if err != nil {
+		return
+	}
+	//lock by area order to avoid dead lock on transfer each other
+	_, _, err = caller.Exec(ctx, `select tid from exs_balance where user_id=$1 and asset=$2 and area=any($3) order by area asc for update`, userID, asset, BalanceAreaArray{from, to})
+	if err != nil {
+		return
+	}
+	transfer = &BalanceTransfer{
+		UserID:   userID,
+		Asset:    asset,
+		FromArea: from,
+		ToArea:   to,
+		Quantity: quantity,
+		Status:   BalanceTransferStatusNormal,
+	}
+	err = AddBalanceTransferCall(caller, ctx, transfer)
+	if err != nil {
+		return
+	}
+	refID := strconv.FormatInt(transfer.TID, 10)
+	err = IncreaseBalanceRecordCall(caller, ctx, &Balance{
+		UserID: userID,
+		Area:   from,
+		Asset:  asset,
+		Free:   decimal.Zero.Sub(quantity),
+	}, BalanceRecordTypeTransfer, refID)
+	if err != nil {
+		return
+	}
+	err = IncreaseBalanceRecordCall(caller, ctx, &Balance{
+		UserID: userID,
+		Area:   to,
+		Asset:  asset,
+		Free:   quantity,
+	}, BalanceRecordTypeTransfer, refID)
 	return
 }
 
@@ -101,6 +195,23 @@ func ListUserBalance(ctx context.Context, userID int64, area BalanceArea, assets
 	return
 }
 
+//ListBalanceForReconcile will list balance which having locked or margin by areas
+func ListBalanceForReconcile(ctx context.Context, areas BalanceAreaArray) (balances []*Balance, err error) {
+	balances, err = ListBalanceForReconcileCall(Pool(), ctx, areas)
+	return
+}
+
+func ListBalanceForReconcileCall(caller crud.Queryer, ctx context.Context, areas BalanceAreaArray) (balances []*Balance, err error) {
+	querySQL := crud.QuerySQL(&Balance{}, "#all")
+	var args []interface{}
+	var and []string
+	and, args = crud.AppendWhere(and, args, true, "area=any($%v)", areas)
+	and = append(and, "(locked<>0 or margin<>0)")
+	querySQL = crud.JoinWhere(querySQL, and, " and ", "order by user_id asc,area asc,asset asc")
+	err = crud.Query(caller, ctx, &Balance{}, "#all", querySQL, args, &balances)
+	return
+}
+
 func CountBalance(ctx context.Context, area BalanceArea, start, end time.Time) (balances map[string]decimal.Decimal, err error) {
 	//not using sql sum for percision loss
 	balances = map[string]decimal.Decimal{}
@@ -121,16 +232,113 @@ func CountBalance(ctx context.Context, area BalanceArea, start, end time.Time) (
 	return
 }
 
-// func ListUserBalanceHistory(ctx context.Context, userID int64, asset string, startTime, endTime time.Time) (histories []*BalanceHistory, err error) {
-// 	err = crud.QueryWheref(
-// 		Pool, ctx, &BalanceHistory{}, "#all",
-// 		"user_id=$%v,asset=$%v,create_time>=$%v,create_time<=$%v",
-// 		[]interface{}{userID, asset, startTime, endTime},
-// 		"order by create_time asc", 0, 0,
-// 		&histories,
-// 	)
-// 	return
-// }
+/**
+ * @apiDefine BalanceTransferUnifySearcher
+ * @apiParam  {String} [asset] the asset filter, multi with comma
+ * @apiParam  {Number} [area] the area filter, it is matched by from_area or to_area, all type supported is <a href="#metadata-Balance">BalanceAreaAll</a>
+ * @apiParam  {Number} [start_time] the time filter
+ * @apiParam  {Number} [end_time] the time filter
+ * @apiParam  {Number} [skip] page skip
+ * @apiParam  {Number} [limit] page limit
+ */
+type BalanceTransferUnifySearcher struct {
+	Model BalanceTransfer `json:"model"`
+	Where struct {
+		UserID    xsql.Int64Array  `json:"user_id" cmp:"user_id=any($%v)" valid:"user_id,o|i,r:0;"`
+		Asset     xsql.StringArray `json:"asset" cmp:"asset=any($%v)" valid:"asset,o|s,l:0;"`
+		Area      BalanceArea      `json:"area" cmp:"(from_area=$%v or to_area=$%v)" valid:"area,o|i,e:;"`
+		StartTime xsql.Time        `json:"start_time" cmp:"create_time>=$%v" valid:"start_time,o|i,r:-1;"`
+		EndTime   xsql.Time        `json:"end_time" cmp:"create_time<$%v" valid:"end_time,o|i,r:-1;"`
+	} `json:"where" join:"and" valid:"inline"`
+	Page struct {
+		Order string `json:"order" default:"order by create_time desc" valid:"order,o|s,l:0;"`
+		Skip  int    `json:"skip" valid:"skip,o|i,r:-1;"`
+		Limit int    `json:"limit" valid:"limit,o|i,r:0;"`
+	} `json:"page" valid:"inline"`
+	Query struct {
+		Transfers []*BalanceTransfer `json:"transfers"`
+	} `json:"query" filter:"#all"`
+	Count struct {
+		Total int64 `json:"total" scan:"tid"`
+	} `json:"count" filter:"count(tid)#all"`
+}
+
+func (b *BalanceTransferUnifySearcher) Apply(ctx context.Context) (err error) {
+	b.Page.Order = crud.BuildOrderby(BalanceTransferOrderbyAll, b.Page.Order)
+	err = crud.ApplyUnify(Pool(), ctx, b)
+	return
+}
+
+/**
+ * @apiDefine BalanceRecordUnifySearcher
+ * @apiParam  {Number} [area] the area filter, all type supported is <a href="#metadata-Balance">BalanceAreaAll</a>
+ * @apiParam  {String} [asset] the asset filter, multi with comma
+ * @apiParam  {Number} [type] the type filter, multi with comma, all type supported is <a href="#metadata-BalanceRecord">BalanceRecordTypeAll</a>
+ * @apiParam  {String} [ref_id] the reference id filter
+ * @apiParam  {Number} [start_time] the time filter
+ * @apiParam  {Number} [end_time] the time filter
+ * @apiParam  {Number} [skip] page skip
+ * @apiParam  {Number} [limit] page limit
+ */
+type BalanceRecordUnifySearcher struct {
+	Model BalanceRecord `json:"model"`
+	Where struct {
+		UserID    xsql.Int64Array        `json:"user_id" cmp:"user_id=any($%v)" valid:"user_id,o|i,r:0;"`
+		Area      BalanceArea            `json:"area" cmp:"area=$%v" valid:"area,o|i,e:;"`
+		Asset     xsql.StringArray       `json:"asset" cmp:"asset=any($%v)" valid:"asset,o|s,l:0;"`
+		Type      BalanceRecordTypeArray `json:"type" cmp:"type=any($%v)" valid:"type,o|i,e:;"`
+		RefID     string                 `json:"ref_id" cmp:"ref_id=$%v" valid:"ref_id,o|s,l:0;"`
+		StartTime xsql.Time              `json:"start_time" cmp:"create_time>=$%v" valid:"start_time,o|i,r:-1;"`
+		EndTime   xsql.Time              `json:"end_time" cmp:"create_time<$%v" valid:"end_time,o|i,r:-1;"`
+	} `json:"where" join:"and" valid:"inline"`
+	Page struct {
+		Order string `json:"order" default:"order by tid desc" valid:"order,o|s,l:0;"`
+		Skip  int    `json:"skip" valid:"skip,o|i,r:-1;"`
+		Limit int    `json:"limit" valid:"limit,o|i,r:0;"`
+	} `json:"page" valid:"inline"`
+	Query struct {
+		Records []*BalanceRecord `json:"records"`
+	} `json:"query" filter:"#all"`
+	Count struct {
+		Total int64 `json:"total" scan:"tid"`
+	} `json:"count" filter:"count(tid)#all"`
+}
+
+func (b *BalanceRecordUnifySearcher) Apply(ctx context.Context) (err error) {
+	b.Page.Order = crud.BuildOrderby(BalanceRecordOrderbyAll, b.Page.Order)
+	err = crud.ApplyUnify(Pool(), ctx, b)
+	return
+}
+
+//ListBalanceAsset will list all distinct asset on balance
+func ListBalanceAsset(ctx context.Context) (assets []string, err error) {
+	rows, err := Pool().Query(ctx, `select distinct asset from exs_balance order by asset asc`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var asset string
+		err = rows.Scan(&asset)
+		if err != nil {
+			break
+		}
+		assets = append(assets, asset)
+	}
+	return
+}
+
+//ListUserBalanceHistory will list user daily balance valuation by create_time range, asset is optional
+func ListUserBalanceHistory(ctx context.Context, userID int64, asset string, startTime, endTime time.Time) (histories []*BalanceHistory, err error) {
+	err = crud.QueryWheref(
+		Pool, ctx, &BalanceHistory{}, "#all",
+		"user_id=$%v,asset=$%v,create_time>=$%v,create_time<=$%v",
+		[]interface{}{userID, asset, startTime, endTime},
+		"order by create_time asc,asset asc", 0, 0,
+		&histories,
+	)
+	return
+}
 
 // func ChangeBalance(ctx context.Context, creator, userID int64, asset string, changed decimal.Decimal) (balance *Balance, order *Order, err error) {
 // 	tx, err := Pool().Begin(ctx)
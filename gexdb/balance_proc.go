@@ -1,52 +1,107 @@
 package gexdb
 
 import (
+	"context"
 	"time"
 
 	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/codingeasygo/util/xtime"
+	"github.com/gexservice/gexservice/base/util"
+	"github.com/gexservice/gexservice/base/xlog"
 	"github.com/shopspring/decimal"
 )
 
+//BalanceRecordSyncer will snapshot the valuation of all user balance per asset to exs_balance_history once a day
 type BalanceRecordSyncer struct {
-	LatestPrice func(asset string) decimal.Decimal
+	LatestPrice func(assets ...string) (prices map[string]decimal.Decimal)
 	TriggerHour int
+	Delay       time.Duration
 	Last        time.Time
 	Assets      []string
+	running     bool
 }
 
-func NewBalanceRecordSyncer(triggerHour int, latestPrice func(asset string) decimal.Decimal) (syncer *BalanceRecordSyncer) {
+func NewBalanceRecordSyncer(triggerHour int, latestPrice func(assets ...string) (prices map[string]decimal.Decimal)) (syncer *BalanceRecordSyncer) {
 	syncer = &BalanceRecordSyncer{
 		TriggerHour: triggerHour,
+		Delay:       time.Minute,
 		LatestPrice: latestPrice,
 	}
 	return
 }
 
+func (b *BalanceRecordSyncer) Start() {
+	b.running = true
+	go util.NamedRunner("BalanceRecordSyncer", b.Delay, &b.running, b.Proc)
+}
+
+func (b *BalanceRecordSyncer) Stop() {
+	b.running = false
+}
+
+//Proc will sync balance valuation to history when current hour is after TriggerHour and today is not synced,
+//pgx.ErrNoRows is returned when not thing to sync
 func (b *BalanceRecordSyncer) Proc() (err error) {
-	// currentHour := time.Now().Hour()
-	// if currentHour != b.TriggerHour || time.Since(b.Last) < 6*time.Hour {
-	// 	err = pgx.ErrNoRows
-	// 	return
-	// }
-	// price := b.LatestPrice("xx")
-	// if price.Sign() <= 0 {
-	// 	xlog.Warnf("BalanceRecordSyncer sync fail with not latest price")
-	// 	err = pgx.ErrNoRows
-	// 	return
-	// }
-	// syncTime := xsql.TimeStartOfToday()
-	// syncSQL := `
-	// 	insert into exs_balance_history(user_id,asset,current,update_time,create_time,status)
-	// 	select m.user_id,m.asset,m.free+m.locked+(y.free+y.locked)*$1,$2,$2,$3 from exs_balance m join exs_balance y on m.asset=$4 and y.asset=$5 and m.user_id=y.user_id
-	// 	on conflict(user_id,asset,create_time) do update set current=excluded.current
-	// `
-	// syncArg := []interface{}{price, syncTime, BalanceHistoryStatusNormal, BalanceAssetMMK, BalanceAssetYWE}
-	// _, affected, err := Pool().Exec(context.Background(), syncSQL, syncArg...)
-	// if err != nil {
-	// 	xlog.Errorf("BalanceRecordSyncer sync fail with %v", err)
-	// 	return
-	// }
-	// xlog.Infof("BalanceRecordSyncer sync %v balance record to history", affected)
-	err = pgx.ErrNoRows
+	syncTime := xtime.TimeStartOfToday()
+	if time.Now().Hour() < b.TriggerHour || !b.Last.Before(syncTime) {
+		err = pgx.ErrNoRows
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	if b.Last.IsZero() { //check if synced before restart
+		var having int64
+		err = Pool().QueryRow(ctx, `select count(tid) from exs_balance_history where create_time=$1`, syncTime).Scan(&having)
+		if err != nil {
+			xlog.Errorf("BalanceRecordSyncer check synced fail with %v", err)
+			return
+		}
+		if having > 0 {
+			b.Last = syncTime
+			err = pgx.ErrNoRows
+			return
+		}
+	}
+	assets := b.Assets
+	if len(assets) < 1 {
+		assets, err = ListBalanceAsset(ctx)
+		if err != nil {
+			xlog.Errorf("BalanceRecordSyncer list balance asset fail with %v", err)
+			return
+		}
+	}
+	prices := b.LatestPrice(assets...)
+	priceAssets, priceValues := xsql.StringArray{}, xsql.Float64Array{}
+	for _, asset := range assets {
+		price := prices[asset]
+		if price.Sign() <= 0 {
+			xlog.Warnf("BalanceRecordSyncer skip sync asset %v for not latest price", asset)
+			continue
+		}
+		value, _ := price.Float64()
+		priceAssets = append(priceAssets, asset)
+		priceValues = append(priceValues, value)
+	}
+	if len(priceAssets) < 1 {
+		xlog.Warnf("BalanceRecordSyncer sync fail with not latest price")
+		err = pgx.ErrNoRows
+		return
+	}
+	syncSQL := `
+		insert into exs_balance_history(user_id,asset,valuation,update_time,create_time,status)
+		select b.user_id,b.asset,sum(b.free+b.locked)*p.price,$1,$2,$3
+		from exs_balance b join unnest($4::text[],$5::float8[]) as p(asset,price) on b.asset=p.asset
+		group by b.user_id,b.asset,p.price having sum(b.free+b.locked)<>0
+		on conflict(user_id,asset,create_time) do update set valuation=excluded.valuation,update_time=excluded.update_time
+	`
+	syncArg := []interface{}{time.Now(), syncTime, BalanceHistoryStatusNormal, priceAssets, priceValues}
+	_, affected, err := Pool().Exec(ctx, syncSQL, syncArg...)
+	if err != nil {
+		xlog.Errorf("BalanceRecordSyncer sync fail with %v", err)
+		return
+	}
+	b.Last = syncTime
+	xlog.Infof("BalanceRecordSyncer sync %v balance valuation to history on %v", affected, syncTime)
 	return
 }
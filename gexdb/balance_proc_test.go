@@ -1,81 +1,138 @@
 package gexdb
 
-// import (
-// 	"testing"
-// 	"time"
+import (
+	"testing"
+	"time"
 
-// 	"github.com/codingeasygo/crud/pgx"
-// 	"github.com/codingeasygo/util/xtime"
-// 	"github.com/shopspring/decimal"
-// )
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xtime"
+	"github.com/shopspring/decimal"
+)
 
-// func TestBalanceProc(t *testing.T) {
-// 	user := testAddUser("TestBalanceProc")
-// 	TouchBalance(ctx, BalanceAssetAll, user.TID)
-// 	err := IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Asset: BalanceAssetYWE, Free: decimal.NewFromFloat(1)})
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Asset: BalanceAssetMMK, Free: decimal.NewFromFloat(50)})
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	//
-// 	syncer := NewBalanceRecordSyncer(time.Now().Hour(), func(asset string) decimal.Decimal { return decimal.NewFromFloat(100) })
-// 	err = syncer.Proc()
-// 	if err != pgx.ErrNoRows {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	histories, err := ListUserBalanceHistory(ctx, user.TID, BalanceAssetMMK, xtime.TimeStartOfToday(), time.Now())
-// 	if err != nil || len(histories) != 1 {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	current, _ := histories[0].Current.Float64()
-// 	if current != 150 {
-// 		t.Error(err)
-// 		return
-// 	}
+func TestBalanceProc(t *testing.T) {
+	clear()
+	user := testAddUser("TestBalanceProc")
+	TouchBalance(ctx, BalanceAreaSpot, []string{"YWE", "USDT"}, user.TID)
+	TouchBalance(ctx, BalanceAreaFutures, []string{"USDT"}, user.TID)
+	err := IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaSpot, Asset: "YWE", Free: decimal.NewFromFloat(1)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaSpot, Asset: "USDT", Free: decimal.NewFromFloat(50)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaFutures, Asset: "USDT", Free: decimal.NewFromFloat(50), Locked: decimal.NewFromFloat(50)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	latestPrice := func(assets ...string) (prices map[string]decimal.Decimal) {
+		prices = map[string]decimal.Decimal{"YWE": decimal.NewFromFloat(100), "USDT": decimal.NewFromFloat(1)}
+		return
+	}
+	//
+	syncer := NewBalanceRecordSyncer(0, latestPrice)
+	err = syncer.Proc()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = syncer.Proc() //synced
+	if err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	err = NewBalanceRecordSyncer(0, latestPrice).Proc() //synced before restart
+	if err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	histories, err := ListUserBalanceHistory(ctx, user.TID, "", xtime.TimeStartOfToday(), time.Now())
+	if err != nil || len(histories) != 2 {
+		t.Error(err)
+		return
+	}
+	histories, err = ListUserBalanceHistory(ctx, user.TID, "USDT", xtime.TimeStartOfToday(), time.Now())
+	if err != nil || len(histories) != 1 || !histories[0].Valuation.Equal(decimal.NewFromFloat(150)) {
+		t.Error(err)
+		return
+	}
+	histories, err = ListUserBalanceHistory(ctx, user.TID, "YWE", xtime.TimeStartOfToday(), time.Now())
+	if err != nil || len(histories) != 1 || !histories[0].Valuation.Equal(decimal.NewFromFloat(100)) {
+		t.Error(err)
+		return
+	}
 
-// 	//
-// 	//test error
-// 	pgx.MockerStart()
-// 	defer pgx.MockerStop()
-// 	pgx.MockerClear()
+	//not hour
+	syncer = NewBalanceRecordSyncer(24, latestPrice)
+	if err = syncer.Proc(); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
 
-// 	//proc error
-// 	pgx.MockerSet("Pool.Exec", 1)
-// 	err = syncer.Proc()
-// 	if err == pgx.ErrNoRows {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
+	//not price
+	_, _, err = Pool().Exec(ctx, `delete from exs_balance_history`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	syncer = NewBalanceRecordSyncer(0, func(assets ...string) map[string]decimal.Decimal { return nil })
+	if err = syncer.Proc(); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
 
-// 	//list history error
-// 	pgx.MockerSet("Pool.Query", 1)
-// 	_, err = ListUserBalanceHistory(ctx, user.TID, BalanceAssetMMK, xtime.TimeStartOfToday(), time.Now())
-// 	if err == nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// 	pgx.MockerSet("Rows.Scan", 1)
-// 	_, err = ListUserBalanceHistory(ctx, user.TID, BalanceAssetMMK, xtime.TimeStartOfToday(), time.Now())
-// 	if err == nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
+	//runner
+	syncer = NewBalanceRecordSyncer(0, latestPrice)
+	syncer.Assets = []string{"USDT"}
+	syncer.Delay = 10 * time.Millisecond
+	syncer.Start()
+	time.Sleep(100 * time.Millisecond)
+	syncer.Stop()
+	if syncer.Last.IsZero() {
+		t.Error("error")
+		return
+	}
 
-// 	//not hour
-// 	syncer = NewBalanceRecordSyncer(1, func(asset string) decimal.Decimal { return decimal.NewFromFloat(100) })
-// 	syncer.Proc()
+	//
+	//test error
+	_, _, err = Pool().Exec(ctx, `delete from exs_balance_history`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
 
-// 	//not price
-// 	syncer = NewBalanceRecordSyncer(time.Now().Hour(), func(asset string) decimal.Decimal { return decimal.NewFromFloat(0) })
-// 	syncer.Proc()
-// }
+	pgx.MockerSet("Rows.Scan", 1)
+	if err = NewBalanceRecordSyncer(0, latestPrice).Proc(); err == nil || err == pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Query", 1)
+	if err = NewBalanceRecordSyncer(0, latestPrice).Proc(); err == nil || err == pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 1)
+	if _, err = ListBalanceAsset(ctx); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Exec", 1)
+	if err = NewBalanceRecordSyncer(0, latestPrice).Proc(); err == nil || err == pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
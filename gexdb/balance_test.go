@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xsql"
 	"github.com/shopspring/decimal"
 )
 
@@ -38,6 +40,11 @@ func TestBalance(t *testing.T) {
 		t.Error(err)
 		return
 	}
+	balances, err = ListBalanceForReconcile(ctx, BalanceAreaArray{BalanceAreaSpot})
+	if err != nil || len(balances) < 1 {
+		t.Error(err)
+		return
+	}
 	if !IsErrBalanceNotEnought(IncreaseBalance(ctx,
 		&Balance{
 			UserID: user.TID,
@@ -162,6 +169,161 @@ func TestBalance(t *testing.T) {
 	// pgx.MockerClear()
 }
 
+func TestBalanceRecord(t *testing.T) {
+	asset := "TEST"
+	user := testAddUser("TestBalanceRecord")
+	_, err := TouchBalance(ctx, BalanceAreaSpot, []string{asset}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalance(ctx, &Balance{
+		UserID: user.TID,
+		Area:   BalanceAreaSpot,
+		Asset:  asset,
+		Free:   decimal.NewFromFloat(100),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalanceRecordCall(Pool(), ctx, &Balance{
+		UserID: user.TID,
+		Area:   BalanceAreaSpot,
+		Asset:  asset,
+		Free:   decimal.NewFromFloat(-10),
+		Locked: decimal.NewFromFloat(10),
+	}, BalanceRecordTypeTradeLock, "123")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//not changed, skip record
+	err = IncreaseBalanceRecordCall(Pool(), ctx, &Balance{
+		UserID: user.TID,
+		Area:   BalanceAreaSpot,
+		Asset:  asset,
+	}, BalanceRecordTypeTrade, "123")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//not enought, skip record
+	err = IncreaseBalanceRecordCall(Pool(), ctx, &Balance{
+		UserID: user.TID,
+		Area:   BalanceAreaSpot,
+		Asset:  asset,
+		Free:   decimal.NewFromFloat(-1000),
+	}, BalanceRecordTypeTrade, "123")
+	if !IsErrBalanceNotEnought(err) {
+		t.Error(err)
+		return
+	}
+	searcher := &BalanceRecordUnifySearcher{}
+	searcher.Where.UserID = xsql.Int64Array{user.TID}
+	err = searcher.Apply(ctx)
+	if err != nil || len(searcher.Query.Records) != 2 || searcher.Count.Total != 2 {
+		t.Errorf("err:%v,records:%v", err, converter.JSON(searcher.Query.Records))
+		return
+	}
+	record := searcher.Query.Records[0]
+	if record.Type != BalanceRecordTypeTradeLock || record.RefID != "123" || !record.FreeDelta.Equal(decimal.NewFromFloat(-10)) || !record.Free.Equal(decimal.NewFromFloat(90)) || !record.Locked.Equal(decimal.NewFromFloat(10)) {
+		t.Errorf("record:%v", converter.JSON(record))
+		return
+	}
+	searcher = &BalanceRecordUnifySearcher{}
+	searcher.Where.UserID = xsql.Int64Array{user.TID}
+	searcher.Where.Type = BalanceRecordTypeArray{BalanceRecordTypeChange}
+	err = searcher.Apply(ctx)
+	if err != nil || len(searcher.Query.Records) != 1 {
+		t.Error(err)
+		return
+	}
+}
+
+func TestTransferBalance(t *testing.T) {
+	asset := "TEST"
+	user := testAddUser("TestTransferBalance")
+	_, err := TouchBalance(ctx, BalanceAreaSpot, []string{asset}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalance(ctx, &Balance{
+		UserID: user.TID,
+		Area:   BalanceAreaSpot,
+		Asset:  asset,
+		Free:   decimal.NewFromFloat(100),
+		Margin: decimal.NewFromFloat(100),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	transfer, err := TransferBalance(ctx, user.TID, asset, BalanceAreaSpot, BalanceAreaFutures, decimal.NewFromFloat(60))
+	if err != nil || transfer.TID < 1 {
+		t.Error(err)
+		return
+	}
+	spot, err := FindBalanceByAsset(ctx, user.TID, BalanceAreaSpot, asset)
+	if err != nil || !spot.Free.Equal(decimal.NewFromFloat(40)) || !spot.Margin.Equal(decimal.NewFromFloat(100)) {
+		t.Errorf("err:%v,spot:%v", err, spot)
+		return
+	}
+	futures, err := FindBalanceByAsset(ctx, user.TID, BalanceAreaFutures, asset)
+	if err != nil || !futures.Free.Equal(decimal.NewFromFloat(60)) {
+		t.Errorf("err:%v,futures:%v", err, futures)
+		return
+	}
+	_, err = TransferBalance(ctx, user.TID, asset, BalanceAreaFutures, BalanceAreaSpot, decimal.NewFromFloat(10))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	records := &BalanceRecordUnifySearcher{}
+	records.Where.UserID = xsql.Int64Array{user.TID}
+	records.Where.Type = BalanceRecordTypeArray{BalanceRecordTypeTransfer}
+	err = records.Apply(ctx)
+	if err != nil || len(records.Query.Records) != 4 {
+		t.Error(err)
+		return
+	}
+	searcher := &BalanceTransferUnifySearcher{}
+	searcher.Where.UserID = xsql.Int64Array{user.TID}
+	searcher.Where.Area = BalanceAreaFutures
+	err = searcher.Apply(ctx)
+	if err != nil || len(searcher.Query.Transfers) != 2 || searcher.Count.Total != 2 {
+		t.Error(err)
+		return
+	}
+	//margin is not transferable
+	_, err = TransferBalance(ctx, user.TID, asset, BalanceAreaSpot, BalanceAreaFunds, decimal.NewFromFloat(100))
+	if !IsErrBalanceNotEnought(err) {
+		t.Error(err)
+		return
+	}
+	_, err = TransferBalance(ctx, user.TID, asset, BalanceAreaSpot, BalanceAreaSpot, decimal.NewFromFloat(1))
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	_, err = TransferBalance(ctx, user.TID, asset, BalanceAreaSpot, BalanceArea(0), decimal.NewFromFloat(1))
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	_, err = TransferBalance(ctx, user.TID, asset, BalanceAreaSpot, BalanceAreaFunds, decimal.Zero)
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	_, err = TransferBalance(ctx, user.TID, "NONE", BalanceAreaSpot, BalanceAreaFunds, decimal.NewFromFloat(1))
+	if !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+}
+
 // func TestChangeBalance(t *testing.T) {
 // 	user := testAddUser("TestChangeBalance")
 // 	TouchBalance(ctx, BalanceAssetAll, user.TID)
@@ -44,13 +44,15 @@ var Redis = func() redis.Conn {
 	panic("redis is not initial")
 }
 
-//CheckDb will check database if is initial
+//CheckDb will check database if is initial, the exists database is upgraded to latest
 func CheckDb(ctx context.Context) (created bool, err error) {
 	_, _, err = Pool().Exec(ctx, `select tid from exs_user limit 1`)
 	if err != nil {
 		xlog.Infof("start generate database...")
 		_, _, err = Pool().Exec(ctx, gexupgrade.LATEST)
 		created = true
+		return
 	}
+	_, _, err = Pool().Exec(ctx, gexupgrade.UPGRADE)
 	return
 }
@@ -55,11 +55,21 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	//upgrade on exists
+	_, err = CheckDb(ctx)
+	if err != nil {
+		panic(err)
+	}
+	err = UpsertAsset(ctx, &Asset{Asset: "TEST", Name: "Test", DisplayPrecision: 8})
+	if err != nil {
+		panic(err)
+	}
 	proxyServer := os.Getenv("PROXY_SERVER")
 	if len(proxyServer) > 0 {
 		proxyAddr = proxyServer
 	}
 	basedb.StoreConf(ctx, ConfigBrokerCommRate, "0.8")
+	basedb.StoreConf(ctx, ConfigWithdrawAddressDelay, "0")
 	go http.ListenAndServe(":6062", nil)
 }
 
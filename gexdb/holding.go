@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/util/xsql"
 	"github.com/shopspring/decimal"
 )
 
@@ -73,3 +74,22 @@ func ListHoldingForBlowupFreeCall(caller crud.Queryer, ctx context.Context, symb
 	err = crud.Query(caller, ctx, &Holding{}, "#all", querySQL, args, &holdings)
 	return
 }
+
+//ListHoldingForReconcile will list holding which having amount/margin or user having open order by symbols
+func ListHoldingForReconcile(ctx context.Context, symbols []string, userIDs []int64) (holdings []*Holding, err error) {
+	holdings, err = ListHoldingForReconcileCall(Pool(), ctx, symbols, userIDs)
+	return
+}
+
+func ListHoldingForReconcileCall(caller crud.Queryer, ctx context.Context, symbols []string, userIDs []int64) (holdings []*Holding, err error) {
+	querySQL := crud.QuerySQL(&Holding{}, "#all")
+	var args []interface{}
+	var and, or []string
+	and, args = crud.AppendWhere(and, args, true, "symbol=any($%v)", xsql.StringArray(symbols))
+	or = append(or, "amount<>0", "margin_used<>0", "margin_added<>0")
+	or, args = crud.AppendWhere(or, args, len(userIDs) > 0, "user_id=any($%v)", xsql.Int64Array(userIDs))
+	and = append(and, "("+strings.Join(or, " or ")+")")
+	querySQL = crud.JoinWhere(querySQL, and, " and ", "order by user_id asc,symbol asc")
+	err = crud.Query(caller, ctx, &Holding{}, "#all", querySQL, args, &holdings)
+	return
+}
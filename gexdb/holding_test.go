@@ -37,4 +37,9 @@ func TestHolding(t *testing.T) {
 		t.Error(err)
 		return
 	}
+	holdings, err = ListHoldingForReconcile(ctx, []string{symbol}, []int64{user.TID})
+	if err != nil || len(holdings) != 1 {
+		t.Error(err)
+		return
+	}
 }
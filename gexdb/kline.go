@@ -9,18 +9,37 @@ import (
 	"golang.org/x/net/context"
 )
 
+//KLineIntervAll is the all supported kline interval
+var KLineIntervAll = []string{"1min", "3min", "5min", "15min", "30min", "1hour", "2hour", "4hour", "6hour", "12hour", "1day", "3day", "1week", "1mon"}
+
+//StringInterv will return the interval duration by string, the 1mon is calendar month and the returned 30 days is only nominal duration,
+//use KLineStartTime/KLineAddTime to get calendar bar time
 func StringInterv(str string) (interval time.Duration, err error) {
 	switch str {
+	case "1min":
+		interval = 1 * time.Minute
+	case "3min":
+		interval = 3 * time.Minute
 	case "5min":
 		interval = 5 * time.Minute
+	case "15min":
+		interval = 15 * time.Minute
 	case "30min":
 		interval = 30 * time.Minute
 	case "1hour":
 		interval = 1 * time.Hour
+	case "2hour":
+		interval = 2 * time.Hour
 	case "4hour":
 		interval = 4 * time.Hour
+	case "6hour":
+		interval = 6 * time.Hour
+	case "12hour":
+		interval = 12 * time.Hour
 	case "1day":
 		interval = 24 * time.Hour
+	case "3day":
+		interval = 3 * 24 * time.Hour
 	case "1week":
 		interval = 7 * 24 * time.Hour
 	case "1mon":
@@ -33,16 +52,30 @@ func StringInterv(str string) (interval time.Duration, err error) {
 
 func IntervString(interval time.Duration) (str string, err error) {
 	switch interval {
+	case 1 * time.Minute:
+		str = "1min"
+	case 3 * time.Minute:
+		str = "3min"
 	case 5 * time.Minute:
 		str = "5min"
+	case 15 * time.Minute:
+		str = "15min"
 	case 30 * time.Minute:
 		str = "30min"
 	case 1 * time.Hour:
 		str = "1hour"
+	case 2 * time.Hour:
+		str = "2hour"
 	case 4 * time.Hour:
 		str = "4hour"
+	case 6 * time.Hour:
+		str = "6hour"
+	case 12 * time.Hour:
+		str = "12hour"
 	case 24 * time.Hour:
 		str = "1day"
+	case 3 * 24 * time.Hour:
+		str = "3day"
 	case 7 * 24 * time.Hour:
 		str = "1week"
 	case 30 * 24 * time.Hour:
@@ -53,7 +86,57 @@ func IntervString(interval time.Duration) (str string, err error) {
 	return
 }
 
+//KLineStartTime will return the bar start time which contain t by interval on location.
+//the day bar is started from 00:00 of location, the 3day bar is aligned by days from 1970-01-01, the week bar is started from sunday and the month bar is started from first day of calendar month
+func KLineStartTime(interval string, t time.Time, loc *time.Location) (start time.Time, err error) {
+	interv, err := StringInterv(interval)
+	if err != nil {
+		return
+	}
+	t = t.In(loc)
+	switch interval {
+	case "1min", "3min", "5min", "15min", "30min":
+		minutes := int(interv / time.Minute)
+		start = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), (t.Minute()/minutes)*minutes, 0, 0, loc)
+	case "1hour", "2hour", "4hour", "6hour", "12hour":
+		hours := int(interv / time.Hour)
+		start = time.Date(t.Year(), t.Month(), t.Day(), (t.Hour()/hours)*hours, 0, 0, 0, loc)
+	case "1day":
+		start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	case "3day":
+		days := int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+		start = time.Date(1970, 1, 1+(days/3)*3, 0, 0, 0, 0, loc)
+	case "1week":
+		start = time.Date(t.Year(), t.Month(), t.Day()-int(t.Weekday()), 0, 0, 0, 0, loc)
+	case "1mon":
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	}
+	return
+}
+
+//KLineAddTime will return the bar start time which is moved by count bars from start, the month bar is moved by calendar month
+func KLineAddTime(interval string, start time.Time, count int) (next time.Time, err error) {
+	interv, err := StringInterv(interval)
+	if err != nil {
+		return
+	}
+	switch interval {
+	case "1day", "3day", "1week":
+		next = start.AddDate(0, 0, count*int(interv/(24*time.Hour)))
+	case "1mon":
+		next = start.AddDate(0, count, 0)
+	default:
+		next = start.Add(time.Duration(count) * interv)
+	}
+	return
+}
+
 func AddMultiKLine(ctx context.Context, lines ...*KLine) (added int64, err error) {
+	added, err = AddMultiKLineCall(Pool(), ctx, lines...)
+	return
+}
+
+func AddMultiKLineCall(caller crud.Queryer, ctx context.Context, lines ...*KLine) (added int64, err error) {
 	if len(lines) < 1 {
 		return
 	}
@@ -66,7 +149,65 @@ func AddMultiKLine(ctx context.Context, lines ...*KLine) (added int64, err error
 		insertVal = append(insertVal, "("+strings.Join(param, ",")+")")
 	}
 	insertSQL := fmt.Sprintf(`insert into %v(%v) values %v`, talbe, strings.Join(fileds, ","), strings.Join(insertVal, ","))
-	_, added, err = Pool().Exec(ctx, insertSQL, insertArg...)
+	_, added, err = caller.Exec(ctx, insertSQL, insertArg...)
+	return
+}
+
+//ReplaceKLine will remove kline by symbol/interval which start_time is in [startTime,endTime) and add lines in one transaction,
+//the flat kline which is not having trade is kept when it is not replaced by lines
+func ReplaceKLine(ctx context.Context, symbol, interval string, startTime, endTime time.Time, lines ...*KLine) (added int64, err error) {
+	tx, err := Pool().Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit(ctx)
+		} else {
+			tx.Rollback(ctx)
+		}
+	}()
+	replaced := []time.Time{}
+	for _, line := range lines {
+		replaced = append(replaced, time.Time(line.StartTime))
+	}
+	_, _, err = tx.Exec(ctx, `delete from exs_kline where symbol=$1 and interv=$2 and start_time>=$3 and start_time<$4 and (count>0 or start_time=any($5))`, symbol, interval, startTime, endTime, replaced)
+	if err != nil {
+		return
+	}
+	added, err = AddMultiKLineCall(tx, ctx, lines...)
+	return
+}
+
+//FindLatestKLine will return the latest persisted kline by symbol/interval, nil is returned when not found
+func FindLatestKLine(ctx context.Context, symbol, interval string) (line *KLine, err error) {
+	var lines []*KLine
+	err = crud.QueryWheref(
+		Pool, ctx, &KLine{}, "#all",
+		"symbol=$%v,interv=$%v", []interface{}{symbol, interval},
+		" order by start_time desc", 0, 1, &lines,
+	)
+	if err == nil && len(lines) > 0 {
+		line = lines[0]
+	}
+	return
+}
+
+//ListOrderForKLine will list filled order by symbol which is updated after startTime and created before endTime, it is used to rebuild kline from order transaction
+func ListOrderForKLine(ctx context.Context, symbol string, startTime, endTime time.Time) (orders []*Order, err error) {
+	querySQL := crud.QuerySQL(&Order{}, "#all")
+	querySQL, args := crud.JoinWheref(querySQL, nil, "symbol=$%v,update_time>=$%v,create_time<$%v", symbol, startTime, endTime)
+	querySQL += " and filled>0 order by create_time asc,tid asc"
+	err = crud.Query(Pool, ctx, &Order{}, "#all", querySQL, args, &orders)
+	return
+}
+
+//ListOrderForTrade will list latest filled order by symbol, it is used to rebuild public trade from order transaction
+func ListOrderForTrade(ctx context.Context, symbol string, limit int) (orders []*Order, err error) {
+	querySQL := crud.QuerySQL(&Order{}, "#all")
+	querySQL, args := crud.JoinWheref(querySQL, nil, "symbol=$%v,filled>$%v", symbol, 0)
+	querySQL += fmt.Sprintf(" order by update_time desc,tid desc limit %v", limit)
+	err = crud.Query(Pool, ctx, &Order{}, "#all", querySQL, args, &orders)
 	return
 }
 
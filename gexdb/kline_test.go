@@ -25,9 +25,69 @@ func TestKLine(t *testing.T) {
 	StringInterv("1week")
 	StringInterv("1mon")
 	StringInterv("xxxx")
+	for _, interval := range KLineIntervAll {
+		interv, err := StringInterv(interval)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		having, err := IntervString(interv)
+		if err != nil || having != interval {
+			t.Errorf("err:%v,interval:%v", err, having)
+			return
+		}
+	}
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	now := time.Date(2022, 3, 16, 13, 47, 10, 0, time.UTC) //2022-03-16 21:47:10 +0800, wednesday
+	starts := map[string]time.Time{
+		"1min":   time.Date(2022, 3, 16, 21, 47, 0, 0, loc),
+		"3min":   time.Date(2022, 3, 16, 21, 45, 0, 0, loc),
+		"15min":  time.Date(2022, 3, 16, 21, 45, 0, 0, loc),
+		"2hour":  time.Date(2022, 3, 16, 20, 0, 0, 0, loc),
+		"6hour":  time.Date(2022, 3, 16, 18, 0, 0, 0, loc),
+		"12hour": time.Date(2022, 3, 16, 12, 0, 0, 0, loc),
+		"1day":   time.Date(2022, 3, 16, 0, 0, 0, 0, loc),
+		"3day":   time.Date(2022, 3, 14, 0, 0, 0, 0, loc),
+		"1week":  time.Date(2022, 3, 13, 0, 0, 0, 0, loc),
+		"1mon":   time.Date(2022, 3, 1, 0, 0, 0, 0, loc),
+	}
+	for interval, expect := range starts {
+		start, err := KLineStartTime(interval, now, loc)
+		if err != nil || !start.Equal(expect) {
+			t.Errorf("interval:%v,err:%v,start:%v,expect:%v", interval, err, start, expect)
+			return
+		}
+	}
+	start, _ := KLineStartTime("1day", now, time.UTC)
+	if !start.Equal(time.Date(2022, 3, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Error(start)
+		return
+	}
+	_, err := KLineStartTime("xxx", now, loc)
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	adds := map[string]time.Time{
+		"15min": time.Date(2022, 3, 16, 21, 15, 0, 0, loc),
+		"3day":  time.Date(2022, 3, 8, 0, 0, 0, 0, loc),
+		"1mon":  time.Date(2022, 1, 1, 0, 0, 0, 0, loc),
+	}
+	for interval, expect := range adds {
+		next, err := KLineAddTime(interval, starts[interval], -2)
+		if err != nil || !next.Equal(expect) {
+			t.Errorf("interval:%v,err:%v,next:%v,expect:%v", interval, err, next, expect)
+			return
+		}
+	}
+	_, err = KLineAddTime("xxx", now, 1)
+	if err == nil {
+		t.Error(err)
+		return
+	}
 	//
 	line := &KLine{StartTime: xsql.TimeNow()}
-	_, err := AddMultiKLine(ctx, line)
+	_, err = AddMultiKLine(ctx, line)
 	if err != nil {
 		t.Error(err)
 		return
@@ -43,6 +103,31 @@ func TestKLine(t *testing.T) {
 		t.Error(err)
 		return
 	}
+	latest, err := FindLatestKLine(ctx, line.Symbol, line.Interv)
+	if err != nil || latest == nil {
+		t.Error(err)
+		return
+	}
+	added, err := ReplaceKLine(ctx, line.Symbol, line.Interv, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), &KLine{Interv: line.Interv, Count: 10, StartTime: xsql.TimeNow()})
+	if err != nil || added != 1 {
+		t.Error(err)
+		return
+	}
+	lines, err = ListKLine(ctx, line.Symbol, line.Interv, time.Now().Add(-time.Hour), time.Now())
+	if err != nil || len(lines) != 1 || lines[0].Count != 10 {
+		t.Error(err)
+		return
+	}
+	latest, err = FindLatestKLine(ctx, "none", line.Interv)
+	if err != nil || latest != nil {
+		t.Error(err)
+		return
+	}
+	_, err = ListOrderForKLine(ctx, "none", time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Error(err)
+		return
+	}
 	//
 	//test error
 	pgx.MockerStart()
@@ -64,4 +149,19 @@ func TestKLine(t *testing.T) {
 		return
 	}
 	pgx.MockerClear()
+	//replace kline error
+	pgx.MockerSet("Pool.Begin", 1)
+	_, err = ReplaceKLine(ctx, line.Symbol, line.Interv, time.Now().Add(-time.Hour), time.Now())
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+	pgx.MockerSet("Tx.Exec", 1)
+	_, err = ReplaceKLine(ctx, line.Symbol, line.Interv, time.Now().Add(-time.Hour), time.Now())
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
 }
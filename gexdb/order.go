@@ -67,6 +67,21 @@ func CancelTriggerOrder(ctx context.Context, userID int64, symbol string, orderI
 	return
 }
 
+func CountOpenOrder(ctx context.Context, userID int64, symbol string) (count int64, err error) {
+	count, err = CountOpenOrderCall(Pool(), ctx, userID, symbol)
+	return
+}
+
+func CountOpenOrderCall(caller crud.Queryer, ctx context.Context, userID int64, symbol string) (count int64, err error) {
+	err = crud.CountWheref(
+		caller, ctx, &Order{}, "count(*)#all",
+		"user_id=$%v,symbol=$%v,type=$%v,status=any($%v)#all",
+		[]interface{}{userID, symbol, OrderTypeTrade, OrderStatusArray{OrderStatusPending, OrderStatusPartialled}},
+		"", &count,
+	)
+	return
+}
+
 func ListOrderForTrigger(ctx context.Context, symbol string, ask, bid decimal.Decimal) (orders []*Order, err error) {
 	orders, err = ListOrderForTriggerCall(Pool(), ctx, symbol, ask, bid)
 	return
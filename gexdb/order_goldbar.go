@@ -0,0 +1,306 @@
+package gexdb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/shopspring/decimal"
+)
+
+//GoldbarAddress is the goldbar pickup address which is configured by goldbar_address
+type GoldbarAddress struct {
+	City    string `json:"city"`
+	Address string `json:"address"`
+}
+
+//GoldbarConfig is the goldbar redemption config
+type GoldbarConfig struct {
+	Rate    decimal.Decimal   `json:"rate"`
+	Fee     decimal.Decimal   `json:"fee"`
+	Address []*GoldbarAddress `json:"address"`
+}
+
+//LoadGoldbarConfigCall will load goldbar rate/fee/address config, the rate must be setted
+func LoadGoldbarConfigCall(caller crud.Queryer, ctx context.Context) (config *GoldbarConfig, err error) {
+	var rate, fee float64
+	var address string
+	err = basedb.LoadConfCall(caller, ctx, ConfigGoldbarRate, &rate)
+	if err != nil {
+		return
+	}
+	if rate <= 0 {
+		err = fmt.Errorf("goldbar rate %v is invalid", rate)
+		return
+	}
+	err = basedb.LoadConfCall(caller, ctx, ConfigGoldbarFee, &fee)
+	if err != nil && err != pgx.ErrNoRows {
+		return
+	}
+	err = basedb.LoadConfCall(caller, ctx, ConfigGoldbarAddress, &address)
+	if err != nil && err != pgx.ErrNoRows {
+		return
+	}
+	config = &GoldbarConfig{
+		Rate: decimal.NewFromFloat(rate),
+		Fee:  decimal.NewFromFloat(fee),
+	}
+	err = nil
+	if len(address) > 0 {
+		err = json.Unmarshal([]byte(address), &config.Address)
+	}
+	return
+}
+
+//CheckAddress will check city and address is configured, it is not limited when address is not configured
+func (g *GoldbarConfig) CheckAddress(city, address string) (err error) {
+	if len(city) < 1 || len(address) < 1 {
+		err = ErrGoldbarAddressInvalid("goldbar city/address is required")
+		return
+	}
+	if len(g.Address) < 1 {
+		return
+	}
+	for _, having := range g.Address {
+		if having.City == city && having.Address == address {
+			return
+		}
+	}
+	err = ErrGoldbarAddressInvalid(fmt.Sprintf("goldbar address %v %v is not supported", city, address))
+	return
+}
+
+//GoldbarCodeFailMax is the max wrong pickup code verify, the goldbar order is locked to verify after it and only can be canceled
+var GoldbarCodeFailMax = 5
+
+func newGoldbarCode() (code string, err error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err == nil {
+		code = fmt.Sprintf("%06d", n.Int64())
+	}
+	return
+}
+
+func appendGoldbarHistory(goldbar *Withdraw, status WithdrawStatus, userID int64, note string) {
+	if goldbar.Transaction == nil {
+		goldbar.Transaction = xsql.M{}
+	}
+	history, _ := goldbar.Transaction["history"].([]interface{})
+	history = append(history, xmap.M{
+		"status":      status,
+		"user_id":     userID,
+		"note":        note,
+		"create_time": xsql.TimeNow(),
+	})
+	goldbar.Transaction["history"] = history
+}
+
+//CreateGoldbar will create goldbar redemption order and lock quantity*rate plus fee on funds YWE balance,
+//the pickup code is generated to transaction.code and it is verified by admin when user pickup,
+//the asset withdraw and user tier quota is checked as withdraw
+func CreateGoldbar(ctx context.Context, userID int64, quantity int64, city, address string) (goldbar *Withdraw, err error) {
+	if quantity < 1 {
+		err = fmt.Errorf("goldbar quantity %v is invalid", quantity)
+		return
+	}
+	tx, err := Pool().Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit(ctx)
+		} else {
+			tx.Rollback(ctx)
+		}
+	}()
+	config, err := LoadGoldbarConfigCall(tx, ctx)
+	if err != nil {
+		return
+	}
+	err = config.CheckAddress(city, address)
+	if err != nil {
+		return
+	}
+	total := config.Rate.Mul(decimal.NewFromInt(quantity))
+	fee := total.Mul(config.Fee)
+	_, err = CheckWithdrawAssetCall(tx, ctx, BalanceAssetGoldbar, total.Add(fee))
+	if err != nil {
+		return
+	}
+	code, err := newGoldbarCode()
+	if err != nil {
+		return
+	}
+	goldbar = &Withdraw{
+		OrderID:  NewOrderID(),
+		Type:     WithdrawTypeGoldbar,
+		UserID:   userID,
+		Creator:  userID,
+		Asset:    BalanceAssetGoldbar,
+		Address:  address,
+		Quantity: total.Add(fee),
+		Fee:      fee,
+		Transaction: xsql.M{
+			"quantity": quantity,
+			"rate":     config.Rate,
+			"fee_rate": config.Fee,
+			"city":     city,
+			"address":  address,
+			"code":     code,
+		},
+		Status: WithdrawStatusPending,
+	}
+	appendGoldbarHistory(goldbar, WithdrawStatusPending, userID, "")
+	balance := &Balance{
+		UserID: userID,
+		Area:   BalanceAreaFunds,
+		Asset:  BalanceAssetGoldbar,
+		Free:   decimal.Zero.Sub(goldbar.Quantity),
+		Locked: goldbar.Quantity,
+	}
+	err = IncreaseBalanceRecordCall(tx, ctx, balance, BalanceRecordTypeGoldbar, goldbar.OrderID)
+	if err != nil {
+		return
+	}
+	//balance is locked for update, so the quota used is not changed by other withdraw on same asset
+	quota, err := LoadWithdrawQuotaCall(tx, ctx, userID, BalanceAssetGoldbar)
+	if err != nil {
+		return
+	}
+	err = quota.Check(goldbar.Quantity)
+	if err != nil {
+		return
+	}
+	err = AddWithdrawCall(tx, ctx, goldbar)
+	return
+}
+
+//CancelGoldbar will cancel the pending goldbar order and refund the locked to free, userID is zero when canceled by admin
+func CancelGoldbar(ctx context.Context, userID, operator int64, orderID, note string) (goldbar *Withdraw, err error) {
+	tx, err := Pool().Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit(ctx)
+		} else {
+			tx.Rollback(ctx)
+		}
+	}()
+	goldbar, err = FindWithdrawByOrderIDCall(tx, ctx, orderID, true)
+	if err != nil {
+		return
+	}
+	if userID > 0 && goldbar.UserID != userID {
+		err = define.ErrNotAccess
+		return
+	}
+	if goldbar.Type != WithdrawTypeGoldbar {
+		err = fmt.Errorf("order is not goldbar")
+		return
+	}
+	if goldbar.Status != WithdrawStatusPending {
+		err = fmt.Errorf("order is not pending")
+		return
+	}
+	balance := &Balance{
+		UserID: goldbar.UserID,
+		Area:   BalanceAreaFunds,
+		Asset:  goldbar.Asset,
+		Free:   goldbar.Quantity,
+		Locked: decimal.Zero.Sub(goldbar.Quantity),
+	}
+	err = IncreaseBalanceRecordCall(tx, ctx, balance, BalanceRecordTypeGoldbar, goldbar.OrderID)
+	if err != nil {
+		return
+	}
+	goldbar.Status = WithdrawStatusCanceled
+	appendGoldbarHistory(goldbar, WithdrawStatusCanceled, operator, note)
+	err = goldbar.UpdateFilter(tx, ctx, "transaction,status")
+	return
+}
+
+//VerifyGoldbar will verify the pickup code by admin and done the pending goldbar order, the locked balance is released,
+//the wrong code is counted to transaction.code_fail and the order is locked to verify after GoldbarCodeFailMax
+func VerifyGoldbar(ctx context.Context, verifier int64, orderID, code string) (goldbar *Withdraw, err error) {
+	tx, err := Pool().Begin(ctx)
+	if err != nil {
+		return
+	}
+	var codeErr error
+	defer func() {
+		if err == nil {
+			err = tx.Commit(ctx)
+		} else {
+			tx.Rollback(ctx)
+		}
+		if err == nil {
+			err = codeErr
+		}
+	}()
+	goldbar, err = FindWithdrawByOrderIDCall(tx, ctx, orderID, true)
+	if err != nil {
+		return
+	}
+	if goldbar.Type != WithdrawTypeGoldbar {
+		err = fmt.Errorf("order is not goldbar")
+		return
+	}
+	if goldbar.Status != WithdrawStatusPending {
+		err = fmt.Errorf("order is not pending")
+		return
+	}
+	codeFail := xmap.M(goldbar.Transaction).IntDef(0, "code_fail")
+	if codeFail >= GoldbarCodeFailMax {
+		err = ErrGoldbarCodeInvalid(fmt.Sprintf("goldbar pickup code is locked by %v fail", codeFail))
+		return
+	}
+	if having := xmap.M(goldbar.Transaction).Str("code"); len(having) < 1 || having != code {
+		//the fail is committed and the code error is returned after commit
+		goldbar.Transaction["code_fail"] = codeFail + 1
+		codeErr = ErrGoldbarCodeInvalid("goldbar pickup code is invalid")
+		err = goldbar.UpdateFilter(tx, ctx, "transaction")
+		return
+	}
+	balance := &Balance{
+		UserID: goldbar.UserID,
+		Area:   BalanceAreaFunds,
+		Asset:  goldbar.Asset,
+		Locked: decimal.Zero.Sub(goldbar.Quantity),
+	}
+	err = IncreaseBalanceRecordCall(tx, ctx, balance, BalanceRecordTypeGoldbar, goldbar.OrderID)
+	if err != nil {
+		return
+	}
+	err = bookWithdrawFeeCall(tx, ctx, goldbar)
+	if err != nil {
+		return
+	}
+	goldbar.Status = WithdrawStatusDone
+	appendGoldbarHistory(goldbar, WithdrawStatusDone, verifier, "")
+	err = goldbar.UpdateFilter(tx, ctx, "transaction,status")
+	return
+}
+
+//LoadGoldbar will load goldbar order with status history on transaction.history, userID is zero when loaded by admin
+func LoadGoldbar(ctx context.Context, userID int64, orderID string) (goldbar *Withdraw, err error) {
+	goldbar, err = FindWithdrawByOrderIDCall(Pool(), ctx, orderID, false)
+	if err != nil {
+		return
+	}
+	if (userID > 0 && goldbar.UserID != userID) || goldbar.Type != WithdrawTypeGoldbar {
+		err = pgx.ErrNoRows
+		return
+	}
+	return
+}
@@ -0,0 +1,272 @@
+package gexdb
+
+import (
+	"testing"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/shopspring/decimal"
+)
+
+func TestGoldbar(t *testing.T) {
+	clear()
+	defer basedb.StoreConf(ctx, ConfigGoldbarAddress, "[]")
+	user := testAddUser("TestGoldbar")
+	_, err := TouchBalance(ctx, BalanceAreaFunds, []string{BalanceAssetGoldbar}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaFunds, Asset: BalanceAssetGoldbar, Free: decimal.NewFromFloat(10000)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	basedb.StoreConf(ctx, ConfigGoldbarRate, "1600")
+	basedb.StoreConf(ctx, ConfigGoldbarFee, "0.005")
+	//create and cancel
+	goldbar, err := CreateGoldbar(ctx, user.TID, 1, "city", "address")
+	if err != nil || !goldbar.Quantity.Equal(decimal.NewFromFloat(1608)) || !goldbar.Fee.Equal(decimal.NewFromFloat(8)) {
+		t.Errorf("err:%v,goldbar:%v", err, goldbar)
+		return
+	}
+	balance, _ := FindBalanceByAsset(ctx, user.TID, BalanceAreaFunds, BalanceAssetGoldbar)
+	if !balance.Free.Equal(decimal.NewFromFloat(8392)) || !balance.Locked.Equal(decimal.NewFromFloat(1608)) {
+		t.Errorf("balance:%v", balance)
+		return
+	}
+	if _, err = CancelGoldbar(ctx, user.TID+1, user.TID+1, goldbar.OrderID, ""); err != define.ErrNotAccess {
+		t.Error(err)
+		return
+	}
+	goldbar, err = CancelGoldbar(ctx, user.TID, user.TID, goldbar.OrderID, "cancel")
+	if err != nil || goldbar.Status != WithdrawStatusCanceled {
+		t.Errorf("err:%v,goldbar:%v", err, goldbar)
+		return
+	}
+	balance, _ = FindBalanceByAsset(ctx, user.TID, BalanceAreaFunds, BalanceAssetGoldbar)
+	if !balance.Free.Equal(decimal.NewFromFloat(10000)) || !balance.Locked.IsZero() {
+		t.Errorf("balance:%v", balance)
+		return
+	}
+	goldbar, err = LoadGoldbar(ctx, user.TID, goldbar.OrderID)
+	if err != nil || len(xmap.M(goldbar.Transaction).ArrayMapDef(nil, "history")) != 2 {
+		t.Errorf("err:%v,goldbar:%v", err, goldbar)
+		return
+	}
+	if _, err = CancelGoldbar(ctx, 0, 0, goldbar.OrderID, ""); err == nil {
+		t.Error(err)
+		return
+	}
+	//create and verify
+	basedb.StoreConf(ctx, ConfigGoldbarAddress, `[{"city":"city","address":"address"}]`)
+	goldbar, err = CreateGoldbar(ctx, user.TID, 2, "city", "address")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	code := xmap.M(goldbar.Transaction).Str("code")
+	if _, err = VerifyGoldbar(ctx, 1, goldbar.OrderID, "xxx"); !IsErrGoldbarCodeInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if having, _ := LoadGoldbar(ctx, 0, goldbar.OrderID); having == nil || xmap.M(having.Transaction).IntDef(0, "code_fail") != 1 {
+		t.Errorf("goldbar:%v", having)
+		return
+	}
+	goldbar, err = VerifyGoldbar(ctx, 1, goldbar.OrderID, code)
+	if err != nil || goldbar.Status != WithdrawStatusDone {
+		t.Errorf("err:%v,goldbar:%v", err, goldbar)
+		return
+	}
+	balance, _ = FindBalanceByAsset(ctx, user.TID, BalanceAreaFunds, BalanceAssetGoldbar)
+	if !balance.Free.Equal(decimal.NewFromFloat(6784)) || !balance.Locked.IsZero() {
+		t.Errorf("balance:%v", balance)
+		return
+	}
+	feeRecord, err := FindBalanceRecordWheref(ctx, "ref_id=$%v,type=$%v", goldbar.OrderID, BalanceRecordTypeFee)
+	if err != nil || feeRecord.UserID != 1000 || !feeRecord.FreeDelta.Equal(goldbar.Fee) {
+		t.Errorf("err:%v,record:%v", err, feeRecord)
+		return
+	}
+	if _, err = VerifyGoldbar(ctx, 1, goldbar.OrderID, code); err == nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CancelGoldbar(ctx, 0, 0, goldbar.OrderID, ""); err == nil {
+		t.Error(err)
+		return
+	}
+	//code locked
+	locked, err := CreateGoldbar(ctx, user.TID, 1, "city", "address")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < GoldbarCodeFailMax; i++ {
+		if _, err = VerifyGoldbar(ctx, 1, locked.OrderID, "xxx"); !IsErrGoldbarCodeInvalid(err) {
+			t.Error(err)
+			return
+		}
+	}
+	if _, err = VerifyGoldbar(ctx, 1, locked.OrderID, xmap.M(locked.Transaction).Str("code")); !IsErrGoldbarCodeInvalid(err) {
+		t.Error(err)
+		return
+	}
+	_, err = CancelGoldbar(ctx, 0, 1, locked.OrderID, "locked")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//asset and quota
+	_, _, err = Pool().Exec(ctx, `update exs_asset set withdraw=$1 where asset=$2`, AssetWithdrawDisabled, BalanceAssetGoldbar)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "address"); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	_, _, err = Pool().Exec(ctx, `update exs_asset set withdraw=$1 where asset=$2`, AssetWithdrawEnabled, BalanceAssetGoldbar)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	basedb.StoreConf(ctx, ConfigWithdrawQuota, `{"":{"YWE":{"daily":"5000"}}}`)
+	if _, err = CreateGoldbar(ctx, user.TID, 2, "city", "address"); !IsErrWithdrawDailyQuota(err) {
+		basedb.StoreConf(ctx, ConfigWithdrawQuota, "{}")
+		t.Error(err)
+		return
+	}
+	basedb.StoreConf(ctx, ConfigWithdrawQuota, "{}")
+	//error
+	if _, err = CreateGoldbar(ctx, user.TID, 0, "city", "address"); err == nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "other"); !IsErrGoldbarAddressInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "", ""); !IsErrGoldbarAddressInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateGoldbar(ctx, user.TID, 100, "city", "address"); !IsErrBalanceNotEnought(err) {
+		t.Error(err)
+		return
+	}
+	if _, err = LoadGoldbar(ctx, user.TID+1, goldbar.OrderID); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	if _, err = LoadGoldbar(ctx, 0, "none"); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	withdraw := &Withdraw{
+		OrderID:  NewOrderID(),
+		Type:     WithdrawTypeWithdraw,
+		UserID:   user.TID,
+		Creator:  user.TID,
+		Asset:    BalanceAssetGoldbar,
+		Quantity: decimal.NewFromFloat(1),
+		Status:   WithdrawStatusPending,
+	}
+	err = AddWithdraw(ctx, withdraw)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CancelGoldbar(ctx, 0, 0, withdraw.OrderID, ""); err == nil {
+		t.Error(err)
+		return
+	}
+	if _, err = VerifyGoldbar(ctx, 1, withdraw.OrderID, ""); err == nil {
+		t.Error(err)
+		return
+	}
+	if _, err = LoadGoldbar(ctx, 0, withdraw.OrderID); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	basedb.StoreConf(ctx, ConfigGoldbarRate, "0")
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "address"); err == nil {
+		t.Error(err)
+		return
+	}
+	basedb.StoreConf(ctx, ConfigGoldbarRate, "1600")
+	basedb.StoreConf(ctx, ConfigGoldbarAddress, "xx")
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "address"); err == nil {
+		t.Error(err)
+		return
+	}
+	basedb.StoreConf(ctx, ConfigGoldbarAddress, "[]")
+	goldbar, err = CreateGoldbar(ctx, user.TID, 1, "city", "address")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Begin", 1)
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "address"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Query", 1)
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "address"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Query", 2)
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "address"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Query", 3)
+	if _, err = CreateGoldbar(ctx, user.TID, 1, "city", "address"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Begin", 1)
+	if _, err = CancelGoldbar(ctx, 0, 0, goldbar.OrderID, ""); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Exec", 1)
+	if _, err = CancelGoldbar(ctx, 0, 0, goldbar.OrderID, ""); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Begin", 1)
+	if _, err = VerifyGoldbar(ctx, 1, goldbar.OrderID, ""); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Exec", 1)
+	if _, err = VerifyGoldbar(ctx, 1, goldbar.OrderID, xmap.M(goldbar.Transaction).Str("code")); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
@@ -1,54 +1,129 @@
 package gexdb
 
-// import (
-// 	"github.com/codingeasygo/util/converter"
-// 	"github.com/codingeasygo/util/xmap"
-// 	"github.com/shopspring/decimal"
-// 	"github.com/gexservice/gexservice/base/xlog"
-// )
+import (
+	"context"
+	"fmt"
+	"time"
 
-// func PayTopupOrder(orderID string, totalAmount decimal.Decimal, result xmap.M) (err error) {
-// 	tx, err := Pool().Begin()
-// 	if err != nil {
-// 		return
-// 	}
-// 	defer func() {
-// 		if err == nil {
-// 			err = tx.Commit()
-// 		} else {
-// 			tx.Rollback()
-// 		}
-// 	}()
-// 	order := Order{OrderID: orderID, Filled: totalAmount, InFilled: totalAmount}
-// 	err = tx.QueryRow(`select tid,type,user_id,in_balance,status from exs_order where order_id=$1 for update`, orderID).Scan(&order.TID, &order.Type, &order.UserID, &order.InBalance, &order.Status)
-// 	if err != nil {
-// 		xlog.Errorf("PayOrder receive pay notify fail with lock order %v, notify result is %v", err, converter.JSON(result))
-// 		return
-// 	}
-// 	if order.Status != OrderStatusPending {
-// 		xlog.Warnf("PayOrder receive pay notify fail with order status is %v, skipped notify result is %v", order.Status, converter.JSON(result))
-// 		return
-// 	}
-// 	if order.Type != OrderTypeTopup {
-// 		xlog.Warnf("PayOrder receive pay notify fail with order type is %v, skipped notify result is %v", order.Type, converter.JSON(result))
-// 		return
-// 	}
-// 	err = tx.ExecRow(`update exs_order set filled=$1,in_filled=$1,status=$2,notify_result=$3 where tid=$4`, order.InFilled, OrderStatusDone, converter.JSON(result), order.TID)
-// 	if err != nil {
-// 		xlog.Errorf("PayOrder receive pay notify fail with update order %v, notify result is %v", err, converter.JSON(result))
-// 		return
-// 	}
-// 	balance := &Balance{
-// 		UserID: order.UserID,
-// 		Asset:  order.InBalance,
-// 		Free:   order.Filled,
-// 		Status: BalanceStatusNormal,
-// 	}
-// 	err = IncreaseBalanceCall(tx, balance)
-// 	if err != nil {
-// 		xlog.Errorf("PayOrder receive pay notify fail with increase blance %v, notify result is %v", err, converter.JSON(result))
-// 		return
-// 	}
-// 	xlog.Infof("PayOrder receive pay notify on order %v success by increase %v %v to user %v", order.OrderID, order.InFilled, order.InBalance, order.UserID)
-// 	return
-// }
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/shopspring/decimal"
+)
+
+//CreateTopup will create pending topup order, the balance is increased when it is paid by PayTopup
+func CreateTopup(ctx context.Context, userID int64, asset string, quantity decimal.Decimal) (topup *Withdraw, err error) {
+	err = CheckTopupAssetCall(Pool(), ctx, asset, quantity)
+	if err != nil {
+		return
+	}
+	topup = &Withdraw{
+		OrderID:     NewOrderID(),
+		Type:        WithdrawTypeTopup,
+		UserID:      userID,
+		Creator:     userID,
+		Asset:       asset,
+		Quantity:    quantity,
+		Transaction: xsql.M{},
+		Status:      WithdrawStatusPending,
+	}
+	err = AddWithdraw(ctx, topup)
+	return
+}
+
+//UpdateTopupPrepay will update the payment prepay info which is created by provider to topup transaction
+func UpdateTopupPrepay(ctx context.Context, topup *Withdraw, provider string, prepay xmap.M) (err error) {
+	if topup.Transaction == nil {
+		topup.Transaction = xsql.M{}
+	}
+	topup.Transaction["provider"] = provider
+	topup.Transaction["prepay"] = prepay
+	err = topup.UpdateFilter(Pool(), ctx, "transaction")
+	return
+}
+
+//PayTopup will mark pending topup to done and increase funds balance by quantity, it is idempotent,
+//the done topup is returned without increase balance again and paid is false,
+//the canceled topup is paid late after expired, it is also done and flagged by late_paid on transaction
+func PayTopup(ctx context.Context, orderID string, amount decimal.Decimal, result xmap.M) (topup *Withdraw, paid bool, err error) {
+	tx, err := Pool().Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit(ctx)
+		} else {
+			tx.Rollback(ctx)
+		}
+	}()
+	topup, err = FindWithdrawByOrderIDCall(tx, ctx, orderID, true)
+	if err != nil {
+		return
+	}
+	if topup.Type != WithdrawTypeTopup {
+		err = fmt.Errorf("order %v is not topup", orderID)
+		return
+	}
+	if topup.Status == WithdrawStatusDone {
+		return
+	}
+	if topup.Status != WithdrawStatusPending && topup.Status != WithdrawStatusCanceled {
+		err = fmt.Errorf("order %v status is %v", orderID, topup.Status)
+		return
+	}
+	if !amount.Equal(topup.Quantity) {
+		err = fmt.Errorf("order %v pay amount %v is not equal to %v", orderID, amount, topup.Quantity)
+		return
+	}
+	_, err = TouchBalanceCall(tx, ctx, BalanceAreaFunds, []string{topup.Asset}, topup.UserID)
+	if err != nil {
+		return
+	}
+	balance := &Balance{
+		UserID: topup.UserID,
+		Area:   BalanceAreaFunds,
+		Asset:  topup.Asset,
+		Free:   topup.Quantity,
+	}
+	err = IncreaseBalanceRecordCall(tx, ctx, balance, BalanceRecordTypeTopup, topup.OrderID)
+	if err != nil {
+		return
+	}
+	if topup.Transaction == nil {
+		topup.Transaction = xsql.M{}
+	}
+	if topup.Status == WithdrawStatusCanceled {
+		topup.Transaction["late_paid"] = 1
+	}
+	topup.Transaction["pay_result"] = result
+	topup.Status = WithdrawStatusDone
+	err = topup.UpdateFilter(tx, ctx, "transaction,status")
+	if err == nil {
+		paid = true
+	}
+	return
+}
+
+//CancelTopup will cancel the pending topup order with reason, pgx.ErrNoRows is returned when topup is not pending
+func CancelTopup(ctx context.Context, orderID string, reason string) (err error) {
+	_, affected, err := Pool().Exec(
+		ctx,
+		`update exs_withdraw set transaction=transaction||jsonb_build_object('cancel_reason',$1::text),update_time=$2,status=$3 where order_id=$4 and type=$5 and status=$6`,
+		reason, xsql.TimeNow(), WithdrawStatusCanceled, orderID, WithdrawTypeTopup, WithdrawStatusPending,
+	)
+	if err == nil && affected < 1 {
+		err = pgx.ErrNoRows
+	}
+	return
+}
+
+//ListPendingTopup will list the pending topup which is created before time
+func ListPendingTopup(ctx context.Context, before time.Time, limit int) (topups []*Withdraw, err error) {
+	err = ScanWithdrawFilterWherefCall(
+		Pool(), ctx, "#all",
+		"type=$%v,status=$%v,create_time<$%v", []interface{}{WithdrawTypeTopup, WithdrawStatusPending, before},
+		fmt.Sprintf("order by create_time asc limit %v", limit), &topups,
+	)
+	return
+}
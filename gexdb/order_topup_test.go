@@ -1,136 +1,145 @@
 package gexdb
 
-// import (
-// 	"fmt"
-// 	"testing"
+import (
+	"testing"
+	"time"
 
-// 	"github.com/codingeasygo/crud/pgx"
-// 	"github.com/codingeasygo/util/xmap"
-// 	"github.com/codingeasygo/util/xsql"
-// 	"github.com/shopspring/decimal"
-// )
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/shopspring/decimal"
+)
 
-// func TestTopupOrder(t *testing.T) {
-// 	user := testAddUser("TestTopupOrder")
-// 	TouchBalance(BalanceAssetAll, user.TID)
-// 	order := &Order{
-// 		Type:      OrderTypeTopup,
-// 		UserID:    user.TID,
-// 		InBalance: BalanceAssetMMK,
-// 		InFilled:  decimal.NewFromFloat(100),
-// 		Status:    OrderStatusPending,
-// 	}
-// 	err := CreateOrder(order)
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	err = UpdateOrderPrepay(order.TID, xsql.M{"test": 1})
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	err = PayTopupOrder(order.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	balances, err := ListUserBalance(order.UserID, BalanceAssetAll, BalanceStatusAll)
-// 	if err != nil || len(balances) != 2 {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	if !balances[BalanceAssetMMK].Free.Equal(order.InFilled) {
-// 		t.Error("value error")
-// 		return
-// 	}
+func TestTopup(t *testing.T) {
+	clear()
+	user := testAddUser("TestTopup")
+	topup, err := CreateTopup(ctx, user.TID, "USDT", decimal.NewFromFloat(100))
+	if err != nil || topup.Type != WithdrawTypeTopup || topup.Status != WithdrawStatusPending {
+		t.Errorf("err:%v,topup:%v", err, topup)
+		return
+	}
+	err = UpdateTopupPrepay(ctx, topup, "test", xmap.M{"test": 1})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//pay
+	paidTopup, paid, err := PayTopup(ctx, topup.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
+	if err != nil || !paid || paidTopup.Status != WithdrawStatusDone {
+		t.Errorf("err:%v,paid:%v,topup:%v", err, paid, paidTopup)
+		return
+	}
+	//pay again
+	_, paid, err = PayTopup(ctx, topup.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
+	if err != nil || paid {
+		t.Errorf("err:%v,paid:%v", err, paid)
+		return
+	}
+	balance, err := FindBalanceByAsset(ctx, user.TID, BalanceAreaFunds, "USDT")
+	if err != nil || !balance.Free.Equal(decimal.NewFromFloat(100)) {
+		t.Errorf("err:%v,balance:%v", err, balance)
+		return
+	}
+	if err = CancelTopup(ctx, topup.OrderID, "test"); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	//cancel
+	canceled, err := CreateTopup(ctx, user.TID, "USDT", decimal.NewFromFloat(100))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pending, err := ListPendingTopup(ctx, time.Now().Add(time.Second), 10)
+	if err != nil || len(pending) != 1 || pending[0].OrderID != canceled.OrderID {
+		t.Errorf("err:%v,pending:%v", err, len(pending))
+		return
+	}
+	pending, err = ListPendingTopup(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil || len(pending) != 0 {
+		t.Errorf("err:%v,pending:%v", err, len(pending))
+		return
+	}
+	err = CancelTopup(ctx, canceled.OrderID, "test")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//late paid
+	latePaid, paid, err := PayTopup(ctx, canceled.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
+	if err != nil || !paid || latePaid.Status != WithdrawStatusDone || latePaid.Transaction["late_paid"] == nil {
+		t.Errorf("err:%v,paid:%v,topup:%v", err, paid, latePaid)
+		return
+	}
+	balance, err = FindBalanceByAsset(ctx, user.TID, BalanceAreaFunds, "USDT")
+	if err != nil || !balance.Free.Equal(decimal.NewFromFloat(200)) {
+		t.Errorf("err:%v,balance:%v", err, balance)
+		return
+	}
+	//error
+	if _, err = CreateTopup(ctx, user.TID, "USDT", decimal.Zero); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	unmatched, err := CreateTopup(ctx, user.TID, "USDT", decimal.NewFromFloat(100))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, _, err = PayTopup(ctx, unmatched.OrderID, decimal.NewFromFloat(10), xmap.M{"test": 1}); err == nil {
+		t.Error(err)
+		return
+	}
+	if _, _, err = PayTopup(ctx, "none", decimal.NewFromFloat(10), xmap.M{"test": 1}); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	withdraw := &Withdraw{
+		OrderID:  NewOrderID(),
+		Type:     WithdrawTypeWithdraw,
+		UserID:   user.TID,
+		Creator:  user.TID,
+		Asset:    "USDT",
+		Quantity: decimal.NewFromFloat(100),
+		Status:   WithdrawStatusPending,
+	}
+	err = AddWithdraw(ctx, withdraw)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, _, err = PayTopup(ctx, withdraw.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1}); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
 
-// 	findOrder, err := FindOrderByOrderID(order.OrderID)
-// 	if err != nil || findOrder.TID != order.TID {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	findOrder, err = FindOrderByOrderID(fmt.Sprintf("%v", order.TID))
-// 	if err != nil || findOrder.TID != order.TID {
-// 		t.Error(err)
-// 		return
-// 	}
+	pgx.MockerSet("Pool.Begin", 1)
+	if _, _, err = PayTopup(ctx, unmatched.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1}); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
 
-// 	//
-// 	//test error
-// 	pgx.MockerStart()
-// 	defer pgx.MockerStop()
-// 	order2 := &Order{
-// 		Type:      OrderTypeTopup,
-// 		UserID:    user.TID,
-// 		InBalance: BalanceAssetMMK,
-// 		InFilled:  decimal.NewFromFloat(100),
-// 		Status:    OrderStatusPending,
-// 	}
-// 	err = CreateOrder(order2)
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	order3 := &Order{
-// 		Type:      OrderTypeGoldbar,
-// 		UserID:    user.TID,
-// 		InBalance: BalanceAssetMMK,
-// 		InFilled:  decimal.NewFromFloat(100),
-// 		Status:    OrderStatusPending,
-// 	}
-// 	err = CreateOrder(order3)
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
+	pgx.MockerSet("Tx.Exec", 1)
+	if _, _, err = PayTopup(ctx, unmatched.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1}); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
 
-// 	//
-// 	//PayTopupOrder error
-// 	pgx.MockerSet("Pool.Begin", 1)
-// 	err = PayTopupOrder(order2.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
-// 	if err == nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
+	pgx.MockerSet("Tx.Exec", 2)
+	if _, _, err = PayTopup(ctx, unmatched.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1}); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
 
-// 	pgx.MockerSet("Row.Scan", 1)
-// 	err = PayTopupOrder(order2.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
-// 	if err == nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	pgx.MockerSet("Tx.Exec", 1)
-// 	err = PayTopupOrder(order2.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
-// 	if err == nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	pgx.MockerSet("Row.Scan", 2)
-// 	err = PayTopupOrder(order2.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1})
-// 	if err == nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	err = PayTopupOrder(order.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1}) //status error
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-
-// 	err = PayTopupOrder(order3.OrderID, decimal.NewFromFloat(100), xmap.M{"test": 1}) //type error
-// 	if err != nil {
-// 		t.Error(err)
-// 		return
-// 	}
-// 	pgx.MockerClear()
-// }
+	pgx.MockerSet("Pool.Exec", 1)
+	if err = CancelTopup(ctx, unmatched.OrderID, "test"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
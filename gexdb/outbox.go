@@ -0,0 +1,108 @@
+package gexdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xsql"
+)
+
+//AddOutboxEventCall will add event to outbox by next sequence of symbol, it should be called in matcher lock to keep sequence ordered by commit
+func AddOutboxEventCall(caller crud.Queryer, ctx context.Context, symbol string, event xsql.M) (outbox *Outbox, err error) {
+	outbox = &Outbox{
+		Symbol:     symbol,
+		Event:      event,
+		UpdateTime: xsql.TimeNow(),
+		CreateTime: xsql.TimeNow(),
+		Status:     OutboxStatusNormal,
+	}
+	err = caller.QueryRow(
+		ctx,
+		`insert into exs_outbox(symbol,sequence,event,update_time,create_time,status) select $1,coalesce(max(sequence),0)+1,$2,$3,$4,$5 from exs_outbox where symbol=$1 returning tid,sequence`,
+		outbox.Symbol, outbox.Event, outbox.UpdateTime, outbox.CreateTime, outbox.Status,
+	).Scan(&outbox.TID, &outbox.Sequence)
+	return
+}
+
+//ListOutboxEvent will list outbox event by symbol which sequence is after the sequence
+func ListOutboxEvent(ctx context.Context, symbol string, after int64, limit int) (outboxes []*Outbox, err error) {
+	outboxes, err = ListOutboxEventCall(Pool(), ctx, symbol, after, limit)
+	return
+}
+
+//ListOutboxEventCall will list outbox event by symbol which sequence is after the sequence
+func ListOutboxEventCall(caller crud.Queryer, ctx context.Context, symbol string, after int64, limit int) (outboxes []*Outbox, err error) {
+	err = crud.QueryWheref(
+		caller, ctx, &Outbox{}, "#all",
+		"symbol=$%v,sequence>$%v", []interface{}{symbol, after},
+		" order by sequence asc", 0, limit, &outboxes,
+	)
+	return
+}
+
+//ListOutboxEventLatest will list latest outbox event by symbol which sequence is before the sequence, the before is not filtered when it is zero
+func ListOutboxEventLatest(ctx context.Context, symbol string, before int64, limit int) (outboxes []*Outbox, err error) {
+	outboxes, err = ListOutboxEventLatestCall(Pool(), ctx, symbol, before, limit)
+	return
+}
+
+//ListOutboxEventLatestCall will list latest outbox event by symbol which sequence is before the sequence, the before is not filtered when it is zero
+func ListOutboxEventLatestCall(caller crud.Queryer, ctx context.Context, symbol string, before int64, limit int) (outboxes []*Outbox, err error) {
+	err = crud.QueryWheref(
+		caller, ctx, &Outbox{}, "#all",
+		"symbol=$%v,sequence<$%v", []interface{}{symbol, before},
+		" order by sequence desc", 0, limit, &outboxes,
+	)
+	return
+}
+
+//LoadOutboxSequence will load the latest stored sequence by symbol, zero is returned when not stored
+func LoadOutboxSequence(ctx context.Context, symbol string) (sequence int64, err error) {
+	sequence, err = LoadOutboxSequenceCall(Pool(), ctx, symbol)
+	return
+}
+
+//LoadOutboxSequenceCall will load the latest stored sequence by symbol, zero is returned when not stored
+func LoadOutboxSequenceCall(caller crud.Queryer, ctx context.Context, symbol string) (sequence int64, err error) {
+	err = caller.QueryRow(ctx, `select coalesce(max(sequence),0) from exs_outbox where symbol=$1`, symbol).Scan(&sequence)
+	return
+}
+
+//LoadOutboxAck will load the last acked sequence by consumer and symbol, zero is returned when not acked
+func LoadOutboxAck(ctx context.Context, consumer, symbol string) (sequence int64, err error) {
+	sequence, err = LoadOutboxAckCall(Pool(), ctx, consumer, symbol)
+	return
+}
+
+//LoadOutboxAckCall will load the last acked sequence by consumer and symbol, zero is returned when not acked
+func LoadOutboxAckCall(caller crud.Queryer, ctx context.Context, consumer, symbol string) (sequence int64, err error) {
+	err = caller.QueryRow(ctx, `select sequence from exs_outbox_ack where consumer=$1 and symbol=$2`, consumer, symbol).Scan(&sequence)
+	if err == pgx.ErrNoRows {
+		err = nil
+	}
+	return
+}
+
+//UpdateOutboxAck will save the last acked sequence by consumer and symbol, the sequence is never moved back
+func UpdateOutboxAck(ctx context.Context, consumer, symbol string, sequence int64) (err error) {
+	err = UpdateOutboxAckCall(Pool(), ctx, consumer, symbol, sequence)
+	return
+}
+
+//UpdateOutboxAckCall will save the last acked sequence by consumer and symbol, the sequence is never moved back
+func UpdateOutboxAckCall(caller crud.Queryer, ctx context.Context, consumer, symbol string, sequence int64) (err error) {
+	_, _, err = caller.Exec(
+		ctx,
+		`insert into exs_outbox_ack(consumer,symbol,sequence,update_time,create_time,status) values ($1,$2,$3,$4,$4,$5) on conflict(consumer,symbol) do update set sequence=greatest(exs_outbox_ack.sequence,excluded.sequence),update_time=excluded.update_time`,
+		consumer, symbol, sequence, xsql.TimeNow(), OutboxAckStatusNormal,
+	)
+	return
+}
+
+//ClearOutbox will remove outbox event which is created before time, the last event of symbol is kept to continue the sequence
+func ClearOutbox(ctx context.Context, before time.Time) (removed int64, err error) {
+	_, removed, err = Pool().Exec(ctx, `delete from exs_outbox o where create_time<$1 and sequence<(select max(sequence) from exs_outbox where symbol=o.symbol)`, before)
+	return
+}
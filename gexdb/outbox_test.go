@@ -0,0 +1,88 @@
+package gexdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xsql"
+)
+
+func TestOutbox(t *testing.T) {
+	symbol := "spot.TestOutbox"
+	_, _, err := Pool().Exec(ctx, `delete from exs_outbox where symbol=$1`, symbol)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	first, err := AddOutboxEventCall(Pool(), ctx, symbol, xsql.M{"Symbol": symbol})
+	if err != nil || first.Sequence < 1 {
+		t.Error(err)
+		return
+	}
+	second, err := AddOutboxEventCall(Pool(), ctx, symbol, xsql.M{"Symbol": symbol})
+	if err != nil || second.Sequence != first.Sequence+1 {
+		t.Error(err)
+		return
+	}
+	outboxes, err := ListOutboxEvent(ctx, symbol, first.Sequence, 10)
+	if err != nil || len(outboxes) != 1 || outboxes[0].Sequence != second.Sequence {
+		t.Error(err)
+		return
+	}
+	outboxes, err = ListOutboxEventLatest(ctx, symbol, 0, 10)
+	if err != nil || len(outboxes) != 2 || outboxes[0].Sequence != second.Sequence {
+		t.Error(err)
+		return
+	}
+	outboxes, err = ListOutboxEventLatest(ctx, symbol, second.Sequence, 10)
+	if err != nil || len(outboxes) != 1 || outboxes[0].Sequence != first.Sequence {
+		t.Error(err)
+		return
+	}
+	sequence, err := LoadOutboxSequence(ctx, symbol)
+	if err != nil || sequence != second.Sequence {
+		t.Error(err)
+		return
+	}
+	acked, err := LoadOutboxAck(ctx, "TestOutbox", symbol)
+	if err != nil || acked != 0 {
+		t.Error(err)
+		return
+	}
+	err = UpdateOutboxAck(ctx, "TestOutbox", symbol, second.Sequence)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = UpdateOutboxAck(ctx, "TestOutbox", symbol, first.Sequence)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	acked, err = LoadOutboxAck(ctx, "TestOutbox", symbol)
+	if err != nil || acked != second.Sequence {
+		t.Error(err)
+		return
+	}
+	removed, err := ClearOutbox(ctx, time.Now().Add(time.Hour))
+	if err != nil || removed < 1 {
+		t.Error(err)
+		return
+	}
+	third, err := AddOutboxEventCall(Pool(), ctx, symbol, xsql.M{"Symbol": symbol})
+	if err != nil || third.Sequence != second.Sequence+1 {
+		t.Error(err)
+		return
+	}
+	//test error
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerSet("Pool.Query", 1)
+	_, err = ListOutboxEvent(ctx, symbol, 0, 10)
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
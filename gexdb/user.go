@@ -2,9 +2,13 @@ package gexdb
 
 import (
 	"context"
+	"time"
 
 	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/crud/pgx"
 	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/basedb"
 )
 
 //FindUserByUsrPwd will return user by match account/email/phone=username and passowrd=matched
@@ -59,6 +63,67 @@ func UserVerifyTradePassword(ctx context.Context, userID int64, password string)
 	return
 }
 
+//FindUserTier will return the risk tier of user, which is stored on external.tier, empty is default tier
+func FindUserTier(ctx context.Context, userID int64) (tier string, err error) {
+	tier, err = FindUserTierCall(Pool(), ctx, userID)
+	return
+}
+
+func FindUserTierCall(caller crud.Queryer, ctx context.Context, userID int64) (tier string, err error) {
+	var having *string
+	err = caller.QueryRow(ctx, `select external->>'tier' from exs_user where tid=$1`, userID).Scan(&having)
+	if err == nil && having != nil {
+		tier = *having
+	}
+	return
+}
+
+//UpdateUserWithdrawWhitelist will update the withdraw whitelist mode of user, which is stored on external.withdraw_whitelist,
+//only saved withdraw address can be used when it is enabled, the disable is delayed by withdraw address delay config,
+//which is stored on external.withdraw_whitelist_disable
+func UpdateUserWithdrawWhitelist(ctx context.Context, userID int64, enabled bool) (err error) {
+	var delay int64
+	err = basedb.LoadConf(ctx, ConfigWithdrawAddressDelay, &delay)
+	if err != nil && err != pgx.ErrNoRows {
+		return
+	}
+	var updateSQL string
+	var args []interface{}
+	if enabled {
+		updateSQL = `update exs_user set external=(coalesce(external,'{}'::jsonb)-'withdraw_whitelist_disable')||jsonb_build_object('withdraw_whitelist',1),update_time=$1 where tid=$2`
+		args = []interface{}{xsql.TimeNow(), userID}
+	} else {
+		disableTime := time.Now().Add(time.Duration(delay) * time.Second)
+		updateSQL = `update exs_user set external=coalesce(external,'{}'::jsonb)||jsonb_build_object('withdraw_whitelist_disable',$1::bigint),update_time=$2 where tid=$3`
+		args = []interface{}{xsql.Time(disableTime).Timestamp(), xsql.TimeNow(), userID}
+	}
+	_, affected, err := Pool().Exec(ctx, updateSQL, args...)
+	if err == nil && affected < 1 {
+		err = pgx.ErrNoRows
+	}
+	return
+}
+
+//FindUserWithdrawWhitelist will return whether the withdraw whitelist mode of user is enabled, it is still enabled before disable time
+func FindUserWithdrawWhitelist(ctx context.Context, userID int64) (enabled bool, err error) {
+	enabled, err = FindUserWithdrawWhitelistCall(Pool(), ctx, userID)
+	return
+}
+
+func FindUserWithdrawWhitelistCall(caller crud.Queryer, ctx context.Context, userID int64) (enabled bool, err error) {
+	var having *string
+	var disableTime *int64
+	err = caller.QueryRow(
+		ctx,
+		`select external->>'withdraw_whitelist',(external->>'withdraw_whitelist_disable')::bigint from exs_user where tid=$1`,
+		userID,
+	).Scan(&having, &disableTime)
+	if err == nil && having != nil {
+		enabled = *having == "1" && (disableTime == nil || *disableTime > xsql.TimeNow().Timestamp())
+	}
+	return
+}
+
 /**
  * @apiDefine UserUnifySearcher
  * @apiParam  {Number} [type] the type filter, multi with comma, all type supported is <a href="#metadata-User">UserTypeAll</a>
@@ -98,3 +163,28 @@ func (t *UserUnifySearcher) Apply(ctx context.Context) (err error) {
 	err = crud.ApplyUnify(Pool(), ctx, t)
 	return
 }
+
+//LockUser will change user status to locked, it is used to freeze the account which balance is not matched
+func LockUser(ctx context.Context, userID int64) (updated int64, err error) {
+	updated, err = crud.UpdateWheref(Pool, ctx, &User{Status: UserStatusLocked}, "status", "tid=$%v,status=$%v", userID, UserStatusNormal)
+	return
+}
+
+//ListLockedUserID will return all user id which status is locked
+func ListLockedUserID(ctx context.Context) (userIDs []int64, err error) {
+	err = crud.QueryWheref(
+		Pool, ctx, &User{}, "tid#all",
+		"status=$%v", []interface{}{UserStatusLocked},
+		"", 0, 0,
+		func(user *User) {
+			userIDs = append(userIDs, user.TID)
+		},
+	)
+	return
+}
+
+//FindUserStatus will return the user status by id
+func FindUserStatus(ctx context.Context, userID int64) (status UserStatus, err error) {
+	err = Pool().QueryRow(ctx, `select status from exs_user where tid=$1`, userID).Scan(&status)
+	return
+}
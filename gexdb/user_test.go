@@ -89,6 +89,22 @@ func TestUser(t *testing.T) {
 		return
 	}
 
+	locked, err := LockUser(ctx, user.TID)
+	if err != nil || locked != 1 {
+		t.Error(err)
+		return
+	}
+	status, err := FindUserStatus(ctx, user.TID)
+	if err != nil || status != UserStatusLocked {
+		t.Error(err)
+		return
+	}
+	err = UpdateUserFilter(ctx, &User{TID: user.TID, Status: UserStatusNormal}, "status")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
 	searcher := &UserUnifySearcher{}
 	searcher.Where.Type = UserTypeAll
 	searcher.Where.Key = *user.Name
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/basedb"
 	"github.com/gexservice/gexservice/base/define"
 	"github.com/shopspring/decimal"
 )
@@ -17,11 +21,14 @@ func FindWithdrawByOrderIDCall(caller crud.Queryer, ctx context.Context, orderID
 	if lock {
 		querySQL += " for update "
 	}
-	err = crud.QueryRow(caller, ctx, &Order{}, "#all", querySQL, args, &withdraw)
+	err = crud.QueryRow(caller, ctx, &Withdraw{}, "#all", querySQL, args, &withdraw)
 	return
 }
 
-func CreateWithdraw(ctx context.Context, userID int64, asset string, quantity decimal.Decimal) (withdraw *Withdraw, err error) {
+//CreateWithdraw will create withdraw order to address and lock the quantity on funds balance, the asset withdraw fee is included in quantity,
+//the address must be allowed by user address book, the withdraw to not saved address can be confirmed after address delay,
+//the withdraw max and user tier quota is checked after balance is locked
+func CreateWithdraw(ctx context.Context, userID int64, asset, address string, quantity decimal.Decimal) (withdraw *Withdraw, err error) {
 	tx, err := Pool().Begin(ctx)
 	if err != nil {
 		return
@@ -33,13 +40,15 @@ func CreateWithdraw(ctx context.Context, userID int64, asset string, quantity de
 			tx.Rollback(ctx)
 		}
 	}()
-	balance := &Balance{
-		UserID: userID,
-		Asset:  asset,
-		Free:   decimal.Zero.Sub(quantity),
-		Locked: quantity,
+	having, err := CheckWithdrawAssetCall(tx, ctx, asset, quantity)
+	if err != nil {
+		return
+	}
+	err = CheckWithdrawMaxCall(tx, ctx, quantity)
+	if err != nil {
+		return
 	}
-	err = IncreaseBalanceCall(tx, ctx, balance)
+	availableTime, err := CheckWithdrawAddressCall(tx, ctx, userID, asset, address)
 	if err != nil {
 		return
 	}
@@ -49,13 +58,144 @@ func CreateWithdraw(ctx context.Context, userID int64, asset string, quantity de
 		UserID:   userID,
 		Creator:  userID,
 		Asset:    asset,
+		Address:  address,
 		Quantity: quantity,
+		Fee:      having.WithdrawFee,
 		Status:   WithdrawStatusPending,
 	}
+	if time.Time(availableTime).After(time.Now()) {
+		withdraw.Transaction = xsql.M{"available_time": availableTime.Timestamp()}
+	}
+	balance := &Balance{
+		UserID: userID,
+		Area:   BalanceAreaFunds,
+		Asset:  asset,
+		Free:   decimal.Zero.Sub(quantity),
+		Locked: quantity,
+	}
+	err = IncreaseBalanceRecordCall(tx, ctx, balance, BalanceRecordTypeWithdraw, withdraw.OrderID)
+	if err != nil {
+		return
+	}
+	//balance is locked for update, so the quota used is not changed by other withdraw on same asset
+	quota, err := LoadWithdrawQuotaCall(tx, ctx, userID, asset)
+	if err != nil {
+		return
+	}
+	err = quota.Check(quantity)
+	if err != nil {
+		return
+	}
 	err = AddWithdrawCall(tx, ctx, withdraw)
 	return
 }
 
+//CheckWithdrawMaxCall will check quantity is not greater than the withdraw max config, it is not limited when config is not setted or zero
+func CheckWithdrawMaxCall(caller crud.Queryer, ctx context.Context, quantity decimal.Decimal) (err error) {
+	var max float64
+	err = basedb.LoadConfCall(caller, ctx, ConfigWithdrawMax, &max)
+	if err == pgx.ErrNoRows {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+	if max > 0 && quantity.GreaterThan(decimal.NewFromFloat(max)) {
+		err = ErrWithdrawMax(fmt.Sprintf("withdraw quantity %v is greater than max %v", quantity, max))
+	}
+	return
+}
+
+//WithdrawQuotaLimit is the withdraw quota limit of asset, zero value is not limited
+type WithdrawQuotaLimit struct {
+	Daily   decimal.Decimal `json:"daily"`
+	Monthly decimal.Decimal `json:"monthly"`
+}
+
+//WithdrawQuotaConfig is the withdraw quota limit config by user tier and asset, empty tier is the default limit
+type WithdrawQuotaConfig map[string]map[string]*WithdrawQuotaLimit
+
+//FindLimit will find quota limit by tier and asset, it will fallback to default limit when tier limit is not found
+func (w WithdrawQuotaConfig) FindLimit(tier, asset string) (limit *WithdrawQuotaLimit) {
+	limit = w[tier][asset]
+	if limit == nil && len(tier) > 0 {
+		limit = w[""][asset]
+	}
+	if limit == nil {
+		limit = &WithdrawQuotaLimit{}
+	}
+	return
+}
+
+//WithdrawQuota is the withdraw quota of user on asset, the remain is nil when it is not limited
+type WithdrawQuota struct {
+	Tier          string           `json:"tier"`
+	Asset         string           `json:"asset"`
+	Daily         decimal.Decimal  `json:"daily"`
+	DailyUsed     decimal.Decimal  `json:"daily_used"`
+	DailyRemain   *decimal.Decimal `json:"daily_remain"`
+	Monthly       decimal.Decimal  `json:"monthly"`
+	MonthlyUsed   decimal.Decimal  `json:"monthly_used"`
+	MonthlyRemain *decimal.Decimal `json:"monthly_remain"`
+}
+
+//Check will check quantity is not out of remain quota
+func (w *WithdrawQuota) Check(quantity decimal.Decimal) (err error) {
+	if w.DailyRemain != nil && quantity.GreaterThan(*w.DailyRemain) {
+		err = ErrWithdrawDailyQuota(fmt.Sprintf("withdraw quantity %v is greater than %v daily remain quota %v", quantity, w.Asset, w.DailyRemain))
+		return
+	}
+	if w.MonthlyRemain != nil && quantity.GreaterThan(*w.MonthlyRemain) {
+		err = ErrWithdrawMonthlyQuota(fmt.Sprintf("withdraw quantity %v is greater than %v monthly remain quota %v", quantity, w.Asset, w.MonthlyRemain))
+		return
+	}
+	return
+}
+
+//LoadWithdrawQuota will load user withdraw quota on asset by user tier, the used is sum of all not canceled withdraw and goldbar in current day/month
+func LoadWithdrawQuota(ctx context.Context, userID int64, asset string) (quota *WithdrawQuota, err error) {
+	quota, err = LoadWithdrawQuotaCall(Pool(), ctx, userID, asset)
+	return
+}
+
+func LoadWithdrawQuotaCall(caller crud.Queryer, ctx context.Context, userID int64, asset string) (quota *WithdrawQuota, err error) {
+	config := WithdrawQuotaConfig{}
+	err = basedb.LoadConfCall(caller, ctx, ConfigWithdrawQuota, &config)
+	if err == pgx.ErrNoRows {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+	quota = &WithdrawQuota{Asset: asset}
+	quota.Tier, err = FindUserTierCall(caller, ctx, userID)
+	if err != nil {
+		return
+	}
+	limit := config.FindLimit(quota.Tier, asset)
+	quota.Daily, quota.Monthly = limit.Daily, limit.Monthly
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	querySQL := `
+		select coalesce(sum(case when create_time>=$1 then quantity else 0 end),0),coalesce(sum(quantity),0)
+		from exs_withdraw where user_id=$2 and type=any($3) and asset=$4 and create_time>=$5 and status<>$6
+	`
+	err = caller.QueryRow(ctx, querySQL, dayStart, userID, WithdrawTypeArray{WithdrawTypeWithdraw, WithdrawTypeGoldbar}, asset, monthStart, WithdrawStatusCanceled).Scan(&quota.DailyUsed, &quota.MonthlyUsed)
+	if err != nil {
+		return
+	}
+	if quota.Daily.Sign() > 0 {
+		remain := decimal.Max(decimal.Zero, quota.Daily.Sub(quota.DailyUsed))
+		quota.DailyRemain = &remain
+	}
+	if quota.Monthly.Sign() > 0 {
+		remain := decimal.Max(decimal.Zero, quota.Monthly.Sub(quota.MonthlyUsed))
+		quota.MonthlyRemain = &remain
+	}
+	return
+}
+
 func CancelWithdraw(ctx context.Context, userID int64, orderID string) (withdraw *Withdraw, err error) {
 	tx, err := Pool().Begin(ctx)
 	if err != nil {
@@ -88,11 +228,12 @@ func CancelWithdraw(ctx context.Context, userID int64, orderID string) (withdraw
 	free := withdraw.Quantity
 	balance := &Balance{
 		UserID: withdraw.UserID,
+		Area:   BalanceAreaFunds,
 		Asset:  withdraw.Asset,
 		Free:   free,
 		Locked: decimal.Zero.Sub(free),
 	}
-	err = IncreaseBalanceCall(tx, ctx, balance)
+	err = IncreaseBalanceRecordCall(tx, ctx, balance, BalanceRecordTypeWithdraw, withdraw.OrderID)
 	if err != nil {
 		return
 	}
@@ -100,6 +241,155 @@ func CancelWithdraw(ctx context.Context, userID int64, orderID string) (withdraw
 	return
 }
 
+//ReviewWithdraw will review withdraw by admin, Pending is confirmed to Confirmed, Confirmed is done to Done with external transaction ref,
+//Pending/Confirmed is rejected to Canceled, the locked balance is released on done and refunded to free on rejected
+func ReviewWithdraw(ctx context.Context, reviewer int64, orderID string, status WithdrawStatus, note, ref string) (withdraw *Withdraw, err error) {
+	tx, err := Pool().Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit(ctx)
+		} else {
+			tx.Rollback(ctx)
+		}
+	}()
+	withdraw, err = FindWithdrawByOrderIDCall(tx, ctx, orderID, true)
+	if err != nil {
+		return
+	}
+	if withdraw.Type != WithdrawTypeWithdraw {
+		err = fmt.Errorf("order is not withdraw")
+		return
+	}
+	if withdraw.Transaction == nil {
+		withdraw.Transaction = xsql.M{}
+	}
+	balance := &Balance{
+		UserID: withdraw.UserID,
+		Area:   BalanceAreaFunds,
+		Asset:  withdraw.Asset,
+	}
+	switch status {
+	case WithdrawStatusConfirmed:
+		if withdraw.Status != WithdrawStatusPending {
+			err = fmt.Errorf("order is not pending")
+			return
+		}
+		availableTime := xsql.TimeUnix(withdraw.Transaction.AsMap().Int64Def(0, "available_time"))
+		if time.Time(availableTime).After(time.Now()) {
+			err = ErrWithdrawAddressInvalid(fmt.Sprintf("withdraw address %v is not available until %v", withdraw.Address, time.Time(availableTime)))
+			return
+		}
+		withdraw.Transaction["confirm_user"] = reviewer
+		withdraw.Transaction["confirm_note"] = note
+	case WithdrawStatusDone:
+		if withdraw.Status != WithdrawStatusConfirmed {
+			err = fmt.Errorf("order is not confirmed")
+			return
+		}
+		if len(ref) < 1 {
+			err = fmt.Errorf("transaction ref is required")
+			return
+		}
+		withdraw.Transaction["done_user"] = reviewer
+		withdraw.Transaction["done_note"] = note
+		withdraw.Transaction["ref"] = ref
+		balance.Locked = decimal.Zero.Sub(withdraw.Quantity)
+		err = bookWithdrawFeeCall(tx, ctx, withdraw)
+		if err != nil {
+			return
+		}
+	case WithdrawStatusCanceled:
+		if withdraw.Status != WithdrawStatusPending && withdraw.Status != WithdrawStatusConfirmed {
+			err = fmt.Errorf("order is not pending or confirmed")
+			return
+		}
+		withdraw.Transaction["reject_user"] = reviewer
+		withdraw.Transaction["reject_note"] = note
+		balance.Free = withdraw.Quantity
+		balance.Locked = decimal.Zero.Sub(withdraw.Quantity)
+	default:
+		err = fmt.Errorf("review status %v is invalid", status)
+		return
+	}
+	withdraw.Status = status
+	err = IncreaseBalanceRecordCall(tx, ctx, balance, BalanceRecordTypeWithdraw, withdraw.OrderID)
+	if err != nil {
+		return
+	}
+	err = withdraw.UpdateFilter(tx, ctx, "transaction,status")
+	return
+}
+
+//bookWithdrawFeeCall will book the withdraw fee to fee account funds balance by config withdraw fee user
+func bookWithdrawFeeCall(caller crud.Queryer, ctx context.Context, withdraw *Withdraw) (err error) {
+	if withdraw.Fee.Sign() <= 0 {
+		return
+	}
+	var feeUser int64
+	err = basedb.LoadConfCall(caller, ctx, ConfigWithdrawFeeUser, &feeUser)
+	if err == pgx.ErrNoRows || (err == nil && feeUser <= 0) {
+		err = fmt.Errorf("withdraw fee user is not configured")
+	}
+	if err != nil {
+		return
+	}
+	_, err = TouchBalanceCall(caller, ctx, BalanceAreaFunds, []string{withdraw.Asset}, feeUser)
+	if err != nil {
+		return
+	}
+	fee := &Balance{
+		UserID: feeUser,
+		Area:   BalanceAreaFunds,
+		Asset:  withdraw.Asset,
+		Free:   withdraw.Fee,
+	}
+	err = IncreaseBalanceRecordCall(caller, ctx, fee, BalanceRecordTypeFee, withdraw.OrderID)
+	return
+}
+
+/**
+ * @apiDefine WithdrawUnifySearcher
+ * @apiParam  {Number} [user_id] the user id filter, multi with comma, it is only supported by admin
+ * @apiParam  {Number} [type] the type filter, multi with comma, all type supported is <a href="#metadata-Withdraw">WithdrawTypeAll</a>
+ * @apiParam  {String} [asset] the asset filter, multi with comma
+ * @apiParam  {Number} [status] the status filter, multi with comma, all status supported is <a href="#metadata-Withdraw">WithdrawStatusAll</a>
+ * @apiParam  {Number} [start_time] the time filter
+ * @apiParam  {Number} [end_time] the time filter
+ * @apiParam  {Number} [skip] page skip
+ * @apiParam  {Number} [limit] page limit
+ */
+type WithdrawUnifySearcher struct {
+	Model Withdraw `json:"model"`
+	Where struct {
+		UserID    xsql.Int64Array     `json:"user_id" cmp:"user_id=any($%v)" valid:"user_id,o|i,r:0;"`
+		Type      WithdrawTypeArray   `json:"type" cmp:"type=any($%v)" valid:"type,o|i,e:;"`
+		Asset     xsql.StringArray    `json:"asset" cmp:"asset=any($%v)" valid:"asset,o|s,l:0;"`
+		Status    WithdrawStatusArray `json:"status" cmp:"status=any($%v)" valid:"status,o|i,e:;"`
+		StartTime xsql.Time           `json:"start_time" cmp:"create_time>=$%v" valid:"start_time,o|i,r:-1;"`
+		EndTime   xsql.Time           `json:"end_time" cmp:"create_time<$%v" valid:"end_time,o|i,r:-1;"`
+	} `json:"where" join:"and" valid:"inline"`
+	Page struct {
+		Order string `json:"order" default:"order by create_time desc" valid:"order,o|s,l:0;"`
+		Skip  int    `json:"skip" valid:"skip,o|i,r:-1;"`
+		Limit int    `json:"limit" valid:"limit,o|i,r:0;"`
+	} `json:"page" valid:"inline"`
+	Query struct {
+		Withdraws []*Withdraw `json:"withdraws"`
+	} `json:"query" filter:"#all"`
+	Count struct {
+		Total int64 `json:"total" scan:"tid"`
+	} `json:"count" filter:"count(tid)#all"`
+}
+
+func (w *WithdrawUnifySearcher) Apply(ctx context.Context) (err error) {
+	w.Page.Order = crud.BuildOrderby(WithdrawOrderbyAll, w.Page.Order)
+	err = crud.ApplyUnify(Pool(), ctx, w)
+	return
+}
+
 // const (
 // 	WithdrawVerifyPending  = 100
 // 	WithdrawVerifyFail     = 200
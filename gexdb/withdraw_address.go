@@ -0,0 +1,98 @@
+package gexdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/basedb"
+)
+
+//UpsertWithdrawAddress will save withdraw address to user address book, the new address can be used after withdraw address delay config,
+//the removed address is delayed again when it is saved, the label is only updated when address is normal
+func UpsertWithdrawAddress(ctx context.Context, address *WithdrawAddress) (err error) {
+	var delay int64
+	err = basedb.LoadConf(ctx, ConfigWithdrawAddressDelay, &delay)
+	if err != nil && err != pgx.ErrNoRows {
+		return
+	}
+	address.UpdateTime = xsql.TimeNow()
+	address.CreateTime = address.UpdateTime
+	address.AvailableTime = xsql.Time(time.Time(address.UpdateTime).Add(time.Duration(delay) * time.Second))
+	address.Status = WithdrawAddressStatusNormal
+	upsertSQL := `
+		insert into exs_withdraw_address(user_id,asset,address,label,available_time,update_time,create_time,status)
+		values ($1,$2,$3,$4,$5,$6,$7,$8)
+		on conflict(user_id,asset,address) do update set label=$4,update_time=$6,status=$8,
+		available_time=(case when exs_withdraw_address.status=$8 then exs_withdraw_address.available_time else $5 end)
+		returning tid,available_time,create_time
+	`
+	err = Pool().QueryRow(
+		ctx, upsertSQL,
+		address.UserID, address.Asset, address.Address, address.Label, address.AvailableTime, address.UpdateTime, address.CreateTime, address.Status,
+	).Scan(&address.TID, &address.AvailableTime, &address.CreateTime)
+	return
+}
+
+//RemoveWithdrawAddress will remove withdraw address from user address book
+func RemoveWithdrawAddress(ctx context.Context, userID, addressID int64) (err error) {
+	_, affected, err := Pool().Exec(
+		ctx,
+		`update exs_withdraw_address set status=$1,update_time=$2 where tid=$3 and user_id=$4 and status=$5`,
+		WithdrawAddressStatusRemoved, xsql.TimeNow(), addressID, userID, WithdrawAddressStatusNormal,
+	)
+	if err == nil && affected < 1 {
+		err = pgx.ErrNoRows
+	}
+	return
+}
+
+//ListWithdrawAddress will list normal withdraw address of user, all asset is returned when asset is empty
+func ListWithdrawAddress(ctx context.Context, userID int64, asset string) (addressList []*WithdrawAddress, err error) {
+	err = ScanWithdrawAddressFilterWherefCall(
+		Pool(), ctx, "#all",
+		"user_id=$%v,asset=$%v,status=$%v", []interface{}{userID, asset, WithdrawAddressStatusNormal},
+		"order by update_time desc", &addressList,
+	)
+	return
+}
+
+//CheckWithdrawAddressCall will check address is allowed to withdraw, the saved address must be available,
+//the not saved address is rejected when user withdraw whitelist is enabled, else it is delayed by withdraw address delay config,
+//the available time of address is returned
+func CheckWithdrawAddressCall(caller crud.Queryer, ctx context.Context, userID int64, asset, address string) (availableTime xsql.Time, err error) {
+	if len(address) < 1 {
+		err = ErrWithdrawAddressInvalid("withdraw address is required")
+		return
+	}
+	saved, err := FindWithdrawAddressWherefCall(caller, ctx, false, "user_id=$%v,asset=$%v,address=$%v,status=$%v#all", userID, asset, address, WithdrawAddressStatusNormal)
+	if err != nil && err != pgx.ErrNoRows {
+		return
+	}
+	err = nil
+	if saved != nil {
+		availableTime = saved.AvailableTime
+		if time.Time(saved.AvailableTime).After(time.Now()) {
+			err = ErrWithdrawAddressInvalid(fmt.Sprintf("withdraw address %v is not available until %v", address, time.Time(saved.AvailableTime)))
+		}
+		return
+	}
+	whitelist, err := FindUserWithdrawWhitelistCall(caller, ctx, userID)
+	if err != nil {
+		return
+	}
+	if whitelist {
+		err = ErrWithdrawAddressInvalid(fmt.Sprintf("withdraw address %v is not in whitelist", address))
+		return
+	}
+	var delay int64
+	err = basedb.LoadConfCall(caller, ctx, ConfigWithdrawAddressDelay, &delay)
+	if err == pgx.ErrNoRows {
+		err = nil
+	}
+	availableTime = xsql.Time(time.Now().Add(time.Duration(delay) * time.Second))
+	return
+}
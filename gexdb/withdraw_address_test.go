@@ -0,0 +1,210 @@
+package gexdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/shopspring/decimal"
+)
+
+func TestWithdrawAddress(t *testing.T) {
+	clear()
+	defer basedb.StoreConf(ctx, ConfigWithdrawAddressDelay, "0")
+	user := testAddUser("TestWithdrawAddress")
+	_, err := TouchBalance(ctx, BalanceAreaFunds, []string{"USDT"}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaFunds, Asset: "USDT", Free: decimal.NewFromFloat(100)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//delay
+	basedb.StoreConf(ctx, ConfigWithdrawAddressDelay, "3600")
+	delayed := &WithdrawAddress{UserID: user.TID, Asset: "USDT", Address: "delayed", Label: "delayed"}
+	err = UpsertWithdrawAddress(ctx, delayed)
+	if err != nil || delayed.TID < 1 || !time.Time(delayed.AvailableTime).After(time.Now()) {
+		t.Errorf("err:%v,address:%v", err, delayed)
+		return
+	}
+	if _, err = CheckWithdrawAddressCall(Pool(), ctx, user.TID, "USDT", "delayed"); !IsErrWithdrawAddressInvalid(err) {
+		t.Error(err)
+		return
+	}
+	if availableTime, err := CheckWithdrawAddressCall(Pool(), ctx, user.TID, "USDT", "other"); err != nil || !time.Time(availableTime).After(time.Now()) {
+		t.Errorf("err:%v,available:%v", err, availableTime)
+		return
+	}
+	if _, err = CheckWithdrawAddressCall(Pool(), ctx, user.TID, "USDT", ""); !IsErrWithdrawAddressInvalid(err) {
+		t.Error(err)
+		return
+	}
+	//not saved is delayed on confirm
+	withdraw, err := CreateWithdraw(ctx, user.TID, "USDT", "other", decimal.NewFromFloat(1))
+	if err != nil || withdraw.Transaction["available_time"] == nil {
+		t.Errorf("err:%v,withdraw:%v", err, withdraw)
+		return
+	}
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusConfirmed, "", ""); !IsErrWithdrawAddressInvalid(err) {
+		t.Error(err)
+		return
+	}
+	_, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "", "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//relabel not change available time
+	availableTime := delayed.AvailableTime
+	delayed.Label = "delayed2"
+	basedb.StoreConf(ctx, ConfigWithdrawAddressDelay, "0")
+	err = UpsertWithdrawAddress(ctx, delayed)
+	if err != nil || !time.Time(delayed.AvailableTime).Equal(time.Time(availableTime)) {
+		t.Errorf("err:%v,address:%v", err, delayed)
+		return
+	}
+	//available
+	available := &WithdrawAddress{UserID: user.TID, Asset: "USDT", Address: "available"}
+	err = UpsertWithdrawAddress(ctx, available)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CheckWithdrawAddressCall(Pool(), ctx, user.TID, "USDT", "available"); err != nil {
+		t.Error(err)
+		return
+	}
+	addressList, err := ListWithdrawAddress(ctx, user.TID, "USDT")
+	if err != nil || len(addressList) != 2 {
+		t.Error(err)
+		return
+	}
+	//whitelist
+	err = UpdateUserWithdrawWhitelist(ctx, user.TID, true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if whitelist, err := FindUserWithdrawWhitelist(ctx, user.TID); err != nil || !whitelist {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "USDT", "other", decimal.NewFromFloat(1)); !IsErrWithdrawAddressInvalid(err) {
+		t.Error(err)
+		return
+	}
+	withdraw, err = CreateWithdraw(ctx, user.TID, "USDT", "available", decimal.NewFromFloat(1))
+	if err != nil || withdraw.Address != "available" || withdraw.Transaction != nil {
+		t.Error(err)
+		return
+	}
+	//remove and add again
+	err = RemoveWithdrawAddress(ctx, user.TID, delayed.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = RemoveWithdrawAddress(ctx, user.TID, delayed.TID); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	addressList, err = ListWithdrawAddress(ctx, user.TID, "")
+	if err != nil || len(addressList) != 1 {
+		t.Error(err)
+		return
+	}
+	err = UpsertWithdrawAddress(ctx, delayed)
+	if err != nil || time.Time(delayed.AvailableTime).After(time.Now()) {
+		t.Errorf("err:%v,address:%v", err, delayed)
+		return
+	}
+	//disable whitelist is delayed
+	basedb.StoreConf(ctx, ConfigWithdrawAddressDelay, "3600")
+	err = UpdateUserWithdrawWhitelist(ctx, user.TID, false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if whitelist, err := FindUserWithdrawWhitelist(ctx, user.TID); err != nil || !whitelist {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "USDT", "other", decimal.NewFromFloat(1)); !IsErrWithdrawAddressInvalid(err) {
+		t.Error(err)
+		return
+	}
+	basedb.StoreConf(ctx, ConfigWithdrawAddressDelay, "0")
+	err = UpdateUserWithdrawWhitelist(ctx, user.TID, false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if whitelist, err := FindUserWithdrawWhitelist(ctx, user.TID); err != nil || whitelist {
+		t.Error(err)
+		return
+	}
+	err = UpdateUserWithdrawWhitelist(ctx, user.TID, true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if whitelist, err := FindUserWithdrawWhitelist(ctx, user.TID); err != nil || !whitelist {
+		t.Error(err)
+		return
+	}
+	if err = UpdateUserWithdrawWhitelist(ctx, -1, false); err != pgx.ErrNoRows {
+		t.Error(err)
+		return
+	}
+	//
+	//test error
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 1)
+	if err = UpsertWithdrawAddress(ctx, delayed); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 1)
+	if _, err = CheckWithdrawAddressCall(Pool(), ctx, user.TID, "USDT", "other"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Query", 1)
+	if _, err = CheckWithdrawAddressCall(Pool(), ctx, user.TID, "USDT", "other"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Exec", 1)
+	if err = RemoveWithdrawAddress(ctx, user.TID, delayed.TID); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Exec", 1)
+	if err = UpdateUserWithdrawWhitelist(ctx, user.TID, true); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 1)
+	if err = UpdateUserWithdrawWhitelist(ctx, user.TID, true); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
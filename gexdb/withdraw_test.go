@@ -1,5 +1,309 @@
 package gexdb
 
+import (
+	"testing"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/shopspring/decimal"
+)
+
+func TestReviewWithdraw(t *testing.T) {
+	clear()
+	user := testAddUser("TestReviewWithdraw")
+	_, err := TouchBalance(ctx, BalanceAreaFunds, []string{"USDT"}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaFunds, Asset: "USDT", Free: decimal.NewFromFloat(100)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	checkBalance := func(free, locked float64) bool {
+		balance, err := FindBalanceByAsset(ctx, user.TID, BalanceAreaFunds, "USDT")
+		if err != nil || !balance.Free.Equal(decimal.NewFromFloat(free)) || !balance.Locked.Equal(decimal.NewFromFloat(locked)) {
+			t.Errorf("err:%v,balance:%v", err, balance)
+			return false
+		}
+		return true
+	}
+	//confirm and done
+	withdraw, err := CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(10))
+	if err != nil || !checkBalance(90, 10) {
+		t.Error(err)
+		return
+	}
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusDone, "", "xx"); err == nil { //not confirmed
+		t.Error(err)
+		return
+	}
+	withdraw, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusConfirmed, "ok", "")
+	if err != nil || withdraw.Status != WithdrawStatusConfirmed || withdraw.Transaction["confirm_note"] != "ok" || !checkBalance(90, 10) {
+		t.Error(err)
+		return
+	}
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusConfirmed, "ok", ""); err == nil { //not pending
+		t.Error(err)
+		return
+	}
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusDone, "", ""); err == nil { //ref required
+		t.Error(err)
+		return
+	}
+	withdraw, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusDone, "", "0xabc")
+	if err != nil || withdraw.Status != WithdrawStatusDone || withdraw.Transaction["ref"] != "0xabc" || !checkBalance(90, 0) {
+		t.Error(err)
+		return
+	}
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "", ""); err == nil { //done
+		t.Error(err)
+		return
+	}
+	//reject pending
+	withdraw, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(10))
+	if err != nil || !checkBalance(80, 10) {
+		t.Error(err)
+		return
+	}
+	withdraw, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "invalid", "")
+	if err != nil || withdraw.Status != WithdrawStatusCanceled || !checkBalance(90, 0) {
+		t.Error(err)
+		return
+	}
+	//reject confirmed
+	withdraw, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(10))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusConfirmed, "", "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	withdraw, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "", "")
+	if err != nil || withdraw.Status != WithdrawStatusCanceled || !checkBalance(90, 0) {
+		t.Error(err)
+		return
+	}
+	//search
+	searcher := &WithdrawUnifySearcher{}
+	searcher.Where.UserID = []int64{user.TID}
+	searcher.Where.Status = WithdrawStatusArray{WithdrawStatusCanceled}
+	err = searcher.Apply(ctx)
+	if err != nil || len(searcher.Query.Withdraws) != 2 || searcher.Count.Total != 2 {
+		t.Error(err)
+		return
+	}
+	//
+	//test error
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusPending, "", ""); err == nil {
+		t.Error(err)
+		return
+	}
+	if _, err = ReviewWithdraw(ctx, 1, "none", WithdrawStatusConfirmed, "", ""); err == nil {
+		t.Error(err)
+		return
+	}
+	withdraw, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(10))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSet("Pool.Begin", 1)
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "", ""); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Exec", 1)
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "", ""); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Query", 2)
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "", ""); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Tx.Query", 1)
+	if _, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusCanceled, "", ""); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
+
+func TestWithdrawQuota(t *testing.T) {
+	clear()
+	defer func() {
+		basedb.StoreConf(ctx, ConfigWithdrawMax, "0")
+		basedb.StoreConf(ctx, ConfigWithdrawQuota, "{}")
+	}()
+	user := testAddUser("TestWithdrawQuota")
+	err := UpsertAsset(ctx, &Asset{Asset: "TESTQ", Name: "Test", DisplayPrecision: 2, WithdrawFee: decimal.NewFromFloat(0.1)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = TouchBalance(ctx, BalanceAreaFunds, []string{"USDT", "TESTQ"}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, asset := range []string{"USDT", "TESTQ"} {
+		err = IncreaseBalanceCall(Pool(), ctx, &Balance{UserID: user.TID, Area: BalanceAreaFunds, Asset: asset, Free: decimal.NewFromFloat(1000)})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	//fee
+	withdraw, err := CreateWithdraw(ctx, user.TID, "TESTQ", "addr", decimal.NewFromFloat(1))
+	if err != nil || !withdraw.Fee.Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("err:%v,withdraw:%v", err, withdraw)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "TESTQ", "addr", decimal.NewFromFloat(0.1)); !IsErrAssetInvalid(err) {
+		t.Error(err)
+		return
+	}
+	_, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusConfirmed, "", "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = ReviewWithdraw(ctx, 1, withdraw.OrderID, WithdrawStatusDone, "", "0xabc")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	feeBalance, err := FindBalanceByAsset(ctx, 1000, BalanceAreaFunds, "TESTQ")
+	if err != nil || !feeBalance.Free.Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("err:%v,balance:%v", err, feeBalance)
+		return
+	}
+	feeRecord, err := FindBalanceRecordWheref(ctx, "ref_id=$%v,type=$%v", withdraw.OrderID, BalanceRecordTypeFee)
+	if err != nil || feeRecord.UserID != 1000 || !feeRecord.FreeDelta.Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("err:%v,record:%v", err, feeRecord)
+		return
+	}
+	//max
+	basedb.StoreConf(ctx, ConfigWithdrawMax, "50")
+	if _, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(60)); !IsErrWithdrawMax(err) {
+		t.Error(err)
+		return
+	}
+	//default quota
+	basedb.StoreConf(ctx, ConfigWithdrawQuota, `{"":{"USDT":{"daily":"20","monthly":"30"}},"vip":{"USDT":{"daily":"50","monthly":"30"}}}`)
+	quota, err := LoadWithdrawQuota(ctx, user.TID, "USDT")
+	if err != nil || quota.DailyRemain == nil || !quota.DailyRemain.Equal(decimal.NewFromFloat(20)) || quota.MonthlyRemain == nil {
+		t.Errorf("err:%v,quota:%v", err, quota)
+		return
+	}
+	withdraw, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(15))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(10)); !IsErrWithdrawDailyQuota(err) {
+		t.Error(err)
+		return
+	}
+	quota, err = LoadWithdrawQuota(ctx, user.TID, "USDT")
+	if err != nil || !quota.DailyUsed.Equal(decimal.NewFromFloat(15)) || !quota.DailyRemain.Equal(decimal.NewFromFloat(5)) {
+		t.Errorf("err:%v,quota:%v", err, quota)
+		return
+	}
+	_, err = CancelWithdraw(ctx, user.TID, withdraw.OrderID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	quota, err = LoadWithdrawQuota(ctx, user.TID, "USDT")
+	if err != nil || !quota.DailyUsed.IsZero() {
+		t.Errorf("err:%v,quota:%v", err, quota)
+		return
+	}
+	//goldbar quota
+	err = AddWithdraw(ctx, &Withdraw{OrderID: NewOrderID(), Type: WithdrawTypeGoldbar, UserID: user.TID, Creator: user.TID, Asset: "USDT", Quantity: decimal.NewFromFloat(5), Status: WithdrawStatusPending})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	quota, err = LoadWithdrawQuota(ctx, user.TID, "USDT")
+	if err != nil || !quota.DailyUsed.Equal(decimal.NewFromFloat(5)) {
+		t.Errorf("err:%v,quota:%v", err, quota)
+		return
+	}
+	//tier quota
+	_, _, err = Pool().Exec(ctx, `update exs_user set external=$1 where tid=$2`, xsql.M{"tier": "vip"}, user.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(25))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = CreateWithdraw(ctx, user.TID, "USDT", "addr", decimal.NewFromFloat(10)); !IsErrWithdrawMonthlyQuota(err) {
+		t.Error(err)
+		return
+	}
+	//not limited
+	quota, err = LoadWithdrawQuota(ctx, user.TID, "TESTQ")
+	if err != nil || quota.Tier != "vip" || quota.DailyRemain != nil || quota.MonthlyRemain != nil {
+		t.Errorf("err:%v,quota:%v", err, quota)
+		return
+	}
+	//
+	//test error
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 1)
+	if _, err = LoadWithdrawQuota(ctx, user.TID, "USDT"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 2)
+	if _, err = LoadWithdrawQuota(ctx, user.TID, "USDT"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 3)
+	if _, err = LoadWithdrawQuota(ctx, user.TID, "USDT"); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+
+	pgx.MockerSet("Rows.Scan", 1)
+	if err = CheckWithdrawMaxCall(Pool(), ctx, decimal.NewFromFloat(1)); err == nil {
+		t.Error(err)
+		return
+	}
+	pgx.MockerClear()
+}
+
 // import (
 // 	"fmt"
 // 	"testing"
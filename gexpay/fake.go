@@ -0,0 +1,133 @@
+package gexpay
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/codingeasygo/util/xmap"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+//FakeProvider is the local payment provider for testing, the callback is signed by hmac-sha256 with secret
+type FakeProvider struct {
+	Secret   string
+	payments map[string]*Payment
+	lck      sync.RWMutex
+}
+
+//NewFakeProvider will return new fake provider
+func NewFakeProvider(secret string) (provider *FakeProvider) {
+	provider = &FakeProvider{
+		Secret:   secret,
+		payments: map[string]*Payment{},
+		lck:      sync.RWMutex{},
+	}
+	return
+}
+
+//Name will return fake
+func (f *FakeProvider) Name() string {
+	return "fake"
+}
+
+//Sign will sign args by sorted key=value joined by &, the sign key is skipped
+func (f *FakeProvider) Sign(args xmap.M) string {
+	keys := []string{}
+	for key := range args {
+		if key != "sign" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%v=%v", key, args[key]))
+	}
+	mac := hmac.New(sha256.New, []byte(f.Secret))
+	mac.Write([]byte(strings.Join(parts, "&")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//CreatePayment will create unpaid payment
+func (f *FakeProvider) CreatePayment(ctx context.Context, orderID, asset string, amount decimal.Decimal) (prepay xmap.M, err error) {
+	f.lck.Lock()
+	defer f.lck.Unlock()
+	f.payments[orderID] = &Payment{
+		OrderID: orderID,
+		Amount:  amount,
+	}
+	prepay = xmap.M{
+		"order_id": orderID,
+		"asset":    asset,
+		"amount":   amount.String(),
+	}
+	prepay["sign"] = f.Sign(prepay)
+	return
+}
+
+//Pay will mark payment to paid and return the signed callback args
+func (f *FakeProvider) Pay(orderID string, amount decimal.Decimal) (args xmap.M, err error) {
+	f.lck.Lock()
+	defer f.lck.Unlock()
+	payment := f.payments[orderID]
+	if payment == nil {
+		err = fmt.Errorf("payment %v is not exists", orderID)
+		return
+	}
+	payment.Amount = amount
+	payment.Paid = true
+	args = xmap.M{
+		"order_id": orderID,
+		"amount":   amount.String(),
+		"status":   "paid",
+		"nonce":    gexdb.NewOrderID(),
+	}
+	args["sign"] = f.Sign(args)
+	payment.Result = args
+	return
+}
+
+//VerifyCallback will verify callback sign and return payment
+func (f *FakeProvider) VerifyCallback(ctx context.Context, args xmap.M) (payment *Payment, err error) {
+	sign := args.Str("sign")
+	if len(sign) < 1 || !hmac.Equal([]byte(sign), []byte(f.Sign(args))) {
+		err = ErrSignInvalid("sign invalid")
+		return
+	}
+	amount, err := decimal.NewFromString(args.Str("amount"))
+	if err != nil {
+		return
+	}
+	payment = &Payment{
+		OrderID: args.Str("order_id"),
+		Amount:  amount,
+		Paid:    args.Str("status") == "paid",
+		Result:  args,
+	}
+	return
+}
+
+//QueryPayment will return payment status, unpaid payment is returned when it is not exists
+func (f *FakeProvider) QueryPayment(ctx context.Context, orderID string) (payment *Payment, err error) {
+	f.lck.RLock()
+	defer f.lck.RUnlock()
+	having := f.payments[orderID]
+	if having == nil {
+		payment = &Payment{OrderID: orderID}
+		return
+	}
+	payment = &Payment{
+		OrderID: having.OrderID,
+		Amount:  having.Amount,
+		Paid:    having.Paid,
+		Result:  having.Result,
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package gexpay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xprop"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/shopspring/decimal"
+)
+
+//ErrSignInvalid is returned when callback sign is not valid
+type ErrSignInvalid string
+
+func (e ErrSignInvalid) Error() string {
+	return string(e)
+}
+
+//IsErrSignInvalid will check if err is ErrSignInvalid
+func IsErrSignInvalid(err error) bool {
+	_, ok := err.(ErrSignInvalid)
+	return ok
+}
+
+//Payment is the payment status which is returned by provider
+type Payment struct {
+	OrderID string          `json:"order_id"`
+	Amount  decimal.Decimal `json:"amount"`
+	Paid    bool            `json:"paid"`
+	Result  xmap.M          `json:"result"`
+}
+
+//Provider is the payment provider interface
+type Provider interface {
+	//Name will return the provider name
+	Name() string
+	//CreatePayment will create payment on provider, the prepay is used by client to start pay
+	CreatePayment(ctx context.Context, orderID, asset string, amount decimal.Decimal) (prepay xmap.M, err error)
+	//VerifyCallback will verify the signed callback args and return the payment
+	VerifyCallback(ctx context.Context, args xmap.M) (payment *Payment, err error)
+	//QueryPayment will query payment status from provider
+	QueryPayment(ctx context.Context, orderID string) (payment *Payment, err error)
+}
+
+//Shared is the shared payment provider
+var Shared Provider
+
+//Runner is the shared topup runner
+var Runner *TopupRunner
+
+//Bootstrap will bootstrap payment provider and topup runner by config, the secret is required and fake provider is only allowed on mock enabled
+func Bootstrap(conf *xprop.Config) (err error) {
+	provider := conf.StrDef("", "/pay/provider")
+	secret := conf.StrDef("", "/pay/secret")
+	if len(provider) > 0 && len(secret) < 1 {
+		err = fmt.Errorf("payment secret is required by provider %v", provider)
+		return
+	}
+	switch provider {
+	case "":
+		xlog.Infof("payment provider is not configured")
+		return
+	case "fake":
+		if conf.StrDef("", "/mock/enabled") != "1" {
+			err = fmt.Errorf("payment provider fake is only supported on mock enabled")
+			return
+		}
+		Shared = NewFakeProvider(secret)
+	default:
+		err = fmt.Errorf("payment provider %v is not supported", provider)
+		return
+	}
+	Runner = NewTopupRunner(Shared)
+	Runner.Expire = time.Duration(conf.IntDef(1800, "/pay/expire")) * time.Second
+	Runner.Start()
+	xlog.Infof("payment provider %v is started", provider)
+	return
+}
@@ -0,0 +1,82 @@
+package gexpay
+
+import (
+	"context"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/gexservice/gexservice/base/util"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+)
+
+//TopupRunner will check the expired pending topup, it will be paid when provider is paid, or cancelled
+type TopupRunner struct {
+	Provider Provider
+	Expire   time.Duration
+	Delay    time.Duration
+	Limit    int
+	running  bool
+}
+
+func NewTopupRunner(provider Provider) (runner *TopupRunner) {
+	runner = &TopupRunner{
+		Provider: provider,
+		Expire:   30 * time.Minute,
+		Delay:    time.Minute,
+		Limit:    100,
+	}
+	return
+}
+
+func (t *TopupRunner) Start() {
+	t.running = true
+	go util.NamedRunner("TopupRunner", t.Delay, &t.running, t.Proc)
+}
+
+func (t *TopupRunner) Stop() {
+	t.running = false
+}
+
+//Proc will process the expired pending topup, pgx.ErrNoRows is returned when not thing to process
+func (t *TopupRunner) Proc() (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	topups, err := gexdb.ListPendingTopup(ctx, time.Now().Add(-t.Expire), t.Limit)
+	if err != nil {
+		xlog.Errorf("TopupRunner list pending topup fail with %v", err)
+		return
+	}
+	if len(topups) < 1 {
+		err = pgx.ErrNoRows
+		return
+	}
+	processed := 0
+	for _, topup := range topups {
+		payment, xerr := t.Provider.QueryPayment(ctx, topup.OrderID)
+		if xerr != nil {
+			xlog.Warnf("TopupRunner query payment %v fail with %v", topup.OrderID, xerr)
+			continue
+		}
+		if payment.Paid {
+			_, _, xerr = gexdb.PayTopup(ctx, topup.OrderID, payment.Amount, payment.Result)
+			if xerr != nil {
+				xlog.Warnf("TopupRunner pay topup %v fail with %v", topup.OrderID, xerr)
+				continue
+			}
+			processed++
+			continue
+		}
+		xerr = gexdb.CancelTopup(ctx, topup.OrderID, "expired")
+		if xerr != nil && xerr != pgx.ErrNoRows {
+			xlog.Warnf("TopupRunner cancel topup %v fail with %v", topup.OrderID, xerr)
+			continue
+		}
+		xlog.Infof("TopupRunner cancel expired topup %v success", topup.OrderID)
+		processed++
+	}
+	if processed < 1 || len(topups) < t.Limit {
+		err = pgx.ErrNoRows
+	}
+	return
+}
@@ -45,17 +45,42 @@ func nameConv(isTable bool, name string) string {
 
 var PgGen = gen.AutoGen{
 	TypeField: map[string]map[string]string{
+		"exs_balance_record": {
+			"area": "BalanceArea",
+		},
+		"exs_balance_transfer": {
+			"from_area": "BalanceArea",
+			"to_area":   "BalanceArea",
+		},
 		"exs_order": {
 			"transaction": "OrderTransaction",
 		},
 	},
 	FieldFilter: map[string]map[string]string{
+		"exs_asset": {
+			gen.FieldsOrder:    "asset,update_time,create_time",
+			gen.FieldsOptional: "display_precision,withdraw_min,withdraw_fee,deposit,withdraw,status",
+			gen.FieldsRequired: "asset,name",
+			gen.FieldsUpdate:   "name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,status",
+		},
+		"exs_balance_record": {
+			gen.FieldsOrder: "update_time,create_time",
+		},
+		"exs_balance_transfer": {
+			gen.FieldsOrder: "update_time,create_time",
+		},
 		"exs_user": {
 			gen.FieldsOrder:    "account,phone,update_time,create_time",
 			gen.FieldsOptional: "role,name,account,phone,password,trade_pass,image,external,status",
 			gen.FieldsFind:     "^password,trade_pass#all",
 			gen.FieldsScan:     "^password,trade_pass#all",
 		},
+		"exs_withdraw": {
+			gen.FieldsOrder: "update_time,create_time",
+		},
+		"exs_withdraw_address": {
+			gen.FieldsOrder: "update_time,create_time",
+		},
 		"exs_order": {
 			gen.FieldsOrder:    "update_time,create_time",
 			gen.FieldsOptional: "tid,quantity,price,total_price,trigger_type,trigger_price,status",
@@ -67,13 +92,19 @@ var PgGen = gen.AutoGen{
 	CodeSlice:    gen.CodeSlicePG,
 	TableRetAdd:  map[string]string{},
 	TableGenAdd: xsql.StringArray{
+		"exs_asset",
 		"exs_balance",
 		"exs_balance_history",
+		"exs_balance_record",
+		"exs_balance_transfer",
 		"exs_kline",
 		"exs_order",
 		"exs_order_comm",
+		"exs_outbox",
+		"exs_outbox_ack",
 		"exs_withdraw",
 		"exs_user",
+		"exs_withdraw_address",
 	},
 	TableNotValid: xsql.StringArray{},
 	TableInclude:  xsql.StringArray{},
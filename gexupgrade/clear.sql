@@ -4,6 +4,8 @@ DROP INDEX IF EXISTS exs_withdraw_type_idx;
 DROP INDEX IF EXISTS exs_withdraw_status_idx;
 DROP INDEX IF EXISTS exs_withdraw_order_id_idx;
 DROP INDEX IF EXISTS exs_withdraw_asset_idx;
+DROP INDEX IF EXISTS exs_withdraw_address_user_asset_address_idx;
+DROP INDEX IF EXISTS exs_withdraw_address_status_idx;
 DROP INDEX IF EXISTS exs_user_update_time_idx;
 DROP INDEX IF EXISTS exs_user_type_idx;
 DROP INDEX IF EXISTS exs_user_status_idx;
@@ -11,6 +13,9 @@ DROP INDEX IF EXISTS exs_user_role_idx;
 DROP INDEX IF EXISTS exs_user_phone_idx;
 DROP INDEX IF EXISTS exs_user_password_idx;
 DROP INDEX IF EXISTS exs_user_account_idx;
+DROP INDEX IF EXISTS exs_outbox_symbol_sequence_idx;
+DROP INDEX IF EXISTS exs_outbox_create_time_idx;
+DROP INDEX IF EXISTS exs_outbox_ack_consumer_symbol_idx;
 DROP INDEX IF EXISTS exs_order_user_id_idx;
 DROP INDEX IF EXISTS exs_order_update_time_idx;
 DROP INDEX IF EXISTS exs_order_unhedged_idx;
@@ -33,19 +38,41 @@ DROP INDEX IF EXISTS exs_holding_status_idx;
 DROP INDEX IF EXISTS exs_holding_blowup_idx;
 DROP INDEX IF EXISTS exs_holding_amount_idx;
 DROP INDEX IF EXISTS exs_balance_user_area_asset_idx;
+DROP INDEX IF EXISTS exs_balance_transfer_user_asset_idx;
+DROP INDEX IF EXISTS exs_balance_transfer_status_idx;
+DROP INDEX IF EXISTS exs_balance_transfer_create_time_idx;
 DROP INDEX IF EXISTS exs_balance_status_idx;
+DROP INDEX IF EXISTS exs_balance_record_user_area_asset_idx;
+DROP INDEX IF EXISTS exs_balance_record_type_idx;
+DROP INDEX IF EXISTS exs_balance_record_status_idx;
+DROP INDEX IF EXISTS exs_balance_record_ref_id_idx;
+DROP INDEX IF EXISTS exs_balance_record_create_time_idx;
 DROP INDEX IF EXISTS exs_balance_history_user_asset_idx;
 DROP INDEX IF EXISTS exs_balance_history_status_idx;
+DROP INDEX IF EXISTS exs_asset_status_idx;
+DROP INDEX IF EXISTS exs_asset_asset_idx;
+ALTER TABLE IF EXISTS exs_withdraw_address ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_user ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_outbox_ack ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_outbox ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_order_comm ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_order ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_kline ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_holding ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_balance_transfer ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_balance_record ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_balance_history ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_balance ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_asset ALTER COLUMN tid DROP DEFAULT;
+DROP SEQUENCE IF EXISTS exs_withdraw_address_tid_seq;
+DROP TABLE IF EXISTS exs_withdraw_address;
 DROP TABLE IF EXISTS exs_withdraw;
 DROP SEQUENCE IF EXISTS exs_user_tid_seq;
 DROP TABLE IF EXISTS exs_user;
+DROP SEQUENCE IF EXISTS exs_outbox_tid_seq;
+DROP SEQUENCE IF EXISTS exs_outbox_ack_tid_seq;
+DROP TABLE IF EXISTS exs_outbox_ack;
+DROP TABLE IF EXISTS exs_outbox;
 DROP SEQUENCE IF EXISTS exs_order_tid_seq;
 DROP SEQUENCE IF EXISTS exs_order_comm_tid_seq;
 DROP TABLE IF EXISTS exs_order_comm;
@@ -54,7 +81,13 @@ DROP SEQUENCE IF EXISTS exs_kline_tid_seq;
 DROP TABLE IF EXISTS exs_kline;
 DROP SEQUENCE IF EXISTS exs_holding_tid_seq;
 DROP TABLE IF EXISTS exs_holding;
+DROP SEQUENCE IF EXISTS exs_balance_transfer_tid_seq;
+DROP TABLE IF EXISTS exs_balance_transfer;
+DROP SEQUENCE IF EXISTS exs_balance_record_tid_seq1;
+DROP TABLE IF EXISTS exs_balance_record;
 DROP SEQUENCE IF EXISTS exs_balance_tid_seq;
 DROP SEQUENCE IF EXISTS exs_balance_record_tid_seq;
 DROP TABLE IF EXISTS exs_balance_history;
 DROP TABLE IF EXISTS exs_balance;
+DROP SEQUENCE IF EXISTS exs_asset_tid_seq;
+DROP TABLE IF EXISTS exs_asset;
@@ -52,7 +52,7 @@ EOF
 cat >> latest.go  << EOF
 const CLEAR = \`
 EOF
-cat $tmpfile | grep 'DROP TABLE' | sed 's/DROP TABLE IF EXISTS/DELETE FROM/' >> latest.go
+cat $tmpfile | grep 'DROP TABLE' | grep -v 'exs_asset;' | sed 's/DROP TABLE IF EXISTS/DELETE FROM/' >> latest.go
 
 cat >> latest.go  << EOF
 \`
@@ -12,6 +12,8 @@ DROP INDEX IF EXISTS exs_withdraw_type_idx;
 DROP INDEX IF EXISTS exs_withdraw_status_idx;
 DROP INDEX IF EXISTS exs_withdraw_order_id_idx;
 DROP INDEX IF EXISTS exs_withdraw_asset_idx;
+DROP INDEX IF EXISTS exs_withdraw_address_user_asset_address_idx;
+DROP INDEX IF EXISTS exs_withdraw_address_status_idx;
 DROP INDEX IF EXISTS exs_user_update_time_idx;
 DROP INDEX IF EXISTS exs_user_type_idx;
 DROP INDEX IF EXISTS exs_user_status_idx;
@@ -19,6 +21,9 @@ DROP INDEX IF EXISTS exs_user_role_idx;
 DROP INDEX IF EXISTS exs_user_phone_idx;
 DROP INDEX IF EXISTS exs_user_password_idx;
 DROP INDEX IF EXISTS exs_user_account_idx;
+DROP INDEX IF EXISTS exs_outbox_symbol_sequence_idx;
+DROP INDEX IF EXISTS exs_outbox_create_time_idx;
+DROP INDEX IF EXISTS exs_outbox_ack_consumer_symbol_idx;
 DROP INDEX IF EXISTS exs_order_user_id_idx;
 DROP INDEX IF EXISTS exs_order_update_time_idx;
 DROP INDEX IF EXISTS exs_order_unhedged_idx;
@@ -41,19 +46,41 @@ DROP INDEX IF EXISTS exs_holding_status_idx;
 DROP INDEX IF EXISTS exs_holding_blowup_idx;
 DROP INDEX IF EXISTS exs_holding_amount_idx;
 DROP INDEX IF EXISTS exs_balance_user_area_asset_idx;
+DROP INDEX IF EXISTS exs_balance_transfer_user_asset_idx;
+DROP INDEX IF EXISTS exs_balance_transfer_status_idx;
+DROP INDEX IF EXISTS exs_balance_transfer_create_time_idx;
 DROP INDEX IF EXISTS exs_balance_status_idx;
+DROP INDEX IF EXISTS exs_balance_record_user_area_asset_idx;
+DROP INDEX IF EXISTS exs_balance_record_type_idx;
+DROP INDEX IF EXISTS exs_balance_record_status_idx;
+DROP INDEX IF EXISTS exs_balance_record_ref_id_idx;
+DROP INDEX IF EXISTS exs_balance_record_create_time_idx;
 DROP INDEX IF EXISTS exs_balance_history_user_asset_idx;
 DROP INDEX IF EXISTS exs_balance_history_status_idx;
+DROP INDEX IF EXISTS exs_asset_status_idx;
+DROP INDEX IF EXISTS exs_asset_asset_idx;
+ALTER TABLE IF EXISTS exs_withdraw_address ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_user ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_outbox_ack ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_outbox ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_order_comm ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_order ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_kline ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_holding ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_balance_transfer ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_balance_record ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_balance_history ALTER COLUMN tid DROP DEFAULT;
 ALTER TABLE IF EXISTS exs_balance ALTER COLUMN tid DROP DEFAULT;
+ALTER TABLE IF EXISTS exs_asset ALTER COLUMN tid DROP DEFAULT;
+DROP SEQUENCE IF EXISTS exs_withdraw_address_tid_seq;
+DROP TABLE IF EXISTS exs_withdraw_address;
 DROP TABLE IF EXISTS exs_withdraw;
 DROP SEQUENCE IF EXISTS exs_user_tid_seq;
 DROP TABLE IF EXISTS exs_user;
+DROP SEQUENCE IF EXISTS exs_outbox_tid_seq;
+DROP SEQUENCE IF EXISTS exs_outbox_ack_tid_seq;
+DROP TABLE IF EXISTS exs_outbox_ack;
+DROP TABLE IF EXISTS exs_outbox;
 DROP SEQUENCE IF EXISTS exs_order_tid_seq;
 DROP SEQUENCE IF EXISTS exs_order_comm_tid_seq;
 DROP TABLE IF EXISTS exs_order_comm;
@@ -62,10 +89,131 @@ DROP SEQUENCE IF EXISTS exs_kline_tid_seq;
 DROP TABLE IF EXISTS exs_kline;
 DROP SEQUENCE IF EXISTS exs_holding_tid_seq;
 DROP TABLE IF EXISTS exs_holding;
-DROP SEQUENCE IF EXISTS exs_balance_tid_seq;
+DROP SEQUENCE IF EXISTS exs_balance_transfer_tid_seq;
+DROP TABLE IF EXISTS exs_balance_transfer;
 DROP SEQUENCE IF EXISTS exs_balance_record_tid_seq;
+DROP TABLE IF EXISTS exs_balance_record;
+DROP SEQUENCE IF EXISTS exs_balance_tid_seq;
+DROP SEQUENCE IF EXISTS exs_balance_history_tid_seq;
 DROP TABLE IF EXISTS exs_balance_history;
 DROP TABLE IF EXISTS exs_balance;
+DROP SEQUENCE IF EXISTS exs_asset_tid_seq;
+DROP TABLE IF EXISTS exs_asset;
+
+
+--
+-- Name: exs_asset; Type: TABLE; Schema: public;
+--
+
+CREATE TABLE exs_asset (
+    tid bigint NOT NULL,
+    asset character varying(30) NOT NULL,
+    name character varying(255) NOT NULL,
+    display_precision integer DEFAULT 0 NOT NULL,
+    withdraw_min double precision DEFAULT 0 NOT NULL,
+    withdraw_fee double precision DEFAULT 0 NOT NULL,
+    deposit integer DEFAULT 100 NOT NULL,
+    withdraw integer DEFAULT 100 NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL
+);
+
+
+--
+-- Name: COLUMN exs_asset.tid; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.tid IS 'the primary key';
+
+
+--
+-- Name: COLUMN exs_asset.asset; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.asset IS 'the asset key';
+
+
+--
+-- Name: COLUMN exs_asset.name; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.name IS 'the asset display name';
+
+
+--
+-- Name: COLUMN exs_asset.display_precision; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.display_precision IS 'the asset display precision';
+
+
+--
+-- Name: COLUMN exs_asset.withdraw_min; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.withdraw_min IS 'the asset minimum withdraw quantity';
+
+
+--
+-- Name: COLUMN exs_asset.withdraw_fee; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.withdraw_fee IS 'the asset withdraw fee for each withdraw';
+
+
+--
+-- Name: COLUMN exs_asset.deposit; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.deposit IS 'the asset deposit status, Enabled=100: is enabled, Disabled=200: is disabled';
+
+
+--
+-- Name: COLUMN exs_asset.withdraw; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.withdraw IS 'the asset withdraw status, Enabled=100: is enabled, Disabled=200: is disabled';
+
+
+--
+-- Name: COLUMN exs_asset.update_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.update_time IS 'the asset update time';
+
+
+--
+-- Name: COLUMN exs_asset.create_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.create_time IS 'the asset create time';
+
+
+--
+-- Name: COLUMN exs_asset.status; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_asset.status IS 'the asset status, Normal=100: is normal, Locked=200: is locked';
+
+
+--
+-- Name: exs_asset_tid_seq; Type: SEQUENCE; Schema: public;
+--
+
+CREATE SEQUENCE exs_asset_tid_seq
+    START WITH 1000
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: exs_asset_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+--
+
+ALTER SEQUENCE exs_asset_tid_seq OWNED BY exs_asset.tid;
 
 
 --
@@ -220,6 +368,153 @@ COMMENT ON COLUMN exs_balance_history.create_time IS 'the balance record create
 COMMENT ON COLUMN exs_balance_history.status IS 'the balance record status, Normal=100: is normal status';
 
 
+--
+-- Name: exs_balance_history_tid_seq; Type: SEQUENCE; Schema: public;
+--
+
+CREATE SEQUENCE exs_balance_history_tid_seq
+    START WITH 1000
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: exs_balance_history_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+--
+
+ALTER SEQUENCE exs_balance_history_tid_seq OWNED BY exs_balance_history.tid;
+
+
+--
+-- Name: exs_balance_record; Type: TABLE; Schema: public;
+--
+
+CREATE TABLE exs_balance_record (
+    tid bigint NOT NULL,
+    user_id bigint NOT NULL,
+    area integer NOT NULL,
+    asset character varying(30) NOT NULL,
+    type integer NOT NULL,
+    ref_id character varying(64) DEFAULT ''::character varying NOT NULL,
+    free_delta double precision DEFAULT 0 NOT NULL,
+    locked_delta double precision DEFAULT 0 NOT NULL,
+    margin_delta double precision DEFAULT 0 NOT NULL,
+    free double precision DEFAULT 0 NOT NULL,
+    locked double precision DEFAULT 0 NOT NULL,
+    margin double precision DEFAULT 0 NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL
+);
+
+
+--
+-- Name: COLUMN exs_balance_record.tid; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.tid IS 'the primary key';
+
+
+--
+-- Name: COLUMN exs_balance_record.user_id; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.user_id IS 'the balance user id';
+
+
+--
+-- Name: COLUMN exs_balance_record.area; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.area IS 'the balance area';
+
+
+--
+-- Name: COLUMN exs_balance_record.asset; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.asset IS 'the balance asset key';
+
+
+--
+-- Name: COLUMN exs_balance_record.type; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.type IS 'the balance record type, Change=100:is manual change, Withdraw=110:is withdraw, Transfer=120:is transfer between area, Topup=130:is topup, Goldbar=140:is goldbar redemption, TradeLock=200:is lock by place order, Trade=210:is trade filled or canceled, Fee=220:is trade or withdraw fee, Margin=300:is margin add or free on futures, Blowup=310:is blowup on futures';
+
+
+--
+-- Name: COLUMN exs_balance_record.ref_id; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.ref_id IS 'the balance record reference id, it is order id, withdraw order id, transfer id or holding id by type';
+
+
+--
+-- Name: COLUMN exs_balance_record.free_delta; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.free_delta IS 'the balance free changed';
+
+
+--
+-- Name: COLUMN exs_balance_record.locked_delta; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.locked_delta IS 'the balance locked changed';
+
+
+--
+-- Name: COLUMN exs_balance_record.margin_delta; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.margin_delta IS 'the balance margin changed';
+
+
+--
+-- Name: COLUMN exs_balance_record.free; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.free IS 'the balance free after changed';
+
+
+--
+-- Name: COLUMN exs_balance_record.locked; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.locked IS 'the balance locked after changed';
+
+
+--
+-- Name: COLUMN exs_balance_record.margin; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.margin IS 'the balance margin after changed';
+
+
+--
+-- Name: COLUMN exs_balance_record.update_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.update_time IS 'the balance record update time';
+
+
+--
+-- Name: COLUMN exs_balance_record.create_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.create_time IS 'the balance record create time';
+
+
+--
+-- Name: COLUMN exs_balance_record.status; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_record.status IS 'the balance record status, Normal=100: is normal status';
+
+
 --
 -- Name: exs_balance_record_tid_seq; Type: SEQUENCE; Schema: public;
 --
@@ -236,7 +531,7 @@ CREATE SEQUENCE exs_balance_record_tid_seq
 -- Name: exs_balance_record_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
 --
 
-ALTER SEQUENCE exs_balance_record_tid_seq OWNED BY exs_balance_history.tid;
+ALTER SEQUENCE exs_balance_record_tid_seq OWNED BY exs_balance_record.tid;
 
 
 --
@@ -258,6 +553,105 @@ CREATE SEQUENCE exs_balance_tid_seq
 ALTER SEQUENCE exs_balance_tid_seq OWNED BY exs_balance.tid;
 
 
+--
+-- Name: exs_balance_transfer; Type: TABLE; Schema: public;
+--
+
+CREATE TABLE exs_balance_transfer (
+    tid bigint NOT NULL,
+    user_id bigint NOT NULL,
+    asset character varying(30) NOT NULL,
+    from_area integer NOT NULL,
+    to_area integer NOT NULL,
+    quantity double precision DEFAULT 0 NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL
+);
+
+
+--
+-- Name: COLUMN exs_balance_transfer.tid; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.tid IS 'the primary key';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.user_id; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.user_id IS 'the transfer user id';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.asset; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.asset IS 'the transfer asset key';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.from_area; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.from_area IS 'the transfer source balance area';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.to_area; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.to_area IS 'the transfer target balance area';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.quantity; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.quantity IS 'the transfer quantity';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.update_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.update_time IS 'the transfer update time';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.create_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.create_time IS 'the transfer create time';
+
+
+--
+-- Name: COLUMN exs_balance_transfer.status; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_balance_transfer.status IS 'the transfer status, Normal=100: is normal status';
+
+
+--
+-- Name: exs_balance_transfer_tid_seq; Type: SEQUENCE; Schema: public;
+--
+
+CREATE SEQUENCE exs_balance_transfer_tid_seq
+    START WITH 1000
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: exs_balance_transfer_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+--
+
+ALTER SEQUENCE exs_balance_transfer_tid_seq OWNED BY exs_balance_transfer.tid;
+
+
 --
 -- Name: exs_holding; Type: TABLE; Schema: public;
 --
@@ -770,66 +1164,232 @@ CREATE TABLE exs_order_comm (
 
 
 --
--- Name: COLUMN exs_order_comm.tid; Type: COMMENT; Schema: public;
+-- Name: COLUMN exs_order_comm.tid; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.tid IS 'the primary key';
+
+
+--
+-- Name: COLUMN exs_order_comm.order_id; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.order_id IS 'the order id';
+
+
+--
+-- Name: COLUMN exs_order_comm.user_id; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.user_id IS 'the user id';
+
+
+--
+-- Name: COLUMN exs_order_comm.type; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.type IS 'the comm type, Normal=100:is normal type';
+
+
+--
+-- Name: COLUMN exs_order_comm.in_balance; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.in_balance IS 'the in balance asset key';
+
+
+--
+-- Name: COLUMN exs_order_comm.in_fee; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.in_fee IS 'the in balance fee';
+
+
+--
+-- Name: COLUMN exs_order_comm.create_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.create_time IS 'the comm create time';
+
+
+--
+-- Name: COLUMN exs_order_comm.status; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_order_comm.status IS 'the comm status, Normal=100:is normal';
+
+
+--
+-- Name: exs_order_comm_tid_seq; Type: SEQUENCE; Schema: public;
+--
+
+CREATE SEQUENCE exs_order_comm_tid_seq
+    START WITH 1000
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: exs_order_comm_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+--
+
+ALTER SEQUENCE exs_order_comm_tid_seq OWNED BY exs_order_comm.tid;
+
+
+--
+-- Name: exs_order_tid_seq; Type: SEQUENCE; Schema: public;
+--
+
+CREATE SEQUENCE exs_order_tid_seq
+    START WITH 1000
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: exs_order_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+--
+
+ALTER SEQUENCE exs_order_tid_seq OWNED BY exs_order.tid;
+
+
+--
+-- Name: exs_outbox; Type: TABLE; Schema: public;
+--
+
+CREATE TABLE exs_outbox (
+    tid bigint NOT NULL,
+    symbol character varying(32) NOT NULL,
+    sequence bigint NOT NULL,
+    event jsonb DEFAULT '{}'::jsonb NOT NULL,
+    update_time timestamp(6) with time zone NOT NULL,
+    create_time timestamp(6) with time zone NOT NULL,
+    status integer NOT NULL
+);
+
+
+--
+-- Name: COLUMN exs_outbox.tid; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_outbox.tid IS 'the primary key';
+
+
+--
+-- Name: COLUMN exs_outbox.symbol; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_outbox.symbol IS 'the outbox event symbol';
+
+
+--
+-- Name: COLUMN exs_outbox.sequence; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_outbox.sequence IS 'the outbox event sequence by symbol';
+
+
+--
+-- Name: COLUMN exs_outbox.event; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_outbox.event IS 'the outbox matcher event';
+
+
+--
+-- Name: COLUMN exs_outbox.update_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_outbox.update_time IS 'the outbox last update time';
+
+
+--
+-- Name: COLUMN exs_outbox.create_time; Type: COMMENT; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.tid IS 'the primary key';
+COMMENT ON COLUMN exs_outbox.create_time IS 'the outbox create time';
 
 
 --
--- Name: COLUMN exs_order_comm.order_id; Type: COMMENT; Schema: public;
+-- Name: COLUMN exs_outbox.status; Type: COMMENT; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.order_id IS 'the order id';
+COMMENT ON COLUMN exs_outbox.status IS 'the outbox status, Normal=100:is normal';
 
 
 --
--- Name: COLUMN exs_order_comm.user_id; Type: COMMENT; Schema: public;
+-- Name: exs_outbox_ack; Type: TABLE; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.user_id IS 'the user id';
+CREATE TABLE exs_outbox_ack (
+    tid bigint NOT NULL,
+    consumer character varying(64) NOT NULL,
+    symbol character varying(32) NOT NULL,
+    sequence bigint DEFAULT 0 NOT NULL,
+    update_time timestamp(6) with time zone NOT NULL,
+    create_time timestamp(6) with time zone NOT NULL,
+    status integer NOT NULL
+);
 
 
 --
--- Name: COLUMN exs_order_comm.type; Type: COMMENT; Schema: public;
+-- Name: COLUMN exs_outbox_ack.tid; Type: COMMENT; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.type IS 'the comm type, Normal=100:is normal type';
+COMMENT ON COLUMN exs_outbox_ack.tid IS 'the primary key';
 
 
 --
--- Name: COLUMN exs_order_comm.in_balance; Type: COMMENT; Schema: public;
+-- Name: COLUMN exs_outbox_ack.consumer; Type: COMMENT; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.in_balance IS 'the in balance asset key';
+COMMENT ON COLUMN exs_outbox_ack.consumer IS 'the outbox consumer name';
 
 
 --
--- Name: COLUMN exs_order_comm.in_fee; Type: COMMENT; Schema: public;
+-- Name: COLUMN exs_outbox_ack.symbol; Type: COMMENT; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.in_fee IS 'the in balance fee';
+COMMENT ON COLUMN exs_outbox_ack.symbol IS 'the outbox event symbol';
 
 
 --
--- Name: COLUMN exs_order_comm.create_time; Type: COMMENT; Schema: public;
+-- Name: COLUMN exs_outbox_ack.sequence; Type: COMMENT; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.create_time IS 'the comm create time';
+COMMENT ON COLUMN exs_outbox_ack.sequence IS 'the last acked outbox event sequence';
 
 
 --
--- Name: COLUMN exs_order_comm.status; Type: COMMENT; Schema: public;
+-- Name: COLUMN exs_outbox_ack.update_time; Type: COMMENT; Schema: public;
 --
 
-COMMENT ON COLUMN exs_order_comm.status IS 'the comm status, Normal=100:is normal';
+COMMENT ON COLUMN exs_outbox_ack.update_time IS 'the outbox ack last update time';
 
 
 --
--- Name: exs_order_comm_tid_seq; Type: SEQUENCE; Schema: public;
+-- Name: COLUMN exs_outbox_ack.create_time; Type: COMMENT; Schema: public;
 --
 
-CREATE SEQUENCE exs_order_comm_tid_seq
+COMMENT ON COLUMN exs_outbox_ack.create_time IS 'the outbox ack create time';
+
+
+--
+-- Name: COLUMN exs_outbox_ack.status; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_outbox_ack.status IS 'the outbox ack status, Normal=100:is normal';
+
+
+--
+-- Name: exs_outbox_ack_tid_seq; Type: SEQUENCE; Schema: public;
+--
+
+CREATE SEQUENCE exs_outbox_ack_tid_seq
     START WITH 1000
     INCREMENT BY 1
     NO MINVALUE
@@ -838,17 +1398,17 @@ CREATE SEQUENCE exs_order_comm_tid_seq
 
 
 --
--- Name: exs_order_comm_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+-- Name: exs_outbox_ack_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
 --
 
-ALTER SEQUENCE exs_order_comm_tid_seq OWNED BY exs_order_comm.tid;
+ALTER SEQUENCE exs_outbox_ack_tid_seq OWNED BY exs_outbox_ack.tid;
 
 
 --
--- Name: exs_order_tid_seq; Type: SEQUENCE; Schema: public;
+-- Name: exs_outbox_tid_seq; Type: SEQUENCE; Schema: public;
 --
 
-CREATE SEQUENCE exs_order_tid_seq
+CREATE SEQUENCE exs_outbox_tid_seq
     START WITH 1000
     INCREMENT BY 1
     NO MINVALUE
@@ -857,10 +1417,10 @@ CREATE SEQUENCE exs_order_tid_seq
 
 
 --
--- Name: exs_order_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+-- Name: exs_outbox_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
 --
 
-ALTER SEQUENCE exs_order_tid_seq OWNED BY exs_order.tid;
+ALTER SEQUENCE exs_outbox_tid_seq OWNED BY exs_outbox.tid;
 
 
 --
@@ -1005,7 +1565,9 @@ CREATE TABLE exs_withdraw (
     user_id bigint NOT NULL,
     creator bigint NOT NULL,
     asset character varying(16) NOT NULL,
+    address character varying(255) DEFAULT ''::character varying NOT NULL,
     quantity double precision DEFAULT 0 NOT NULL,
+    fee double precision DEFAULT 0 NOT NULL,
     transaction jsonb DEFAULT '{}'::jsonb NOT NULL,
     update_time timestamp(6) with time zone NOT NULL,
     create_time timestamp(6) with time zone NOT NULL,
@@ -1055,6 +1617,13 @@ COMMENT ON COLUMN exs_withdraw.creator IS 'the withdraw order creator user id';
 COMMENT ON COLUMN exs_withdraw.asset IS 'the withdraw asset';
 
 
+--
+-- Name: COLUMN exs_withdraw.address; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw.address IS 'the withdraw destination address';
+
+
 --
 -- Name: COLUMN exs_withdraw.quantity; Type: COMMENT; Schema: public;
 --
@@ -1062,6 +1631,13 @@ COMMENT ON COLUMN exs_withdraw.asset IS 'the withdraw asset';
 COMMENT ON COLUMN exs_withdraw.quantity IS 'the withdraw order quantity';
 
 
+--
+-- Name: COLUMN exs_withdraw.fee; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw.fee IS 'the withdraw order fee, it is included in quantity';
+
+
 --
 -- Name: COLUMN exs_withdraw.transaction; Type: COMMENT; Schema: public;
 --
@@ -1090,6 +1666,112 @@ COMMENT ON COLUMN exs_withdraw.create_time IS 'the withdraw order create time';
 COMMENT ON COLUMN exs_withdraw.status IS 'the withdraw order status, Pending=100:is pending, Confirmed=200:is confirmed, Done=300:is done, Canceled=320: is canceled';
 
 
+--
+-- Name: exs_withdraw_address; Type: TABLE; Schema: public;
+--
+
+CREATE TABLE exs_withdraw_address (
+    tid bigint NOT NULL,
+    user_id bigint NOT NULL,
+    asset character varying(30) NOT NULL,
+    address character varying(255) NOT NULL,
+    label character varying(255) DEFAULT ''::character varying NOT NULL,
+    available_time timestamp with time zone NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL
+);
+
+
+--
+-- Name: COLUMN exs_withdraw_address.tid; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.tid IS 'the primary key';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.user_id; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.user_id IS 'the address owner user id';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.asset; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.asset IS 'the address asset key';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.address; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.address IS 'the withdraw destination address';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.label; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.label IS 'the address label';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.available_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.available_time IS 'the address available time, it can be used to withdraw after this time';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.update_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.update_time IS 'the address update time';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.create_time; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.create_time IS 'the address create time';
+
+
+--
+-- Name: COLUMN exs_withdraw_address.status; Type: COMMENT; Schema: public;
+--
+
+COMMENT ON COLUMN exs_withdraw_address.status IS 'the address status, Normal=100: is normal, Removed=-1: is removed';
+
+
+--
+-- Name: exs_withdraw_address_tid_seq; Type: SEQUENCE; Schema: public;
+--
+
+CREATE SEQUENCE exs_withdraw_address_tid_seq
+    START WITH 1000
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: exs_withdraw_address_tid_seq; Type: SEQUENCE OWNED BY; Schema: public;
+--
+
+ALTER SEQUENCE exs_withdraw_address_tid_seq OWNED BY exs_withdraw_address.tid;
+
+
+--
+-- Name: exs_asset tid; Type: DEFAULT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_asset ALTER COLUMN tid SET DEFAULT nextval('exs_asset_tid_seq'::regclass);
+
+
 --
 -- Name: exs_balance tid; Type: DEFAULT; Schema: public;
 --
@@ -1101,7 +1783,21 @@ ALTER TABLE IF EXISTS ONLY exs_balance ALTER COLUMN tid SET DEFAULT nextval('exs
 -- Name: exs_balance_history tid; Type: DEFAULT; Schema: public;
 --
 
-ALTER TABLE IF EXISTS ONLY exs_balance_history ALTER COLUMN tid SET DEFAULT nextval('exs_balance_record_tid_seq'::regclass);
+ALTER TABLE IF EXISTS ONLY exs_balance_history ALTER COLUMN tid SET DEFAULT nextval('exs_balance_history_tid_seq'::regclass);
+
+
+--
+-- Name: exs_balance_record tid; Type: DEFAULT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_balance_record ALTER COLUMN tid SET DEFAULT nextval('exs_balance_record_tid_seq'::regclass);
+
+
+--
+-- Name: exs_balance_transfer tid; Type: DEFAULT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_balance_transfer ALTER COLUMN tid SET DEFAULT nextval('exs_balance_transfer_tid_seq'::regclass);
 
 
 --
@@ -1132,6 +1828,20 @@ ALTER TABLE IF EXISTS ONLY exs_order ALTER COLUMN tid SET DEFAULT nextval('exs_o
 ALTER TABLE IF EXISTS ONLY exs_order_comm ALTER COLUMN tid SET DEFAULT nextval('exs_order_comm_tid_seq'::regclass);
 
 
+--
+-- Name: exs_outbox tid; Type: DEFAULT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_outbox ALTER COLUMN tid SET DEFAULT nextval('exs_outbox_tid_seq'::regclass);
+
+
+--
+-- Name: exs_outbox_ack tid; Type: DEFAULT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_outbox_ack ALTER COLUMN tid SET DEFAULT nextval('exs_outbox_ack_tid_seq'::regclass);
+
+
 --
 -- Name: exs_user tid; Type: DEFAULT; Schema: public;
 --
@@ -1139,6 +1849,21 @@ ALTER TABLE IF EXISTS ONLY exs_order_comm ALTER COLUMN tid SET DEFAULT nextval('
 ALTER TABLE IF EXISTS ONLY exs_user ALTER COLUMN tid SET DEFAULT nextval('exs_user_tid_seq'::regclass);
 
 
+--
+-- Name: exs_withdraw_address tid; Type: DEFAULT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_withdraw_address ALTER COLUMN tid SET DEFAULT nextval('exs_withdraw_address_tid_seq'::regclass);
+
+
+--
+-- Name: exs_asset exs_asset_pkey; Type: CONSTRAINT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_asset
+    ADD CONSTRAINT exs_asset_pkey PRIMARY KEY (tid);
+
+
 --
 -- Name: exs_balance exs_balance_pkey; Type: CONSTRAINT; Schema: public;
 --
@@ -1148,13 +1873,29 @@ ALTER TABLE IF EXISTS ONLY exs_balance
 
 
 --
--- Name: exs_balance_history exs_balance_record_pkey; Type: CONSTRAINT; Schema: public;
+-- Name: exs_balance_history exs_balance_history_pkey; Type: CONSTRAINT; Schema: public;
 --
 
 ALTER TABLE IF EXISTS ONLY exs_balance_history
+    ADD CONSTRAINT exs_balance_history_pkey PRIMARY KEY (tid);
+
+
+--
+-- Name: exs_balance_record exs_balance_record_pkey; Type: CONSTRAINT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_balance_record
     ADD CONSTRAINT exs_balance_record_pkey PRIMARY KEY (tid);
 
 
+--
+-- Name: exs_balance_transfer exs_balance_transfer_pkey; Type: CONSTRAINT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_balance_transfer
+    ADD CONSTRAINT exs_balance_transfer_pkey PRIMARY KEY (tid);
+
+
 --
 -- Name: exs_holding exs_holding_pkey; Type: CONSTRAINT; Schema: public;
 --
@@ -1195,6 +1936,22 @@ ALTER TABLE IF EXISTS ONLY exs_order
     ADD CONSTRAINT exs_order_pkey PRIMARY KEY (tid);
 
 
+--
+-- Name: exs_outbox_ack exs_outbox_ack_pkey; Type: CONSTRAINT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_outbox_ack
+    ADD CONSTRAINT exs_outbox_ack_pkey PRIMARY KEY (tid);
+
+
+--
+-- Name: exs_outbox exs_outbox_pkey; Type: CONSTRAINT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_outbox
+    ADD CONSTRAINT exs_outbox_pkey PRIMARY KEY (tid);
+
+
 --
 -- Name: exs_user exs_user_pkey; Type: CONSTRAINT; Schema: public;
 --
@@ -1203,6 +1960,28 @@ ALTER TABLE IF EXISTS ONLY exs_user
     ADD CONSTRAINT exs_user_pkey PRIMARY KEY (tid);
 
 
+--
+-- Name: exs_withdraw_address exs_withdraw_address_pkey; Type: CONSTRAINT; Schema: public;
+--
+
+ALTER TABLE IF EXISTS ONLY exs_withdraw_address
+    ADD CONSTRAINT exs_withdraw_address_pkey PRIMARY KEY (tid);
+
+
+--
+-- Name: exs_asset_asset_idx; Type: INDEX; Schema: public;
+--
+
+CREATE UNIQUE INDEX exs_asset_asset_idx ON exs_asset USING btree (asset);
+
+
+--
+-- Name: exs_asset_status_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_asset_status_idx ON exs_asset USING btree (status);
+
+
 --
 -- Name: exs_balance_history_status_idx; Type: INDEX; Schema: public;
 --
@@ -1217,6 +1996,41 @@ CREATE INDEX exs_balance_history_status_idx ON exs_balance_history USING btree (
 CREATE UNIQUE INDEX exs_balance_history_user_asset_idx ON exs_balance_history USING btree (user_id, asset, create_time);
 
 
+--
+-- Name: exs_balance_record_create_time_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_record_create_time_idx ON exs_balance_record USING btree (create_time);
+
+
+--
+-- Name: exs_balance_record_ref_id_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_record_ref_id_idx ON exs_balance_record USING btree (ref_id);
+
+
+--
+-- Name: exs_balance_record_status_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_record_status_idx ON exs_balance_record USING btree (status);
+
+
+--
+-- Name: exs_balance_record_type_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_record_type_idx ON exs_balance_record USING btree (type);
+
+
+--
+-- Name: exs_balance_record_user_area_asset_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_record_user_area_asset_idx ON exs_balance_record USING btree (user_id, area, asset);
+
+
 --
 -- Name: exs_balance_status_idx; Type: INDEX; Schema: public;
 --
@@ -1224,6 +2038,27 @@ CREATE UNIQUE INDEX exs_balance_history_user_asset_idx ON exs_balance_history US
 CREATE INDEX exs_balance_status_idx ON exs_balance USING btree (status);
 
 
+--
+-- Name: exs_balance_transfer_create_time_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_transfer_create_time_idx ON exs_balance_transfer USING btree (create_time);
+
+
+--
+-- Name: exs_balance_transfer_status_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_transfer_status_idx ON exs_balance_transfer USING btree (status);
+
+
+--
+-- Name: exs_balance_transfer_user_asset_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_balance_transfer_user_asset_idx ON exs_balance_transfer USING btree (user_id, asset);
+
+
 --
 -- Name: exs_balance_user_area_asset_idx; Type: INDEX; Schema: public;
 --
@@ -1378,6 +2213,27 @@ CREATE INDEX exs_order_update_time_idx ON exs_order USING btree (update_time);
 CREATE INDEX exs_order_user_id_idx ON exs_order USING btree (user_id);
 
 
+--
+-- Name: exs_outbox_ack_consumer_symbol_idx; Type: INDEX; Schema: public;
+--
+
+CREATE UNIQUE INDEX exs_outbox_ack_consumer_symbol_idx ON exs_outbox_ack USING btree (consumer, symbol);
+
+
+--
+-- Name: exs_outbox_create_time_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_outbox_create_time_idx ON exs_outbox USING btree (create_time);
+
+
+--
+-- Name: exs_outbox_symbol_sequence_idx; Type: INDEX; Schema: public;
+--
+
+CREATE UNIQUE INDEX exs_outbox_symbol_sequence_idx ON exs_outbox USING btree (symbol, sequence);
+
+
 --
 -- Name: exs_user_account_idx; Type: INDEX; Schema: public;
 --
@@ -1427,6 +2283,20 @@ CREATE INDEX exs_user_type_idx ON exs_user USING btree (type);
 CREATE INDEX exs_user_update_time_idx ON exs_user USING btree (update_time);
 
 
+--
+-- Name: exs_withdraw_address_status_idx; Type: INDEX; Schema: public;
+--
+
+CREATE INDEX exs_withdraw_address_status_idx ON exs_withdraw_address USING btree (status);
+
+
+--
+-- Name: exs_withdraw_address_user_asset_address_idx; Type: INDEX; Schema: public;
+--
+
+CREATE UNIQUE INDEX exs_withdraw_address_user_asset_address_idx ON exs_withdraw_address USING btree (user_id, asset, address);
+
+
 --
 -- Name: exs_withdraw_asset_idx; Type: INDEX; Schema: public;
 --
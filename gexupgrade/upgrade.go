@@ -10,4 +10,139 @@ INSERT INTO exs_config(key,value,update_time) VALUES ('goldbar_tips', '', '2021-
 INSERT INTO exs_config(key,value,update_time) VALUES ('welcome_message', 'welcom', '2021-07-04 12:51:17.074424+00');
 INSERT INTO exs_config(key,value,update_time) VALUES ('withdraw_max', '50000', '2021-07-04 12:51:17.074424+00');
 INSERT INTO exs_config(key,value,update_time) VALUES ('trade_rule', 'rule', '2021-07-04 12:51:17.074424+00');
+INSERT INTO exs_config(key,value,update_time) VALUES ('withdraw_quota', '{}', '2021-07-04 12:51:17.074424+00');
+INSERT INTO exs_config(key,value,update_time) VALUES ('withdraw_address_delay', '86400', '2021-07-04 12:51:17.074424+00');
+INSERT INTO exs_config(key,value,update_time) VALUES ('withdraw_fee_user', '1000', '2021-07-04 12:51:17.074424+00');
+INSERT INTO exs_asset(asset,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,update_time,create_time,status) VALUES ('USDT', 'USDT', 8, 0, 0, 100, 100, '2021-07-04 12:51:17.074424+00', '2021-07-04 12:51:17.074424+00', 100);
+INSERT INTO exs_asset(asset,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,update_time,create_time,status) VALUES ('YWE', 'YWE', 8, 0, 0, 100, 100, '2021-07-04 12:51:17.074424+00', '2021-07-04 12:51:17.074424+00', 100);
+`
+
+//UPGRADE will upgrade the exists database to latest, it is safe to run on every start
+const UPGRADE = `
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname='exs_balance_record_pkey' AND conrelid='exs_balance_history'::regclass) THEN
+        ALTER TABLE exs_balance_history RENAME CONSTRAINT exs_balance_record_pkey TO exs_balance_history_pkey;
+        ALTER SEQUENCE exs_balance_record_tid_seq RENAME TO exs_balance_history_tid_seq;
+    END IF;
+END $$;
+
+CREATE SEQUENCE IF NOT EXISTS exs_balance_record_tid_seq START WITH 1000 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+CREATE TABLE IF NOT EXISTS exs_balance_record (
+    tid bigint DEFAULT nextval('exs_balance_record_tid_seq'::regclass) NOT NULL,
+    user_id bigint NOT NULL,
+    area integer NOT NULL,
+    asset character varying(30) NOT NULL,
+    type integer NOT NULL,
+    ref_id character varying(64) DEFAULT ''::character varying NOT NULL,
+    free_delta double precision DEFAULT 0 NOT NULL,
+    locked_delta double precision DEFAULT 0 NOT NULL,
+    margin_delta double precision DEFAULT 0 NOT NULL,
+    free double precision DEFAULT 0 NOT NULL,
+    locked double precision DEFAULT 0 NOT NULL,
+    margin double precision DEFAULT 0 NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL,
+    CONSTRAINT exs_balance_record_pkey PRIMARY KEY (tid)
+);
+ALTER SEQUENCE exs_balance_record_tid_seq OWNED BY exs_balance_record.tid;
+CREATE INDEX IF NOT EXISTS exs_balance_record_create_time_idx ON exs_balance_record USING btree (create_time);
+CREATE INDEX IF NOT EXISTS exs_balance_record_ref_id_idx ON exs_balance_record USING btree (ref_id);
+CREATE INDEX IF NOT EXISTS exs_balance_record_status_idx ON exs_balance_record USING btree (status);
+CREATE INDEX IF NOT EXISTS exs_balance_record_type_idx ON exs_balance_record USING btree (type);
+CREATE INDEX IF NOT EXISTS exs_balance_record_user_area_asset_idx ON exs_balance_record USING btree (user_id, area, asset);
+
+CREATE SEQUENCE IF NOT EXISTS exs_balance_transfer_tid_seq START WITH 1000 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+CREATE TABLE IF NOT EXISTS exs_balance_transfer (
+    tid bigint DEFAULT nextval('exs_balance_transfer_tid_seq'::regclass) NOT NULL,
+    user_id bigint NOT NULL,
+    asset character varying(30) NOT NULL,
+    from_area integer NOT NULL,
+    to_area integer NOT NULL,
+    quantity double precision DEFAULT 0 NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL,
+    CONSTRAINT exs_balance_transfer_pkey PRIMARY KEY (tid)
+);
+ALTER SEQUENCE exs_balance_transfer_tid_seq OWNED BY exs_balance_transfer.tid;
+CREATE INDEX IF NOT EXISTS exs_balance_transfer_create_time_idx ON exs_balance_transfer USING btree (create_time);
+CREATE INDEX IF NOT EXISTS exs_balance_transfer_status_idx ON exs_balance_transfer USING btree (status);
+CREATE INDEX IF NOT EXISTS exs_balance_transfer_user_asset_idx ON exs_balance_transfer USING btree (user_id, asset);
+
+CREATE SEQUENCE IF NOT EXISTS exs_asset_tid_seq START WITH 1000 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+CREATE TABLE IF NOT EXISTS exs_asset (
+    tid bigint DEFAULT nextval('exs_asset_tid_seq'::regclass) NOT NULL,
+    asset character varying(30) NOT NULL,
+    name character varying(255) NOT NULL,
+    display_precision integer DEFAULT 0 NOT NULL,
+    withdraw_min double precision DEFAULT 0 NOT NULL,
+    withdraw_fee double precision DEFAULT 0 NOT NULL,
+    deposit integer DEFAULT 100 NOT NULL,
+    withdraw integer DEFAULT 100 NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL,
+    CONSTRAINT exs_asset_pkey PRIMARY KEY (tid)
+);
+ALTER SEQUENCE exs_asset_tid_seq OWNED BY exs_asset.tid;
+CREATE UNIQUE INDEX IF NOT EXISTS exs_asset_asset_idx ON exs_asset USING btree (asset);
+CREATE INDEX IF NOT EXISTS exs_asset_status_idx ON exs_asset USING btree (status);
+
+ALTER TABLE exs_withdraw ADD COLUMN IF NOT EXISTS address character varying(255) DEFAULT ''::character varying NOT NULL;
+ALTER TABLE exs_withdraw ADD COLUMN IF NOT EXISTS fee double precision DEFAULT 0 NOT NULL;
+
+CREATE SEQUENCE IF NOT EXISTS exs_withdraw_address_tid_seq START WITH 1000 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+CREATE TABLE IF NOT EXISTS exs_withdraw_address (
+    tid bigint DEFAULT nextval('exs_withdraw_address_tid_seq'::regclass) NOT NULL,
+    user_id bigint NOT NULL,
+    asset character varying(30) NOT NULL,
+    address character varying(255) NOT NULL,
+    label character varying(255) DEFAULT ''::character varying NOT NULL,
+    available_time timestamp with time zone NOT NULL,
+    update_time timestamp with time zone NOT NULL,
+    create_time timestamp with time zone NOT NULL,
+    status integer NOT NULL,
+    CONSTRAINT exs_withdraw_address_pkey PRIMARY KEY (tid)
+);
+ALTER SEQUENCE exs_withdraw_address_tid_seq OWNED BY exs_withdraw_address.tid;
+CREATE INDEX IF NOT EXISTS exs_withdraw_address_status_idx ON exs_withdraw_address USING btree (status);
+CREATE UNIQUE INDEX IF NOT EXISTS exs_withdraw_address_user_asset_address_idx ON exs_withdraw_address USING btree (user_id, asset, address);
+
+CREATE SEQUENCE IF NOT EXISTS exs_outbox_tid_seq START WITH 1000 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+CREATE TABLE IF NOT EXISTS exs_outbox (
+    tid bigint DEFAULT nextval('exs_outbox_tid_seq'::regclass) NOT NULL,
+    symbol character varying(32) NOT NULL,
+    sequence bigint NOT NULL,
+    event jsonb DEFAULT '{}'::jsonb NOT NULL,
+    update_time timestamp(6) with time zone NOT NULL,
+    create_time timestamp(6) with time zone NOT NULL,
+    status integer NOT NULL,
+    CONSTRAINT exs_outbox_pkey PRIMARY KEY (tid)
+);
+ALTER SEQUENCE exs_outbox_tid_seq OWNED BY exs_outbox.tid;
+CREATE INDEX IF NOT EXISTS exs_outbox_create_time_idx ON exs_outbox USING btree (create_time);
+CREATE UNIQUE INDEX IF NOT EXISTS exs_outbox_symbol_sequence_idx ON exs_outbox USING btree (symbol, sequence);
+
+CREATE SEQUENCE IF NOT EXISTS exs_outbox_ack_tid_seq START WITH 1000 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+CREATE TABLE IF NOT EXISTS exs_outbox_ack (
+    tid bigint DEFAULT nextval('exs_outbox_ack_tid_seq'::regclass) NOT NULL,
+    consumer character varying(64) NOT NULL,
+    symbol character varying(32) NOT NULL,
+    sequence bigint DEFAULT 0 NOT NULL,
+    update_time timestamp(6) with time zone NOT NULL,
+    create_time timestamp(6) with time zone NOT NULL,
+    status integer NOT NULL,
+    CONSTRAINT exs_outbox_ack_pkey PRIMARY KEY (tid)
+);
+ALTER SEQUENCE exs_outbox_ack_tid_seq OWNED BY exs_outbox_ack.tid;
+CREATE UNIQUE INDEX IF NOT EXISTS exs_outbox_ack_consumer_symbol_idx ON exs_outbox_ack USING btree (consumer, symbol);
+
+INSERT INTO exs_config(key,value,update_time) VALUES ('withdraw_quota', '{}', '2021-07-04 12:51:17.074424+00') ON CONFLICT (key) DO NOTHING;
+INSERT INTO exs_config(key,value,update_time) VALUES ('withdraw_address_delay', '86400', '2021-07-04 12:51:17.074424+00') ON CONFLICT (key) DO NOTHING;
+INSERT INTO exs_config(key,value,update_time) VALUES ('withdraw_fee_user', '1000', '2021-07-04 12:51:17.074424+00') ON CONFLICT (key) DO NOTHING;
+INSERT INTO exs_asset(asset,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,update_time,create_time,status) VALUES ('USDT', 'USDT', 8, 0, 0, 100, 100, '2021-07-04 12:51:17.074424+00', '2021-07-04 12:51:17.074424+00', 100) ON CONFLICT (asset) DO NOTHING;
+INSERT INTO exs_asset(asset,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,update_time,create_time,status) VALUES ('YWE', 'YWE', 8, 0, 0, 100, 100, '2021-07-04 12:51:17.074424+00', '2021-07-04 12:51:17.074424+00', 100) ON CONFLICT (asset) DO NOTHING;
+INSERT INTO exs_asset(asset,name,display_precision,withdraw_min,withdraw_fee,deposit,withdraw,update_time,create_time,status) SELECT DISTINCT asset, asset, 8, 0, 0, 100, 100, now(), now(), 100 FROM exs_balance ON CONFLICT (asset) DO NOTHING;
 `
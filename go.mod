@@ -10,6 +10,7 @@ require (
 	github.com/codingeasygo/web v0.0.0-20221103094050-e9f39f8e9983
 	github.com/gomodule/redigo v1.8.9
 	github.com/shopspring/decimal v1.3.1
+	github.com/vmihailenco/msgpack/v5 v5.3.5
 	go.uber.org/zap v1.23.0
 	golang.org/x/net v0.1.0
 )
@@ -25,6 +26,7 @@ require (
 	github.com/jackc/pgtype v1.11.0 // indirect
 	github.com/jackc/pgx/v4 v4.16.1 // indirect
 	github.com/jackc/puddle v1.2.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
 	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97 // indirect
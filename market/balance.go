@@ -53,3 +53,23 @@ func CalcBalanceTotalValue(ctx context.Context, userID int64, area gexdb.Balance
 	}
 	return
 }
+
+//ListAssetPrice will return the latest price of asset by quote pair on spot, the price of quote is always 1
+func ListAssetPrice(assets ...string) (prices map[string]decimal.Decimal) {
+	symbols := []string{}
+	for _, asset := range assets {
+		if asset != Quote {
+			symbols = append(symbols, fmt.Sprintf("spot.%v%v", asset, Quote))
+		}
+	}
+	latest := Shared.ListLatestPrice(symbols...)
+	prices = map[string]decimal.Decimal{}
+	for _, asset := range assets {
+		if asset == Quote {
+			prices[asset] = decimal.NewFromInt(1)
+		} else {
+			prices[asset] = latest[fmt.Sprintf("spot.%v%v", asset, Quote)]
+		}
+	}
+	return
+}
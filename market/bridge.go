@@ -0,0 +1,255 @@
+package market
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/util/debug"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/matcher"
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	//BridgeModePublish is the matching node mode, the market data is published to bridge after processed
+	BridgeModePublish = "publish"
+	//BridgeModeEdge is the read-only node mode, the market data is received from bridge and only served to websocket
+	BridgeModeEdge = "edge"
+)
+
+const (
+	BridgeKLine  = "kline"
+	BridgeDepth  = "depth"
+	BridgeTrades = "trades"
+)
+
+//Bridge is the bridge used by bootstrap, the market is not bridged when it is nil
+var Bridge MarketBridge
+
+//BridgeMode is the bridge mode used by bootstrap, default is BridgeModePublish
+var BridgeMode = BridgeModePublish
+
+//MarketBridge is the pub/sub bridge to fan-out market data across service instance
+type MarketBridge interface {
+	//Publish will publish data to all subscriber
+	Publish(data []byte) (err error)
+	//Subscribe will receive data from bridge, it is blocked until bridge is closed
+	Subscribe(recv func(data []byte)) (err error)
+	//Close will close the bridge and stop subscribe
+	Close() (err error)
+}
+
+//BridgeMessage is the market data message which is transferred by bridge
+type BridgeMessage struct {
+	Type     string                  `json:"type"`
+	Symbol   string                  `json:"symbol"`
+	Sequence int64                   `json:"sequence,omitempty"`
+	KLines   []*gexdb.KLine          `json:"klines,omitempty"`
+	Depth    *DepthCache             `json:"depth,omitempty"`
+	Trades   []*matcher.MatcherTrade `json:"trades,omitempty"`
+}
+
+//RedisBridge is the market bridge by redis pub/sub
+type RedisBridge struct {
+	Channel    string
+	Redis      func() redis.Conn
+	RetryDelay time.Duration
+	pubsub     *redis.PubSubConn
+	closed     bool
+	lock       sync.RWMutex
+}
+
+//NewRedisBridge will return new redis bridge on channel
+func NewRedisBridge(channel string, conn func() redis.Conn) (bridge *RedisBridge) {
+	bridge = &RedisBridge{
+		Channel:    channel,
+		Redis:      conn,
+		RetryDelay: 3 * time.Second,
+		lock:       sync.RWMutex{},
+	}
+	return
+}
+
+//Publish will publish data to redis channel
+func (r *RedisBridge) Publish(data []byte) (err error) {
+	conn := r.Redis()
+	defer conn.Close()
+	_, err = conn.Do("PUBLISH", r.Channel, data)
+	return
+}
+
+//Subscribe will subscribe redis channel and call recv by message, it will resubscribe after RetryDelay when connection is broken
+func (r *RedisBridge) Subscribe(recv func(data []byte)) (err error) {
+	for {
+		r.lock.Lock()
+		if r.closed {
+			r.lock.Unlock()
+			break
+		}
+		pubsub := &redis.PubSubConn{Conn: r.Redis()}
+		r.pubsub = pubsub
+		r.lock.Unlock()
+		err = pubsub.Subscribe(r.Channel)
+		done := false
+		for err == nil && !done {
+			switch v := pubsub.Receive().(type) {
+			case redis.Message:
+				recv(v.Data)
+			case redis.Subscription:
+				done = v.Kind == "unsubscribe" && v.Count == 0
+			case error:
+				err = v
+			}
+		}
+		pubsub.Close()
+		r.lock.RLock()
+		closed := r.closed
+		r.lock.RUnlock()
+		if closed {
+			err = nil
+			break
+		}
+		xlog.Warnf("RedisBridge subscribe %v fail with %v, will retry after %v", r.Channel, err, r.RetryDelay)
+		time.Sleep(r.RetryDelay)
+	}
+	xlog.Infof("RedisBridge subscribe %v is stopped", r.Channel)
+	return
+}
+
+//Close will unsubscribe redis channel and stop subscribe
+func (r *RedisBridge) Close() (err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.closed = true
+	if r.pubsub != nil {
+		err = r.pubsub.Unsubscribe()
+	}
+	return
+}
+
+//publishBridge will add message to bridge queue, it is skipped when bridge is not enabled or on edge mode
+func (m *Market) publishBridge(message *BridgeMessage) {
+	if m.Bridge == nil || m.BridgeMode == BridgeModeEdge {
+		return
+	}
+	select {
+	case m.bridgeQueue <- message:
+	default:
+		xlog.Warnf("Market bridge queue is full, skip publish %v on %v", message.Type, message.Symbol)
+	}
+}
+
+func (m *Market) loopBridge() {
+	defer m.waiter.Done()
+	running := true
+	for running {
+		select {
+		case <-m.exiter:
+			running = false
+		case message := <-m.bridgeQueue:
+			data, _ := json.Marshal(message)
+			if err := m.Bridge.Publish(data); err != nil {
+				xlog.Warnf("Market publish %v on %v to bridge fail with %v", message.Type, message.Symbol, err)
+			}
+		}
+	}
+	xlog.Infof("Market bridge loop is stopped")
+}
+
+func (m *Market) loopBridgeSubscribe() {
+	defer m.waiter.Done()
+	err := m.Bridge.Subscribe(m.procBridge)
+	xlog.Infof("Market bridge subscribe is stopped by %v", err)
+}
+
+//procPublishSnapshot will publish current kline and depth of all symbol, it is called by kline gen ticker to keep edge node is synced
+func (m *Market) procPublishSnapshot() {
+	if m.Bridge == nil || m.BridgeMode == BridgeModeEdge {
+		return
+	}
+	for _, symbol := range m.Symbols {
+		lines := []*gexdb.KLine{}
+		m.klineLock.RLock()
+		for _, interval := range gexdb.KLineIntervAll {
+			if line := m.klineVal[klineKey(symbol, interval)]; line != nil {
+				copied := *line
+				lines = append(lines, &copied)
+			}
+		}
+		m.klineLock.RUnlock()
+		if len(lines) > 0 {
+			m.publishBridge(&BridgeMessage{Type: BridgeKLine, Symbol: symbol, KLines: lines})
+		}
+		m.depthLock.RLock()
+		depth := m.depthVal[symbol]
+		m.depthLock.RUnlock()
+		if depth != nil {
+			m.publishBridge(&BridgeMessage{Type: BridgeDepth, Symbol: symbol, Sequence: depth.Sequence, Depth: depth})
+		}
+	}
+}
+
+//procBridge will process the market data received from bridge on edge node
+func (m *Market) procBridge(data []byte) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			xlog.Errorf("Market proc bridge panic with %v, callstack is \n%v", perr, debug.CallStatck())
+		}
+	}()
+	message := &BridgeMessage{}
+	err := json.Unmarshal(data, message)
+	if err != nil {
+		xlog.Warnf("Market proc bridge fail with %v by %v", err, string(data))
+		return
+	}
+	switch message.Type {
+	case BridgeKLine:
+		m.procBridgeKLine(message)
+	case BridgeDepth:
+		m.procBridgeDepth(message)
+	case BridgeTrades:
+		event := &matcher.MatcherEvent{Symbol: message.Symbol, Sequence: message.Sequence, Trades: message.Trades}
+		if m.checkSequence(event, true) {
+			m.procTriggerTrade(event)
+		}
+	default:
+		err = fmt.Errorf("not supported type %v", message.Type)
+		xlog.Warnf("Market proc bridge fail with %v", err)
+	}
+}
+
+func (m *Market) procBridgeKLine(message *BridgeMessage) {
+	m.klineLock.Lock()
+	defer m.klineLock.Unlock()
+	for _, line := range message.KLines {
+		key := klineKey(line.Symbol, line.Interv)
+		having := m.klineVal[key]
+		if having != nil && having.StartTime.Timestamp() != line.StartTime.Timestamp() {
+			delete(m.klineCache, key)
+		}
+		m.klineVal[key] = line
+		if line.Close.Sign() > 0 {
+			m.avgPrice[line.Symbol] = line.Close
+		}
+	}
+}
+
+//procBridgeDepth will keep the depth with update id of matching node, so the snapshot and diff is continuous on all node
+func (m *Market) procBridgeDepth(message *BridgeMessage) {
+	depth := message.Depth
+	if depth == nil {
+		return
+	}
+	m.depthLock.Lock()
+	having := m.depthVal[depth.Symbol]
+	if having != nil && having.UpdateID == depth.UpdateID && having.Sequence == depth.Sequence {
+		m.depthLock.Unlock()
+		return
+	}
+	m.depthVal[depth.Symbol] = depth
+	m.depthLock.Unlock()
+	m.notifyDepth(depth.Symbol)
+}
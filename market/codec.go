@@ -0,0 +1,152 @@
+package market
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"time"
+
+	"github.com/codingeasygo/util/xsql"
+	"github.com/shopspring/decimal"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	//CodecJSON is the default codec, the message is sent by text frame when compress is not enabled
+	CodecJSON = "json"
+	//CodecMsgpack is the MessagePack codec, the message is sent by binary frame
+	CodecMsgpack = "msgpack"
+	//CompressDeflate is the per-message deflate compress
+	CompressDeflate = "deflate"
+)
+
+//frameFlagCompressed is the binary frame header flag, the binary frame is [flag byte][payload]
+const frameFlagCompressed byte = 1
+
+//CompressMin is the min payload size to compress when compress is enabled, the smaller message is sent without compress
+var CompressMin = 256
+
+//MsgpackExtDecimal is the msgpack extension type of decimal, the extension data is decimal string like "100.5"
+const MsgpackExtDecimal int8 = 1
+
+func init() {
+	msgpack.RegisterExtEncoder(MsgpackExtDecimal, decimal.Decimal{}, func(enc *msgpack.Encoder, v reflect.Value) ([]byte, error) {
+		return []byte(v.Interface().(decimal.Decimal).String()), nil
+	})
+	msgpack.RegisterExtDecoder(MsgpackExtDecimal, decimal.Decimal{}, func(dec *msgpack.Decoder, v reflect.Value, extLen int) (err error) {
+		data := make([]byte, extLen)
+		err = dec.ReadFull(data)
+		if err != nil {
+			return
+		}
+		value, err := decimal.NewFromString(string(data))
+		if err == nil {
+			v.Set(reflect.ValueOf(value))
+		}
+		return
+	})
+	//the time is encoded to millisecond timestamp like json
+	msgpack.Register(
+		xsql.Time{},
+		func(enc *msgpack.Encoder, v reflect.Value) error {
+			return enc.EncodeInt(v.Interface().(xsql.Time).Timestamp())
+		},
+		func(dec *msgpack.Decoder, v reflect.Value) (err error) {
+			timestamp, err := dec.DecodeInt64()
+			if err == nil {
+				v.Set(reflect.ValueOf(xsql.Time(time.Unix(0, timestamp*1e6))))
+			}
+			return
+		},
+	)
+}
+
+//MarshalCodec will encode value by codec, the msgpack struct is encoded by json tag, the decimal is MsgpackExtDecimal extension and time is timestamp
+func MarshalCodec(codec string, v interface{}) (data []byte, err error) {
+	if codec != CodecMsgpack {
+		data, err = json.Marshal(v)
+		return
+	}
+	buffer := bytes.NewBuffer(nil)
+	encoder := msgpack.NewEncoder(buffer)
+	encoder.SetCustomStructTag("json")
+	encoder.UseCompactInts(true)
+	err = encoder.Encode(v)
+	data = buffer.Bytes()
+	return
+}
+
+//UnmarshalCodec will decode data to value by codec, the msgpack struct is decoded by json tag
+func UnmarshalCodec(codec string, data []byte, v interface{}) (err error) {
+	if codec != CodecMsgpack {
+		err = json.Unmarshal(data, v)
+		return
+	}
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+	err = decoder.Decode(v)
+	return
+}
+
+//PackFrame will pack payload to binary frame, the payload is compressed when compress is true and payload is not less than CompressMin
+func PackFrame(payload []byte, compress bool) (data []byte, err error) {
+	if !compress || len(payload) < CompressMin {
+		data = append([]byte{0}, payload...)
+		return
+	}
+	buffer := bytes.NewBuffer([]byte{frameFlagCompressed})
+	writer, _ := flate.NewWriter(buffer, flate.BestSpeed)
+	_, err = writer.Write(payload)
+	if err == nil {
+		err = writer.Close()
+	}
+	data = buffer.Bytes()
+	return
+}
+
+//UnpackFrame will unpack binary frame to payload
+func UnpackFrame(data []byte) (payload []byte, err error) {
+	if len(data) < 1 {
+		err = fmt.Errorf("%v", "empty frame")
+		return
+	}
+	if data[0]&frameFlagCompressed != frameFlagCompressed {
+		payload = data[1:]
+		return
+	}
+	reader := flate.NewReader(bytes.NewReader(data[1:]))
+	defer reader.Close()
+	payload, err = ioutil.ReadAll(reader)
+	return
+}
+
+//SetCodec will set the codec and compress of connection, empty codec is json and empty compress is not compress
+func (m *MarketConn) SetCodec(codec, compress string) (err error) {
+	if len(codec) < 1 {
+		codec = CodecJSON
+	}
+	if codec != CodecJSON && codec != CodecMsgpack {
+		err = fmt.Errorf("codec %v is not supported", codec)
+		return
+	}
+	if len(compress) > 0 && compress != CompressDeflate {
+		err = fmt.Errorf("compress %v is not supported", compress)
+		return
+	}
+	m.codecLock.Lock()
+	m.codecName = codec
+	m.compress = compress == CompressDeflate
+	m.codecLock.Unlock()
+	return
+}
+
+//LoadCodec will return the codec and compress of connection
+func (m *MarketConn) LoadCodec() (codec string, compress bool) {
+	m.codecLock.RLock()
+	codec, compress = m.codecName, m.compress
+	m.codecLock.RUnlock()
+	return
+}
@@ -5,12 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/codingeasygo/util/debug"
 	"github.com/codingeasygo/util/xmap"
 	"github.com/codingeasygo/util/xsql"
-	"github.com/codingeasygo/util/xtime"
 	"github.com/codingeasygo/web"
 	"github.com/gexservice/gexservice/base/define"
 	"github.com/gexservice/gexservice/base/xlog"
@@ -21,12 +21,28 @@ import (
 )
 
 var Shared *Market
+var Outbox *matcher.MatcherOutbox
 var Quote string = "USDT"
 
+//Location is the timezone of kline bar boundary, it is used by new market
+var Location = time.Local
+
 func Bootstrap() {
 	Shared = NewMarket(matcher.Shared.Symbols...)
-	matcher.Shared.AddMonitor("*", Shared)
+	Shared.Bridge, Shared.BridgeMode = Bridge, BridgeMode
+	if Bridge != nil && BridgeMode == BridgeModeEdge {
+		xlog.Infof("Market is running on edge mode, the market data is only received from bridge")
+	} else if matcher.Shared.OutboxOn {
+		Shared.loadOutboxSequence()
+		Outbox = matcher.NewMatcherOutbox("market", matcher.MatcherMonitorF(Shared.ProcMatched), matcher.Shared.Symbols...)
+		matcher.Shared.AddMonitor("*", Outbox)
+	} else {
+		matcher.Shared.AddMonitor("*", Shared)
+	}
 	Shared.Start()
+	if Outbox != nil {
+		Outbox.Start()
+	}
 }
 
 func LoadKLine(symbol, interval string) (line *gexdb.KLine) {
@@ -54,28 +70,55 @@ func LoadDepth(symbol string, max int) (depth *DepthCache) {
 	return
 }
 
+func RebuildKLine(ctx context.Context, symbol, interval string, startTime, endTime time.Time) (lines []*gexdb.KLine, err error) {
+	lines, err = Shared.RebuildKLine(ctx, symbol, interval, startTime, endTime)
+	return
+}
+
+func ListTicker(symbols ...string) (tickers []*Ticker) {
+	tickers = Shared.ListTicker(symbols...)
+	return
+}
+
+func ListTrades(ctx context.Context, symbol string, limit int) (trades []*matcher.MatcherTrade, err error) {
+	trades, err = Shared.ListTrades(ctx, symbol, limit)
+	return
+}
+
 func klineKey(symbol, interv string) string {
 	return fmt.Sprintf("%v-%v", symbol, interv)
 }
 
 type MarketConn struct {
-	Conn    *websocket.Conn
-	Codec   *websocket.Codec
-	KLines  map[string]int
-	Depths  map[string]int
-	Tickers map[string]int
-	Timeout time.Duration
-	Ready   bool
-	Latest  time.Time
+	Conn       *websocket.Conn
+	Codec      *websocket.Codec
+	KLines     map[string]int
+	Depths     map[string]int
+	DepthDiffs map[string]*DepthCache
+	Tickers    map[string]int
+	Trades     map[string]int
+	UserID     int64
+	UserOn     bool
+	Timeout    time.Duration
+	Ready      bool
+	latest     int64      //the latest receive time in unix nano, it is written by reader and read by keepalive, so it is accessed by atomic
+	subLock    sync.Mutex //the subscription map is replaced by copy under lock, so it is safe to read after load
+	keepalive  bool
+	codecName  string
+	compress   bool
+	codecLock  sync.RWMutex
 }
 
 func NewMarketConn(conn *websocket.Conn) (mc *MarketConn) {
 	mc = &MarketConn{
-		Conn:    conn,
-		Timeout: 3 * time.Second,
-		Latest:  time.Now(),
-		KLines:  map[string]int{},
-		Depths:  map[string]int{},
+		Conn:       conn,
+		Timeout:    3 * time.Second,
+		latest:     time.Now().UnixNano(),
+		KLines:     map[string]int{},
+		Depths:     map[string]int{},
+		DepthDiffs: map[string]*DepthCache{},
+		Trades:     map[string]int{},
+		codecName:  CodecJSON,
 	}
 	mc.Codec = &websocket.Codec{
 		Marshal:   mc.codecMarshal,
@@ -97,17 +140,37 @@ func (m *MarketConn) codecMarshal(v interface{}) (data []byte, payloadType byte,
 			payloadType = websocket.TextFrame
 			data = []byte(s)
 		}
-	} else {
+		return
+	}
+	codec, compress := m.LoadCodec()
+	if codec == CodecJSON && !compress {
 		payloadType = websocket.TextFrame
 		data, err = json.Marshal(v)
+		return
+	}
+	payloadType = websocket.BinaryFrame
+	data, err = MarshalCodec(codec, v)
+	if err == nil {
+		data, err = PackFrame(data, compress)
 	}
 	return
 }
 
+//LoadLatest will return the latest receive time
+func (m *MarketConn) LoadLatest() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&m.latest))
+}
+
 func (m *MarketConn) codecUnmarshal(data []byte, payloadType byte, v interface{}) (err error) {
-	m.Latest = time.Now()
+	atomic.StoreInt64(&m.latest, time.Now().UnixNano())
 	switch payloadType {
 	case websocket.PingFrame, websocket.PongFrame:
+	case websocket.BinaryFrame:
+		codec, _ := m.LoadCodec()
+		data, err = UnpackFrame(data)
+		if err == nil {
+			err = UnmarshalCodec(codec, data, v)
+		}
 	default:
 		err = json.Unmarshal(data, v)
 	}
@@ -153,18 +216,22 @@ type KLineCache struct {
 }
 
 type DepthCache struct {
-	Bids   [][]decimal.Decimal `json:"bids"`
-	Asks   [][]decimal.Decimal `json:"asks"`
-	Symbol string              `json:"symbol"`
-	Time   xsql.Time           `json:"time"`
+	Bids     [][]decimal.Decimal `json:"bids"`
+	Asks     [][]decimal.Decimal `json:"asks"`
+	Symbol   string              `json:"symbol"`
+	Sequence int64               `json:"sequence"`
+	UpdateID int64               `json:"update_id"`
+	Time     xsql.Time           `json:"time"`
 }
 
 func (d *DepthCache) Slice(max int) (depth *DepthCache) {
 	depth = &DepthCache{
-		Asks:   d.Asks,
-		Bids:   d.Bids,
-		Symbol: d.Symbol,
-		Time:   xsql.TimeNow(),
+		Asks:     d.Asks,
+		Bids:     d.Bids,
+		Symbol:   d.Symbol,
+		Sequence: d.Sequence,
+		UpdateID: d.UpdateID,
+		Time:     xsql.TimeNow(),
 	}
 	if len(depth.Asks) > max {
 		depth.Asks = depth.Asks[0:max]
@@ -175,6 +242,63 @@ func (d *DepthCache) Slice(max int) (depth *DepthCache) {
 	return
 }
 
+//DepthDiff is the changed price level between two depth, the quantity is zero when level is removed from book,
+//the level which is only moved out of depth window is not included, client should keep the best window levels after apply
+type DepthDiff struct {
+	Bids          [][]decimal.Decimal `json:"bids"`
+	Asks          [][]decimal.Decimal `json:"asks"`
+	Symbol        string              `json:"symbol"`
+	Sequence      int64               `json:"sequence"`
+	FirstUpdateID int64               `json:"first_update_id"`
+	LastUpdateID  int64               `json:"last_update_id"`
+	Time          xsql.Time           `json:"time"`
+}
+
+//diffDepthLevel will return changed level from prev to next, the prev level which is worse than last next level is moved out of window when next is full
+func diffDepthLevel(prev, next [][]decimal.Decimal, window int, bid bool) (levels [][]decimal.Decimal) {
+	having := map[string]decimal.Decimal{}
+	for _, level := range prev {
+		having[level[0].String()] = level[1]
+	}
+	for _, level := range next {
+		key := level[0].String()
+		quantity, ok := having[key]
+		delete(having, key)
+		if ok && quantity.Equal(level[1]) {
+			continue
+		}
+		levels = append(levels, level)
+	}
+	full := window > 0 && len(next) >= window
+	for _, level := range prev {
+		if _, ok := having[level[0].String()]; !ok {
+			continue
+		}
+		if full {
+			last := next[len(next)-1][0]
+			if (bid && level[0].LessThan(last)) || (!bid && level[0].GreaterThan(last)) {
+				continue
+			}
+		}
+		levels = append(levels, []decimal.Decimal{level[0], decimal.Zero})
+	}
+	return
+}
+
+//NewDepthDiff will return the changed price level from prev to next depth by window, the update id is from prev.UpdateID+1 to next.UpdateID
+func NewDepthDiff(prev, next *DepthCache, window int) (diff *DepthDiff) {
+	diff = &DepthDiff{
+		Bids:          diffDepthLevel(prev.Bids, next.Bids, window, true),
+		Asks:          diffDepthLevel(prev.Asks, next.Asks, window, false),
+		Symbol:        next.Symbol,
+		Sequence:      next.Sequence,
+		FirstUpdateID: prev.UpdateID + 1,
+		LastUpdateID:  next.UpdateID,
+		Time:          xsql.TimeNow(),
+	}
+	return
+}
+
 type klineQueueItem struct {
 	Conn *MarketConn
 }
@@ -182,24 +306,60 @@ type depthQueueItem struct {
 	Conn   *MarketConn
 	Symbol string
 }
+type tradeQueueItem struct {
+	Conn   *MarketConn
+	Symbol string
+	Trades []*matcher.MatcherTrade
+}
+type userQueueItem struct {
+	Conn     *MarketConn
+	Symbol   string
+	Sequence int64
+	Orders   []*gexdb.Order
+	Balances []*gexdb.Balance
+	Holdings []*gexdb.Holding
+}
 
 type Market struct {
 	Symbols          []string
 	WaitTimeout      time.Duration
 	KLineGenDelay    time.Duration
 	KLineNotifyDelay time.Duration
+	Location         *time.Location
 	NotiryRunner     int
+	TradeMax         int
+	TickerWindow     time.Duration
+	TickerBucket     time.Duration
+	RebuildWindow    time.Duration
+	SubMax           int
+	DepthWindow      int
+	PingDelay        time.Duration
+	IdleTimeout      time.Duration
+	Bridge           MarketBridge
+	BridgeMode       string
 	OnConnect        func(conn *websocket.Conn)
 	OnDisconnect     func(conn *websocket.Conn)
 	eventQueue       chan *matcher.MatcherEvent
 	avgPrice         map[string]decimal.Decimal
 	klineVal         map[string]*gexdb.KLine
 	klineCache       map[string]*KLineCache
+	klineSeed        map[string]time.Time
 	klineQueue       chan *klineQueueItem
 	klineLock        sync.RWMutex
 	depthVal         map[string]*DepthCache
 	depthQueue       chan *depthQueueItem
 	depthLock        sync.RWMutex
+	tradeVal         map[string][]*matcher.MatcherTrade
+	tradeQueue       chan *tradeQueueItem
+	tradeLock        sync.RWMutex
+	tickerVal        map[string][]*tickerBucket
+	tickerSequence   map[string]int64
+	tickerLock       sync.RWMutex
+	userQueue        chan *userQueueItem
+	bridgeQueue      chan *BridgeMessage
+	sequenceVal      map[string]int64
+	sequenceGap      map[string]int64
+	sequenceLock     sync.RWMutex
 	wsconn           map[string]*MarketConn
 	wslock           sync.RWMutex
 	exiter           chan int
@@ -212,16 +372,38 @@ func NewMarket(symbols ...string) (market *Market) {
 		WaitTimeout:      3 * time.Second,
 		KLineGenDelay:    time.Second,
 		KLineNotifyDelay: time.Second,
+		Location:         Location,
 		NotiryRunner:     3,
+		TradeMax:         100,
+		TickerWindow:     24 * time.Hour,
+		TickerBucket:     5 * time.Minute,
+		RebuildWindow:    24 * time.Hour,
+		SubMax:           100,
+		DepthWindow:      30,
+		PingDelay:        20 * time.Second,
+		IdleTimeout:      60 * time.Second,
+		BridgeMode:       BridgeModePublish,
 		eventQueue:       make(chan *matcher.MatcherEvent, 1024),
 		avgPrice:         map[string]decimal.Decimal{},
 		klineVal:         map[string]*gexdb.KLine{},
 		klineCache:       map[string]*KLineCache{},
+		klineSeed:        map[string]time.Time{},
 		klineQueue:       make(chan *klineQueueItem, 1024),
 		klineLock:        sync.RWMutex{},
 		depthVal:         map[string]*DepthCache{},
 		depthQueue:       make(chan *depthQueueItem, 1024),
 		depthLock:        sync.RWMutex{},
+		tradeVal:         map[string][]*matcher.MatcherTrade{},
+		tradeQueue:       make(chan *tradeQueueItem, 1024),
+		tradeLock:        sync.RWMutex{},
+		tickerVal:        map[string][]*tickerBucket{},
+		tickerSequence:   map[string]int64{},
+		tickerLock:       sync.RWMutex{},
+		userQueue:        make(chan *userQueueItem, 1024),
+		bridgeQueue:      make(chan *BridgeMessage, 1024),
+		sequenceVal:      map[string]int64{},
+		sequenceGap:      map[string]int64{},
+		sequenceLock:     sync.RWMutex{},
 		wsconn:           map[string]*MarketConn{},
 		wslock:           sync.RWMutex{},
 		exiter:           make(chan int, 1024),
@@ -231,22 +413,41 @@ func NewMarket(symbols ...string) (market *Market) {
 }
 
 func (m *Market) Start() {
+	if m.Bridge == nil || m.BridgeMode != BridgeModeEdge {
+		m.procRebuildKLine()
+	}
+	m.loadTicker()
 	m.waiter.Add(1)
 	go m.loopEvent()
 	m.waiter.Add(1)
 	go m.loopTriggerKLine()
+	m.waiter.Add(1)
+	go m.loopKeepalive()
 	for i := 0; i < m.NotiryRunner; i++ {
 		m.waiter.Add(1)
 		go m.loopNotify()
 	}
+	if m.Bridge != nil {
+		m.waiter.Add(1)
+		go m.loopBridge()
+	}
+	if m.Bridge != nil && m.BridgeMode == BridgeModeEdge {
+		m.waiter.Add(1)
+		go m.loopBridgeSubscribe()
+	}
 }
 
 func (m *Market) Stop() {
+	m.exiter <- 0
 	m.exiter <- 0
 	m.exiter <- 0
 	for i := 0; i < m.NotiryRunner; i++ {
 		m.exiter <- 0
 	}
+	if m.Bridge != nil {
+		m.exiter <- 0
+		m.Bridge.Close()
+	}
 	m.waiter.Wait()
 }
 
@@ -257,6 +458,69 @@ func (m *Market) OnMatched(ctx context.Context, event *matcher.MatcherEvent) {
 	}
 }
 
+//ProcMatched will process matcher event directly, it is used by outbox consumer which ack event after processed.
+//the outbox delivery is at-least-once, so the applied sequence is skipped, only the depth snapshot is taken when not cached
+func (m *Market) ProcMatched(ctx context.Context, event *matcher.MatcherEvent) {
+	if !m.checkSequence(event, false) {
+		if event.Depth != nil && m.LoadDepth(event.Symbol, 1) == nil {
+			m.procTriggerDepth(event)
+		}
+		return
+	}
+	m.procGenKLine(event)
+	m.procTriggerDepth(event)
+	m.procTriggerTrade(event)
+	m.procTriggerUser(event)
+}
+
+//checkSequence will check event sequence by symbol, it return false when event is already processed, and record gap when some event is lost.
+//the sequence 1 is accepted as matcher restarted when restartable, it is only for memory sequence when outbox is off
+func (m *Market) checkSequence(event *matcher.MatcherEvent, restartable bool) (fresh bool) {
+	if event.Sequence < 1 {
+		return true
+	}
+	m.sequenceLock.Lock()
+	defer m.sequenceLock.Unlock()
+	last := m.sequenceVal[event.Symbol]
+	if last > 0 && event.Sequence <= last && (!restartable || event.Sequence != 1) {
+		xlog.Debugf("Market skip processed event %v/%v, last is %v", event.Symbol, event.Sequence, last)
+		return false
+	}
+	if last > 0 && event.Sequence > last+1 {
+		m.sequenceGap[event.Symbol] += event.Sequence - last - 1
+		xlog.Warnf("Market found event gap on %v from %v to %v", event.Symbol, last, event.Sequence)
+	}
+	m.sequenceVal[event.Symbol] = event.Sequence
+	return true
+}
+
+//loadOutboxSequence will seed the processed sequence by latest outbox sequence, it is called before start,
+//so the event stored before is skipped on outbox catch up because it is already applied by kline/ticker seeded from db
+func (m *Market) loadOutboxSequence() (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.WaitTimeout)
+	defer cancel()
+	for _, symbol := range m.Symbols {
+		var sequence int64
+		sequence, err = gexdb.LoadOutboxSequence(ctx, symbol)
+		if err != nil {
+			xlog.Warnf("Market load outbox sequence by %v fail with %v", symbol, err)
+			break
+		}
+		m.sequenceLock.Lock()
+		m.sequenceVal[symbol] = sequence
+		m.sequenceLock.Unlock()
+	}
+	return
+}
+
+//LoadSequence will return the last processed event sequence and lost event count by symbol
+func (m *Market) LoadSequence(symbol string) (sequence, gap int64) {
+	m.sequenceLock.RLock()
+	defer m.sequenceLock.RUnlock()
+	sequence, gap = m.sequenceVal[symbol], m.sequenceGap[symbol]
+	return
+}
+
 func (m *Market) loopEvent() {
 	defer m.waiter.Done()
 	ticker := time.NewTicker(m.KLineGenDelay)
@@ -267,10 +531,17 @@ func (m *Market) loopEvent() {
 		case <-m.exiter:
 			running = false
 		case event := <-m.eventQueue:
-			m.procGenKLine(event)
-			m.procTriggerDepth(event)
+			if m.checkSequence(event, true) {
+				m.procGenKLine(event)
+				m.procTriggerDepth(event)
+				m.procTriggerTrade(event)
+				m.procTriggerUser(event)
+			}
 		case <-ticker.C:
-			m.procGenKLine(nil)
+			if m.Bridge == nil || m.BridgeMode != BridgeModeEdge {
+				m.procGenKLine(nil)
+				m.procPublishSnapshot()
+			}
 		}
 	}
 	xlog.Infof("Market event loop is stopped")
@@ -278,27 +549,10 @@ func (m *Market) loopEvent() {
 
 func (m *Market) listCurrentKLine(symbol string) (lines []*gexdb.KLine) {
 	now := time.Now()
-
-	startMin5 := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), (now.Minute()/5)*5, 0, 0, now.Location())
-	lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startMin5), Interv: "5min", UpdateTime: xsql.TimeNow()})
-
-	startMin30 := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), (now.Minute()/30)*30, 0, 0, now.Location())
-	lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startMin30), Interv: "30min", UpdateTime: xsql.TimeNow()})
-
-	startHour1 := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
-	lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startHour1), Interv: "1hour", UpdateTime: xsql.TimeNow()})
-
-	startHour4 := time.Date(now.Year(), now.Month(), now.Day(), (now.Hour()/4)*4, 0, 0, 0, now.Location())
-	lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startHour4), Interv: "4hour", UpdateTime: xsql.TimeNow()})
-
-	startDay1 := xtime.TimeStartOfToday()
-	lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startDay1), Interv: "1day", UpdateTime: xsql.TimeNow()})
-
-	startWeek := xtime.TimeStartOfWeek()
-	lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startWeek), Interv: "1week", UpdateTime: xsql.TimeNow()})
-
-	startMonth := xtime.TimeStartOfMonth()
-	lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startMonth), Interv: "1mon", UpdateTime: xsql.TimeNow()})
+	for _, interval := range gexdb.KLineIntervAll {
+		startTime, _ := gexdb.KLineStartTime(interval, now, m.Location)
+		lines = append(lines, &gexdb.KLine{Symbol: symbol, StartTime: xsql.Time(startTime), Interv: interval, UpdateTime: xsql.TimeNow()})
+	}
 	return
 }
 
@@ -317,8 +571,17 @@ func (m *Market) procGenKLine(event *matcher.MatcherEvent) (err error) {
 		}
 		order := event.Orders[0]
 		avgPrice := order.AvgPrice
+		tradeTime := order.UpdateTime
+		if len(event.Trades) > 0 {
+			tradeTime = event.Trades[0].Time
+		}
 
 		m.klineLock.Lock()
+		if seed, ok := m.klineSeed[event.Symbol]; ok && !time.Time(tradeTime).IsZero() && time.Time(tradeTime).Before(seed) {
+			m.klineLock.Unlock()
+			xlog.Debugf("Market skip kline by event %v/%v which is traded before rebuild", event.Symbol, event.Sequence)
+			return
+		}
 		m.avgPrice[event.Symbol] = avgPrice
 		for _, line := range m.listCurrentKLine(event.Symbol) {
 			key := klineKey(line.Symbol, line.Interv)
@@ -396,13 +659,28 @@ func (m *Market) procTriggerDepth(event *matcher.MatcherEvent) (err error) {
 	}()
 	m.depthLock.Lock()
 	depth := &DepthCache{
-		Symbol: event.Symbol,
-		Asks:   event.Depth.Asks,
-		Bids:   event.Depth.Bids,
-		Time:   xsql.TimeNow(),
+		Symbol:   event.Symbol,
+		Asks:     event.Depth.Asks,
+		Bids:     event.Depth.Bids,
+		Sequence: event.Sequence,
+		UpdateID: 1,
+		Time:     xsql.TimeNow(),
+	}
+	if having := m.depthVal[depth.Symbol]; having != nil {
+		depth.UpdateID = having.UpdateID + 1
+	}
+	if depth.UpdateID < event.Sequence { //seed from matcher sequence, so update id is not reset after restart
+		depth.UpdateID = event.Sequence
 	}
 	m.depthVal[depth.Symbol] = depth
 	m.depthLock.Unlock()
+	m.publishBridge(&BridgeMessage{Type: BridgeDepth, Symbol: depth.Symbol, Sequence: depth.Sequence, Depth: depth})
+	m.notifyDepth(depth.Symbol)
+	return
+}
+
+//notifyDepth will push depth/ticker to connection which subscribe depth/depth diff/ticker of symbol
+func (m *Market) notifyDepth(symbol string) {
 	conns := []*MarketConn{}
 	m.wslock.RLock()
 	for _, conn := range m.wsconn {
@@ -410,12 +688,110 @@ func (m *Market) procTriggerDepth(event *matcher.MatcherEvent) (err error) {
 	}
 	m.wslock.RUnlock()
 	for _, conn := range conns {
-		if conn.Depths[depth.Symbol] < 1 && conn.Tickers[depth.Symbol] < 1 {
+		conn.subLock.Lock()
+		diff := conn.DepthDiffs[symbol]
+		depths, tickers := conn.Depths, conn.Tickers
+		conn.subLock.Unlock()
+		if depths[symbol] < 1 && tickers[symbol] < 1 && diff == nil {
+			continue
+		}
+		select {
+		case m.depthQueue <- &depthQueueItem{Conn: conn, Symbol: symbol}:
+		default:
+		}
+	}
+}
+
+//procTriggerTrade will keep latest trade in memory and push trade to connection which subscribe trades
+func (m *Market) procTriggerTrade(event *matcher.MatcherEvent) (err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			xlog.Errorf("Market proc trigger trade panic with %v, callstack is \n%v", perr, debug.CallStatck())
+			err = fmt.Errorf("%v", perr)
+		}
+	}()
+	if len(event.Trades) < 1 {
+		return
+	}
+	for _, trade := range event.Trades {
+		trade.Sequence = event.Sequence
+	}
+	m.procGenTicker(event)
+	m.tradeLock.Lock()
+	trades := append(m.tradeVal[event.Symbol], event.Trades...)
+	if len(trades) > m.TradeMax {
+		trades = append([]*matcher.MatcherTrade{}, trades[len(trades)-m.TradeMax:]...)
+	}
+	m.tradeVal[event.Symbol] = trades
+	m.tradeLock.Unlock()
+	m.publishBridge(&BridgeMessage{Type: BridgeTrades, Symbol: event.Symbol, Sequence: event.Sequence, Trades: event.Trades})
+	conns := []*MarketConn{}
+	m.wslock.RLock()
+	for _, conn := range m.wsconn {
+		if _, _, _, trades, _ := conn.loadSub(); trades[event.Symbol] > 0 {
+			conns = append(conns, conn)
+		}
+	}
+	m.wslock.RUnlock()
+	for _, conn := range conns {
+		select {
+		case m.tradeQueue <- &tradeQueueItem{Conn: conn, Symbol: event.Symbol, Trades: event.Trades}:
+		default:
+			xlog.Warnf("Market trade queue is full, skip notify trades on %v", event.Symbol)
+		}
+	}
+	return
+}
+
+//procTriggerUser will group event orders/balances/holdings by user and push to connection which subscribe user data
+func (m *Market) procTriggerUser(event *matcher.MatcherEvent) (err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			xlog.Errorf("Market proc trigger user panic with %v, callstack is \n%v", perr, debug.CallStatck())
+			err = fmt.Errorf("%v", perr)
+		}
+	}()
+	conns := []*MarketConn{}
+	m.wslock.RLock()
+	for _, conn := range m.wsconn {
+		if _, _, _, _, userOn := conn.loadSub(); userOn && conn.UserID > 0 {
+			conns = append(conns, conn)
+		}
+	}
+	m.wslock.RUnlock()
+	if len(conns) < 1 {
+		return
+	}
+	items := map[int64]*userQueueItem{}
+	findItem := func(userID int64) *userQueueItem {
+		item := items[userID]
+		if item == nil {
+			item = &userQueueItem{Symbol: event.Symbol, Sequence: event.Sequence}
+			items[userID] = item
+		}
+		return item
+	}
+	for _, order := range event.Orders {
+		item := findItem(order.UserID)
+		item.Orders = append(item.Orders, order)
+	}
+	for _, balance := range event.Balances {
+		item := findItem(balance.UserID)
+		item.Balances = append(item.Balances, balance)
+	}
+	for _, holding := range event.Holdings {
+		item := findItem(holding.UserID)
+		item.Holdings = append(item.Holdings, holding)
+	}
+	for _, conn := range conns {
+		item := items[conn.UserID]
+		if item == nil {
 			continue
 		}
 		select {
-		case m.depthQueue <- &depthQueueItem{Conn: conn, Symbol: depth.Symbol}:
+		case m.userQueue <- &userQueueItem{Conn: conn, Symbol: item.Symbol, Sequence: item.Sequence, Orders: item.Orders, Balances: item.Balances, Holdings: item.Holdings}:
 		default:
+			xlog.Warnf("Market user queue is full, skip notify user %v on %v", conn.UserID, event.Symbol)
 		}
 	}
 	return
@@ -460,12 +836,13 @@ func (m *Market) procTriggerKLine() (err error) {
 	m.wslock.RUnlock()
 
 	for _, conn := range conns {
-		if !conn.Ready && time.Since(conn.Latest) > m.WaitTimeout {
+		if !conn.Ready && time.Since(conn.LoadLatest()) > m.WaitTimeout {
 			conn.Close()
 			continue
 		}
 		having := false
-		for key := range conn.KLines {
+		klines, _, _, _, _ := conn.loadSub()
+		for key := range klines {
 			line := lines[key]
 			if line != nil {
 				having = true
@@ -490,7 +867,12 @@ func (m *Market) loopNotify() {
 			m.procNotifyKLine(item.Conn)
 		case item := <-m.depthQueue:
 			m.procNotifyDepth(item.Conn, item.Symbol)
+			m.procNotifyDepthDiff(item.Conn, item.Symbol)
 			m.procNotifyTicker(item.Conn, item.Symbol)
+		case item := <-m.tradeQueue:
+			m.procNotifyTrade(item)
+		case item := <-m.userQueue:
+			m.procNotifyUser(item)
 		}
 	}
 }
@@ -502,8 +884,9 @@ func (m *Market) procNotifyKLine(conn *MarketConn) (err error) {
 		}
 	}()
 	lines := map[string]*gexdb.KLine{}
+	klines, _, _, _, _ := conn.loadSub()
 	m.klineLock.RLock()
-	for key := range conn.KLines {
+	for key := range klines {
 		line := m.klineVal[key]
 		if line != nil {
 			lines[key] = line
@@ -530,7 +913,8 @@ func (m *Market) procNotifyDepth(conn *MarketConn, symbol string) (err error) {
 			xlog.Errorf("Market proc notify depth panic with %v, callstack is \n%v", perr, debug.CallStatck())
 		}
 	}()
-	max := conn.Depths[symbol]
+	_, depths, _, _, _ := conn.loadSub()
+	max := depths[symbol]
 	if max < 1 {
 		return
 	}
@@ -551,13 +935,55 @@ func (m *Market) procNotifyDepth(conn *MarketConn, symbol string) (err error) {
 	return
 }
 
+//procNotifyDepthDiff will send the changed price level from last sent depth to connection, the last sent depth is kept only when send success,
+//the send is not in connection lock, and the last sent depth is not kept when it is changed by subscribe on sending
+func (m *Market) procNotifyDepthDiff(conn *MarketConn, symbol string) (err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			xlog.Errorf("Market proc notify depth diff panic with %v, callstack is \n%v", perr, debug.CallStatck())
+		}
+	}()
+	conn.subLock.Lock()
+	prev := conn.DepthDiffs[symbol]
+	conn.subLock.Unlock()
+	if prev == nil {
+		return
+	}
+	m.depthLock.RLock()
+	depth := m.depthVal[symbol]
+	m.depthLock.RUnlock()
+	if depth == nil || depth.UpdateID <= prev.UpdateID {
+		return
+	}
+	diff := NewDepthDiff(prev, depth, m.DepthWindow)
+	if len(diff.Bids) < 1 && len(diff.Asks) < 1 {
+		return
+	}
+	err = conn.Send(xmap.M{
+		"action": "notify.depth_diff",
+		"depth":  diff,
+		"code":   define.Success,
+	})
+	if err != nil {
+		conn.Close()
+		return
+	}
+	conn.subLock.Lock()
+	if conn.DepthDiffs[symbol] == prev {
+		conn.DepthDiffs[symbol] = depth
+	}
+	conn.subLock.Unlock()
+	return
+}
+
 func (m *Market) procNotifyTicker(conn *MarketConn, symbol string) (err error) {
 	defer func() {
 		if perr := recover(); perr != nil {
 			xlog.Errorf("Market proc notify ticker panic with %v, callstack is \n%v", perr, debug.CallStatck())
 		}
 	}()
-	on := conn.Tickers[symbol]
+	_, _, tickers, _, _ := conn.loadSub()
+	on := tickers[symbol]
 	if on < 1 {
 		return
 	}
@@ -567,13 +993,74 @@ func (m *Market) procNotifyTicker(conn *MarketConn, symbol string) (err error) {
 	if depth == nil || len(depth.Asks) < 1 || len(depth.Bids) < 1 {
 		return
 	}
+	ticker := m.LoadTicker(symbol)
 	err = conn.Send(xmap.M{
 		"action": "notify.ticker",
 		"ticker": xmap.M{
-			"symbol": symbol,
-			"ask":    depth.Asks[0],
-			"bid":    depth.Bids[0],
-			"close":  m.LoadLatestPrice(symbol),
+			"symbol":         symbol,
+			"ask":            depth.Asks[0],
+			"bid":            depth.Bids[0],
+			"close":          m.LoadLatestPrice(symbol),
+			"sequence":       depth.Sequence,
+			"open":           ticker.Open,
+			"high":           ticker.High,
+			"low":            ticker.Low,
+			"last":           ticker.Last,
+			"volume":         ticker.Volume,
+			"quote_volume":   ticker.QuoteVolume,
+			"change":         ticker.Change,
+			"change_percent": ticker.ChangePercent,
+			"count":          ticker.Count,
+		},
+		"code": define.Success,
+	})
+	if err != nil {
+		conn.Close()
+	}
+	return
+}
+
+func (m *Market) procNotifyTrade(item *tradeQueueItem) (err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			xlog.Errorf("Market proc notify trade panic with %v, callstack is \n%v", perr, debug.CallStatck())
+		}
+	}()
+	conn := item.Conn
+	_, _, _, trades, _ := conn.loadSub()
+	if trades[item.Symbol] < 1 {
+		return
+	}
+	err = conn.Send(xmap.M{
+		"action": "notify.trades",
+		"symbol": item.Symbol,
+		"trades": item.Trades,
+		"code":   define.Success,
+	})
+	if err != nil {
+		conn.Close()
+	}
+	return
+}
+
+func (m *Market) procNotifyUser(item *userQueueItem) (err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			xlog.Errorf("Market proc notify user panic with %v, callstack is \n%v", perr, debug.CallStatck())
+		}
+	}()
+	conn := item.Conn
+	if _, _, _, _, userOn := conn.loadSub(); !userOn {
+		return
+	}
+	err = conn.Send(xmap.M{
+		"action": "notify.user",
+		"user": xmap.M{
+			"symbol":   item.Symbol,
+			"sequence": item.Sequence,
+			"orders":   item.Orders,
+			"balances": item.Balances,
+			"holdings": item.Holdings,
 		},
 		"code": define.Success,
 	})
@@ -583,15 +1070,23 @@ func (m *Market) procNotifyTicker(conn *MarketConn, symbol string) (err error) {
 	return
 }
 
+//SrvHTTP will serve websocket, the login user on session is bound to connection for "sub.user"
 func (m *Market) SrvHTTP(s *web.Session) web.Result {
-	srv := websocket.Server{Handler: m.HandWs}
+	userID := s.Int64Def(0, "user_id")
+	srv := websocket.Server{Handler: func(raw *websocket.Conn) { m.HandWsUser(raw, userID) }}
 	srv.ServeHTTP(s.W, s.R)
 	return web.Return
 }
 
 func (m *Market) HandWs(raw *websocket.Conn) {
+	m.HandWsUser(raw, 0)
+}
+
+//HandWsUser will handle websocket connection which is bound to user, the user data can be subscribed only when userID is valid
+func (m *Market) HandWsUser(raw *websocket.Conn, userID int64) {
 	var err error
 	conn := NewMarketConn(raw)
+	conn.UserID = userID
 	key := fmt.Sprintf("%p", conn)
 	m.wslock.Lock()
 	m.wsconn[key] = conn
@@ -608,6 +1103,10 @@ func (m *Market) HandWs(raw *websocket.Conn) {
 	if m.OnConnect != nil {
 		m.OnConnect(raw)
 	}
+	query := raw.Request().URL.Query()
+	if xerr := conn.SetCodec(query.Get("codec"), query.Get("compress")); xerr != nil {
+		xlog.Warnf("Market ws from %v set codec fail with %v, using json", raw.Request().RemoteAddr, xerr)
+	}
 	xlog.Infof("Market accept ws from %v", raw.Request().RemoteAddr)
 	for {
 		cmd := xmap.M{}
@@ -620,19 +1119,38 @@ func (m *Market) HandWs(raw *websocket.Conn) {
 		}
 		action := cmd.StrDef("", "action")
 		switch action {
+		case "codec":
+			err = m.handCodec(conn, cmd)
+		case "sub":
+			err = m.handSub(conn, cmd)
+		case "unsub":
+			err = m.handUnsub(conn, cmd)
+		case "list":
+			err = m.handList(conn, cmd)
+		case "ping":
+			conn.enableKeepalive()
+			err = m.handPing(conn, cmd)
+		case "pong":
+			//the latest time is updated on receive, so only opt in keepalive
+			conn.enableKeepalive()
 		case "sub.kline":
 			err = m.handSubKLine(conn, cmd)
 		case "sub.depth":
 			err = m.handSubDepth(conn, cmd)
+		case "sub.depth_diff":
+			err = m.handSubDepthDiff(conn, cmd)
 		case "sub.ticker":
 			err = m.handSubTicker(conn, cmd)
+		case "sub.trades":
+			err = m.handSubTrades(conn, cmd)
+		case "sub.user":
+			err = m.handSubUser(conn, cmd)
 		default:
-			conn.Send(xmap.M{
+			err = conn.Send(xmap.M{
 				"action":  "error",
 				"code":    define.ArgsInvalid,
-				"message": "unknow action",
+				"message": "unknow action " + action,
 			})
-			err = fmt.Errorf("unknow action")
 		}
 		if err != nil {
 			break
@@ -641,6 +1159,26 @@ func (m *Market) HandWs(raw *websocket.Conn) {
 	xlog.Infof("Market ws from %v is closed by %v", raw.Request().RemoteAddr, err)
 }
 
+func (m *Market) handCodec(conn *MarketConn, cmd xmap.M) (err error) {
+	codec := cmd.StrDef("", "codec")
+	compress := cmd.StrDef("", "compress")
+	xerr := conn.SetCodec(codec, compress)
+	if xerr != nil {
+		err = conn.Send(xmap.M{
+			"action":  "codec",
+			"code":    define.ArgsInvalid,
+			"message": xerr.Error(),
+		})
+		return
+	}
+	xlog.Infof("Market ws from %v is using codec %v and compress %v", conn.RemoteAddr(), codec, compress)
+	err = conn.Send(xmap.M{
+		"action": "codec",
+		"code":   define.Success,
+	})
+	return
+}
+
 func (m *Market) handSubKLine(conn *MarketConn, cmd xmap.M) (err error) {
 	klines := map[string]int{}
 	for _, sub := range cmd.ArrayMapDef(nil, "symbols") {
@@ -648,7 +1186,16 @@ func (m *Market) handSubKLine(conn *MarketConn, cmd xmap.M) (err error) {
 		interval := sub.StrDef("", "interval")
 		klines[klineKey(symbol, interval)] = 1
 	}
+	conn.subLock.Lock()
+	having := len(conn.KLines)
+	conn.subLock.Unlock()
+	if ok, xerr := m.checkSubLimit(conn, "sub.kline", conn.SubCount()-having+len(klines)); !ok {
+		err = xerr
+		return
+	}
+	conn.subLock.Lock()
 	conn.KLines = klines
+	conn.subLock.Unlock()
 	conn.Ready = true
 	xlog.Infof("Market ws from %v is ready for kline", conn.RemoteAddr())
 	err = conn.Send(xmap.M{
@@ -665,7 +1212,16 @@ func (m *Market) handSubDepth(conn *MarketConn, cmd xmap.M) (err error) {
 		max := sub.IntDef(5, "max")
 		depths[symbol] = max
 	}
+	conn.subLock.Lock()
+	having := len(conn.Depths)
+	conn.subLock.Unlock()
+	if ok, xerr := m.checkSubLimit(conn, "sub.depth", conn.SubCount()-having+len(depths)); !ok {
+		err = xerr
+		return
+	}
+	conn.subLock.Lock()
 	conn.Depths = depths
+	conn.subLock.Unlock()
 	conn.Ready = true
 	xlog.Infof("Market ws from %v is ready for depth", conn.RemoteAddr())
 	err = conn.Send(xmap.M{
@@ -675,12 +1231,54 @@ func (m *Market) handSubDepth(conn *MarketConn, cmd xmap.M) (err error) {
 	return
 }
 
+func (m *Market) handSubDepthDiff(conn *MarketConn, cmd xmap.M) (err error) {
+	diffs := map[string]*DepthCache{}
+	updateIDs := map[string]int64{}
+	m.depthLock.RLock()
+	for _, symbol := range cmd.ArrayStrDef(nil, "symbols") {
+		depth := m.depthVal[symbol]
+		if depth == nil {
+			depth = &DepthCache{Symbol: symbol}
+		}
+		diffs[symbol] = depth
+		updateIDs[symbol] = depth.UpdateID
+	}
+	m.depthLock.RUnlock()
+	conn.subLock.Lock()
+	having := len(conn.DepthDiffs)
+	conn.subLock.Unlock()
+	if ok, xerr := m.checkSubLimit(conn, "sub.depth_diff", conn.SubCount()-having+len(diffs)); !ok {
+		err = xerr
+		return
+	}
+	conn.subLock.Lock()
+	conn.DepthDiffs = diffs
+	conn.subLock.Unlock()
+	conn.Ready = true
+	xlog.Infof("Market ws from %v is ready for depth diff", conn.RemoteAddr())
+	err = conn.Send(xmap.M{
+		"action":     "sub.depth_diff",
+		"update_ids": updateIDs,
+		"code":       define.Success,
+	})
+	return
+}
+
 func (m *Market) handSubTicker(conn *MarketConn, cmd xmap.M) (err error) {
 	tickers := map[string]int{}
 	for _, symbol := range cmd.ArrayStrDef(nil, "symbols") {
 		tickers[symbol] = 1
 	}
+	conn.subLock.Lock()
+	having := len(conn.Tickers)
+	conn.subLock.Unlock()
+	if ok, xerr := m.checkSubLimit(conn, "sub.ticker", conn.SubCount()-having+len(tickers)); !ok {
+		err = xerr
+		return
+	}
+	conn.subLock.Lock()
 	conn.Tickers = tickers
+	conn.subLock.Unlock()
 	conn.Ready = true
 	xlog.Infof("Market ws from %v is ready for ticker", conn.RemoteAddr())
 	err = conn.Send(xmap.M{
@@ -690,6 +1288,61 @@ func (m *Market) handSubTicker(conn *MarketConn, cmd xmap.M) (err error) {
 	return
 }
 
+func (m *Market) handSubTrades(conn *MarketConn, cmd xmap.M) (err error) {
+	trades := map[string]int{}
+	for _, symbol := range cmd.ArrayStrDef(nil, "symbols") {
+		trades[symbol] = 1
+	}
+	conn.subLock.Lock()
+	having := len(conn.Trades)
+	conn.subLock.Unlock()
+	if ok, xerr := m.checkSubLimit(conn, "sub.trades", conn.SubCount()-having+len(trades)); !ok {
+		err = xerr
+		return
+	}
+	conn.subLock.Lock()
+	conn.Trades = trades
+	conn.subLock.Unlock()
+	conn.Ready = true
+	xlog.Infof("Market ws from %v is ready for trades", conn.RemoteAddr())
+	err = conn.Send(xmap.M{
+		"action": "sub.trades",
+		"code":   define.Success,
+	})
+	return
+}
+
+func (m *Market) handSubUser(conn *MarketConn, cmd xmap.M) (err error) {
+	if m.Bridge != nil && m.BridgeMode == BridgeModeEdge {
+		err = m.sendActionError(conn, "sub.user", define.NotAccess, "user data is not supported on edge node")
+		return
+	}
+	if conn.UserID < 1 {
+		err = conn.Send(xmap.M{
+			"action":  "sub.user",
+			"code":    define.NotAccess,
+			"message": "not login",
+		})
+		return
+	}
+	if _, _, _, _, userOn := conn.loadSub(); !userOn {
+		if ok, xerr := m.checkSubLimit(conn, "sub.user", conn.SubCount()+1); !ok {
+			err = xerr
+			return
+		}
+	}
+	conn.subLock.Lock()
+	conn.UserOn = true
+	conn.subLock.Unlock()
+	conn.Ready = true
+	xlog.Infof("Market ws from %v is ready for user %v", conn.RemoteAddr(), conn.UserID)
+	err = conn.Send(xmap.M{
+		"action": "sub.user",
+		"code":   define.Success,
+	})
+	return
+}
+
 func (m *Market) LoadKLine(symbol, interval string) (line *gexdb.KLine) {
 	m.klineLock.RLock()
 	defer m.klineLock.RUnlock()
@@ -699,11 +1352,10 @@ func (m *Market) LoadKLine(symbol, interval string) (line *gexdb.KLine) {
 
 func (m *Market) ListKLine(ctx context.Context, symbol, interval string, startTime, endTime time.Time) (lines []*gexdb.KLine, err error) {
 	key := klineKey(symbol, interval)
-	interv, err := gexdb.StringInterv(interval)
+	startTime, err = gexdb.KLineStartTime(interval, startTime, m.Location)
 	if err != nil {
 		return
 	}
-	startTime = xtime.TimeUnix((xtime.Timestamp(startTime) / interv.Milliseconds()) * interv.Milliseconds())
 	m.klineLock.Lock()
 	defer m.klineLock.Unlock()
 	latest := m.klineVal[key]
@@ -760,6 +1412,40 @@ func (m *Market) ListLatestPrice(symbols ...string) (prices map[string]decimal.D
 	return
 }
 
+//ListTrades will list latest trade by symbol from memory, the newest trade is first, it will fall back to stored outbox event when memory is not enough,
+//and then fall back to order transaction when outbox is not enabled or not enough, the trade rebuilt from order is not having sequence
+func (m *Market) ListTrades(ctx context.Context, symbol string, limit int) (trades []*matcher.MatcherTrade, err error) {
+	m.tradeLock.RLock()
+	having := m.tradeVal[symbol]
+	for i := len(having) - 1; i >= 0 && len(trades) < limit; i-- {
+		trades = append(trades, having[i])
+	}
+	m.tradeLock.RUnlock()
+	if len(trades) >= limit {
+		return
+	}
+	stored, err := matcher.ListOutboxTrade(ctx, symbol, limit)
+	if err == nil && len(stored) > len(trades) {
+		trades = stored
+	}
+	if len(trades) >= limit {
+		return
+	}
+	orders, err := gexdb.ListOrderForTrade(ctx, symbol, limit)
+	if err != nil {
+		return
+	}
+	rebuilt := newTradeByOrders(symbol, orders...)
+	if len(rebuilt) <= len(trades) {
+		return
+	}
+	trades = nil
+	for i := len(rebuilt) - 1; i >= 0 && len(trades) < limit; i-- {
+		trades = append(trades, rebuilt[i].Trade)
+	}
+	return
+}
+
 func (m *Market) LoadDepth(symbol string, max int) (depth *DepthCache) {
 	m.depthLock.RLock()
 	defer m.depthLock.RUnlock()
@@ -0,0 +1,273 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/matcher"
+	"github.com/shopspring/decimal"
+)
+
+type rebuildMatch struct {
+	Taker  *gexdb.Order
+	Maker  *gexdb.Order
+	Filled decimal.Decimal
+	Time   time.Time
+}
+
+//isTakerOf will return true when a is the taker of matched between a and b, the trigger order is always taker, other is the later created
+func isTakerOf(a, b *gexdb.Order) bool {
+	if (a.Type == gexdb.OrderTypeTrigger) != (b.Type == gexdb.OrderTypeTrigger) {
+		return a.Type == gexdb.OrderTypeTrigger
+	}
+	if a.CreateTime.Timestamp() != b.CreateTime.Timestamp() {
+		return a.CreateTime.Timestamp() > b.CreateTime.Timestamp()
+	}
+	return a.TID > b.TID
+}
+
+//rebuildTrade is the public trade rebuilt from order transaction, it keep the taker order id to count kline by taker like market event
+type rebuildTrade struct {
+	Taker string
+	Trade *matcher.MatcherTrade
+}
+
+//newTradeByOrders will rebuild public trade by order transaction which is sorted by time asc, the maker item is the trade record of one match,
+//the legacy order which is not having maker item is matched by both side transaction and the taker is decided by isTakerOf
+func newTradeByOrders(symbol string, orders ...*gexdb.Order) (trades []*rebuildTrade) {
+	orderAll := map[string]*gexdb.Order{}
+	recorded := map[string]bool{}
+	for _, order := range orders {
+		orderAll[order.OrderID] = order
+		for _, tran := range order.Transaction.Trans {
+			if !tran.Maker {
+				continue
+			}
+			recorded[order.OrderID] = true
+			recorded[tran.OrderID] = true
+			side := gexdb.OrderSideBuy
+			if order.Side == gexdb.OrderSideBuy {
+				side = gexdb.OrderSideSell
+			}
+			trades = append(trades, &rebuildTrade{
+				Taker: tran.OrderID,
+				Trade: &matcher.MatcherTrade{Symbol: symbol, Side: side, Price: tran.Price, Quantity: tran.Filled, Time: tran.CreateTime},
+			})
+		}
+	}
+	matched := map[string]*rebuildMatch{}
+	sides := map[string]decimal.Decimal{}
+	for _, order := range orders {
+		if recorded[order.OrderID] {
+			continue
+		}
+		for _, tran := range order.Transaction.Trans {
+			other := orderAll[tran.OrderID]
+			if other == nil || other.OrderID == order.OrderID || recorded[other.OrderID] {
+				continue
+			}
+			taker, maker := other, order
+			if isTakerOf(order, other) {
+				taker, maker = order, other
+			}
+			key := taker.OrderID + "-" + maker.OrderID
+			side := key + "-" + order.OrderID
+			sides[side] = sides[side].Add(tran.Filled)
+			match := matched[key]
+			if match == nil {
+				match = &rebuildMatch{Taker: taker, Maker: maker, Time: time.Time(tran.CreateTime)}
+				matched[key] = match
+			}
+			if sides[side].GreaterThan(match.Filled) {
+				match.Filled = sides[side]
+			}
+			if time.Time(tran.CreateTime).Before(match.Time) {
+				match.Time = time.Time(tran.CreateTime)
+			}
+		}
+	}
+	for _, match := range matched {
+		price := match.Maker.Price
+		if !price.IsPositive() {
+			price = match.Taker.AvgPrice
+		}
+		trades = append(trades, &rebuildTrade{
+			Taker: match.Taker.OrderID,
+			Trade: &matcher.MatcherTrade{Symbol: symbol, Side: match.Taker.Side, Price: price, Quantity: match.Filled, Time: xsql.Time(match.Time)},
+		})
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		a, b := time.Time(trades[i].Trade.Time), time.Time(trades[j].Trade.Time)
+		if a.Equal(b) {
+			return trades[i].Taker < trades[j].Taker
+		}
+		return a.Before(b)
+	})
+	return
+}
+
+//NewKLineByOrders will generate kline by public trade which is rebuilt from order transaction, the price is filled price of each trade,
+//the count is added by taker like market event
+func NewKLineByOrders(symbol, interval string, loc *time.Location, orders ...*gexdb.Order) (lines []*gexdb.KLine, err error) {
+	lines, err = newKLineByTrades(symbol, interval, loc, newTradeByOrders(symbol, orders...)...)
+	return
+}
+
+func newKLineByTrades(symbol, interval string, loc *time.Location, trades ...*rebuildTrade) (lines []*gexdb.KLine, err error) {
+	var line *gexdb.KLine
+	var takers map[string]bool
+	for _, trade := range trades {
+		var startTime time.Time
+		startTime, err = gexdb.KLineStartTime(interval, time.Time(trade.Trade.Time), loc)
+		if err != nil {
+			return
+		}
+		price := trade.Trade.Price
+		if line == nil || line.StartTime.Timestamp() != xsql.Time(startTime).Timestamp() {
+			line = &gexdb.KLine{
+				Symbol:     symbol,
+				Interv:     interval,
+				Open:       price,
+				Low:        price,
+				High:       price,
+				StartTime:  xsql.Time(startTime),
+				UpdateTime: xsql.TimeNow(),
+			}
+			lines = append(lines, line)
+			takers = map[string]bool{}
+		}
+		line.Amount = line.Amount.Add(trade.Trade.Quantity)
+		line.Volume = line.Volume.Add(trade.Trade.Quantity.Mul(price))
+		if !takers[trade.Taker] {
+			takers[trade.Taker] = true
+			line.Count++
+		}
+		line.Close = price
+		if line.Low.GreaterThan(price) {
+			line.Low = price
+		}
+		if line.High.LessThan(price) {
+			line.High = price
+		}
+	}
+	return
+}
+
+//RebuildKLine will regenerate kline by order transaction which start_time is in [startTime,endTime), the current bar is not rebuild because it is still generating
+func (m *Market) RebuildKLine(ctx context.Context, symbol, interval string, startTime, endTime time.Time) (lines []*gexdb.KLine, err error) {
+	startTime, err = gexdb.KLineStartTime(interval, startTime, m.Location)
+	if err != nil {
+		return
+	}
+	currentTime, _ := gexdb.KLineStartTime(interval, time.Now(), m.Location)
+	if endTime.IsZero() || endTime.After(currentTime) {
+		endTime = currentTime
+	}
+	if !startTime.Before(endTime) {
+		err = fmt.Errorf("start time %v is not before end time %v", startTime, endTime)
+		return
+	}
+	orders, err := gexdb.ListOrderForKLine(ctx, symbol, startTime, endTime)
+	if err != nil {
+		return
+	}
+	all, err := NewKLineByOrders(symbol, interval, m.Location, orders...)
+	if err != nil {
+		return
+	}
+	for _, line := range all {
+		if !time.Time(line.StartTime).Before(startTime) && time.Time(line.StartTime).Before(endTime) {
+			lines = append(lines, line)
+		}
+	}
+	added, err := gexdb.ReplaceKLine(ctx, symbol, interval, startTime, endTime, lines...)
+	if err != nil {
+		return
+	}
+	m.klineLock.Lock()
+	delete(m.klineCache, klineKey(symbol, interval))
+	m.klineLock.Unlock()
+	xlog.Infof("Market rebuild %v kline on %v/%v from %v to %v success", added, symbol, interval, startTime, endTime)
+	return
+}
+
+//procRebuildKLine will rebuild the kline gap after latest persisted kline in rebuild window and seed the current kline, it is called on start,
+//the trade before rebuild time is counted in seeded kline, so the event which is traded before is skipped by procGenKLine
+func (m *Market) procRebuildKLine() (err error) {
+	if m.RebuildWindow <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*m.WaitTimeout)
+	defer cancel()
+	now := time.Now()
+	windowTime := now.Add(-m.RebuildWindow)
+	for _, symbol := range m.Symbols {
+		var orders []*gexdb.Order
+		orders, err = gexdb.ListOrderForKLine(ctx, symbol, windowTime, now)
+		if err != nil {
+			xlog.Warnf("Market rebuild kline by %v fail with %v", symbol, err)
+			break
+		}
+		trades := []*rebuildTrade{}
+		for _, trade := range newTradeByOrders(symbol, orders...) {
+			if time.Time(trade.Trade.Time).Before(now) {
+				trades = append(trades, trade)
+			}
+		}
+		m.klineLock.Lock()
+		m.klineSeed[symbol] = now
+		m.klineLock.Unlock()
+		for _, interval := range gexdb.KLineIntervAll {
+			currentTime, _ := gexdb.KLineStartTime(interval, now, m.Location)
+			var all []*gexdb.KLine
+			all, err = newKLineByTrades(symbol, interval, m.Location, trades...)
+			if err != nil {
+				break
+			}
+			var latest *gexdb.KLine
+			latest, err = gexdb.FindLatestKLine(ctx, symbol, interval)
+			if err != nil {
+				break
+			}
+			var lines []*gexdb.KLine
+			var current *gexdb.KLine
+			for _, line := range all {
+				lineTime := time.Time(line.StartTime)
+				if lineTime.Before(windowTime) || (latest != nil && !lineTime.After(time.Time(latest.StartTime))) {
+					continue
+				}
+				if lineTime.Equal(currentTime) {
+					current = line
+				} else if lineTime.Before(currentTime) {
+					lines = append(lines, line)
+				}
+			}
+			if len(lines) > 0 {
+				_, err = gexdb.ReplaceKLine(ctx, symbol, interval, time.Time(lines[0].StartTime), currentTime, lines...)
+				if err != nil {
+					break
+				}
+				xlog.Infof("Market rebuild %v gap kline on %v/%v from %v", len(lines), symbol, interval, time.Time(lines[0].StartTime))
+			}
+			if current != nil {
+				m.klineLock.Lock()
+				key := klineKey(symbol, interval)
+				if _, ok := m.klineVal[key]; !ok {
+					m.klineVal[key] = current
+					m.avgPrice[symbol] = current.Close
+				}
+				m.klineLock.Unlock()
+			}
+		}
+		if err != nil {
+			xlog.Warnf("Market rebuild kline by %v fail with %v", symbol, err)
+			break
+		}
+	}
+	return
+}
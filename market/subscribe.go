@@ -0,0 +1,366 @@
+package market
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/define"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+)
+
+const (
+	ChannelKLine     = "kline"
+	ChannelDepth     = "depth"
+	ChannelDepthDiff = "depth_diff"
+	ChannelTicker    = "ticker"
+	ChannelTrades    = "trades"
+	ChannelUser      = "user"
+)
+
+//MarketChannel is the subscription channel of market ws
+type MarketChannel struct {
+	Type     string `json:"type"`
+	Symbol   string `json:"symbol,omitempty"`
+	Interval string `json:"interval,omitempty"`
+	Max      int    `json:"max,omitempty"`
+}
+
+func parseChannel(cmd xmap.M) (channels []*MarketChannel, err error) {
+	for _, item := range cmd.ArrayMapDef(nil, "channels") {
+		channel := &MarketChannel{
+			Type:     item.StrDef("", "type"),
+			Symbol:   item.StrDef("", "symbol"),
+			Interval: item.StrDef("", "interval"),
+			Max:      item.IntDef(5, "max"),
+		}
+		switch channel.Type {
+		case ChannelKLine:
+			if _, xerr := gexdb.StringInterv(channel.Interval); xerr != nil || len(channel.Symbol) < 1 {
+				err = fmt.Errorf("channel %v symbol or interval is invalid", channel.Type)
+			}
+		case ChannelDepth, ChannelDepthDiff, ChannelTicker, ChannelTrades:
+			if len(channel.Symbol) < 1 {
+				err = fmt.Errorf("channel %v symbol is required", channel.Type)
+			}
+		case ChannelUser:
+		default:
+			err = fmt.Errorf("channel %v is not supported", channel.Type)
+		}
+		if err != nil {
+			break
+		}
+		channels = append(channels, channel)
+	}
+	if err == nil && len(channels) < 1 {
+		err = fmt.Errorf("%v", "channels is required")
+	}
+	return
+}
+
+func copyIntMap(src map[string]int) (dst map[string]int) {
+	dst = map[string]int{}
+	for key, val := range src {
+		dst[key] = val
+	}
+	return
+}
+
+//loadSub will return the current subscription map of connection, the map is not changed after loaded because it is replaced by copy
+func (m *MarketConn) loadSub() (klines, depths, tickers, trades map[string]int, userOn bool) {
+	m.subLock.Lock()
+	klines, depths, tickers, trades, userOn = m.KLines, m.Depths, m.Tickers, m.Trades, m.UserOn
+	m.subLock.Unlock()
+	return
+}
+
+//SubCount will return the active subscription count of connection
+func (m *MarketConn) SubCount() (count int) {
+	m.subLock.Lock()
+	count = len(m.DepthDiffs) + len(m.KLines) + len(m.Depths) + len(m.Tickers) + len(m.Trades)
+	if m.UserOn {
+		count++
+	}
+	m.subLock.Unlock()
+	return
+}
+
+//ListChannel will return the active subscription channel of connection
+func (m *MarketConn) ListChannel() (channels []*MarketChannel) {
+	channels = []*MarketChannel{}
+	m.subLock.Lock()
+	defer m.subLock.Unlock()
+	for key := range m.KLines {
+		if i := strings.LastIndex(key, "-"); i > 0 {
+			channels = append(channels, &MarketChannel{Type: ChannelKLine, Symbol: key[:i], Interval: key[i+1:]})
+		}
+	}
+	for symbol, max := range m.Depths {
+		channels = append(channels, &MarketChannel{Type: ChannelDepth, Symbol: symbol, Max: max})
+	}
+	for symbol := range m.DepthDiffs {
+		channels = append(channels, &MarketChannel{Type: ChannelDepthDiff, Symbol: symbol})
+	}
+	for symbol := range m.Tickers {
+		channels = append(channels, &MarketChannel{Type: ChannelTicker, Symbol: symbol})
+	}
+	for symbol := range m.Trades {
+		channels = append(channels, &MarketChannel{Type: ChannelTrades, Symbol: symbol})
+	}
+	if m.UserOn {
+		channels = append(channels, &MarketChannel{Type: ChannelUser})
+	}
+	sort.SliceStable(channels, func(i, j int) bool {
+		if channels[i].Type == channels[j].Type {
+			return channels[i].Symbol+channels[i].Interval < channels[j].Symbol+channels[j].Interval
+		}
+		return channels[i].Type < channels[j].Type
+	})
+	return
+}
+
+func (m *Market) sendActionError(conn *MarketConn, action string, code int, message string) (err error) {
+	err = conn.Send(xmap.M{
+		"action":  action,
+		"code":    code,
+		"message": message,
+	})
+	return
+}
+
+//checkSubLimit will check the subscription count after changed, it send error to connection when limit is exceeded
+func (m *Market) checkSubLimit(conn *MarketConn, action string, count int) (ok bool, err error) {
+	if m.SubMax < 1 || count <= m.SubMax {
+		ok = true
+		return
+	}
+	xlog.Warnf("Market ws from %v %v fail with subscription %v is exceeded limit %v", conn.RemoteAddr(), action, count, m.SubMax)
+	err = m.sendActionError(conn, action, define.ArgsInvalid, fmt.Sprintf("subscription limit %v is exceeded", m.SubMax))
+	return
+}
+
+//handSub will add channel to subscription, the subscription map is replaced by copy because it is read by notify runner
+func (m *Market) handSub(conn *MarketConn, cmd xmap.M) (err error) {
+	channels, xerr := parseChannel(cmd)
+	if xerr != nil {
+		err = m.sendActionError(conn, "sub", define.ArgsInvalid, xerr.Error())
+		return
+	}
+	conn.subLock.Lock()
+	klines, depths := copyIntMap(conn.KLines), copyIntMap(conn.Depths)
+	tickers, trades := copyIntMap(conn.Tickers), copyIntMap(conn.Trades)
+	diffs := map[string]*DepthCache{}
+	for symbol, depth := range conn.DepthDiffs {
+		diffs[symbol] = depth
+	}
+	userOn := conn.UserOn
+	conn.subLock.Unlock()
+	updateIDs := map[string]int64{}
+	for _, channel := range channels {
+		switch channel.Type {
+		case ChannelKLine:
+			klines[klineKey(channel.Symbol, channel.Interval)] = 1
+		case ChannelDepth:
+			depths[channel.Symbol] = channel.Max
+		case ChannelDepthDiff:
+			m.depthLock.RLock()
+			depth := m.depthVal[channel.Symbol]
+			m.depthLock.RUnlock()
+			if depth == nil {
+				depth = &DepthCache{Symbol: channel.Symbol}
+			}
+			diffs[channel.Symbol] = depth
+			updateIDs[channel.Symbol] = depth.UpdateID
+		case ChannelTicker:
+			tickers[channel.Symbol] = 1
+		case ChannelTrades:
+			trades[channel.Symbol] = 1
+		case ChannelUser:
+			if m.Bridge != nil && m.BridgeMode == BridgeModeEdge {
+				err = m.sendActionError(conn, "sub", define.NotAccess, "user data is not supported on edge node")
+				return
+			}
+			if conn.UserID < 1 {
+				err = m.sendActionError(conn, "sub", define.NotAccess, "not login")
+				return
+			}
+			userOn = true
+		}
+	}
+	count := len(klines) + len(depths) + len(diffs) + len(tickers) + len(trades)
+	if userOn {
+		count++
+	}
+	if ok, xerr := m.checkSubLimit(conn, "sub", count); !ok {
+		err = xerr
+		return
+	}
+	conn.subLock.Lock()
+	conn.KLines, conn.Depths, conn.Tickers, conn.Trades, conn.UserOn = klines, depths, tickers, trades, userOn
+	conn.DepthDiffs = diffs
+	conn.subLock.Unlock()
+	conn.Ready = true
+	xlog.Infof("Market ws from %v is subscribed %v channel, having %v", conn.RemoteAddr(), len(channels), count)
+	err = conn.Send(xmap.M{
+		"action":     "sub",
+		"code":       define.Success,
+		"count":      count,
+		"update_ids": updateIDs,
+	})
+	return
+}
+
+//handUnsub will remove channel from subscription, the channel without symbol will remove all symbol of the type
+func (m *Market) handUnsub(conn *MarketConn, cmd xmap.M) (err error) {
+	channels := []*MarketChannel{}
+	for _, item := range cmd.ArrayMapDef(nil, "channels") {
+		channels = append(channels, &MarketChannel{
+			Type:     item.StrDef("", "type"),
+			Symbol:   item.StrDef("", "symbol"),
+			Interval: item.StrDef("", "interval"),
+		})
+	}
+	conn.subLock.Lock()
+	klines, depths := copyIntMap(conn.KLines), copyIntMap(conn.Depths)
+	tickers, trades := copyIntMap(conn.Tickers), copyIntMap(conn.Trades)
+	diffs := map[string]*DepthCache{}
+	for symbol, depth := range conn.DepthDiffs {
+		diffs[symbol] = depth
+	}
+	userOn := conn.UserOn
+	conn.subLock.Unlock()
+	removeSymbol := func(having map[string]int, symbol string) {
+		if len(symbol) > 0 {
+			delete(having, symbol)
+			return
+		}
+		for key := range having {
+			delete(having, key)
+		}
+	}
+	for _, channel := range channels {
+		switch channel.Type {
+		case ChannelKLine:
+			for key := range klines {
+				if len(channel.Symbol) < 1 || key == klineKey(channel.Symbol, channel.Interval) || (len(channel.Interval) < 1 && strings.HasPrefix(key, channel.Symbol+"-")) {
+					delete(klines, key)
+				}
+			}
+		case ChannelDepth:
+			removeSymbol(depths, channel.Symbol)
+		case ChannelDepthDiff:
+			for symbol := range diffs {
+				if len(channel.Symbol) < 1 || symbol == channel.Symbol {
+					delete(diffs, symbol)
+				}
+			}
+		case ChannelTicker:
+			removeSymbol(tickers, channel.Symbol)
+		case ChannelTrades:
+			removeSymbol(trades, channel.Symbol)
+		case ChannelUser:
+			userOn = false
+		default:
+			err = m.sendActionError(conn, "unsub", define.ArgsInvalid, fmt.Sprintf("channel %v is not supported", channel.Type))
+			return
+		}
+	}
+	conn.subLock.Lock()
+	conn.KLines, conn.Depths, conn.Tickers, conn.Trades, conn.UserOn = klines, depths, tickers, trades, userOn
+	conn.DepthDiffs = diffs
+	conn.subLock.Unlock()
+	count := conn.SubCount()
+	xlog.Infof("Market ws from %v is unsubscribed %v channel, having %v", conn.RemoteAddr(), len(channels), count)
+	err = conn.Send(xmap.M{
+		"action": "unsub",
+		"code":   define.Success,
+		"count":  count,
+	})
+	return
+}
+
+func (m *Market) handList(conn *MarketConn, cmd xmap.M) (err error) {
+	channels := conn.ListChannel()
+	err = conn.Send(xmap.M{
+		"action":   "list",
+		"code":     define.Success,
+		"channels": channels,
+		"count":    len(channels),
+		"max":      m.SubMax,
+	})
+	return
+}
+
+func (m *Market) handPing(conn *MarketConn, cmd xmap.M) (err error) {
+	err = conn.Send(xmap.M{
+		"action": "pong",
+		"code":   define.Success,
+		"time":   xsql.TimeNow().Timestamp(),
+	})
+	return
+}
+
+func (m *Market) loopKeepalive() {
+	defer m.waiter.Done()
+	ticker := time.NewTicker(m.PingDelay)
+	defer ticker.Stop()
+	running := true
+	for running {
+		select {
+		case <-m.exiter:
+			running = false
+		case <-ticker.C:
+			m.procKeepalive()
+		}
+	}
+	xlog.Infof("Market keepalive loop is stopped")
+}
+
+//enableKeepalive will opt in idle timeout of connection, it is called when client send ping/pong, so the listen-only client is not closed by idle
+func (m *MarketConn) enableKeepalive() {
+	m.subLock.Lock()
+	m.keepalive = true
+	m.subLock.Unlock()
+}
+
+func (m *MarketConn) isKeepalive() (keepalive bool) {
+	m.subLock.Lock()
+	keepalive = m.keepalive
+	m.subLock.Unlock()
+	return
+}
+
+//procKeepalive will send ping to connection which is idle more than PingDelay and close connection which is idle more than IdleTimeout,
+//the idle timeout is only for connection which is opted in by ping/pong, other is only closed when send ping fail
+func (m *Market) procKeepalive() (err error) {
+	conns := []*MarketConn{}
+	m.wslock.RLock()
+	for _, conn := range m.wsconn {
+		conns = append(conns, conn)
+	}
+	m.wslock.RUnlock()
+	for _, conn := range conns {
+		idle := time.Since(conn.LoadLatest())
+		if idle >= m.IdleTimeout && conn.isKeepalive() {
+			xlog.Infof("Market ws from %v is idle %v, will close it", conn.RemoteAddr(), idle)
+			conn.Close()
+			continue
+		}
+		if idle < m.PingDelay {
+			continue
+		}
+		xerr := conn.Send(xmap.M{
+			"action": "ping",
+			"time":   xsql.TimeNow().Timestamp(),
+		})
+		if xerr != nil {
+			xlog.Warnf("Market send ping to %v fail with %v", conn.RemoteAddr(), xerr)
+			conn.Close()
+		}
+	}
+	return
+}
@@ -0,0 +1,191 @@
+package market
+
+import (
+	"context"
+	"time"
+
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/matcher"
+	"github.com/shopspring/decimal"
+)
+
+//Ticker is the rolling window statistics of symbol
+type Ticker struct {
+	Symbol        string          `json:"symbol"`
+	Open          decimal.Decimal `json:"open"`
+	High          decimal.Decimal `json:"high"`
+	Low           decimal.Decimal `json:"low"`
+	Last          decimal.Decimal `json:"last"`
+	Volume        decimal.Decimal `json:"volume"`
+	QuoteVolume   decimal.Decimal `json:"quote_volume"`
+	Change        decimal.Decimal `json:"change"`
+	ChangePercent decimal.Decimal `json:"change_percent"`
+	Count         int64           `json:"count"`
+	OpenTime      xsql.Time       `json:"open_time"`
+	CloseTime     xsql.Time       `json:"close_time"`
+}
+
+type tickerBucket struct {
+	Start  int64
+	Open   decimal.Decimal
+	High   decimal.Decimal
+	Low    decimal.Decimal
+	Close  decimal.Decimal
+	Volume decimal.Decimal
+	Quote  decimal.Decimal
+	Count  int64
+}
+
+func (t *tickerBucket) add(price, quantity decimal.Decimal, count int64) {
+	if t.Open.Sign() <= 0 {
+		t.Open = price
+	}
+	if t.High.LessThan(price) {
+		t.High = price
+	}
+	if t.Low.Sign() <= 0 || t.Low.GreaterThan(price) {
+		t.Low = price
+	}
+	t.Close = price
+	t.Volume = t.Volume.Add(quantity)
+	t.Quote = t.Quote.Add(price.Mul(quantity))
+	t.Count += count
+}
+
+//loadTicker will seed ticker bucket by 5min kline in window and current 5min kline which is seeded by procRebuildKLine, it is called on start,
+//the applied sequence is seeded by processed sequence, so the event which is already counted in kline is skipped on outbox catch up
+func (m *Market) loadTicker() (err error) {
+	if m.TickerBucket != 5*time.Minute {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.WaitTimeout)
+	defer cancel()
+	now := time.Now()
+	for _, symbol := range m.Symbols {
+		var lines []*gexdb.KLine
+		lines, err = gexdb.ListKLine(ctx, symbol, "5min", now.Add(-m.TickerWindow), now)
+		if err != nil {
+			xlog.Warnf("Market load ticker by %v fail with %v", symbol, err)
+			break
+		}
+		m.klineLock.RLock()
+		current := m.klineVal[klineKey(symbol, "5min")]
+		m.klineLock.RUnlock()
+		if current != nil && current.Count > 0 && (len(lines) < 1 || current.StartTime.Timestamp() > lines[0].StartTime.Timestamp()) {
+			lines = append([]*gexdb.KLine{current}, lines...)
+		}
+		buckets := []*tickerBucket{}
+		for i := len(lines) - 1; i >= 0; i-- {
+			line := lines[i]
+			buckets = append(buckets, &tickerBucket{
+				Start:  line.StartTime.Timestamp(),
+				Open:   line.Open,
+				High:   line.High,
+				Low:    line.Low,
+				Close:  line.Close,
+				Volume: line.Amount,
+				Quote:  line.Volume,
+				Count:  line.Count,
+			})
+		}
+		sequence, _ := m.LoadSequence(symbol)
+		m.tickerLock.Lock()
+		m.tickerVal[symbol] = buckets
+		m.tickerSequence[symbol] = sequence
+		m.tickerLock.Unlock()
+	}
+	return
+}
+
+//procGenTicker will add event trade to ticker bucket and remove bucket out of window,
+//the event which sequence is applied is skipped, the sequence 1 is accepted as matcher restarted like checkSequence
+func (m *Market) procGenTicker(event *matcher.MatcherEvent) {
+	if len(event.Trades) < 1 {
+		return
+	}
+	bucketSize := m.TickerBucket.Milliseconds()
+	m.tickerLock.Lock()
+	defer m.tickerLock.Unlock()
+	if event.Sequence > 0 {
+		last := m.tickerSequence[event.Symbol]
+		if last > 0 && event.Sequence <= last && event.Sequence != 1 {
+			return
+		}
+		m.tickerSequence[event.Symbol] = event.Sequence
+	}
+	buckets := m.tickerVal[event.Symbol]
+	for _, trade := range event.Trades {
+		start := (trade.Time.Timestamp() / bucketSize) * bucketSize
+		var bucket *tickerBucket
+		if len(buckets) > 0 && buckets[len(buckets)-1].Start >= start {
+			bucket = buckets[len(buckets)-1]
+		} else {
+			bucket = &tickerBucket{Start: start}
+			buckets = append(buckets, bucket)
+		}
+		bucket.add(trade.Price, trade.Quantity, 1)
+	}
+	m.tickerVal[event.Symbol] = m.trimTicker(buckets, time.Now())
+}
+
+func (m *Market) trimTicker(buckets []*tickerBucket, now time.Time) []*tickerBucket {
+	oldest := now.Add(-m.TickerWindow).UnixMilli()
+	skip := 0
+	for skip < len(buckets) && buckets[skip].Start+m.TickerBucket.Milliseconds() <= oldest {
+		skip++
+	}
+	if skip > 0 {
+		buckets = append([]*tickerBucket{}, buckets[skip:]...)
+	}
+	return buckets
+}
+
+//LoadTicker will return the rolling window statistics by symbol, the last is latest price and other is zero when there is not trade in window
+func (m *Market) LoadTicker(symbol string) (ticker *Ticker) {
+	now := time.Now()
+	ticker = &Ticker{
+		Symbol:    symbol,
+		OpenTime:  xsql.Time(now.Add(-m.TickerWindow)),
+		CloseTime: xsql.Time(now),
+	}
+	m.tickerLock.RLock()
+	buckets := m.trimTicker(m.tickerVal[symbol], now)
+	for _, bucket := range buckets {
+		if ticker.Open.Sign() <= 0 {
+			ticker.Open = bucket.Open
+		}
+		if ticker.High.LessThan(bucket.High) {
+			ticker.High = bucket.High
+		}
+		if ticker.Low.Sign() <= 0 || ticker.Low.GreaterThan(bucket.Low) {
+			ticker.Low = bucket.Low
+		}
+		ticker.Last = bucket.Close
+		ticker.Volume = ticker.Volume.Add(bucket.Volume)
+		ticker.QuoteVolume = ticker.QuoteVolume.Add(bucket.Quote)
+		ticker.Count += bucket.Count
+	}
+	m.tickerLock.RUnlock()
+	if len(buckets) < 1 {
+		ticker.Last = m.LoadLatestPrice(symbol)
+		return
+	}
+	ticker.Change = ticker.Last.Sub(ticker.Open)
+	if ticker.Open.IsPositive() {
+		ticker.ChangePercent = ticker.Change.Mul(decimal.NewFromInt(100)).DivRound(ticker.Open, 2)
+	}
+	return
+}
+
+//ListTicker will return the rolling window statistics by symbols, all symbol is returned when symbols is empty
+func (m *Market) ListTicker(symbols ...string) (tickers []*Ticker) {
+	if len(symbols) < 1 {
+		symbols = m.Symbols
+	}
+	for _, symbol := range symbols {
+		tickers = append(tickers, m.LoadTicker(symbol))
+	}
+	return
+}
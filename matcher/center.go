@@ -16,45 +16,82 @@ import (
 )
 
 type MatcherCenter struct {
-	Symbols      []string
-	TriggerDelay time.Duration
-	matcherAll   map[string]Matcher
-	matcherLock  sync.RWMutex
-	monitorAll   map[string]map[string]MatcherMonitor
-	monitorLock  sync.RWMutex
-	eventRun     int
-	eventQueue   chan *MatcherEvent
-	cacheMax     int
-	cacheBalance map[string]bool
-	cacheLast    time.Time
-	cacheLock    sync.RWMutex
-	exiter       chan int
-	waiter       sync.WaitGroup
+	Symbols            []string
+	TriggerDelay       time.Duration
+	EventMax           int
+	EventWait          time.Duration
+	OutboxOn           bool
+	OutboxKeep         time.Duration
+	ReconcileDelay     time.Duration
+	ReconcileTolerance decimal.Decimal
+	ReconcileFreeze    bool
+	UserTier           func(ctx context.Context, userID int64) (tier string, err error)
+	matcherAll         map[string]Matcher
+	matcherLock        sync.RWMutex
+	monitorAll         map[string]map[string]MatcherMonitor
+	monitorLock        sync.RWMutex
+	limitAll           map[string]*RiskLimit
+	limitLock          sync.RWMutex
+	eventAll           map[string]*EventQueue
+	eventLock          sync.RWMutex
+	eventStarted       bool
+	sequenceAll        map[string]int64
+	sequenceLock       sync.Mutex
+	cacheMax           int
+	cacheBalance       map[string]bool
+	cacheLast          time.Time
+	cacheLock          sync.RWMutex
+	outboxLast         time.Time
+	reconcileTime      time.Time
+	reconcileLast      *BalanceReconcile
+	frozenAll          map[int64]bool
+	reconcileLock      sync.RWMutex
+	exiter             chan int
+	waiter             sync.WaitGroup
 }
 
-func NewMatcherCenter(eventRun, eventMax, cacheMax int) (center *MatcherCenter) {
+func NewMatcherCenter(eventMax, cacheMax int) (center *MatcherCenter) {
 	center = &MatcherCenter{
-		TriggerDelay: time.Second,
-		matcherAll:   map[string]Matcher{},
-		matcherLock:  sync.RWMutex{},
-		monitorAll:   map[string]map[string]MatcherMonitor{},
-		monitorLock:  sync.RWMutex{},
-		eventQueue:   make(chan *MatcherEvent, eventMax),
-		eventRun:     eventRun,
-		cacheMax:     cacheMax,
-		cacheBalance: map[string]bool{},
-		cacheLock:    sync.RWMutex{},
-		exiter:       make(chan int, 1),
-		waiter:       sync.WaitGroup{},
+		TriggerDelay:       time.Second,
+		EventMax:           eventMax,
+		EventWait:          100 * time.Millisecond,
+		OutboxOn:           false,
+		OutboxKeep:         72 * time.Hour,
+		ReconcileTolerance: decimal.NewFromFloat(0.00000001),
+		matcherAll:         map[string]Matcher{},
+		matcherLock:        sync.RWMutex{},
+		monitorAll:         map[string]map[string]MatcherMonitor{},
+		monitorLock:        sync.RWMutex{},
+		limitAll:           map[string]*RiskLimit{},
+		limitLock:          sync.RWMutex{},
+		eventAll:           map[string]*EventQueue{},
+		eventLock:          sync.RWMutex{},
+		sequenceAll:        map[string]int64{},
+		sequenceLock:       sync.Mutex{},
+		cacheMax:           cacheMax,
+		cacheBalance:       map[string]bool{},
+		cacheLock:          sync.RWMutex{},
+		frozenAll:          map[int64]bool{},
+		reconcileLock:      sync.RWMutex{},
+		exiter:             make(chan int, 1),
+		waiter:             sync.WaitGroup{},
 	}
 	return
 }
 
 func BootstrapMatcherCenterByConfig(config *xprop.Config) (center *MatcherCenter, err error) {
-	eventRun := config.IntDef(1, "matcher/matcher_event_run")
 	eventMax := config.IntDef(4096, "matcher/matcher_event_max")
 	cacheMax := config.IntDef(10000, "matcher/balance_cache_max")
-	center = NewMatcherCenter(eventRun, eventMax, cacheMax)
+	center = NewMatcherCenter(eventMax, cacheMax)
+	center.EventWait = time.Duration(config.IntDef(100, "matcher/matcher_event_wait")) * time.Millisecond
+	if len(config.StrDef("", "matcher/matcher_event_run")) > 0 {
+		xlog.Warnf("Bootstrap matcher/matcher_event_run is not supported now, the event is dispatched by one worker of each symbol, use matcher/matcher_event_max to set queue size")
+	}
+	center.OutboxOn = config.StrDef("1", "matcher/outbox_on") == "1"
+	center.OutboxKeep = time.Duration(config.IntDef(72, "matcher/outbox_keep")) * time.Hour
+	center.ReconcileDelay = time.Duration(config.IntDef(0, "matcher/reconcile_delay")) * time.Second
+	center.ReconcileTolerance = decimal.NewFromFloat(config.Float64Def(0.00000001, "matcher/reconcile_tolerance"))
+	center.ReconcileFreeze = config.StrDef("0", "matcher/reconcile_freeze") == "1"
 	for _, sec := range config.Seces {
 		if !strings.HasPrefix(sec, "matcher.") {
 			continue
@@ -66,6 +103,8 @@ func BootstrapMatcherCenterByConfig(config *xprop.Config) (center *MatcherCenter
 		var precisionPrice int32 = 8
 		var symbol, base, quote string
 		var fee, marginMax, marginAdd float64 = 0.002, 0.99, 0.01
+		var maxOpenOrders int64
+		var maxOrderNotional, maxPositionNotional float64
 		err = config.ValidFormat(
 			strings.ReplaceAll(`
 				_S/precision_quantity,o|i,r:0;
@@ -76,28 +115,40 @@ func BootstrapMatcherCenterByConfig(config *xprop.Config) (center *MatcherCenter
 				_S/fee,0|f,r:-1~1;
 				_S/margin_max,o|f,r:0~1;
 				_S/margin_add,o|f,r:0~1;
+				_S/max_open_orders,o|i,r:0;
+				_S/max_order_notional,o|f,r:0;
+				_S/max_position_notional,o|f,r:0;
 			`, "_S", sec),
 			&precisionQuantity, &precisionPrice, &symbol, &base, &quote, &fee, &marginMax, &marginAdd,
+			&maxOpenOrders, &maxOrderNotional, &maxPositionNotional,
 		)
 		if err != nil {
 			break
 		}
+		center.AddLimit(symbol, "", &RiskLimit{
+			MaxOpenOrders:       maxOpenOrders,
+			MaxOrderNotional:    decimal.NewFromFloat(maxOrderNotional),
+			MaxPositionNotional: decimal.NewFromFloat(maxPositionNotional),
+		})
 		if strings.HasPrefix(symbol, "spot.") {
 			spot := NewSpotMatcher(symbol, base, quote, center)
 			spot.Fee = decimal.NewFromFloat(fee)
 			spot.PrecisionPrice = precisionPrice
 			spot.PrecisionQuantity = precisionQuantity
 			spot.PrepareProcess = center.PrepareSpotMatcher
+			spot.CheckProcess = center.checkRiskLimit
 			center.AddMatcher(symbol, spot)
 			xlog.Infof("Bootstrap register spot matcher by symbol %v", symbol)
 		} else if strings.HasPrefix(symbol, "futures.") {
 			futures := NewFuturesMatcher(symbol, quote, center)
+			futures.Base = base
 			futures.Fee = decimal.NewFromFloat(fee)
 			futures.PrecisionPrice = precisionPrice
 			futures.PrecisionQuantity = precisionQuantity
 			futures.MarginMax = decimal.NewFromFloat(marginMax)
 			futures.MarginAdd = decimal.NewFromFloat(marginAdd)
 			futures.PrepareProcess = center.PrepareFuturesMatcher
+			futures.CheckProcess = center.checkRiskLimit
 			center.AddMatcher(symbol, futures)
 			xlog.Infof("Bootstrap register futures matcher by symbol %v", symbol)
 		} else {
@@ -105,22 +156,48 @@ func BootstrapMatcherCenterByConfig(config *xprop.Config) (center *MatcherCenter
 			break
 		}
 	}
+	if err != nil {
+		return
+	}
+	for _, sec := range config.Seces {
+		if !strings.HasPrefix(sec, "matcher_limit.") {
+			continue
+		}
+		var symbol, tier string
+		var maxOpenOrders int64
+		var maxOrderNotional, maxPositionNotional float64
+		err = config.ValidFormat(
+			strings.ReplaceAll(`
+				_S/symbol,r|s,l:0;
+				_S/tier,r|s,l:0;
+				_S/max_open_orders,o|i,r:0;
+				_S/max_order_notional,o|f,r:0;
+				_S/max_position_notional,o|f,r:0;
+			`, "_S", sec),
+			&symbol, &tier, &maxOpenOrders, &maxOrderNotional, &maxPositionNotional,
+		)
+		if err != nil {
+			break
+		}
+		center.AddLimit(symbol, tier, &RiskLimit{
+			MaxOpenOrders:       maxOpenOrders,
+			MaxOrderNotional:    decimal.NewFromFloat(maxOrderNotional),
+			MaxPositionNotional: decimal.NewFromFloat(maxPositionNotional),
+		})
+		center.UserTier = gexdb.FindUserTier
+		xlog.Infof("Bootstrap register %v risk limit by tier %v", symbol, tier)
+	}
 	return
 }
 
 func (m *MatcherCenter) Start() {
-	for i := 0; i < m.eventRun; i++ {
-		m.waiter.Add(1)
-		go m.loopMatcherEvent()
-	}
+	m.startEventQueue()
 	m.waiter.Add(1)
 	go m.loopTriggerOrder(m.TriggerDelay)
 }
 
 func (m *MatcherCenter) Stop() {
-	for i := 0; i < m.eventRun; i++ {
-		m.exiter <- 0
-	}
+	m.stopEventQueue()
 	m.exiter <- 0
 	m.waiter.Wait()
 }
@@ -139,9 +216,46 @@ func (m *MatcherCenter) FindMatcher(symbol string) (matcher Matcher) {
 	return
 }
 
+//FindSymbol will return the symbol config by symbol, nil is returned when symbol is not supported
+func (m *MatcherCenter) FindSymbol(symbol string) (info *SymbolInfo) {
+	switch matcher := m.FindMatcher(symbol).(type) {
+	case *SpotMatcher:
+		info = &SymbolInfo{
+			Symbol:            matcher.Symbol,
+			Type:              "spot",
+			Base:              matcher.Base,
+			Quote:             matcher.Quote,
+			Fee:               matcher.Fee,
+			PrecisionQuantity: matcher.PrecisionQuantity,
+			PrecisionPrice:    matcher.PrecisionPrice,
+		}
+	case *FuturesMatcher:
+		info = &SymbolInfo{
+			Symbol:            matcher.Symbol,
+			Type:              "futures",
+			Base:              matcher.Base,
+			Quote:             matcher.Quote,
+			Fee:               matcher.Fee,
+			PrecisionQuantity: matcher.PrecisionQuantity,
+			PrecisionPrice:    matcher.PrecisionPrice,
+		}
+	}
+	return
+}
+
+//ListSymbol will return all supported symbol config by register order
+func (m *MatcherCenter) ListSymbol() (infos []*SymbolInfo) {
+	for _, symbol := range m.Symbols {
+		if info := m.FindSymbol(symbol); info != nil {
+			infos = append(infos, info)
+		}
+	}
+	return
+}
+
 func (m *MatcherCenter) AddMonitor(symbol string, monitor MatcherMonitor) {
-	m.matcherLock.Lock()
-	defer m.matcherLock.Unlock()
+	m.monitorLock.Lock()
+	defer m.monitorLock.Unlock()
 	key := fmt.Sprintf("%p", monitor)
 	if m.monitorAll[symbol] == nil {
 		m.monitorAll[symbol] = map[string]MatcherMonitor{}
@@ -150,55 +264,14 @@ func (m *MatcherCenter) AddMonitor(symbol string, monitor MatcherMonitor) {
 }
 
 func (m *MatcherCenter) RemoveMonitor(symbol string, monitor MatcherMonitor) {
-	m.matcherLock.Lock()
-	defer m.matcherLock.Unlock()
+	m.monitorLock.Lock()
+	defer m.monitorLock.Unlock()
 	key := fmt.Sprintf("%p", monitor)
 	if m.monitorAll[symbol] != nil {
 		delete(m.monitorAll[symbol], key)
 	}
 }
 
-func (m *MatcherCenter) OnMatched(ctx context.Context, event *MatcherEvent) {
-	select {
-	case m.eventQueue <- event:
-	default:
-		xlog.Warnf("MatcherCenter matcher event queue is full, skip one for %v", event.Symbol)
-	}
-}
-
-func (m *MatcherCenter) loopMatcherEvent() {
-	defer m.waiter.Done()
-	xlog.Infof("MatcherCenter matcher event running is starting")
-	running := true
-	for running {
-		select {
-		case <-m.exiter:
-			running = false
-		case event := <-m.eventQueue:
-			m.procMatcherEvent(event)
-		}
-	}
-	xlog.Infof("MatcherCenter matcher event running is stopped")
-}
-
-func (m *MatcherCenter) procMatcherEvent(event *MatcherEvent) {
-	m.monitorLock.RLock()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer func() {
-		if rerr := recover(); rerr != nil {
-			xlog.Errorf("MatcherCenter proc matcher event is panic with %v, call stack is \n%v", rerr, debug.CallStatck())
-		}
-		cancel()
-		m.monitorLock.RUnlock()
-	}()
-	for _, monitor := range m.monitorAll[event.Symbol] {
-		monitor.OnMatched(ctx, event)
-	}
-	for _, monitor := range m.monitorAll["*"] {
-		monitor.OnMatched(ctx, event)
-	}
-}
-
 func (m *MatcherCenter) loopTriggerOrder(delay time.Duration) {
 	defer m.waiter.Done()
 	ticker := time.NewTicker(delay)
@@ -210,6 +283,8 @@ func (m *MatcherCenter) loopTriggerOrder(delay time.Duration) {
 			running = false
 		case <-ticker.C:
 			m.procTriggerOrder()
+			m.procClearOutbox()
+			m.procReconcileBalance()
 		}
 	}
 	xlog.Infof("MatcherCenter order trigger is stopped")
@@ -299,6 +374,10 @@ func (m *MatcherCenter) touchBalance(key string) {
 }
 
 func (m *MatcherCenter) PrepareSpotMatcher(ctx context.Context, matcher *SpotMatcher, userID int64) (err error) {
+	err = m.checkFrozen(ctx, userID)
+	if err != nil {
+		return
+	}
 	m.cacheLock.Lock()
 	defer m.cacheLock.Unlock()
 	keyQuote := fmt.Sprintf("%v-%v-%v", matcher.Area, matcher.Quote, userID)
@@ -314,6 +393,10 @@ func (m *MatcherCenter) PrepareSpotMatcher(ctx context.Context, matcher *SpotMat
 }
 
 func (m *MatcherCenter) PrepareFuturesMatcher(ctx context.Context, matcher *FuturesMatcher, userID int64) (err error) {
+	err = m.checkFrozen(ctx, userID)
+	if err != nil {
+		return
+	}
 	m.cacheLock.Lock()
 	defer m.cacheLock.Unlock()
 	keyQuote := fmt.Sprintf("%v-%v-%v", matcher.Area, matcher.Quote, userID)
@@ -374,6 +457,11 @@ func (m *MatcherCenter) ProcessOrder(ctx context.Context, args *gexdb.Order) (or
 		return
 	}
 	if args.TID < 1 && args.Type == gexdb.OrderTypeTrigger {
+		err = m.checkRiskLimit(gexdb.Pool(), ctx, args, matcher.Depth(1))
+		if err != nil {
+			err = NewErrMatcher(err, "[ProcessOrder] check risk limit by user %v", args.UserID)
+			return
+		}
 		if args.UserID <= 0 || args.Quantity.Sign() <= 0 || args.TriggerPrice.Sign() <= 0 {
 			err = fmt.Errorf("process trigger userID/quantity/trigger_price is required or too small")
 			err = NewErrMatcher(err, "[ProcessOrder] args invalid")
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
 	"github.com/codingeasygo/util/xmap"
 	"github.com/codingeasygo/util/xprop"
+	"github.com/codingeasygo/util/xsql"
 	"github.com/gexservice/gexservice/gexdb"
 	"github.com/shopspring/decimal"
 )
@@ -44,9 +46,14 @@ func TestMatcherCenter(t *testing.T) {
 		t.Error(err)
 		return
 	}
+	symbols := center.ListSymbol()
+	if len(symbols) != 2 || symbols[0].Type != "spot" || symbols[1].Type != "futures" || symbols[1].Base != "YWE" || center.FindSymbol("none") != nil {
+		t.Error(converter.JSON(symbols))
+		return
+	}
 	center.Start()
 	center.TriggerDelay = 10 * time.Millisecond
-	center.eventQueue = make(chan *MatcherEvent, 1)
+	center.EventMax = 1
 	eventWaiter := make(chan int, 1)
 	monitor := MatcherMonitorF(func(ctx context.Context, event *MatcherEvent) {
 		select {
@@ -525,3 +532,139 @@ fee=0.002
 		}
 	}
 }
+
+func TestMatcherCenterRiskLimit(t *testing.T) {
+	clear()
+	config := xprop.NewConfig()
+	config.LoadPropString(`
+[matcher.FUTURES_YWEUSDT]
+on=1
+symbol=futures.YWEUSDT
+base=YWE
+quote=USDT
+fee=0.002
+max_open_orders=1
+max_order_notional=1000
+max_position_notional=150
+
+[matcher_limit.FUTURES_YWEUSDT_VIP]
+symbol=futures.YWEUSDT
+tier=vip
+max_open_orders=2
+max_order_notional=10000
+	`)
+	center, err := BootstrapMatcherCenterByConfig(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	env := testFuturesInit(100)
+	symbol := "futures.YWEUSDT"
+	//market on empty book
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Buyer.TID, Symbol: symbol, Side: gexdb.OrderSideBuy, Quantity: decimal.NewFromFloat(100)})
+	if RiskLimitCode(err) != gexdb.CodeOrderNotionalLimit {
+		t.Error(err)
+		return
+	}
+	//open order limit
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Seller.TID, Symbol: symbol, Side: gexdb.OrderSideSell, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(100)})
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Seller.TID, Symbol: symbol, Side: gexdb.OrderSideSell, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(110)})
+	if RiskLimitCode(err) != gexdb.CodeOpenOrderLimit {
+		t.Error(err)
+		return
+	}
+	//order notional limit
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Buyer.TID, Symbol: symbol, Side: gexdb.OrderSideBuy, Quantity: decimal.NewFromFloat(20), Price: decimal.NewFromFloat(100)})
+	if RiskLimitCode(err) != gexdb.CodeOrderNotionalLimit {
+		t.Error(err)
+		return
+	}
+	//position limit
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Buyer.TID, Symbol: symbol, Side: gexdb.OrderSideBuy, Quantity: decimal.NewFromFloat(2), Price: decimal.NewFromFloat(100)})
+	if RiskLimitCode(err) != gexdb.CodePositionLimit {
+		t.Error(err)
+		return
+	}
+	//tier limit
+	_, _, err = gexdb.Pool().Exec(ctx, `update exs_user set external=$1 where tid=$2`, xsql.M{"tier": "vip"}, env.Seller.TID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Seller.TID, Symbol: symbol, Side: gexdb.OrderSideSell, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(110)})
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Seller.TID, Symbol: symbol, Side: gexdb.OrderSideSell, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(120)})
+	if RiskLimitCode(err) != gexdb.CodeOpenOrderLimit {
+		t.Error(err)
+		return
+	}
+	if limit := center.FindLimit(symbol, "vip"); limit.MaxOpenOrders != 2 || !limit.MaxPositionNotional.Equal(decimal.NewFromFloat(150)) {
+		t.Errorf("%v", converter.JSON(limit))
+		return
+	}
+	if RiskLimitCode(fmt.Errorf("xx")) != 0 {
+		t.Error("error")
+		return
+	}
+	//error
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerSetCall("Pool.Query", 1).ShouldError(t).Call(func(trigger int) (res xmap.M, err error) {
+		_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Seller.TID, Symbol: symbol, Side: gexdb.OrderSideSell, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(120)})
+		return
+	})
+	center.UserTier = nil
+	pgx.MockerSetCall("Tx.Query", 1, "Tx.Query", 2).ShouldError(t).Call(func(trigger int) (res xmap.M, err error) {
+		_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Buyer.TID, Symbol: symbol, Side: gexdb.OrderSideBuy, Quantity: decimal.NewFromFloat(0.5), Price: decimal.NewFromFloat(100)})
+		return
+	})
+}
+
+func TestMatcherCenterDispatch(t *testing.T) {
+	center := NewMatcherCenter(1, 100)
+	center.EventWait = 10 * time.Millisecond
+	center.Start()
+	defer center.Stop()
+	slowWaiter := make(chan int)
+	futuresWaiter := make(chan int, 10)
+	spotWaiter := make(chan int, 10)
+	center.AddMonitor("futures.YWEUSDT", MatcherMonitorF(func(ctx context.Context, event *MatcherEvent) {
+		<-slowWaiter
+		futuresWaiter <- 1
+	}))
+	center.AddMonitor("spot.YWEUSDT", MatcherMonitorF(func(ctx context.Context, event *MatcherEvent) {
+		spotWaiter <- 1
+	}))
+	center.OnMatched(ctx, &MatcherEvent{Symbol: "futures.YWEUSDT"})
+	center.OnMatched(ctx, &MatcherEvent{Symbol: "futures.YWEUSDT"})
+	center.OnMatched(ctx, &MatcherEvent{Symbol: "futures.YWEUSDT"})
+	center.OnMatched(ctx, &MatcherEvent{Symbol: "spot.YWEUSDT"})
+	select {
+	case <-spotWaiter:
+	case <-time.After(time.Second):
+		t.Error("spot event is blocked")
+		return
+	}
+	metrics := center.EventMetrics()
+	if len(metrics) != 2 || metrics[0].Symbol != "futures.YWEUSDT" || metrics[0].Received != 3 || metrics[0].Waited < 1 || metrics[0].Spilled < 1 || metrics[1].Processed != 1 {
+		t.Errorf("%v", converter.JSON(metrics))
+		return
+	}
+	close(slowWaiter)
+	//spilled event is not dropped
+	for i := 0; i < 3; i++ {
+		select {
+		case <-futuresWaiter:
+		case <-time.After(time.Second):
+			t.Error("futures event is lost")
+			return
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package matcher
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codingeasygo/util/debug"
+	"github.com/gexservice/gexservice/base/xlog"
+)
+
+//EventQueue is the matcher event queue of one symbol, it is processed by own worker to keep event order and isolate slow monitor of other symbol,
+//the event is spilled to memory list after wait timeout when queue is full, so it is never dropped
+type EventQueue struct {
+	Symbol    string
+	queue     chan *MatcherEvent
+	spill     []*MatcherEvent
+	spillLock sync.Mutex
+	spilling  chan int
+	received  int64
+	processed int64
+	waited    int64
+	waitCost  int64
+	spilled   int64
+	costLast  int64
+	costMax   int64
+	exiter    chan int
+}
+
+//EventMetric is the metric of matcher event queue by symbol, the wait cost is total milliseconds of waiting on full queue
+type EventMetric struct {
+	Symbol    string `json:"symbol"`
+	Pending   int    `json:"pending"`
+	Capacity  int    `json:"capacity"`
+	Received  int64  `json:"received"`
+	Processed int64  `json:"processed"`
+	Waited    int64  `json:"waited"`
+	WaitCost  int64  `json:"wait_cost"`
+	Spilled   int64  `json:"spilled"`
+	CostLast  int64  `json:"cost_last"`
+	CostMax   int64  `json:"cost_max"`
+}
+
+func (m *MatcherCenter) findEventQueue(symbol string) (queue *EventQueue) {
+	m.eventLock.RLock()
+	queue = m.eventAll[symbol]
+	m.eventLock.RUnlock()
+	if queue != nil {
+		return
+	}
+	m.eventLock.Lock()
+	defer m.eventLock.Unlock()
+	queue = m.eventAll[symbol]
+	if queue != nil {
+		return
+	}
+	queue = &EventQueue{
+		Symbol:    symbol,
+		queue:     make(chan *MatcherEvent, m.EventMax),
+		spillLock: sync.Mutex{},
+		spilling:  make(chan int, 1),
+		exiter:    make(chan int, 1),
+	}
+	m.eventAll[symbol] = queue
+	if m.eventStarted {
+		m.waiter.Add(1)
+		go m.loopMatcherEvent(queue)
+	}
+	return
+}
+
+func (m *MatcherCenter) startEventQueue() {
+	m.eventLock.Lock()
+	defer m.eventLock.Unlock()
+	m.eventStarted = true
+	for _, queue := range m.eventAll {
+		m.waiter.Add(1)
+		go m.loopMatcherEvent(queue)
+	}
+}
+
+func (m *MatcherCenter) stopEventQueue() {
+	m.eventLock.Lock()
+	defer m.eventLock.Unlock()
+	m.eventStarted = false
+	for _, queue := range m.eventAll {
+		queue.exiter <- 0
+	}
+}
+
+//OnMatched will dispatch event to queue by symbol, it is called in matcher book lock, so it will wait at most EventWait when queue is full
+//to backpressure the matcher, and spill event to memory list after wait timeout, the spilled event is processed in order after queue
+func (m *MatcherCenter) OnMatched(ctx context.Context, event *MatcherEvent) {
+	queue := m.findEventQueue(event.Symbol)
+	atomic.AddInt64(&queue.received, 1)
+	queue.spillLock.Lock()
+	defer queue.spillLock.Unlock()
+	if len(queue.spill) < 1 {
+		select {
+		case queue.queue <- event:
+			return
+		default:
+		}
+		atomic.AddInt64(&queue.waited, 1)
+		startTime := time.Now()
+		waiter := time.NewTimer(m.EventWait)
+		defer waiter.Stop()
+		select {
+		case queue.queue <- event:
+			atomic.AddInt64(&queue.waitCost, time.Since(startTime).Milliseconds())
+			return
+		case <-waiter.C:
+			atomic.AddInt64(&queue.waitCost, time.Since(startTime).Milliseconds())
+		}
+		xlog.Warnf("MatcherCenter matcher event queue is full after wait %v, spill event for %v", m.EventWait, event.Symbol)
+	}
+	queue.spill = append(queue.spill, event)
+	atomic.AddInt64(&queue.spilled, 1)
+	select {
+	case queue.spilling <- 1:
+	default:
+	}
+}
+
+//EventMetrics will return the metric of all event queue
+func (m *MatcherCenter) EventMetrics() (metrics []*EventMetric) {
+	m.eventLock.RLock()
+	defer m.eventLock.RUnlock()
+	for _, queue := range m.eventAll {
+		queue.spillLock.Lock()
+		spill := len(queue.spill)
+		queue.spillLock.Unlock()
+		metrics = append(metrics, &EventMetric{
+			Symbol:    queue.Symbol,
+			Pending:   len(queue.queue) + spill,
+			Capacity:  cap(queue.queue),
+			Received:  atomic.LoadInt64(&queue.received),
+			Processed: atomic.LoadInt64(&queue.processed),
+			Waited:    atomic.LoadInt64(&queue.waited),
+			WaitCost:  atomic.LoadInt64(&queue.waitCost),
+			Spilled:   atomic.LoadInt64(&queue.spilled),
+			CostLast:  atomic.LoadInt64(&queue.costLast),
+			CostMax:   atomic.LoadInt64(&queue.costMax),
+		})
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Symbol < metrics[j].Symbol
+	})
+	return
+}
+
+func (m *MatcherCenter) loopMatcherEvent(queue *EventQueue) {
+	defer m.waiter.Done()
+	xlog.Infof("MatcherCenter matcher event running on %v is starting", queue.Symbol)
+	running := true
+	for running {
+		select {
+		case <-queue.exiter:
+			running = false
+		case event := <-queue.queue:
+			m.procQueueEvent(queue, event)
+		case <-queue.spilling:
+		}
+		if !running || len(queue.queue) > 0 {
+			continue
+		}
+		//the spilled event is after all event in queue, so it is processed when queue is empty
+		queue.spillLock.Lock()
+		spill := queue.spill
+		queue.spill = nil
+		queue.spillLock.Unlock()
+		for _, event := range spill {
+			m.procQueueEvent(queue, event)
+		}
+	}
+	xlog.Infof("MatcherCenter matcher event running on %v is stopped", queue.Symbol)
+}
+
+func (m *MatcherCenter) procQueueEvent(queue *EventQueue, event *MatcherEvent) {
+	startTime := time.Now()
+	m.procMatcherEvent(event)
+	cost := time.Since(startTime).Milliseconds()
+	atomic.AddInt64(&queue.processed, 1)
+	atomic.StoreInt64(&queue.costLast, cost)
+	if cost > atomic.LoadInt64(&queue.costMax) {
+		atomic.StoreInt64(&queue.costMax, cost)
+	}
+}
+
+func (m *MatcherCenter) procMatcherEvent(event *MatcherEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer func() {
+		if rerr := recover(); rerr != nil {
+			xlog.Errorf("MatcherCenter proc matcher event is panic with %v, call stack is \n%v", rerr, debug.CallStatck())
+		}
+		cancel()
+	}()
+	monitors := []MatcherMonitor{}
+	m.monitorLock.RLock()
+	for _, monitor := range m.monitorAll[event.Symbol] {
+		monitors = append(monitors, monitor)
+	}
+	for _, monitor := range m.monitorAll["*"] {
+		monitors = append(monitors, monitor)
+	}
+	m.monitorLock.RUnlock()
+	for _, monitor := range monitors {
+		monitor.OnMatched(ctx, event)
+	}
+}
@@ -37,12 +37,14 @@ type FuturesMatcher struct {
 	PrecisionPrice    int32
 	Area              gexdb.BalanceArea
 	Symbol            string
+	Base              string
 	Quote             string
 	Fee               decimal.Decimal
 	MarginMax         decimal.Decimal
 	MarginAdd         decimal.Decimal
 	NewOrderID        func() string
 	PrepareProcess    func(ctx context.Context, matcher *FuturesMatcher, userID int64) error
+	CheckProcess      func(caller crud.Queryer, ctx context.Context, args *gexdb.Order, depth *orderbook.Depth) error
 	Monitor           MatcherMonitor
 	bookUser          map[int64]map[int64]int
 	bookVal           *orderbook.OrderBook
@@ -250,29 +252,36 @@ func (f *FuturesMatcher) processCancelOrder(ctx context.Context, args *gexdb.Ord
 			xlog.Errorf("FuturesMatcher process cancel by %v,%v is panic with %v,\n%v", args.UserID, args.OrderID, rerr, debug.CallStatck())
 			err = fmt.Errorf("%v", rerr)
 		}
+		changed.AddOrder(order)
+		changed.AddMatched(nil, nil, cancelOrder)
 		if tx != nil {
+			if err == nil {
+				changed.Depth = f.bookVal.Depth(30)
+				err = storeMatched(tx, ctx, f.Monitor, changed)
+			}
 			if err == nil {
 				err = tx.Commit(ctx)
 			} else {
 				tx.Rollback(ctx)
 			}
+			if err == nil {
+				commitMatched(f.Monitor, changed)
+			}
 		}
 		if err != nil && rollback != nil {
 			rollback()
 		}
-		changed.AddOrder(order)
-		changed.AddMatched(nil, nil, cancelOrder)
 		if err == nil {
 			f.syncUserOrder(changed)
 		}
 		cancel()
 		changed.Depth = f.bookVal.Depth(30)
-		f.bookLock.Unlock()
 
-		//monitor
+		//monitor under book lock to keep event order
 		if err == nil && f.Monitor != nil {
 			f.Monitor.OnMatched(ctx, changed)
 		}
+		f.bookLock.Unlock()
 	}()
 
 	tx, err = gexdb.Pool().Begin(ctx)
@@ -351,12 +360,22 @@ func (f *FuturesMatcher) processMarketOrder(ctx context.Context, args *gexdb.Ord
 			xlog.Errorf("FuturesMatcher process market by %v,%v,%v,%v is panic with %v,\n%v", args.UserID, args.Side, args.TotalPrice, args.Quantity, rerr, debug.CallStatck())
 			err = fmt.Errorf("%v", rerr)
 		}
+		changed.AddOrder(order)
+		changed.AddMatched(doneOrder, partOrder, nil)
+		changed.AddTrade(order, doneOrder, partOrder, partFilled)
 		if tx != nil {
+			if err == nil {
+				changed.Depth = f.bookVal.Depth(30)
+				err = storeMatched(tx, ctx, f.Monitor, changed)
+			}
 			if err == nil {
 				err = tx.Commit(ctx)
 			} else {
 				tx.Rollback(ctx)
 			}
+			if err == nil {
+				commitMatched(f.Monitor, changed)
+			}
 			if err != nil && order != nil && order.Status == 0 {
 				order.Status = gexdb.OrderStatusCanceled
 			}
@@ -364,19 +383,17 @@ func (f *FuturesMatcher) processMarketOrder(ctx context.Context, args *gexdb.Ord
 		if err != nil && rollback != nil {
 			rollback()
 		}
-		changed.AddOrder(order)
-		changed.AddMatched(doneOrder, partOrder, nil)
 		if err == nil {
 			f.syncUserOrder(changed)
 		}
 		cancel()
 		changed.Depth = f.bookVal.Depth(30)
-		f.bookLock.Unlock()
 
-		//monitor
+		//monitor under book lock to keep event order
 		if err == nil && f.Monitor != nil {
 			f.Monitor.OnMatched(ctx, changed)
 		}
+		f.bookLock.Unlock()
 	}()
 
 	tx, err = gexdb.Pool().Begin(ctx)
@@ -384,6 +401,15 @@ func (f *FuturesMatcher) processMarketOrder(ctx context.Context, args *gexdb.Ord
 		err = NewErrMatcher(err, "[ProcessMarket] begin tx fail")
 		return
 	}
+
+	//check risk under book lock
+	if args.TID < 1 && f.CheckProcess != nil {
+		err = f.CheckProcess(tx, ctx, args, f.bookVal.Depth(1))
+		if err != nil {
+			err = NewErrMatcher(err, "[ProcessMarket] check process by user %v", args.UserID)
+			return
+		}
+	}
 	startDepth := f.bookVal.Depth(1)
 
 	//process order
@@ -530,12 +556,22 @@ func (f *FuturesMatcher) processLimitOrder(ctx context.Context, args *gexdb.Orde
 			xlog.Errorf("FuturesMatcher process limit by %v,%v,%v,%v is panic with %v,\n%v", args.UserID, args.Side, args.Quantity, args.Price, rerr, debug.CallStatck())
 			err = fmt.Errorf("%v", rerr)
 		}
+		changed.AddOrder(order)
+		changed.AddMatched(doneOrder, partOrder, nil)
+		changed.AddTrade(order, doneOrder, partOrder, partFilled)
 		if tx != nil {
+			if err == nil {
+				changed.Depth = f.bookVal.Depth(30)
+				err = storeMatched(tx, ctx, f.Monitor, changed)
+			}
 			if err == nil {
 				err = tx.Commit(ctx)
 			} else {
 				tx.Rollback(ctx)
 			}
+			if err == nil {
+				commitMatched(f.Monitor, changed)
+			}
 			if err != nil && order != nil && order.Status == 0 {
 				order.Status = gexdb.OrderStatusCanceled
 			}
@@ -543,19 +579,17 @@ func (f *FuturesMatcher) processLimitOrder(ctx context.Context, args *gexdb.Orde
 		if err != nil && rollback != nil {
 			rollback()
 		}
-		changed.AddOrder(order)
-		changed.AddMatched(doneOrder, partOrder, nil)
 		if err == nil {
 			f.syncUserOrder(changed)
 		}
 		cancel()
 		changed.Depth = f.bookVal.Depth(30)
-		f.bookLock.Unlock()
 
-		//montiro
+		//monitor under book lock to keep event order
 		if err == nil && f.Monitor != nil {
 			f.Monitor.OnMatched(ctx, changed)
 		}
+		f.bookLock.Unlock()
 	}()
 
 	tx, err = gexdb.Pool().Begin(ctx)
@@ -563,6 +597,15 @@ func (f *FuturesMatcher) processLimitOrder(ctx context.Context, args *gexdb.Orde
 		err = NewErrMatcher(err, "[ProcessLimit] begin tx")
 		return
 	}
+
+	//check risk under book lock
+	if args.TID < 1 && f.CheckProcess != nil {
+		err = f.CheckProcess(tx, ctx, args, f.bookVal.Depth(1))
+		if err != nil {
+			err = NewErrMatcher(err, "[ProcessLimit] check process by user %v", args.UserID)
+			return
+		}
+	}
 	startDepth := f.bookVal.Depth(1)
 
 	//process order
@@ -773,7 +816,7 @@ func (f *FuturesMatcher) freeBlowup(tx *pgx.Tx, ctx context.Context, changed *Ma
 			Locked: decimal.Zero.Sub(marginFree),
 			Margin: decimal.Zero.Sub(marginFree),
 		}
-		err = gexdb.IncreaseBalanceCall(tx, ctx, balance)
+		err = gexdb.IncreaseBalanceRecordCall(tx, ctx, balance, gexdb.BalanceRecordTypeMargin, fmt.Sprintf("%v", holding.TID))
 		if err != nil {
 			err = NewErrMatcher(err, "[freeBlowup] free margin by %v fail", converter.JSON(balance))
 			return
@@ -831,7 +874,7 @@ func (f *FuturesMatcher) blowupHolding(tx *pgx.Tx, ctx context.Context, changed
 			Locked: marginAdd,
 			Margin: marginAdd,
 		}
-		err = gexdb.IncreaseBalanceCall(tx, ctx, balance)
+		err = gexdb.IncreaseBalanceRecordCall(tx, ctx, balance, gexdb.BalanceRecordTypeMargin, fmt.Sprintf("%v", holding.TID))
 		if err != nil {
 			err = NewErrMatcher(err, "[blowupHolding] add margin by %v fail", converter.JSON(balance))
 			return
@@ -917,7 +960,7 @@ func (f *FuturesMatcher) blowupHolding(tx *pgx.Tx, ctx context.Context, changed
 		Locked: decimal.Zero.Sub(marginClear),
 		Margin: decimal.Zero.Sub(marginClear),
 	}
-	err = gexdb.IncreaseBalanceCall(tx, ctx, balance)
+	err = gexdb.IncreaseBalanceRecordCall(tx, ctx, balance, gexdb.BalanceRecordTypeBlowup, order.OrderID)
 	if err != nil {
 		err = NewErrMatcher(err, "[blowupHolding] blowup balance by %v fail", converter.JSON(balance))
 		return
@@ -944,6 +987,7 @@ func (f *FuturesMatcher) blowupHolding(tx *pgx.Tx, ctx context.Context, changed
 	}
 	changed.AddOrder(order)
 	changed.AddMatched(doneOrder, partOrder, nil)
+	changed.AddTrade(order, doneOrder, partOrder, partFilled)
 	return
 }
 
@@ -988,6 +1032,7 @@ func (f *FuturesMatcher) doneBookOrder(tx *pgx.Tx, ctx context.Context, changed
 			Filled:     bookOrder.Quantity(),
 			Price:      order.Price,
 			TotalPrice: order.Price.Mul(bookOrder.Quantity()),
+			Maker:      true,
 			FeeBalance: f.Quote,
 			FeeFilled:  order.Price.Mul(bookOrder.Quantity()).Mul(f.Fee),
 			CreateTime: xsql.TimeNow(),
@@ -1025,6 +1070,7 @@ func (f *FuturesMatcher) partBookOrder(tx *pgx.Tx, ctx context.Context, changed
 		Filled:     partDone,
 		Price:      order.Price,
 		TotalPrice: order.Price.Mul(partDone),
+		Maker:      true,
 		FeeBalance: f.Quote,
 		FeeFilled:  order.Price.Mul(partDone).Mul(f.Fee),
 		CreateTime: xsql.TimeNow(),
@@ -1081,7 +1127,7 @@ func (f *FuturesMatcher) syncBalanceByOrderAdd(tx *pgx.Tx, ctx context.Context,
 		Locked: newLocked.Sub(oldLocked),
 		Free:   oldLocked.Sub(newLocked),
 	}
-	err = gexdb.IncreaseBalanceCall(tx, ctx, balance)
+	err = gexdb.IncreaseBalanceRecordCall(tx, ctx, balance, gexdb.BalanceRecordTypeTradeLock, order.OrderID)
 	if err != nil {
 		err = NewErrMatcher(err, "[syncBalanceByOrderChanged] change balance %v fail", converter.JSON(balance))
 		return
@@ -1118,7 +1164,7 @@ func (f *FuturesMatcher) syncBalanceByOrderCancel(tx *pgx.Tx, ctx context.Contex
 		Locked: newLocked.Sub(oldLocked),
 		Free:   oldLocked.Sub(newLocked),
 	}
-	err = gexdb.IncreaseBalanceCall(tx, ctx, balance)
+	err = gexdb.IncreaseBalanceRecordCall(tx, ctx, balance, gexdb.BalanceRecordTypeTrade, order.OrderID)
 	if err != nil {
 		err = NewErrMatcher(err, "[syncBalanceByOrderCancel] change balance %v fail", converter.JSON(balance))
 		return
@@ -1234,17 +1280,29 @@ func (f *FuturesMatcher) syncHoldingByPartDone(tx *pgx.Tx, ctx context.Context,
 		balance.Margin = balance.Margin.Add(marginOpen)
 	}
 	fee := partHolding.Abs().Mul(order.AvgPrice).Mul(f.Fee)
-	balance.Locked = balance.Locked.Sub(fee)
 	holding.MarginUsed = holding.CalcMargin(f.PrecisionPrice)
 	holding.Blowup = holding.CalcBlowup(f.PrecisionPrice, f.MarginMax)
 	if holding.Amount.Sign() == 0 {
 		holding.Open = decimal.Zero
 	}
-	err = gexdb.IncreaseBalanceCall(tx, ctx, balance)
+	err = gexdb.IncreaseBalanceRecordCall(tx, ctx, balance, gexdb.BalanceRecordTypeTrade, order.OrderID)
 	if err != nil {
 		err = NewErrMatcher(err, "[syncHoldingByPartDone] change balance %v fail", converter.JSON(balance))
 		return
 	}
+	if !fee.IsZero() {
+		balance = &gexdb.Balance{
+			UserID: order.UserID,
+			Area:   f.Area,
+			Asset:  f.Quote,
+			Locked: decimal.Zero.Sub(fee),
+		}
+		err = gexdb.IncreaseBalanceRecordCall(tx, ctx, balance, gexdb.BalanceRecordTypeFee, order.OrderID)
+		if err != nil {
+			err = NewErrMatcher(err, "[syncHoldingByPartDone] change fee balance %v fail", converter.JSON(balance))
+			return
+		}
+	}
 	err = holding.UpdateFilter(tx, ctx, "amount,open,margin_used,blowup#all")
 	if err != nil {
 		err = NewErrMatcher(err, "[syncHoldingByPartDone] change holding %v fail", converter.JSON(holding))
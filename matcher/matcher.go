@@ -6,12 +6,14 @@ import (
 
 	"github.com/centny/orderbook"
 	"github.com/codingeasygo/util/xprop"
+	"github.com/codingeasygo/util/xsql"
 	"github.com/gexservice/gexservice/gexdb"
 	"github.com/shopspring/decimal"
 )
 
 type MatcherEvent struct {
 	Symbol       string
+	Sequence     int64
 	Orders       []*gexdb.Order
 	DoneOrder    map[string]bool
 	PartOrder    map[string]bool
@@ -20,9 +22,20 @@ type MatcherEvent struct {
 	Holdings     map[string]*gexdb.Holding
 	Blowups      map[string]*gexdb.Holding
 	DoneOrderIDs map[int64][]int64
+	Trades       []*MatcherTrade
 	Depth        *orderbook.Depth
 }
 
+//MatcherTrade is the public trade of one match between taker order and book order
+type MatcherTrade struct {
+	Symbol   string          `json:"symbol"`
+	Sequence int64           `json:"sequence"`
+	Side     gexdb.OrderSide `json:"side"`
+	Price    decimal.Decimal `json:"price"`
+	Quantity decimal.Decimal `json:"quantity"`
+	Time     xsql.Time       `json:"time"`
+}
+
 type MatcherMonitor interface {
 	OnMatched(ctx context.Context, event *MatcherEvent)
 }
@@ -61,6 +74,20 @@ func (m *MatcherEvent) AddMatched(doneOrder []*orderbook.Order, partOrder, cance
 	}
 }
 
+//AddTrade will add public trade by taker order and matched book order, the trade price is book order price and the side is taker side
+func (m *MatcherEvent) AddTrade(taker *gexdb.Order, doneOrder []*orderbook.Order, partOrder *orderbook.Order, partFilled decimal.Decimal) {
+	now := xsql.TimeNow()
+	for _, order := range doneOrder {
+		if order.ID() == taker.OrderID {
+			continue
+		}
+		m.Trades = append(m.Trades, &MatcherTrade{Symbol: m.Symbol, Side: taker.Side, Price: order.Price(), Quantity: order.Quantity(), Time: now})
+	}
+	if partOrder != nil && partOrder.ID() != taker.OrderID && partFilled.IsPositive() {
+		m.Trades = append(m.Trades, &MatcherTrade{Symbol: m.Symbol, Side: taker.Side, Price: partOrder.Price(), Quantity: partFilled, Time: now})
+	}
+}
+
 func (m *MatcherEvent) AddBalance(balances ...*gexdb.Balance) {
 	for _, balance := range balances {
 		m.Balances[BalanceKey(balance)] = balance
@@ -93,12 +120,20 @@ type ErrNotCancelable string
 
 func (e ErrNotCancelable) Error() string { return string(e) }
 
+type ErrRiskLimit struct {
+	Code    int
+	Message string
+}
+
+func (e *ErrRiskLimit) Error() string { return e.Message }
+
 type ErrStackable interface {
 	error
 	Stack() string
 	IsBalanceNotEnought() bool
 	IsBalanceNotFound() bool
 	IsNotCancelable() bool
+	RiskLimitCode() int
 }
 
 type ErrMatcher struct {
@@ -145,6 +180,10 @@ func (e *ErrMatcher) IsNotCancelable() bool {
 	return IsErrNotCancelable(e.Base)
 }
 
+func (e *ErrMatcher) RiskLimitCode() int {
+	return RiskLimitCode(e.Base)
+}
+
 func ErrStack(err error) string {
 	if v, ok := err.(ErrStackable); ok {
 		return v.Stack()
@@ -180,6 +219,28 @@ func IsErrNotCancelable(err error) bool {
 	}
 }
 
+//RiskLimitCode will return the ExReturnCode when err is caused by risk limit, or 0
+func RiskLimitCode(err error) int {
+	if v, ok := err.(ErrStackable); ok {
+		return v.RiskLimitCode()
+	} else if v, ok := err.(*ErrRiskLimit); ok {
+		return v.Code
+	} else {
+		return 0
+	}
+}
+
+//SymbolInfo is the symbol config of matcher
+type SymbolInfo struct {
+	Symbol            string          `json:"symbol"`
+	Type              string          `json:"type"`
+	Base              string          `json:"base"`
+	Quote             string          `json:"quote"`
+	Fee               decimal.Decimal `json:"fee"`
+	PrecisionQuantity int32           `json:"precision_quantity"`
+	PrecisionPrice    int32           `json:"precision_price"`
+}
+
 type Matcher interface {
 	Bootstrap(ctx context.Context) (changed *MatcherEvent, err error)
 	ProcessCancel(ctx context.Context, userID int64, orderID string) (order *gexdb.Order, err error)
@@ -193,12 +254,21 @@ var Shared *MatcherCenter
 
 func Bootstrap(conf *xprop.Config) (err error) {
 	Shared, err = BootstrapMatcherCenterByConfig(conf)
+	if err == nil {
+		err = Shared.LoadFrozen(context.Background())
+	}
 	if err == nil {
 		Shared.Start()
 	}
 	return
 }
 
+//BootstrapEdge will only register symbol by config without starting, it is used on edge node which is not matching order
+func BootstrapEdge(conf *xprop.Config) (err error) {
+	Shared, err = BootstrapMatcherCenterByConfig(conf)
+	return
+}
+
 func ProcessCancel(ctx context.Context, userID int64, symbol string, orderID string) (order *gexdb.Order, err error) {
 	order, err = Shared.ProcessCancel(ctx, userID, symbol, orderID)
 	return
@@ -218,3 +288,23 @@ func ProcessOrder(ctx context.Context, args *gexdb.Order) (order *gexdb.Order, e
 	order, err = Shared.ProcessOrder(ctx, args)
 	return
 }
+
+func FindSymbol(symbol string) (info *SymbolInfo) {
+	info = Shared.FindSymbol(symbol)
+	return
+}
+
+func ListSymbol() (infos []*SymbolInfo) {
+	infos = Shared.ListSymbol()
+	return
+}
+
+func ReconcileBalance(ctx context.Context) (result *BalanceReconcile, err error) {
+	result, err = Shared.ReconcileBalance(ctx)
+	return
+}
+
+func LastReconcile() (result *BalanceReconcile) {
+	result = Shared.LastReconcile()
+	return
+}
@@ -144,6 +144,27 @@ func assetDepthEmpty(depth *orderbook.Depth) {
 	}
 }
 
+func TestMatcherEventTrade(t *testing.T) {
+	event := NewMatcherEvent("spot.YWEUSDT")
+	taker := &gexdb.Order{OrderID: "taker", Side: gexdb.OrderSideBuy}
+	doneOrder := []*orderbook.Order{
+		orderbook.NewOrder("maker1", orderbook.Sell, decimal.NewFromFloat(1), decimal.NewFromFloat(100), time.Now()),
+		orderbook.NewOrder("taker", orderbook.Buy, decimal.NewFromFloat(1.5), decimal.NewFromFloat(101), time.Now()),
+	}
+	partOrder := orderbook.NewOrder("maker2", orderbook.Sell, decimal.NewFromFloat(1), decimal.NewFromFloat(101), time.Now())
+	event.AddTrade(taker, doneOrder, partOrder, decimal.NewFromFloat(0.5))
+	if len(event.Trades) != 2 || !event.Trades[0].Price.Equal(decimal.NewFromFloat(100)) || !event.Trades[1].Quantity.Equal(decimal.NewFromFloat(0.5)) || event.Trades[1].Side != gexdb.OrderSideBuy {
+		t.Error("error")
+		return
+	}
+	event = NewMatcherEvent("spot.YWEUSDT")
+	event.AddTrade(taker, doneOrder[:1], orderbook.NewOrder("taker", orderbook.Buy, decimal.NewFromFloat(1), decimal.NewFromFloat(100), time.Now()), decimal.NewFromFloat(1))
+	if len(event.Trades) != 1 {
+		t.Error("error")
+		return
+	}
+}
+
 func TestErrMatcher(t *testing.T) {
 	err := fmt.Errorf("error")
 	notEnought := NewErrMatcher(gexdb.ErrBalanceNotEnought("Not Enought"), "abc")
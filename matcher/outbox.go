@@ -0,0 +1,253 @@
+package matcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/debug"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+)
+
+//MatcherEventStore is the monitor which will store matcher event in the same transaction of matched
+type MatcherEventStore interface {
+	StoreMatched(tx *pgx.Tx, ctx context.Context, event *MatcherEvent) (err error)
+	CommitMatched(event *MatcherEvent)
+}
+
+func storeMatched(tx *pgx.Tx, ctx context.Context, monitor MatcherMonitor, event *MatcherEvent) (err error) {
+	if store, ok := monitor.(MatcherEventStore); ok {
+		err = store.StoreMatched(tx, ctx, event)
+	}
+	return
+}
+
+func commitMatched(monitor MatcherMonitor, event *MatcherEvent) {
+	if store, ok := monitor.(MatcherEventStore); ok {
+		store.CommitMatched(event)
+	}
+}
+
+//StoreMatched will store matcher event to outbox and stamp event sequence, it is called in matcher lock before commit.
+//the sequence is kept in memory when outbox is off, so it will be restarted from 1 after matcher restart
+func (m *MatcherCenter) StoreMatched(tx *pgx.Tx, ctx context.Context, event *MatcherEvent) (err error) {
+	if !m.OutboxOn {
+		m.sequenceLock.Lock()
+		event.Sequence = m.sequenceAll[event.Symbol] + 1
+		m.sequenceLock.Unlock()
+		return
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	stored := xsql.M{}
+	err = json.Unmarshal(data, &stored)
+	if err != nil {
+		return
+	}
+	outbox, err := gexdb.AddOutboxEventCall(tx, ctx, event.Symbol, stored)
+	if err != nil {
+		err = NewErrMatcher(err, "[StoreMatched] add outbox event by %v fail", event.Symbol)
+		return
+	}
+	event.Sequence = outbox.Sequence
+	return
+}
+
+//CommitMatched will advance memory sequence after matched is committed, so the sequence is not skipped when commit fail
+func (m *MatcherCenter) CommitMatched(event *MatcherEvent) {
+	if m.OutboxOn {
+		return
+	}
+	m.sequenceLock.Lock()
+	if event.Sequence > m.sequenceAll[event.Symbol] {
+		m.sequenceAll[event.Symbol] = event.Sequence
+	}
+	m.sequenceLock.Unlock()
+}
+
+func (m *MatcherCenter) procClearOutbox() (err error) {
+	if !m.OutboxOn || m.OutboxKeep <= 0 || time.Since(m.outboxLast) < time.Hour {
+		return
+	}
+	m.outboxLast = time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	removed, err := gexdb.ClearOutbox(ctx, time.Now().Add(-m.OutboxKeep))
+	if err != nil {
+		xlog.Warnf("MatcherCenter clear outbox event fail with %v", err)
+		return
+	}
+	if removed > 0 {
+		xlog.Infof("MatcherCenter clear %v outbox event before %v", removed, m.OutboxKeep)
+	}
+	return
+}
+
+//ListOutboxTrade will list latest trade by symbol from stored outbox event, the newest trade is first.
+//it will scan at most 10*limit event, so it may return less than limit when most of event is not matched
+func ListOutboxTrade(ctx context.Context, symbol string, limit int) (trades []*MatcherTrade, err error) {
+	var before int64
+	for scan := 0; scan < 10 && len(trades) < limit; scan++ {
+		var outboxes []*gexdb.Outbox
+		outboxes, err = gexdb.ListOutboxEventLatest(ctx, symbol, before, limit)
+		if err != nil || len(outboxes) < 1 {
+			break
+		}
+		for _, outbox := range outboxes {
+			before = outbox.Sequence
+			event := &MatcherEvent{}
+			data, _ := json.Marshal(outbox.Event)
+			if xerr := json.Unmarshal(data, event); xerr != nil {
+				continue
+			}
+			for i := len(event.Trades) - 1; i >= 0 && len(trades) < limit; i-- {
+				trade := event.Trades[i]
+				trade.Sequence = outbox.Sequence
+				trades = append(trades, trade)
+			}
+		}
+		if len(outboxes) < limit {
+			break
+		}
+	}
+	return
+}
+
+//MatcherOutbox will deliver the stored matcher event to monitor and ack by consumer, it will catch up from last ack on restart.
+//the delivery is at-least-once, event after last ack may be delivered again after restart, so monitor must skip the applied sequence
+type MatcherOutbox struct {
+	Consumer  string
+	Symbols   []string
+	Monitor   MatcherMonitor
+	Limit     int
+	Delay     time.Duration
+	Timeout   time.Duration
+	notify    chan int
+	exiter    chan int
+	waiter    sync.WaitGroup
+	delivered map[string]int64 //the last delivered sequence, it is only accessed on outbox loop
+}
+
+func NewMatcherOutbox(consumer string, monitor MatcherMonitor, symbols ...string) (outbox *MatcherOutbox) {
+	outbox = &MatcherOutbox{
+		Consumer:  consumer,
+		Symbols:   symbols,
+		Monitor:   monitor,
+		Limit:     100,
+		Delay:     time.Second,
+		Timeout:   10 * time.Second,
+		notify:    make(chan int, 1),
+		exiter:    make(chan int, 1),
+		waiter:    sync.WaitGroup{},
+		delivered: map[string]int64{},
+	}
+	return
+}
+
+func (o *MatcherOutbox) Start() {
+	o.waiter.Add(1)
+	go o.loopOutbox()
+}
+
+func (o *MatcherOutbox) Stop() {
+	o.exiter <- 0
+	o.waiter.Wait()
+}
+
+//OnMatched will notify outbox to deliver new stored event
+func (o *MatcherOutbox) OnMatched(ctx context.Context, event *MatcherEvent) {
+	select {
+	case o.notify <- 1:
+	default:
+	}
+}
+
+func (o *MatcherOutbox) loopOutbox() {
+	defer o.waiter.Done()
+	ticker := time.NewTicker(o.Delay)
+	defer ticker.Stop()
+	running := true
+	xlog.Infof("MatcherOutbox(%v) is starting by %v ticker", o.Consumer, o.Delay)
+	o.procOutbox()
+	for running {
+		select {
+		case <-o.exiter:
+			running = false
+		case <-o.notify:
+			o.procOutbox()
+		case <-ticker.C:
+			o.procOutbox()
+		}
+	}
+	xlog.Infof("MatcherOutbox(%v) is stopped", o.Consumer)
+}
+
+func (o *MatcherOutbox) procOutbox() (delivered int, err error) {
+	defer func() {
+		if rerr := recover(); rerr != nil {
+			xlog.Errorf("MatcherOutbox(%v) proc outbox is panic with %v, call stack is \n%v", o.Consumer, rerr, debug.CallStatck())
+			err = fmt.Errorf("%v", rerr)
+		}
+	}()
+	for _, symbol := range o.Symbols {
+		for {
+			n, xerr := o.procSymbolOutbox(symbol)
+			delivered += n
+			if xerr != nil {
+				xlog.Warnf("MatcherOutbox(%v) proc outbox by %v fail with %v", o.Consumer, symbol, xerr)
+				err = xerr
+				break
+			}
+			if n < o.Limit {
+				break
+			}
+		}
+	}
+	return
+}
+
+func (o *MatcherOutbox) procSymbolOutbox(symbol string) (delivered int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+	acked, err := gexdb.LoadOutboxAck(ctx, o.Consumer, symbol)
+	if err != nil {
+		return
+	}
+	if last := o.delivered[symbol]; last > acked { //ack is not saved on last proc
+		acked = last
+	}
+	outboxes, err := gexdb.ListOutboxEvent(ctx, symbol, acked, o.Limit)
+	if err != nil || len(outboxes) < 1 {
+		return
+	}
+	for _, outbox := range outboxes {
+		if ctx.Err() != nil {
+			break
+		}
+		event := &MatcherEvent{}
+		data, _ := json.Marshal(outbox.Event)
+		if xerr := json.Unmarshal(data, event); xerr != nil {
+			xlog.Warnf("MatcherOutbox(%v) decode outbox event %v/%v fail with %v, skip it", o.Consumer, symbol, outbox.Sequence, xerr)
+		} else {
+			event.Sequence = outbox.Sequence
+			o.Monitor.OnMatched(ctx, event)
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		acked = outbox.Sequence
+		o.delivered[symbol] = acked
+		delivered++
+	}
+	if delivered > 0 {
+		err = gexdb.UpdateOutboxAck(context.Background(), o.Consumer, symbol, acked)
+	}
+	return
+}
@@ -0,0 +1,144 @@
+package matcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xprop"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+func TestMatcherOutbox(t *testing.T) {
+	clear()
+	config := xprop.NewConfig()
+	config.LoadPropString(matcherConfig)
+	center, err := BootstrapMatcherCenterByConfig(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	center.OutboxOn = true
+	env := testFuturesInit(200)
+	symbol := "futures.YWEUSDT"
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Seller.TID, Symbol: symbol, Side: gexdb.OrderSideSell, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(100)})
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Buyer.TID, Symbol: symbol, Side: gexdb.OrderSideBuy, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(100)})
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	events := []*MatcherEvent{}
+	outbox := NewMatcherOutbox("test", MatcherMonitorF(func(ctx context.Context, event *MatcherEvent) {
+		events = append(events, event)
+	}), symbol)
+	outbox.Limit = 1
+	delivered, err := outbox.procOutbox()
+	if err != nil || delivered != 2 || len(events) != 2 || events[0].Sequence != 1 || events[1].Sequence != 2 {
+		t.Errorf("err:%v,delivered:%v,events:%v", err, delivered, len(events))
+		return
+	}
+	if len(events[1].Orders) != 1 || events[1].Orders[0].Filled.Sign() <= 0 || events[1].Depth == nil {
+		t.Error("event error")
+		return
+	}
+	if len(events[1].Trades) != 1 || !events[1].Trades[0].Price.Equal(decimal.NewFromFloat(100)) || events[1].Trades[0].Side != gexdb.OrderSideBuy {
+		t.Error("trade error")
+		return
+	}
+	trades, err := ListOutboxTrade(ctx, symbol, 10)
+	if err != nil || len(trades) != 1 || trades[0].Sequence != 2 {
+		t.Errorf("err:%v,trades:%v", err, len(trades))
+		return
+	}
+	trades, err = ListOutboxTrade(ctx, symbol, 1)
+	if err != nil || len(trades) != 1 {
+		t.Errorf("err:%v,trades:%v", err, len(trades))
+		return
+	}
+	//catch up by ack
+	delivered, err = outbox.procOutbox()
+	if err != nil || delivered != 0 {
+		t.Error(err)
+		return
+	}
+	restart := NewMatcherOutbox("test2", MatcherMonitorF(func(ctx context.Context, event *MatcherEvent) {}), symbol)
+	delivered, err = restart.procOutbox()
+	if err != nil || delivered != 2 {
+		t.Error(err)
+		return
+	}
+	//skip delivered when ack is not saved
+	pgx.MockerStart()
+	pgx.MockerSet("Pool.Exec", 1)
+	redeliver := NewMatcherOutbox("test3", MatcherMonitorF(func(ctx context.Context, event *MatcherEvent) {}), symbol)
+	delivered, err = redeliver.procOutbox()
+	pgx.MockerStop()
+	if err == nil || delivered != 2 {
+		t.Error(err)
+		return
+	}
+	delivered, err = redeliver.procOutbox()
+	if err != nil || delivered != 0 {
+		t.Error(err)
+		return
+	}
+	//start
+	outbox.Delay = 10 * time.Millisecond
+	outbox.Start()
+	outbox.OnMatched(ctx, nil)
+	outbox.OnMatched(ctx, nil)
+	time.Sleep(50 * time.Millisecond)
+	outbox.Stop()
+	//clear
+	center.procClearOutbox()
+	center.outboxLast = time.Time{}
+	center.OutboxKeep = -time.Hour
+	center.procClearOutbox()
+	center.OutboxKeep = time.Millisecond
+	center.procClearOutbox()
+	//error
+	_, err = center.ProcessOrder(ctx, &gexdb.Order{Type: gexdb.OrderTypeTrade, UserID: env.Seller.TID, Symbol: symbol, Side: gexdb.OrderSideSell, Quantity: decimal.NewFromFloat(1), Price: decimal.NewFromFloat(100)})
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerSetCall("Pool.Query", 1, "Rows.Scan", 1, "Pool.Exec", 1).ShouldError(t).Call(func(trigger int) (res xmap.M, err error) {
+		_, err = restart.procOutbox()
+		return
+	})
+	pgx.MockerClear()
+	center.outboxLast = time.Time{}
+	pgx.MockerSet("Pool.Exec", 1)
+	center.procClearOutbox()
+	pgx.MockerClear()
+	pgx.MockerPanic("Pool.Query", 1)
+	restart.procOutbox()
+	pgx.MockerClear()
+	center.OutboxOn = false
+	event := NewMatcherEvent(symbol)
+	center.StoreMatched(nil, ctx, event)
+	if event.Sequence != 1 {
+		t.Error("error")
+		return
+	}
+	center.StoreMatched(nil, ctx, event) //not committed
+	if event.Sequence != 1 {
+		t.Error("error")
+		return
+	}
+	center.CommitMatched(event)
+	center.StoreMatched(nil, ctx, event)
+	if event.Sequence != 2 {
+		t.Error("error")
+		return
+	}
+}
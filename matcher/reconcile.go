@@ -0,0 +1,313 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/debug"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+//BalanceDrift is the balance which locked/margin is not matched to open order and holding
+type BalanceDrift struct {
+	UserID       int64             `json:"user_id"`
+	Area         gexdb.BalanceArea `json:"area"`
+	Asset        string            `json:"asset"`
+	Locked       decimal.Decimal   `json:"locked"`
+	ExpectLocked decimal.Decimal   `json:"expect_locked"`
+	Margin       decimal.Decimal   `json:"margin"`
+	ExpectMargin decimal.Decimal   `json:"expect_margin"`
+	Frozen       bool              `json:"frozen"`
+}
+
+//BalanceReconcile is the result of balance reconcile
+type BalanceReconcile struct {
+	Checked int             `json:"checked"`
+	Drifts  []*BalanceDrift `json:"drifts"`
+	Time    xsql.Time       `json:"time"`
+}
+
+//ReconcileBalance will recompute the expected locked/margin by open order and holding on all matcher
+//and compare to stored balance, the drift balance is logged and the user is frozen when ReconcileFreeze is on.
+//the order and balance is scanned on database snapshot which is taken under all matcher book lock
+func (m *MatcherCenter) ReconcileBalance(ctx context.Context) (result *BalanceReconcile, err error) {
+	result = &BalanceReconcile{Time: xsql.TimeNow()}
+	drifts, err := m.reconcileBalance(ctx)
+	if err != nil {
+		return
+	}
+	for _, drift := range drifts {
+		result.Checked++
+		if drift.Locked.Sub(drift.ExpectLocked).Abs().LessThanOrEqual(m.ReconcileTolerance) && drift.Margin.Sub(drift.ExpectMargin).Abs().LessThanOrEqual(m.ReconcileTolerance) {
+			continue
+		}
+		result.Drifts = append(result.Drifts, drift)
+	}
+	frozen := map[int64]bool{}
+	for _, drift := range result.Drifts {
+		if m.ReconcileFreeze {
+			if _, have := frozen[drift.UserID]; !have {
+				_, xerr := gexdb.LockUser(ctx, drift.UserID)
+				if xerr != nil {
+					xlog.Errorf("MatcherCenter reconcile freeze user %v fail with %v", drift.UserID, xerr)
+				}
+				frozen[drift.UserID] = xerr == nil
+			}
+			drift.Frozen = frozen[drift.UserID]
+		}
+		xlog.Warnf(
+			"MatcherCenter reconcile found balance drift on user %v area %v asset %v, locked %v expect %v, margin %v expect %v, frozen %v",
+			drift.UserID, drift.Area, drift.Asset, drift.Locked, drift.ExpectLocked, drift.Margin, drift.ExpectMargin, drift.Frozen,
+		)
+	}
+	m.reconcileLock.Lock()
+	for userID, ok := range frozen {
+		if ok {
+			m.frozenAll[userID] = true
+		}
+	}
+	m.reconcileLast = result
+	m.reconcileLock.Unlock()
+	xlog.Infof("MatcherCenter reconcile %v balance done with %v drift", result.Checked, len(result.Drifts))
+	return
+}
+
+func (m *MatcherCenter) reconcileBalance(ctx context.Context) (drifts []*BalanceDrift, err error) {
+	//all scan is running on repeatable read snapshot, so the book lock is only held when snapshot is taken
+	tx, err := gexdb.Pool().Begin(ctx)
+	if err != nil {
+		err = NewErrMatcher(err, "[ReconcileBalance] begin fail")
+		return
+	}
+	defer tx.Rollback(ctx)
+	_, _, err = tx.Exec(ctx, `set transaction isolation level repeatable read read only`)
+	if err != nil {
+		err = NewErrMatcher(err, "[ReconcileBalance] set transaction fail")
+		return
+	}
+	spots, futures, err := m.reconcileSnapshot(tx, ctx)
+	if err != nil {
+		err = NewErrMatcher(err, "[ReconcileBalance] take snapshot fail")
+		return
+	}
+	driftAll := map[string]*BalanceDrift{}
+	areaAll := gexdb.BalanceAreaArray{}
+	expect := func(userID int64, area gexdb.BalanceArea, asset string, create bool) (drift *BalanceDrift) {
+		key := fmt.Sprintf("%v-%v-%v", userID, area, asset)
+		drift = driftAll[key]
+		if drift == nil && create {
+			drift = &BalanceDrift{UserID: userID, Area: area, Asset: asset}
+			driftAll[key] = drift
+		}
+		return
+	}
+	scope := map[string]bool{}
+	addScope := func(area gexdb.BalanceArea, assets ...string) {
+		for _, asset := range assets {
+			scope[fmt.Sprintf("%v-%v", area, asset)] = true
+		}
+		for _, having := range areaAll {
+			if having == area {
+				return
+			}
+		}
+		areaAll = append(areaAll, area)
+	}
+
+	//open order
+	var orders []*gexdb.Order
+	err = gexdb.ScanOrderFilterWherefCall(tx, ctx, "#all", "symbol=any($%v),status=any($%v)", []interface{}{xsql.StringArray(m.Symbols), gexdb.OrderStatusArray{gexdb.OrderStatusPending, gexdb.OrderStatusPartialled}}, "order by create_time asc,tid asc", &orders)
+	if err != nil {
+		err = NewErrMatcher(err, "[ReconcileBalance] list open order fail")
+		return
+	}
+	orderAll := map[string]map[int64][]*gexdb.Order{}
+	for _, order := range orders {
+		if orderAll[order.Symbol] == nil {
+			orderAll[order.Symbol] = map[int64][]*gexdb.Order{}
+		}
+		orderAll[order.Symbol][order.UserID] = append(orderAll[order.Symbol][order.UserID], order)
+	}
+
+	//spot locked by limit order
+	for _, spot := range spots {
+		addScope(spot.Area, spot.Base, spot.Quote)
+		for userID, userOrders := range orderAll[spot.Symbol] {
+			for _, order := range userOrders {
+				if !order.Price.IsPositive() {
+					continue
+				}
+				if order.Side == gexdb.OrderSideBuy {
+					drift := expect(userID, spot.Area, spot.Quote, true)
+					drift.ExpectLocked = drift.ExpectLocked.Add(order.Quantity.Mul(order.Price))
+				} else {
+					drift := expect(userID, spot.Area, spot.Base, true)
+					drift.ExpectLocked = drift.ExpectLocked.Add(order.Quantity)
+				}
+			}
+		}
+	}
+
+	//futures locked by limit order and holding
+	if len(futures) > 0 {
+		symbols := []string{}
+		userIDs := []int64{}
+		for _, f := range futures {
+			symbols = append(symbols, f.Symbol)
+			for userID := range orderAll[f.Symbol] {
+				userIDs = append(userIDs, userID)
+			}
+		}
+		var holdings []*gexdb.Holding
+		holdings, err = gexdb.ListHoldingForReconcileCall(tx, ctx, symbols, userIDs)
+		if err != nil {
+			err = NewErrMatcher(err, "[ReconcileBalance] list holding fail")
+			return
+		}
+		holdingAll := map[string]*gexdb.Holding{}
+		for _, holding := range holdings {
+			holdingAll[fmt.Sprintf("%v-%v", holding.Symbol, holding.UserID)] = holding
+		}
+		for _, f := range futures {
+			addScope(f.Area, f.Quote)
+			for userID := range orderAll[f.Symbol] {
+				key := fmt.Sprintf("%v-%v", f.Symbol, userID)
+				if holdingAll[key] == nil {
+					holdingAll[key] = &gexdb.Holding{UserID: userID, Symbol: f.Symbol}
+				}
+			}
+			for _, holding := range holdingAll {
+				if holding.Symbol != f.Symbol {
+					continue
+				}
+				if holding.Lever < 1 {
+					holding.Lever = 1
+				}
+				drift := expect(holding.UserID, f.Area, f.Quote, true)
+				locked := f.calcHoldingLocked(holding, orderAll[f.Symbol][holding.UserID], nil)
+				drift.ExpectLocked = drift.ExpectLocked.Add(locked).Add(holding.MarginAdded)
+				drift.ExpectMargin = drift.ExpectMargin.Add(holding.MarginUsed).Add(holding.MarginAdded)
+			}
+		}
+	}
+
+	//stored balance
+	balances, err := gexdb.ListBalanceForReconcileCall(tx, ctx, areaAll)
+	if err != nil {
+		err = NewErrMatcher(err, "[ReconcileBalance] list balance fail")
+		return
+	}
+	for _, balance := range balances {
+		if !scope[fmt.Sprintf("%v-%v", balance.Area, balance.Asset)] {
+			continue
+		}
+		drift := expect(balance.UserID, balance.Area, balance.Asset, true)
+		drift.Locked = balance.Locked
+		drift.Margin = balance.Margin
+	}
+	for _, drift := range driftAll {
+		drifts = append(drifts, drift)
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].UserID != drifts[j].UserID {
+			return drifts[i].UserID < drifts[j].UserID
+		}
+		if drifts[i].Area != drifts[j].Area {
+			return drifts[i].Area < drifts[j].Area
+		}
+		return drifts[i].Asset < drifts[j].Asset
+	})
+	return
+}
+
+//reconcileSnapshot will lock all book and take the database snapshot by first query,
+//the orders/holdings/balances in snapshot is consistent because they are only changed under book lock
+func (m *MatcherCenter) reconcileSnapshot(tx *pgx.Tx, ctx context.Context) (spots []*SpotMatcher, futures []*FuturesMatcher, err error) {
+	for _, symbol := range m.Symbols {
+		switch matcher := m.FindMatcher(symbol).(type) {
+		case *SpotMatcher:
+			matcher.bookLock.RLock()
+			defer matcher.bookLock.RUnlock()
+			spots = append(spots, matcher)
+		case *FuturesMatcher:
+			matcher.bookLock.RLock()
+			defer matcher.bookLock.RUnlock()
+			futures = append(futures, matcher)
+		}
+	}
+	_, _, err = tx.Exec(ctx, `select 1`)
+	return
+}
+
+//LastReconcile will return the last balance reconcile result, nil is returned when reconcile is not executed
+func (m *MatcherCenter) LastReconcile() (result *BalanceReconcile) {
+	m.reconcileLock.RLock()
+	defer m.reconcileLock.RUnlock()
+	result = m.reconcileLast
+	return
+}
+
+func (m *MatcherCenter) procReconcileBalance() (err error) {
+	if m.ReconcileDelay <= 0 || time.Since(m.reconcileTime) < m.ReconcileDelay {
+		return
+	}
+	m.reconcileTime = time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer func() {
+		if rerr := recover(); rerr != nil {
+			xlog.Errorf("MatcherCenter proc reconcile balance is panic with %v, call stack is \n%v", rerr, debug.CallStatck())
+			err = fmt.Errorf("%v", rerr)
+		}
+		cancel()
+	}()
+	_, err = m.ReconcileBalance(ctx)
+	if err != nil {
+		xlog.Warnf("MatcherCenter reconcile balance fail with %v", err)
+	}
+	return
+}
+
+//LoadFrozen will load all locked user to frozen, so the freeze is still enforced after restart
+func (m *MatcherCenter) LoadFrozen(ctx context.Context) (err error) {
+	userIDs, err := gexdb.ListLockedUserID(ctx)
+	if err != nil {
+		err = NewErrMatcher(err, "[LoadFrozen] list locked user fail")
+		return
+	}
+	m.reconcileLock.Lock()
+	for _, userID := range userIDs {
+		m.frozenAll[userID] = true
+	}
+	m.reconcileLock.Unlock()
+	xlog.Infof("MatcherCenter load %v locked user to frozen", len(userIDs))
+	return
+}
+
+//checkFrozen will return error when user is frozen by reconcile or loaded as locked on start and still locked
+func (m *MatcherCenter) checkFrozen(ctx context.Context, userID int64) (err error) {
+	m.reconcileLock.RLock()
+	frozen := m.frozenAll[userID]
+	m.reconcileLock.RUnlock()
+	if !frozen {
+		return
+	}
+	status, err := gexdb.FindUserStatus(ctx, userID)
+	if err != nil {
+		return
+	}
+	if status == gexdb.UserStatusLocked {
+		err = fmt.Errorf("user %v is frozen by balance reconcile", userID)
+		return
+	}
+	//unfrozen by admin
+	m.reconcileLock.Lock()
+	delete(m.frozenAll, userID)
+	m.reconcileLock.Unlock()
+	return
+}
@@ -0,0 +1,132 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+	"github.com/codingeasygo/util/xprop"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+func TestReconcileBalance(t *testing.T) {
+	clear()
+	config := xprop.NewConfig()
+	config.LoadPropString(matcherConfig)
+	center, err := BootstrapMatcherCenterByConfig(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if center.LastReconcile() != nil {
+		t.Error("error")
+		return
+	}
+	//spot
+	spotArea := gexdb.BalanceAreaSpot
+	spotSymbol := "spot.YWEUSDT"
+	userBase := testAddUser("TestReconcileBalance-Base")
+	userQuote := testAddUser("TestReconcileBalance-Quote")
+	gexdb.TouchBalance(ctx, spotArea, spotBalanceAll, userBase.TID, userQuote.TID)
+	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{UserID: userBase.TID, Area: spotArea, Asset: spotBalanceBase, Free: decimal.NewFromFloat(1000)})
+	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{UserID: userQuote.TID, Area: spotArea, Asset: spotBalanceQuote, Free: decimal.NewFromFloat(1000)})
+	_, err = center.ProcessLimit(ctx, userBase.TID, spotSymbol, gexdb.OrderSideSell, decimal.NewFromFloat(1), decimal.NewFromFloat(100))
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	_, err = center.ProcessLimit(ctx, userQuote.TID, spotSymbol, gexdb.OrderSideBuy, decimal.NewFromFloat(1), decimal.NewFromFloat(90))
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	//futures
+	env := testFuturesInit(300)
+	futuresSymbol := "futures.YWEUSDT"
+	_, err = center.ProcessLimit(ctx, env.Seller.TID, futuresSymbol, gexdb.OrderSideSell, decimal.NewFromFloat(1), decimal.NewFromFloat(100))
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	_, err = center.ProcessMarket(ctx, env.Buyer.TID, futuresSymbol, gexdb.OrderSideBuy, decimal.Zero, decimal.NewFromFloat(0.5))
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	//not drift
+	result, err := center.ReconcileBalance(ctx)
+	if err != nil || result.Checked < 4 || len(result.Drifts) > 0 || center.LastReconcile() != result {
+		t.Errorf("err:%v,result:%v", err, converter.JSON(result))
+		return
+	}
+	//drift
+	gexdb.IncreaseBalanceCall(gexdb.Pool(), ctx, &gexdb.Balance{UserID: env.Buyer.TID, Area: env.Area, Asset: futuresBalanceQuote, Free: decimal.NewFromFloat(-1), Locked: decimal.NewFromFloat(1)})
+	result, err = center.ReconcileBalance(ctx)
+	if err != nil || len(result.Drifts) != 1 || result.Drifts[0].UserID != env.Buyer.TID || result.Drifts[0].Frozen {
+		t.Errorf("err:%v,result:%v", err, converter.JSON(result))
+		return
+	}
+	//freeze
+	center.ReconcileFreeze = true
+	result, err = center.ReconcileBalance(ctx)
+	if err != nil || len(result.Drifts) != 1 || !result.Drifts[0].Frozen {
+		t.Errorf("err:%v,result:%v", err, converter.JSON(result))
+		return
+	}
+	_, err = center.ProcessLimit(ctx, env.Buyer.TID, futuresSymbol, gexdb.OrderSideBuy, decimal.NewFromFloat(0.1), decimal.NewFromFloat(90))
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	//freeze after restart
+	center.frozenAll = map[int64]bool{}
+	err = center.LoadFrozen(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = center.ProcessLimit(ctx, env.Buyer.TID, futuresSymbol, gexdb.OrderSideBuy, decimal.NewFromFloat(0.1), decimal.NewFromFloat(90))
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	//unfreeze by admin
+	err = gexdb.UpdateUserFilter(ctx, &gexdb.User{TID: env.Buyer.TID, Status: gexdb.UserStatusNormal}, "status")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = center.ProcessLimit(ctx, env.Buyer.TID, futuresSymbol, gexdb.OrderSideBuy, decimal.NewFromFloat(0.1), decimal.NewFromFloat(90))
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	//proc
+	center.ReconcileDelay = time.Millisecond
+	err = center.procReconcileBalance()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	center.ReconcileDelay = time.Hour
+	center.procReconcileBalance()
+	//error
+	pgx.MockerStart()
+	defer pgx.MockerStop()
+	pgx.MockerSetCall("Pool.Begin", 1, "Tx.Exec", 1, "Tx.Exec", 2, "Tx.Query", 1, "Tx.Query", 2, "Tx.Query", 3).ShouldError(t).Call(func(trigger int) (res xmap.M, err error) {
+		_, err = center.ReconcileBalance(ctx)
+		return
+	})
+	pgx.MockerSetCall("Pool.Query", 1).ShouldError(t).Call(func(trigger int) (res xmap.M, err error) {
+		err = center.LoadFrozen(ctx)
+		return
+	})
+	pgx.MockerSetCall("Rows.Scan", 1).ShouldError(t).Call(func(trigger int) (res xmap.M, err error) {
+		center.frozenAll[env.Buyer.TID] = true
+		err = center.checkFrozen(ctx, env.Buyer.TID)
+		return
+	})
+}
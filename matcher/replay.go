@@ -0,0 +1,272 @@
+package matcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/converter"
+	"github.com/gexservice/gexservice/base/xlog"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ReplayActionPlace  = "place"
+	ReplayActionCancel = "cancel"
+)
+
+//ReplayCommand is the recorded order command to replay
+type ReplayCommand struct {
+	Action string       `json:"action"`
+	Time   int64        `json:"time"`
+	Order  *gexdb.Order `json:"order"`
+}
+
+//ReplayDivergence is the different between recorded outcome and replayed outcome
+type ReplayDivergence struct {
+	Kind   string      `json:"kind"`
+	Key    string      `json:"key"`
+	Field  string      `json:"field"`
+	Expect interface{} `json:"expect"`
+	Actual interface{} `json:"actual"`
+}
+
+//ReplayReport is the result of replay
+type ReplayReport struct {
+	Commands    int                 `json:"commands"`
+	Failed      map[string]string   `json:"failed"`
+	Divergences []*ReplayDivergence `json:"divergences"`
+}
+
+//String will return readable divergence report
+func (r *ReplayReport) String() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "replay %v commands, %v failed, %v divergences\n", r.Commands, len(r.Failed), len(r.Divergences))
+	failed := []string{}
+	for orderID := range r.Failed {
+		failed = append(failed, orderID)
+	}
+	sort.Strings(failed)
+	for _, orderID := range failed {
+		fmt.Fprintf(buf, "  failed  order %v: %v\n", orderID, r.Failed[orderID])
+	}
+	for _, d := range r.Divergences {
+		fmt.Fprintf(buf, "  diverge %v %v %v: expect %v, actual %v\n", d.Kind, d.Key, d.Field, d.Expect, d.Actual)
+	}
+	return buf.String()
+}
+
+//ReadJSONL will read one json value by line, the value is created by newValue and passed to onValue
+func ReadJSONL(reader io.Reader, newValue func() interface{}, onValue func(v interface{})) (err error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := strings.TrimSpace(scanner.Text())
+		if len(data) < 1 {
+			continue
+		}
+		v := newValue()
+		err = json.Unmarshal([]byte(data), v)
+		if err != nil {
+			err = fmt.Errorf("parse line %v fail with %v", line, err)
+			return
+		}
+		onValue(v)
+	}
+	err = scanner.Err()
+	return
+}
+
+//NewReplayCommand will create replay command by recorded orders, which is exported from exs_order.
+//the order is placed on create time and canceled on update time when it is canceled limit order, the blowup order is skipped because it is created by matcher
+func NewReplayCommand(orders ...*gexdb.Order) (commands []*ReplayCommand) {
+	for _, order := range orders {
+		if order.Type != gexdb.OrderTypeTrade && order.Type != gexdb.OrderTypeTrigger {
+			continue
+		}
+		commands = append(commands, &ReplayCommand{Action: ReplayActionPlace, Time: order.CreateTime.Timestamp(), Order: order})
+		canceled := order.Status == gexdb.OrderStatusCanceled || order.Status == gexdb.OrderStatusPartCanceled
+		if order.Type == gexdb.OrderTypeTrade && order.Price.Sign() > 0 && canceled {
+			commands = append(commands, &ReplayCommand{Action: ReplayActionCancel, Time: order.UpdateTime.Timestamp(), Order: order})
+		}
+	}
+	sort.SliceStable(commands, func(i, j int) bool {
+		if commands[i].Time == commands[j].Time {
+			return commands[i].Action == ReplayActionPlace && commands[j].Action == ReplayActionCancel
+		}
+		return commands[i].Time < commands[j].Time
+	})
+	return
+}
+
+//Replayer will replay recorded command by matcher center on scratch database and diff the outcome
+type Replayer struct {
+	Center       *MatcherCenter
+	SeedBalances []*gexdb.Balance
+	SeedHoldings []*gexdb.Holding
+	replayed     map[string]string
+}
+
+//NewReplayer will create replayer by center, the user which is not found on scratch database is replayed by default tier
+func NewReplayer(center *MatcherCenter) (replayer *Replayer) {
+	replayer = &Replayer{
+		Center:   center,
+		replayed: map[string]string{},
+	}
+	if userTier := center.UserTier; userTier != nil {
+		center.UserTier = func(ctx context.Context, userID int64) (tier string, err error) {
+			tier, err = userTier(ctx, userID)
+			if err == pgx.ErrNoRows {
+				tier, err = "", nil
+			}
+			return
+		}
+	}
+	return
+}
+
+//Seed will seed the balance and holding before replay
+func (r *Replayer) Seed(ctx context.Context) (err error) {
+	for _, seed := range r.SeedBalances {
+		_, err = gexdb.TouchBalance(ctx, seed.Area, []string{seed.Asset}, seed.UserID)
+		if err != nil {
+			break
+		}
+		err = gexdb.IncreaseBalance(ctx, &gexdb.Balance{
+			UserID: seed.UserID,
+			Area:   seed.Area,
+			Asset:  seed.Asset,
+			Free:   seed.Free,
+			Margin: seed.Margin,
+		})
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		err = NewErrMatcher(err, "[Replay] seed balance fail")
+		return
+	}
+	for _, seed := range r.SeedHoldings {
+		var holding *gexdb.Holding
+		_, err = gexdb.TouchHolding(ctx, []string{seed.Symbol}, seed.UserID)
+		if err == nil {
+			holding, err = gexdb.FindHoldlingBySymbol(ctx, seed.UserID, seed.Symbol)
+		}
+		if err == nil {
+			holding.Amount, holding.Open, holding.Blowup, holding.Lever = seed.Amount, seed.Open, seed.Blowup, seed.Lever
+			holding.MarginUsed, holding.MarginAdded = seed.MarginUsed, seed.MarginAdded
+			err = holding.UpdateFilter(gexdb.Pool, ctx, "amount,open,blowup,lever,margin_used,margin_added")
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		err = NewErrMatcher(err, "[Replay] seed holding fail")
+	}
+	return
+}
+
+//Replay will replay the command one by one and try trigger order after each command
+func (r *Replayer) Replay(ctx context.Context, commands ...*ReplayCommand) (report *ReplayReport) {
+	report = &ReplayReport{Failed: map[string]string{}}
+	for _, command := range commands {
+		report.Commands++
+		recorded := command.Order
+		var order *gexdb.Order
+		var err error
+		switch command.Action {
+		case ReplayActionCancel:
+			orderID := r.replayed[recorded.OrderID]
+			if len(orderID) < 1 {
+				err = fmt.Errorf("order is not replayed")
+				break
+			}
+			order, err = r.Center.ProcessCancel(ctx, recorded.UserID, recorded.Symbol, orderID)
+		default:
+			order, err = r.Center.ProcessOrder(ctx, &gexdb.Order{
+				Type:         recorded.Type,
+				UserID:       recorded.UserID,
+				Creator:      recorded.Creator,
+				Symbol:       recorded.Symbol,
+				Side:         recorded.Side,
+				Quantity:     recorded.Quantity,
+				Price:        recorded.Price,
+				TotalPrice:   recorded.TotalPrice,
+				TriggerType:  recorded.TriggerType,
+				TriggerPrice: recorded.TriggerPrice,
+			})
+			if err == nil {
+				r.replayed[recorded.OrderID] = order.OrderID
+			}
+		}
+		if err != nil {
+			report.Failed[recorded.OrderID] = fmt.Sprintf("%v fail with %v", command.Action, err)
+			xlog.Debugf("Replayer %v order %v fail with %v", command.Action, converter.JSON(recorded), ErrStack(err))
+			continue
+		}
+		r.Center.procTriggerSybmolOrder(ctx, recorded.Symbol)
+	}
+	return
+}
+
+//Diff will diff the replayed outcome with recorded orders/balances/holdings and append divergence to report
+func (r *Replayer) Diff(ctx context.Context, report *ReplayReport, orders []*gexdb.Order, balances []*gexdb.Balance, holdings []*gexdb.Holding) (err error) {
+	diffDecimal := func(kind, key, field string, expect, actual decimal.Decimal) {
+		if !expect.Equal(actual) {
+			report.Divergences = append(report.Divergences, &ReplayDivergence{Kind: kind, Key: key, Field: field, Expect: expect, Actual: actual})
+		}
+	}
+	for _, expect := range orders {
+		orderID := r.replayed[expect.OrderID]
+		if len(orderID) < 1 {
+			continue
+		}
+		var actual *gexdb.Order
+		actual, err = gexdb.FindOrderByOrderID(ctx, orderID)
+		if err != nil {
+			return
+		}
+		if expect.Status != actual.Status {
+			report.Divergences = append(report.Divergences, &ReplayDivergence{Kind: "order", Key: expect.OrderID, Field: "status", Expect: expect.Status, Actual: actual.Status})
+		}
+		diffDecimal("order", expect.OrderID, "filled", expect.Filled, actual.Filled)
+		diffDecimal("order", expect.OrderID, "avg_price", expect.AvgPrice, actual.AvgPrice)
+		diffDecimal("order", expect.OrderID, "total_price", expect.TotalPrice, actual.TotalPrice)
+		diffDecimal("order", expect.OrderID, "fee_filled", expect.FeeFilled, actual.FeeFilled)
+		diffDecimal("order", expect.OrderID, "profit", expect.Profit, actual.Profit)
+	}
+	for _, expect := range balances {
+		var actual *gexdb.Balance
+		actual, err = gexdb.FindBalanceByAsset(ctx, expect.UserID, expect.Area, expect.Asset)
+		if err != nil {
+			return
+		}
+		key := BalanceKey(expect)
+		diffDecimal("balance", key, "free", expect.Free, actual.Free)
+		diffDecimal("balance", key, "locked", expect.Locked, actual.Locked)
+		diffDecimal("balance", key, "margin", expect.Margin, actual.Margin)
+	}
+	for _, expect := range holdings {
+		var actual *gexdb.Holding
+		actual, err = gexdb.FindHoldlingBySymbol(ctx, expect.UserID, expect.Symbol)
+		if err != nil {
+			return
+		}
+		key := HoldingKey(expect)
+		diffDecimal("holding", key, "amount", expect.Amount, actual.Amount)
+		diffDecimal("holding", key, "open", expect.Open, actual.Open)
+		diffDecimal("holding", key, "blowup", expect.Blowup, actual.Blowup)
+		diffDecimal("holding", key, "margin_used", expect.MarginUsed, actual.MarginUsed)
+	}
+	return
+}
@@ -0,0 +1,142 @@
+package matcher
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xprop"
+	"github.com/codingeasygo/util/xsql"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+func TestReplay(t *testing.T) {
+	clear()
+	config := xprop.NewConfig()
+	config.LoadPropString(matcherConfig)
+	center, err := BootstrapMatcherCenterByConfig(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	area := gexdb.BalanceAreaSpot
+	userBase := testAddUser("TestReplay-Base")
+	userQuote := testAddUser("TestReplay-Quote")
+	seedBalances := []*gexdb.Balance{
+		{UserID: userBase.TID, Area: area, Asset: spotBalanceBase, Free: decimal.NewFromFloat(10000)},
+		{UserID: userQuote.TID, Area: area, Asset: spotBalanceQuote, Free: decimal.NewFromFloat(10000)},
+	}
+	recorder := NewReplayer(center)
+	recorder.SeedBalances = seedBalances
+	err = recorder.Seed(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	//record
+	sell1, _ := center.ProcessLimit(ctx, userBase.TID, spotBalanceSymbol, gexdb.OrderSideSell, decimal.NewFromFloat(1), decimal.NewFromFloat(100))
+	sell2, _ := center.ProcessLimit(ctx, userBase.TID, spotBalanceSymbol, gexdb.OrderSideSell, decimal.NewFromFloat(1), decimal.NewFromFloat(110))
+	buy1, _ := center.ProcessMarket(ctx, userQuote.TID, spotBalanceSymbol, gexdb.OrderSideBuy, decimal.NewFromFloat(150), decimal.Zero)
+	time.Sleep(10 * time.Millisecond)
+	_, err = center.ProcessCancel(ctx, userBase.TID, spotBalanceSymbol, sell2.OrderID)
+	if err != nil {
+		t.Error(ErrStack(err))
+		return
+	}
+	orders := []*gexdb.Order{}
+	for _, orderID := range []string{sell1.OrderID, sell2.OrderID, buy1.OrderID} {
+		order, err := gexdb.FindOrderByOrderID(ctx, orderID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		orders = append(orders, order)
+	}
+	balances := []*gexdb.Balance{}
+	for _, seed := range seedBalances {
+		for _, asset := range spotBalanceAll {
+			balance, err := gexdb.FindBalanceByAsset(ctx, seed.UserID, area, asset)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			balances = append(balances, balance)
+		}
+	}
+	//export and load
+	buffer := bytes.NewBuffer(nil)
+	for _, order := range orders {
+		buffer.WriteString(converter.JSON(order) + "\n\n")
+	}
+	loaded := []*gexdb.Order{}
+	err = ReadJSONL(buffer, func() interface{} { return &gexdb.Order{} }, func(v interface{}) { loaded = append(loaded, v.(*gexdb.Order)) })
+	if err != nil || len(loaded) != len(orders) {
+		t.Error(err)
+		return
+	}
+	commands := NewReplayCommand(loaded...)
+	if len(commands) != 4 || commands[3].Action != ReplayActionCancel {
+		t.Error(converter.JSON(commands))
+		return
+	}
+	//replay
+	clear()
+	center, _ = BootstrapMatcherCenterByConfig(config)
+	center.TriggerDelay = time.Hour
+	center.UserTier = gexdb.FindUserTier
+	center.Start()
+	defer center.Stop()
+	replayer := NewReplayer(center)
+	if tier, err := center.UserTier(ctx, -1); err != nil || len(tier) > 0 { //user not found
+		t.Error(err)
+		return
+	}
+	replayer.SeedBalances = seedBalances
+	err = replayer.Seed(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	report := replayer.Replay(ctx, commands...)
+	err = replayer.Diff(ctx, report, loaded, balances, nil)
+	if err != nil || len(report.Failed) > 0 || len(report.Divergences) > 0 {
+		t.Errorf("err:%v,report:%v", err, report)
+		return
+	}
+	//divergence
+	loaded[0].Filled = decimal.NewFromFloat(0.5)
+	loaded[0].Status = gexdb.OrderStatusPartialled
+	balances[0].Free = decimal.Zero
+	report = replayer.Replay(ctx, &ReplayCommand{Action: ReplayActionCancel, Order: &gexdb.Order{OrderID: "none"}})
+	err = replayer.Diff(ctx, report, loaded, balances, nil)
+	if err != nil || len(report.Failed) != 1 || len(report.Divergences) != 3 {
+		t.Errorf("err:%v,report:%v", err, report)
+		return
+	}
+	if !strings.Contains(report.String(), "1 failed, 3 divergences") {
+		t.Error(report.String())
+		return
+	}
+	//error
+	err = ReadJSONL(bytes.NewBufferString("xxx"), func() interface{} { return &gexdb.Order{} }, func(v interface{}) {})
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	replayer.SeedBalances = []*gexdb.Balance{{UserID: userBase.TID, Area: area, Asset: spotBalanceBase, Free: decimal.NewFromFloat(-100000)}}
+	err = replayer.Seed(ctx)
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	replayer.SeedBalances = nil
+	replayer.SeedHoldings = []*gexdb.Holding{{UserID: userBase.TID, Symbol: "futures.YWEUSDT", Amount: decimal.NewFromFloat(1), Open: decimal.NewFromFloat(100), CreateTime: xsql.TimeNow()}}
+	err = replayer.Seed(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+}
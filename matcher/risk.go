@@ -0,0 +1,156 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/centny/orderbook"
+	"github.com/codingeasygo/crud"
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/shopspring/decimal"
+)
+
+//RiskLimit is the limit by symbol and user tier, zero value is not limited
+type RiskLimit struct {
+	MaxOpenOrders       int64
+	MaxOrderNotional    decimal.Decimal
+	MaxPositionNotional decimal.Decimal
+}
+
+func (r *RiskLimit) IsZero() bool {
+	return r.MaxOpenOrders <= 0 && r.MaxOrderNotional.Sign() <= 0 && r.MaxPositionNotional.Sign() <= 0
+}
+
+func riskLimitKey(symbol, tier string) string {
+	return fmt.Sprintf("%v-%v", symbol, tier)
+}
+
+//AddLimit will add risk limit by symbol and tier, empty tier is the default limit
+func (m *MatcherCenter) AddLimit(symbol, tier string, limit *RiskLimit) {
+	m.limitLock.Lock()
+	defer m.limitLock.Unlock()
+	m.limitAll[riskLimitKey(symbol, tier)] = limit
+}
+
+//FindLimit will find risk limit by symbol and tier, the field not set on tier limit is inherited from default limit
+func (m *MatcherCenter) FindLimit(symbol, tier string) (limit *RiskLimit) {
+	m.limitLock.RLock()
+	defer m.limitLock.RUnlock()
+	base := m.limitAll[riskLimitKey(symbol, "")]
+	if len(tier) < 1 {
+		limit = base
+		return
+	}
+	having := m.limitAll[riskLimitKey(symbol, tier)]
+	if having == nil || base == nil {
+		limit = having
+		if limit == nil {
+			limit = base
+		}
+		return
+	}
+	limit = &RiskLimit{
+		MaxOpenOrders:       having.MaxOpenOrders,
+		MaxOrderNotional:    having.MaxOrderNotional,
+		MaxPositionNotional: having.MaxPositionNotional,
+	}
+	if limit.MaxOpenOrders <= 0 {
+		limit.MaxOpenOrders = base.MaxOpenOrders
+	}
+	if limit.MaxOrderNotional.Sign() <= 0 {
+		limit.MaxOrderNotional = base.MaxOrderNotional
+	}
+	if limit.MaxPositionNotional.Sign() <= 0 {
+		limit.MaxPositionNotional = base.MaxPositionNotional
+	}
+	return
+}
+
+//checkRiskLimit will check order by risk limit, it is called by matcher with book locked and depth is current book
+func (m *MatcherCenter) checkRiskLimit(caller crud.Queryer, ctx context.Context, args *gexdb.Order, depth *orderbook.Depth) (err error) {
+	tier := ""
+	if m.UserTier != nil {
+		tier, err = m.UserTier(ctx, args.UserID)
+		if err != nil {
+			return
+		}
+	}
+	limit := m.FindLimit(args.Symbol, tier)
+	if limit == nil || limit.IsZero() {
+		return
+	}
+	price := args.Price
+	if price.Sign() <= 0 && args.Type == gexdb.OrderTypeTrigger {
+		price = args.TriggerPrice
+	}
+	if price.Sign() <= 0 {
+		if depth != nil && args.Side == gexdb.OrderSideBuy && len(depth.Asks) > 0 {
+			price = depth.Asks[0][0]
+		}
+		if depth != nil && args.Side == gexdb.OrderSideSell && len(depth.Bids) > 0 {
+			price = depth.Bids[0][0]
+		}
+	}
+	if price.Sign() <= 0 && (args.Quantity.Sign() > 0 || args.TotalPrice.Sign() <= 0) && (limit.MaxOrderNotional.Sign() > 0 || limit.MaxPositionNotional.Sign() > 0) {
+		err = &ErrRiskLimit{
+			Code:    gexdb.CodeOrderNotionalLimit,
+			Message: fmt.Sprintf("order notional can't be checked by no price on %v", args.Symbol),
+		}
+		return
+	}
+	quantity := args.Quantity
+	if quantity.Sign() <= 0 && args.TotalPrice.Sign() > 0 && price.Sign() > 0 {
+		quantity = args.TotalPrice.DivRound(price, 8)
+	}
+	notional := quantity.Mul(price)
+	if args.Quantity.Sign() <= 0 && args.TotalPrice.Sign() > 0 {
+		notional = args.TotalPrice
+	}
+	if limit.MaxOrderNotional.Sign() > 0 && notional.GreaterThan(limit.MaxOrderNotional) {
+		err = &ErrRiskLimit{
+			Code:    gexdb.CodeOrderNotionalLimit,
+			Message: fmt.Sprintf("order notional %v is over limit %v", notional, limit.MaxOrderNotional),
+		}
+		return
+	}
+	if limit.MaxOpenOrders > 0 && args.Type == gexdb.OrderTypeTrade && args.Price.Sign() > 0 {
+		var opened int64
+		opened, err = gexdb.CountOpenOrderCall(caller, ctx, args.UserID, args.Symbol)
+		if err != nil {
+			return
+		}
+		if opened >= limit.MaxOpenOrders {
+			err = &ErrRiskLimit{
+				Code:    gexdb.CodeOpenOrderLimit,
+				Message: fmt.Sprintf("open order %v is reach limit %v", opened, limit.MaxOpenOrders),
+			}
+			return
+		}
+	}
+	if limit.MaxPositionNotional.Sign() > 0 && strings.HasPrefix(args.Symbol, "futures.") && price.Sign() > 0 {
+		having := decimal.Zero
+		holding, xerr := gexdb.FindHoldlingBySymbolCall(caller, ctx, args.UserID, args.Symbol, false)
+		if xerr != nil && xerr != pgx.ErrNoRows {
+			err = xerr
+			return
+		}
+		if holding != nil {
+			having = holding.Amount
+		}
+		willResult := having.Add(quantity)
+		if args.Side == gexdb.OrderSideSell {
+			willResult = having.Sub(quantity)
+		}
+		position := willResult.Abs().Mul(price)
+		if willResult.Abs().GreaterThan(having.Abs()) && position.GreaterThan(limit.MaxPositionNotional) {
+			err = &ErrRiskLimit{
+				Code:    gexdb.CodePositionLimit,
+				Message: fmt.Sprintf("position notional %v is over limit %v", position, limit.MaxPositionNotional),
+			}
+			return
+		}
+	}
+	return
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/centny/orderbook"
+	"github.com/codingeasygo/crud"
 	"github.com/codingeasygo/crud/pgx"
 	"github.com/codingeasygo/util/converter"
 	"github.com/codingeasygo/util/debug"
@@ -28,6 +29,7 @@ type SpotMatcher struct {
 	Fee               decimal.Decimal
 	NewOrderID        func() string
 	PrepareProcess    func(ctx context.Context, matcher *SpotMatcher, userID int64) error
+	CheckProcess      func(caller crud.Queryer, ctx context.Context, args *gexdb.Order, depth *orderbook.Depth) error
 	Monitor           MatcherMonitor
 	bookVal           *orderbook.OrderBook
 	bookLock          sync.RWMutex
@@ -229,25 +231,32 @@ func (s *SpotMatcher) processCancelOrder(ctx context.Context, args *gexdb.Order)
 			err = fmt.Errorf("%v", rerr)
 		}
 		if tx != nil {
+			if err == nil {
+				changed.AddOrder(order)
+				changed.AddMatched(nil, nil, cancelOrder)
+				changed.Depth = s.bookVal.Depth(30)
+				err = storeMatched(tx, ctx, s.Monitor, changed)
+			}
 			if err == nil {
 				err = tx.Commit(ctx)
 			} else {
 				tx.Rollback(ctx)
 			}
+			if err == nil {
+				commitMatched(s.Monitor, changed)
+			}
 		}
 		if err != nil && rollback != nil {
 			rollback()
 		}
 		cancel()
 		changed.Depth = s.bookVal.Depth(30)
-		s.bookLock.Unlock()
 
-		//monitor
+		//monitor under book lock to keep event order
 		if err == nil && s.Monitor != nil {
-			changed.AddOrder(order)
-			changed.AddMatched(nil, nil, cancelOrder)
 			s.Monitor.OnMatched(ctx, changed)
 		}
+		s.bookLock.Unlock()
 	}()
 
 	tx, err = gexdb.Pool().Begin(ctx)
@@ -313,11 +322,21 @@ func (s *SpotMatcher) processMarketOrder(ctx context.Context, args *gexdb.Order)
 			err = fmt.Errorf("%v", rerr)
 		}
 		if tx != nil {
+			if err == nil {
+				changed.AddOrder(order)
+				changed.AddMatched(doneOrder, partOrder, nil)
+				changed.AddTrade(order, doneOrder, partOrder, partFilled)
+				changed.Depth = s.bookVal.Depth(30)
+				err = storeMatched(tx, ctx, s.Monitor, changed)
+			}
 			if err == nil {
 				err = tx.Commit(ctx)
 			} else {
 				tx.Rollback(ctx)
 			}
+			if err == nil {
+				commitMatched(s.Monitor, changed)
+			}
 			// if err != nil && order != nil && order.Status == 0 {
 			// 	order.Status = gexdb.OrderStatusCanceled
 			// }
@@ -327,14 +346,12 @@ func (s *SpotMatcher) processMarketOrder(ctx context.Context, args *gexdb.Order)
 		}
 		cancel()
 		changed.Depth = s.bookVal.Depth(30)
-		s.bookLock.Unlock()
 
-		//monitor
+		//monitor under book lock to keep event order
 		if err == nil && s.Monitor != nil {
-			changed.AddOrder(order)
-			changed.AddMatched(doneOrder, partOrder, nil)
 			s.Monitor.OnMatched(ctx, changed)
 		}
+		s.bookLock.Unlock()
 	}()
 
 	tx, err = gexdb.Pool().Begin(ctx)
@@ -343,6 +360,15 @@ func (s *SpotMatcher) processMarketOrder(ctx context.Context, args *gexdb.Order)
 		return
 	}
 
+	//check risk under book lock
+	if args.TID < 1 && s.CheckProcess != nil {
+		err = s.CheckProcess(tx, ctx, args, s.bookVal.Depth(1))
+		if err != nil {
+			err = NewErrMatcher(err, "[ProcessMarket] check process by user %v", args.UserID)
+			return
+		}
+	}
+
 	//process order
 	if args.TID > 0 {
 		order, err = gexdb.FindOrderWherefCall(tx, ctx, true, "tid=$%v", args.TID)
@@ -476,11 +502,21 @@ func (s *SpotMatcher) processLimitOrder(ctx context.Context, args *gexdb.Order)
 			err = fmt.Errorf("%v", rerr)
 		}
 		if tx != nil {
+			if err == nil {
+				changed.AddOrder(order)
+				changed.AddMatched(doneOrder, partOrder, nil)
+				changed.AddTrade(order, doneOrder, partOrder, partFilled)
+				changed.Depth = s.bookVal.Depth(30)
+				err = storeMatched(tx, ctx, s.Monitor, changed)
+			}
 			if err == nil {
 				err = tx.Commit(ctx)
 			} else {
 				tx.Rollback(ctx)
 			}
+			if err == nil {
+				commitMatched(s.Monitor, changed)
+			}
 			if err != nil && order != nil && order.Status == 0 {
 				order.Status = gexdb.OrderStatusCanceled
 			}
@@ -490,14 +526,12 @@ func (s *SpotMatcher) processLimitOrder(ctx context.Context, args *gexdb.Order)
 		}
 		cancel()
 		changed.Depth = s.bookVal.Depth(30)
-		s.bookLock.Unlock()
 
-		//montiro
+		//monitor under book lock to keep event order
 		if err == nil && s.Monitor != nil {
-			changed.AddOrder(order)
-			changed.AddMatched(doneOrder, partOrder, nil)
 			s.Monitor.OnMatched(ctx, changed)
 		}
+		s.bookLock.Unlock()
 	}()
 
 	tx, err = gexdb.Pool().Begin(ctx)
@@ -505,6 +539,15 @@ func (s *SpotMatcher) processLimitOrder(ctx context.Context, args *gexdb.Order)
 		err = NewErrMatcher(err, "[ProcessLimit] begin tx fail")
 		return
 	}
+
+	//check risk under book lock
+	if args.TID < 1 && s.CheckProcess != nil {
+		err = s.CheckProcess(tx, ctx, args, s.bookVal.Depth(1))
+		if err != nil {
+			err = NewErrMatcher(err, "[ProcessLimit] check process by user %v", args.UserID)
+			return
+		}
+	}
 	if args.TID > 0 {
 		order, err = gexdb.FindOrderWherefCall(tx, ctx, true, "tid=$%v", args.TID)
 		if err != nil {
@@ -542,7 +585,7 @@ func (s *SpotMatcher) processLimitOrder(ctx context.Context, args *gexdb.Order)
 		lockedBalance.Free = decimal.Zero.Sub(order.Quantity)
 		lockedBalance.Locked = order.Quantity
 	}
-	err = gexdb.IncreaseBalanceCall(tx, ctx, lockedBalance)
+	err = gexdb.IncreaseBalanceRecordCall(tx, ctx, lockedBalance, gexdb.BalanceRecordTypeTradeLock, order.OrderID)
 	if err != nil {
 		err = NewErrMatcher(err, "[ProcessLimit] lock balance fail by %v", converter.JSON(lockedBalance))
 		return
@@ -689,6 +732,7 @@ func (s *SpotMatcher) doneBookOrder(tx *pgx.Tx, ctx context.Context, changed *Ma
 			Filled:     bookOrder.Quantity(),
 			Price:      order.Price,
 			TotalPrice: order.Price.Mul(bookOrder.Quantity()),
+			Maker:      true,
 			CreateTime: xsql.TimeNow(),
 		}
 		if order.Side == gexdb.OrderSideBuy {
@@ -734,10 +778,11 @@ func (s *SpotMatcher) partBookOrder(tx *pgx.Tx, ctx context.Context, base *gexdb
 	}
 
 	tran := &gexdb.OrderTransactionItem{
-		OrderID:    partOrder.ID(),
+		OrderID:    base.OrderID,
 		Filled:     partDone,
 		Price:      order.Price,
 		TotalPrice: order.Price.Mul(partDone),
+		Maker:      true,
 		CreateTime: xsql.TimeNow(),
 	}
 	if base.Side == gexdb.OrderSideBuy {
@@ -826,12 +871,27 @@ func (s *SpotMatcher) syncBalanceByOrderDone(tx *pgx.Tx, ctx context.Context, ch
 			}
 		}
 	}
-	err = gexdb.IncreaseBalanceCall(tx, ctx, in)
+	//the in balance is recorded by gross filled, the fee is recorded as fee record
+	in.Free = in.Free.Add(order.FeeFilled)
+	err = gexdb.IncreaseBalanceRecordCall(tx, ctx, in, gexdb.BalanceRecordTypeTrade, order.OrderID)
 	if err != nil {
 		err = NewErrMatcher(err, "[syncBalanceByOrderDone] change in balance %v fail", converter.JSON(in))
 		return
 	}
-	err = gexdb.IncreaseBalanceCall(tx, ctx, out)
+	if !order.FeeFilled.IsZero() {
+		in = &gexdb.Balance{
+			UserID: order.UserID,
+			Area:   s.Area,
+			Asset:  in.Asset,
+			Free:   decimal.Zero.Sub(order.FeeFilled),
+		}
+		err = gexdb.IncreaseBalanceRecordCall(tx, ctx, in, gexdb.BalanceRecordTypeFee, order.OrderID)
+		if err != nil {
+			err = NewErrMatcher(err, "[syncBalanceByOrderDone] change fee balance %v fail", converter.JSON(in))
+			return
+		}
+	}
+	err = gexdb.IncreaseBalanceRecordCall(tx, ctx, out, gexdb.BalanceRecordTypeTrade, order.OrderID)
 	if err != nil {
 		err = NewErrMatcher(err, "[syncBalanceByOrderDone] change out balance %v fail", converter.JSON(out))
 		return
@@ -618,6 +618,11 @@ func TestSpotMatcherLimit(t *testing.T) {
 		assetBalanceLocked(userBase.TID, area, spotBalanceQuote, decimal.NewFromFloat(0))
 		assetBalanceLocked(userBase.TID, area, spotBalanceBase, decimal.NewFromFloat(0))
 		assetDepthEmpty(matcher.Depth(0))
+		feeRecord, err := gexdb.FindBalanceRecordWheref(ctx, "ref_id=$%v,type=$%v", buyOrder.OrderID, gexdb.BalanceRecordTypeFee)
+		if err != nil || feeRecord.Asset != spotBalanceBase || !feeRecord.FreeDelta.Equal(decimal.NewFromFloat(-0.001)) {
+			t.Errorf("err:%v,record:%v", err, feeRecord)
+			return
+		}
 	}
 	{ //buy sell all, prepare
 		buyOrder, err := matcher.ProcessLimit(ctx, userQuote.TID, gexdb.OrderSideBuy, decimal.NewFromFloat(0.5), decimal.NewFromFloat(100))
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/codingeasygo/crud/pgx"
+	"github.com/codingeasygo/util/xprop"
+	"github.com/gexservice/gexservice/base/basedb"
+	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/gexupgrade"
+	"github.com/gexservice/gexservice/matcher"
+)
+
+//replay will replay recorded orders on scratch database and print divergence report, the usage is
+//  service replay -conf conf/replay.properties -orders orders.jsonl [-seed seed_balance.jsonl] [-seed-holdings seed_holding.jsonl] [-balances balance.jsonl] [-holdings holding.jsonl] [-clear]
+//the -conf is required and the config must be marked scratch by server/scratch=1, it will refuse to run on live config
+func replay(args []string) (code int) {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	confPath := flags.String("conf", "", "the config file, it is required and must be marked scratch by server/scratch=1")
+	ordersPath := flags.String("orders", "", "the recorded orders jsonl which is exported from exs_order")
+	seedPath := flags.String("seed", "", "the seed balances jsonl which is exported from exs_balance before recorded")
+	seedHoldingPath := flags.String("seed-holdings", "", "the seed holdings jsonl which is exported from exs_holding before recorded")
+	balancesPath := flags.String("balances", "", "the recorded balances jsonl which is exported from exs_balance after recorded")
+	holdingsPath := flags.String("holdings", "", "the recorded holdings jsonl which is exported from exs_holding after recorded")
+	clearAll := flags.Bool("clear", false, "clear all data on scratch database before replay")
+	err := flags.Parse(args)
+	if err != nil || len(*ordersPath) < 1 {
+		flags.Usage()
+		return 1
+	}
+	if len(*confPath) < 1 {
+		fmt.Fprintf(os.Stderr, "the -conf is required\n")
+		return 1
+	}
+	ctx := context.Background()
+	conf := xprop.NewConfig()
+	err = conf.Load(*confPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load config from %v fail with %v\n", *confPath, err)
+		return 1
+	}
+	if conf.StrDef("0", "/server/scratch") != "1" {
+		fmt.Fprintf(os.Stderr, "the database on %v is not marked as scratch by server/scratch=1, refuse to replay\n", *confPath)
+		return 1
+	}
+	_, err = pgx.Bootstrap(conf.Str("/server/pg_con"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "connect database fail with %v\n", err)
+		return 1
+	}
+	basedb.SYS = "exs"
+	basedb.Pool = pgx.Pool
+	gexdb.Pool = pgx.Pool
+	_, err = gexdb.CheckDb(ctx)
+	if err == nil && *clearAll {
+		_, _, err = gexdb.Pool().Exec(ctx, gexupgrade.CLEAR)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prepare database fail with %v\n", err)
+		return 1
+	}
+	center, err := matcher.BootstrapMatcherCenterByConfig(conf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bootstrap matcher fail with %v\n", err)
+		return 1
+	}
+	orders := []*gexdb.Order{}
+	seedBalances, balances := []*gexdb.Balance{}, []*gexdb.Balance{}
+	seedHoldings, holdings := []*gexdb.Holding{}, []*gexdb.Holding{}
+	err = readJSONL(*ordersPath, func() interface{} { return &gexdb.Order{} }, func(v interface{}) { orders = append(orders, v.(*gexdb.Order)) })
+	if err == nil {
+		err = readJSONL(*seedPath, func() interface{} { return &gexdb.Balance{} }, func(v interface{}) { seedBalances = append(seedBalances, v.(*gexdb.Balance)) })
+	}
+	if err == nil {
+		err = readJSONL(*seedHoldingPath, func() interface{} { return &gexdb.Holding{} }, func(v interface{}) { seedHoldings = append(seedHoldings, v.(*gexdb.Holding)) })
+	}
+	if err == nil {
+		err = readJSONL(*balancesPath, func() interface{} { return &gexdb.Balance{} }, func(v interface{}) { balances = append(balances, v.(*gexdb.Balance)) })
+	}
+	if err == nil {
+		err = readJSONL(*holdingsPath, func() interface{} { return &gexdb.Holding{} }, func(v interface{}) { holdings = append(holdings, v.(*gexdb.Holding)) })
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	center.TriggerDelay = time.Hour //trigger order is processed by replayer after each command
+	center.Start()
+	defer center.Stop()
+	replayer := matcher.NewReplayer(center)
+	replayer.SeedBalances = seedBalances
+	replayer.SeedHoldings = seedHoldings
+	err = replayer.Seed(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", matcher.ErrStack(err))
+		return 1
+	}
+	report := replayer.Replay(ctx, matcher.NewReplayCommand(orders...)...)
+	err = replayer.Diff(ctx, report, orders, balances, holdings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "diff replay fail with %v\n", err)
+		return 1
+	}
+	fmt.Print(report.String())
+	if len(report.Failed) > 0 || len(report.Divergences) > 0 {
+		return 2
+	}
+	return 0
+}
+
+func readJSONL(path string, newValue func() interface{}, onValue func(v interface{})) (err error) {
+	if len(path) < 1 {
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	err = matcher.ReadJSONL(file, newValue, onValue)
+	if err != nil {
+		err = fmt.Errorf("read %v fail with %v", path, err)
+	}
+	return
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"time"
 
 	"github.com/Centny/rediscache"
 	"github.com/codingeasygo/crud/pgx"
@@ -21,6 +22,7 @@ import (
 	"github.com/gexservice/gexservice/base/xlog"
 	"github.com/gexservice/gexservice/gexapi"
 	"github.com/gexservice/gexservice/gexdb"
+	"github.com/gexservice/gexservice/gexpay"
 	"github.com/gexservice/gexservice/market"
 	"github.com/gexservice/gexservice/matcher"
 )
@@ -30,6 +32,9 @@ func main() {
 		fmt.Printf(`service %v version\n`, Version)
 		return
 	}
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(replay(os.Args[2:]))
+	}
 	confPath := "conf/gexservice.properties"
 	if len(os.Args) > 1 {
 		confPath = os.Args[1]
@@ -102,25 +107,53 @@ func main() {
 	web.HandleFunc("^/adm/status(\\?.*)?$", func(hs *web.Session) web.Result {
 		res := xmap.M{}
 		res["http"], _ = web.Shared.State()
+		if matcher.Shared != nil {
+			res["matcher"] = matcher.Shared.EventMetrics()
+		}
 		return hs.SendJSON(res)
 	})
 	{ //mp config
 		conf.Range("admin", func(key string, val interface{}) { gexapi.ConfAdminH[key] = val })
 	}
-	// if conf.StrDef("", "mock/enabled") == "1" { //mock
-	// 	xlog.Warnf("mock is enabled")
-	// 	web.Shared.HandleFunc("^/mock/payTopupOrder(\\?.*)?$", gexapi.MockPayTopupOrderH)
-	// }
 	//
-	err = matcher.Bootstrap(conf)
+	edge := conf.StrDef("", "/market/bridge") != "" && conf.StrDef(market.BridgeModePublish, "/market/bridge_mode") == market.BridgeModeEdge
+	if edge {
+		err = matcher.BootstrapEdge(conf)
+	} else {
+		err = matcher.Bootstrap(conf)
+	}
 	if err != nil {
 		panic(err)
 	}
+	if klineTZ := conf.StrDef("", "/market/kline_tz"); len(klineTZ) > 0 {
+		market.Location, err = time.LoadLocation(klineTZ)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if bridge := conf.StrDef("", "/market/bridge"); bridge == "redis" {
+		market.Bridge = market.NewRedisBridge(conf.StrDef("gex_market", "/market/bridge_channel"), rediscache.C)
+		market.BridgeMode = conf.StrDef(market.BridgeModePublish, "/market/bridge_mode")
+		xlog.Infof("market bridge is enabled by %v on %v mode", bridge, market.BridgeMode)
+	} else if len(bridge) > 0 {
+		panic(fmt.Sprintf("market bridge %v is not supported", bridge))
+	}
 	market.Bootstrap()
-	gexapi.Handle("", web.Shared)
-	uploader := baseapi.NewUploadH(conf.StrDef("upload", "/server/upload"), "/upload")
-	web.Handle("^/usr/upload(\\?.*)?$", uploader)
-	web.Shared.HandleNormal("^/upload.*$", http.StripPrefix("/upload", http.FileServer(http.Dir(conf.StrDef("upload", "/server/upload")))))
+	if edge {
+		xlog.Infof("service is running on edge mode, only market handler is registered")
+		gexapi.HandleEdge("", web.Shared)
+	} else {
+		err = gexpay.Bootstrap(conf)
+		if err != nil {
+			panic(err)
+		}
+		balanceSyncer := gexdb.NewBalanceRecordSyncer(conf.IntDef(0, "/market/balance_history_hour"), market.ListAssetPrice)
+		balanceSyncer.Start()
+		gexapi.Handle("", web.Shared)
+		uploader := baseapi.NewUploadH(conf.StrDef("upload", "/server/upload"), "/upload")
+		web.Handle("^/usr/upload(\\?.*)?$", uploader)
+		web.Shared.HandleNormal("^/upload.*$", http.StripPrefix("/upload", http.FileServer(http.Dir(conf.StrDef("upload", "/server/upload")))))
+	}
 	web.Shared.HandleNormal("^/debug/.*$", http.DefaultServeMux)
 	web.Shared.HandleNormal("^/apidoc.*$", http.FileServer(http.Dir(conf.StrDef("www", "/server/apidoc"))))
 	web.Shared.HandleNormal("^.*$", http.FileServer(http.Dir(conf.StrDef("www", "/server/www"))))